@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Analyse-Index (Funktionsverwendung) und Hintergrund-Reindex
+// =============================================================================
+
+// AnalysisIndex enthält die Ergebnisse der Code-Analyse über alle Scripts
+type AnalysisIndex struct {
+	// Functions ordnet globalen Funktionen die IDs der definierenden Scripts zu
+	Functions map[string][]int `json:"functions"`
+	// Usages ordnet Funktionsnamen die IDs der aufrufenden Scripts zu
+	Usages map[string][]int `json:"usages"`
+}
+
+var functionDefPattern = regexp.MustCompile(`\bfunction\s+([A-Za-z_][A-Za-z0-9_]*)\s*\(`)
+
+// UnusedFunctions liefert definierte Funktionen ohne Aufrufe, sortiert
+func (idx *AnalysisIndex) UnusedFunctions() []string {
+	var unused []string
+	for name := range idx.Functions {
+		if len(idx.Usages[name]) == 0 {
+			unused = append(unused, name)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// reindexProgressMsg meldet den Fortschritt des Reindex
+type reindexProgressMsg struct {
+	phase       string
+	done, total int
+}
+
+// reindexDoneMsg beendet den Reindex
+type reindexDoneMsg struct {
+	index      *AnalysisIndex
+	analyzed   int   // neu analysierte Scripts (übrige aus dem Zwischenspeicher)
+	saveErr    error // Zwischenspeicher nicht beschreibbar
+	ftsKept    bool  // Volltextindex nicht neu aufgebaut (nur lesend)
+	ftsCurrent bool  // Volltextindex übersprungen, da keine Scripts geändert
+	err        error
+}
+
+// RebuildFTS baut den Volltextindex neu auf; ohne FTS5-Tabelle passiert nichts
+func (db *NinoxDB) RebuildFTS(ctx context.Context) error {
+	var name string
+	err := db.conn.QueryRowContext(ctx,
+		`SELECT name FROM sqlite_master WHERE type = 'table' AND name = 'scripts_fts'`).Scan(&name)
+	if err != nil {
+		return nil
+	}
+	_, err = db.conn.ExecContext(ctx, `INSERT INTO scripts_fts(scripts_fts) VALUES ('rebuild')`)
+	if err != nil && strings.Contains(err.Error(), "fts5") {
+		return nil // SQLite ohne FTS5: Suche nutzt LIKE
+	}
+	return err
+}
+
+// ftsStateDDL merkt sich den Stand der Scripts beim letzten Neuaufbau des Volltextindex.
+// Die Tabelle liegt wie scripts_fts in der Extraktion selbst: wird die Datei ersetzt,
+// fehlt mit dem Index auch der Stand, und es wird neu aufgebaut.
+const ftsStateDDL = `CREATE TABLE IF NOT EXISTS tui_fts_state (
+	id INTEGER PRIMARY KEY CHECK (id = 1),
+	digest TEXT NOT NULL
+)`
+
+// scriptsDigest fasst die Inhalts-Hashes aller Scripts (GetScriptHashes) zusammen
+func scriptsDigest(hashes map[int]string) string {
+	ids := make([]int, 0, len(hashes))
+	for id := range hashes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	var b strings.Builder
+	for _, id := range ids {
+		fmt.Fprintf(&b, "%d:%s\n", id, hashes[id])
+	}
+	return scriptHash(b.String())
+}
+
+// RefreshFTS baut den Volltextindex nur neu auf, wenn sich Scripts seit dem letzten
+// Aufbau geändert haben (Vergleich der Hashes, ohne den Code zu laden). Einzelne
+// Zeilen lassen sich im FTS5-Index mit externem Inhalt nicht ohne die alten Werte
+// ersetzen; die sind nach einer neuen Extraktion verloren, daher wird dann
+// vollständig neu aufgebaut. Beim Speichern aus dem Editor sind sie noch bekannt
+// (UpdateScriptCode aktualisiert nur die eine Zeile).
+func (db *NinoxDB) RefreshFTS(ctx context.Context) (rebuilt bool, err error) {
+	hashes, err := db.GetScriptHashes()
+	if err != nil {
+		return false, err
+	}
+	digest := scriptsDigest(hashes)
+	var indexed string
+	db.conn.QueryRowContext(ctx, `SELECT digest FROM tui_fts_state WHERE id = 1`).Scan(&indexed)
+	if indexed == digest {
+		return false, nil
+	}
+
+	if err := db.RebuildFTS(ctx); err != nil {
+		return false, err
+	}
+	if _, err := db.conn.ExecContext(ctx, ftsStateDDL); err == nil {
+		db.conn.ExecContext(ctx, `INSERT OR REPLACE INTO tui_fts_state (id, digest) VALUES (1, ?)`, digest)
+	}
+	return true, nil
+}
+
+// buildAnalysisIndex analysiert alle Scripts, deren Hash nicht in cached steht, und meldet
+// den Fortschritt über progress. fresh enthält die neu berechneten Ergebnisse zum Speichern.
+func buildAnalysisIndex(ctx context.Context, scripts []Script, cached map[string]scriptAnalysis,
+	progress func(done, total int)) (idx *AnalysisIndex, fresh map[string]scriptAnalysis, err error) {
+	analyses := make(map[string]scriptAnalysis, len(scripts))
+	fresh = make(map[string]scriptAnalysis)
+	for i, s := range scripts {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		if _, done := analyses[s.Hash]; !done {
+			if a, ok := cached[s.Hash]; ok {
+				analyses[s.Hash] = a
+			} else {
+				analyses[s.Hash] = analyzeScript(s.Code)
+				fresh[s.Hash] = analyses[s.Hash]
+			}
+		}
+		progress(i+1, len(scripts))
+	}
+	return indexFromAnalyses(scripts, analyses), fresh, nil
+}
+
+// startReindex startet FTS-Rebuild und Analyse im Hintergrund
+func (m Model) startReindex() (tea.Model, tea.Cmd) {
+	if m.reindexCancel != nil {
+		// Läuft bereits: erneutes Drücken bricht ab
+		m.reindexCancel()
+		return m, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan tea.Msg, 1)
+	m.reindexCancel = cancel
+	m.reindexCh = ch
+	m.progress = "⟳ Volltextindex wird neu aufgebaut…"
+
+	// Abfragen des Hintergrund-Reindex enden mit dem Abbruch
+	db, scripts := m.db.WithContext(ctx), m.allScripts
+	go func() {
+		defer close(ch)
+
+		// Der Volltextindex liegt in der Extraktion selbst
+		ftsRebuilt := false
+		if db.Writable() {
+			var err error
+			if ftsRebuilt, err = db.RefreshFTS(ctx); err != nil {
+				ch <- reindexDoneMsg{err: err}
+				return
+			}
+		}
+
+		cached, err := db.LoadScriptAnalyses()
+		if err != nil {
+			cached = nil // Zwischenspeicher unlesbar: alles neu analysieren
+		}
+
+		last := -1
+		idx, fresh, err := buildAnalysisIndex(ctx, scripts, cached, func(done, total int) {
+			// Nur bei Prozentsprüngen melden, nie blockieren
+			percent := done * 100 / max(1, total)
+			if percent == last {
+				return
+			}
+			last = percent
+			select {
+			case ch <- reindexProgressMsg{"Analyse", done, total}:
+			default:
+			}
+		})
+		if err != nil {
+			ch <- reindexDoneMsg{err: err}
+			return
+		}
+		ch <- reindexDoneMsg{index: idx, analyzed: len(fresh), saveErr: db.SaveScriptAnalyses(ctx, fresh),
+			ftsKept: !db.Writable(), ftsCurrent: db.Writable() && !ftsRebuilt}
+	}()
+
+	return m, waitForReindex(ch)
+}
+
+// waitForReindex wartet auf die nächste Nachricht des Reindex
+func waitForReindex(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// handleReindexMsg verarbeitet Fortschritt und Abschluss des Reindex
+func (m Model) handleReindexMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case reindexProgressMsg:
+		m.progress = fmt.Sprintf("⟳ %s %d%% (%d/%d) – R bricht ab",
+			msg.phase, msg.done*100/max(1, msg.total), msg.done, msg.total)
+		return m, waitForReindex(m.reindexCh)
+
+	case reindexDoneMsg:
+		m.reindexCancel = nil
+		m.reindexCh = nil
+		m.progress = ""
+		switch {
+		case msg.err == context.Canceled:
+			m.status = "Reindex abgebrochen"
+		case msg.err != nil:
+			m.status = fmt.Sprintf("❌ Reindex fehlgeschlagen: %v", msg.err)
+		default:
+			m.analysis = msg.index
+			m.status = fmt.Sprintf("✓ Index aktualisiert: %d Funktionen, %d unbenutzt (%d Scripts neu analysiert)",
+				len(msg.index.Functions), len(msg.index.UnusedFunctions()), msg.analyzed)
+			switch {
+			case errors.Is(msg.saveErr, errReadOnly):
+				m.status += " – nicht gespeichert (nur lesend, --allow-write)"
+			case msg.saveErr != nil:
+				m.status += fmt.Sprintf(" – nicht gespeichert: %v", msg.saveErr)
+			}
+			if msg.ftsKept {
+				m.status += " – Volltextindex unverändert"
+			}
+			if msg.ftsCurrent {
+				m.status += " – Volltextindex aktuell"
+			}
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Zwischenspeicher der Analyse je Script-Hash (Hilfstabelle, siehe writeAccess)
+// =============================================================================
+
+// analysisVersion wird erhöht, wenn sich die Analyse ändert; ältere Einträge verfallen
+const analysisVersion = 1
+
+const analysisTableDDL = `CREATE TABLE IF NOT EXISTS tui_script_analysis (
+	code_hash TEXT PRIMARY KEY,
+	version INTEGER NOT NULL,
+	defines TEXT NOT NULL,
+	calls TEXT NOT NULL
+)`
+
+var callPattern = regexp.MustCompile(`\b([A-Za-z_][A-Za-z0-9_]*)\s*\(`)
+
+// scriptAnalysis ist das vom übrigen Bestand unabhängige Ergebnis für ein Script
+type scriptAnalysis struct {
+	Defines []string // definierte globale Funktionen
+	Calls   []string // aufgerufene Namen (ohne die eigenen Definitionen)
+}
+
+// analyzeScript ermittelt Definitionen und Aufrufe eines Scripts
+func analyzeScript(code string) scriptAnalysis {
+	var a scriptAnalysis
+	for _, match := range functionDefPattern.FindAllStringSubmatch(code, -1) {
+		a.Defines = append(a.Defines, match[1])
+	}
+
+	seen := make(map[string]bool)
+	for _, match := range callPattern.FindAllStringSubmatch(functionDefPattern.ReplaceAllString(code, ""), -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			a.Calls = append(a.Calls, match[1])
+		}
+	}
+	sort.Strings(a.Calls)
+	return a
+}
+
+// LoadScriptAnalyses lädt die gespeicherten Analysen der aktuellen Version.
+// Ohne Hilfstabelle ist das Ergebnis leer.
+func (db *NinoxDB) LoadScriptAnalyses() (map[string]scriptAnalysis, error) {
+	analyses := make(map[string]scriptAnalysis)
+	aux, err := db.auxDB()
+	if err != nil {
+		return analyses, nil // nur lesend: nichts gespeichert
+	}
+	rows, err := aux.Query(`SELECT code_hash, defines, calls FROM tui_script_analysis WHERE version = ?`, analysisVersion)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such table") {
+			return analyses, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hash, defines, calls string
+		if err := rows.Scan(&hash, &defines, &calls); err != nil {
+			return nil, err
+		}
+		analyses[hash] = scriptAnalysis{Defines: splitNames(defines), Calls: splitNames(calls)}
+	}
+	return analyses, rows.Err()
+}
+
+// SaveScriptAnalyses speichert neu berechnete Analysen in der Hilfstabelle
+func (db *NinoxDB) SaveScriptAnalyses(ctx context.Context, analyses map[string]scriptAnalysis) error {
+	if len(analyses) == 0 {
+		return nil
+	}
+	aux, err := db.auxDB()
+	if err != nil {
+		return err
+	}
+	if _, err := aux.ExecContext(ctx, analysisTableDDL); err != nil {
+		return err
+	}
+
+	tx, err := aux.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO tui_script_analysis (code_hash, version, defines, calls) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for hash, a := range analyses {
+		if _, err := stmt.ExecContext(ctx, hash, analysisVersion, strings.Join(a.Defines, " "), strings.Join(a.Calls, " ")); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func splitNames(s string) []string {
+	return strings.Fields(s)
+}
+
+// indexFromAnalyses setzt den Analyse-Index aus den Ergebnissen je Script zusammen
+func indexFromAnalyses(scripts []Script, analyses map[string]scriptAnalysis) *AnalysisIndex {
+	idx := &AnalysisIndex{Functions: make(map[string][]int), Usages: make(map[string][]int)}
+	for _, s := range scripts {
+		for _, name := range analyses[s.Hash].Defines {
+			idx.Functions[name] = append(idx.Functions[name], s.ID)
+		}
+	}
+	for _, s := range scripts {
+		for _, name := range analyses[s.Hash].Calls {
+			if _, ok := idx.Functions[name]; ok {
+				idx.Usages[name] = append(idx.Usages[name], s.ID)
+			}
+		}
+	}
+	return idx
+}
+
+// cachedAnalysisIndex liefert den Index allein aus gespeicherten Analysen,
+// sofern sie alle Scripts abdecken (sonst nil: erst R analysiert die übrigen)
+func cachedAnalysisIndex(db *NinoxDB, scripts []Script) *AnalysisIndex {
+	analyses, err := db.LoadScriptAnalyses()
+	if err != nil || len(analyses) == 0 {
+		return nil
+	}
+	for _, s := range scripts {
+		if _, ok := analyses[s.Hash]; !ok {
+			return nil
+		}
+	}
+	return indexFromAnalyses(scripts, analyses)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"path"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Archivierte Datenbanken: standardmäßig ausgeblendet, per Umschalter erreichbar
+// =============================================================================
+
+// SetArchived ermittelt die archivierten Datenbanken: Spalte databases.archived
+// (extract --archive) oder Namensmuster aus der Konfiguration (archived)
+func (db *NinoxDB) SetArchived(patterns []string) error {
+	archived := make(map[string]bool)
+	column := "0"
+	if db.tableColumns("databases")["archived"] {
+		column = "COALESCE(archived, 0)"
+	}
+
+	rows, err := db.conn.Query(`SELECT id, name, ` + column + ` FROM databases`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, name string
+		var flagged bool
+		if err := rows.Scan(&id, &name, &flagged); err != nil {
+			return err
+		}
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name)); ok {
+				flagged = true
+			}
+		}
+		if flagged {
+			archived[id] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	db.archived = archived
+	db.mu.Unlock()
+	return nil
+}
+
+// SetShowArchived blendet archivierte Datenbanken ein bzw. wieder aus
+func (db *NinoxDB) SetShowArchived(show bool) {
+	db.mu.Lock()
+	db.showArchived = show
+	db.mu.Unlock()
+}
+
+// HasArchived meldet, ob Datenbanken als archiviert markiert sind
+func (db *NinoxDB) HasArchived() bool {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return len(db.archived) > 0
+}
+
+// IsArchived meldet, ob eine Datenbank archiviert ist
+func (db *NinoxDB) IsArchived(databaseID string) bool {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.archived[databaseID]
+}
+
+// hiddenArchived liefert die auszublendenden Datenbank-IDs (leer bei showArchived);
+// der Aufrufer hält db.mu
+func (db *NinoxDB) hiddenArchived() []interface{} {
+	if db.showArchived {
+		return nil
+	}
+	var ids []interface{}
+	for id := range db.archived {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// toggleArchived zeigt archivierte Datenbanken an bzw. blendet sie wieder aus
+func (m Model) toggleArchived() (tea.Model, tea.Cmd) {
+	if !m.db.HasArchived() {
+		m.status = "Keine archivierten Datenbanken (archived in der Konfiguration)"
+		return m, nil
+	}
+	m.showArchived = !m.showArchived
+	m.db.SetShowArchived(m.showArchived)
+	m.reloadLists()
+
+	if m.showArchived {
+		m.status = "Archivierte Datenbanken werden angezeigt (z zum Ausblenden)"
+	} else {
+		m.status = "Archivierte Datenbanken ausgeblendet"
+	}
+	return m, nil
+}
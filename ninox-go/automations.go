@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Automatisierungen: unbeaufsichtigt laufende Scripts (Zeitpläne, Webhooks, Trigger)
+// =============================================================================
+
+// automationKinds ordnet Script-Typen der Art der Automatisierung zu. Trigger
+// zählen dazu, weil sie auch bei Änderungen über API und andere Scripts laufen.
+var automationKinds = map[string]string{
+	"schedule":     "schedule",
+	"webhook":      "webhook",
+	"afterCreate":  "trigger",
+	"afterUpdate":  "trigger",
+	"afterDelete":  "trigger",
+	"beforeDelete": "trigger",
+}
+
+var automationKindNames = map[string]string{
+	"schedule": "Zeitplan",
+	"webhook":  "Webhook",
+	"trigger":  "Trigger",
+}
+
+// automationFilters ist die Reihenfolge, in der Tab die Arten durchschaltet ("" = alle)
+var automationFilters = []string{"", "schedule", "webhook", "trigger"}
+
+// Automation ist der Auslöser eines zeitgesteuerten Scripts bzw. Webhooks
+type Automation struct {
+	Kind      string
+	Condition string
+	Enabled   bool
+}
+
+// automationKey identifiziert eine Automatisierung einer Datenbank
+func automationKey(databaseID, elementID string) string {
+	return databaseID + "/" + elementID
+}
+
+// HasAutomations meldet, ob die Extraktion Zeitpläne und Webhooks enthält (ältere nicht)
+func (db *NinoxDB) HasAutomations() bool {
+	return len(db.tableColumns("automations")) > 0
+}
+
+// GetAutomations lädt die Auslöser aller Zeitpläne und Webhooks
+func (db *NinoxDB) GetAutomations() (map[string]Automation, error) {
+	automations := make(map[string]Automation)
+	if !db.HasAutomations() {
+		return automations, nil
+	}
+
+	ignore := db.ignoreFilter("database_id", "", "")
+	rows, err := db.conn.Query(`
+		SELECT database_id, element_id, kind, COALESCE(trigger_condition, ''), COALESCE(enabled, 1)
+		FROM automations
+		WHERE 1 = 1` + ignore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dbID, elementID string
+		var a Automation
+		if err := rows.Scan(&dbID, &elementID, &a.Kind, &a.Condition, &a.Enabled); err != nil {
+			return nil, err
+		}
+		automations[automationKey(dbID, elementID)] = a
+	}
+	return automations, rows.Err()
+}
+
+// automationRow ist eine Zeile der Automatisierungs-Ansicht
+type automationRow struct {
+	Kind      string
+	Condition string
+	Enabled   bool
+	Script    *Script
+}
+
+// triggerCondition beschreibt, wann ein Trigger läuft, z.B. "nach Änderung von Kunden.Status"
+func triggerCondition(s Script) string {
+	switch s.CodeType {
+	case "afterCreate":
+		return "nach Anlegen in " + s.TableName
+	case "afterUpdate":
+		if s.ElementName != "" {
+			return "nach Änderung von " + s.TableName + "." + s.ElementName
+		}
+		return "nach Änderung eines Datensatzes in " + s.TableName
+	case "afterDelete":
+		return "nach Löschen in " + s.TableName
+	case "beforeDelete":
+		return "vor Löschen in " + s.TableName
+	}
+	return ""
+}
+
+// buildAutomationRows sammelt alle Automatisierungen, sortiert nach Art, Datenbank und Ort
+func buildAutomationRows(scripts []Script, automations map[string]Automation) []automationRow {
+	var rows []automationRow
+	for i := range scripts {
+		s := &scripts[i]
+		kind, ok := automationKinds[s.CodeType]
+		if !ok {
+			continue
+		}
+		row := automationRow{Kind: kind, Enabled: true, Script: s}
+		if kind == "trigger" {
+			if s.TableName == "" {
+				continue
+			}
+			row.Condition = triggerCondition(*s)
+		} else if a, ok := automations[automationKey(s.DatabaseID, s.ElementID)]; ok {
+			row.Condition, row.Enabled = a.Condition, a.Enabled
+		}
+		rows = append(rows, row)
+	}
+
+	order := map[string]int{"schedule": 0, "webhook": 1, "trigger": 2}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if order[a.Kind] != order[b.Kind] {
+			return order[a.Kind] < order[b.Kind]
+		}
+		if c := cmpText(a.Script.DatabaseName, b.Script.DatabaseName); c != 0 {
+			return c < 0
+		}
+		return cmpText(scriptLabel(*a.Script), scriptLabel(*b.Script)) < 0
+	})
+	return rows
+}
+
+// visibleAutomations liefert die Zeilen der gewählten Art
+func (m Model) visibleAutomations() []automationRow {
+	if m.automationFilter == "" {
+		return m.automationRows
+	}
+	var rows []automationRow
+	for _, r := range m.automationRows {
+		if r.Kind == m.automationFilter {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// openAutomations öffnet die Ansicht der Automatisierungen
+func (m Model) openAutomations() (tea.Model, tea.Cmd) {
+	if m.mode == viewAutomations {
+		return m, nil
+	}
+	automations, err := m.db.GetAutomations()
+	if err != nil {
+		m.status = fmt.Sprintf("Fehler beim Laden der Automatisierungen: %v", err)
+		return m, nil
+	}
+
+	m.automationRows = buildAutomationRows(m.allScripts, automations)
+	m.automationReturn = m.mode
+	m.selectedAutomation = 0
+	m.mode = viewAutomations
+	if !m.db.HasAutomations() {
+		m.status = "Extraktion ohne Zeitpläne und Webhooks – nur Trigger (neu extrahieren)"
+	}
+	return m, nil
+}
+
+// closeAutomations kehrt zur Ansicht vor dem Öffnen zurück
+func (m Model) closeAutomations() (tea.Model, tea.Cmd) {
+	m.mode = m.automationReturn
+	m.prevMode = m.automationReturn
+	return m, nil
+}
+
+// cycleAutomationFilter schaltet zwischen allen Arten und einer einzelnen Art um
+func (m Model) cycleAutomationFilter() (tea.Model, tea.Cmd) {
+	for i, f := range automationFilters {
+		if f == m.automationFilter {
+			m.automationFilter = automationFilters[(i+1)%len(automationFilters)]
+			break
+		}
+	}
+	m.selectedAutomation = 0
+	return m, nil
+}
+
+// openAutomationScript zeigt das Script der ausgewählten Zeile
+func (m Model) openAutomationScript() (tea.Model, tea.Cmd) {
+	rows := m.visibleAutomations()
+	if m.selectedAutomation >= len(rows) {
+		return m, nil
+	}
+	script := *rows[m.selectedAutomation].Script
+	m.codeScript = &script
+	m.codeView.SetContent(highlightCode(script.Code))
+	m.codeView.GotoTop()
+	m.prevMode = viewAutomations
+	m.mode = viewCode
+	return m, nil
+}
+
+// renderAutomations rendert Zeitpläne, Webhooks und Trigger mit ihren Auslösern
+func (m Model) renderAutomations() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("⏱ Automatisierungen") + "\n\n")
+
+	counts := make(map[string]int)
+	for _, r := range m.automationRows {
+		counts[r.Kind]++
+	}
+	var tabs []string
+	for _, f := range automationFilters {
+		label := fmt.Sprintf("Alle (%d)", len(m.automationRows))
+		if f != "" {
+			label = fmt.Sprintf("%s (%d)", automationKindNames[f], counts[f])
+		}
+		if f == m.automationFilter {
+			label = "[" + label + "]"
+		}
+		tabs = append(tabs, label)
+	}
+	b.WriteString(mutedStyle.Render("  "+strings.Join(tabs, " · ")+"  (Tab wechseln)") + "\n\n")
+
+	rows := m.visibleAutomations()
+	if len(rows) == 0 {
+		b.WriteString(mutedStyle.Render("  Keine Automatisierungen gefunden.") + "\n")
+		return boxStyle.Width(m.width - 4).Render(b.String())
+	}
+
+	header := fmt.Sprintf("  %-10s %-40s %s", "Art", "Script", "Auslöser")
+	b.WriteString(tableHeaderStyle.Render(header) + "\n")
+
+	height := max(3, m.height-14)
+	start := 0
+	if m.selectedAutomation >= height {
+		start = m.selectedAutomation - height + 1
+	}
+	end := min(len(rows), start+height)
+
+	for i := start; i < end; i++ {
+		r := rows[i]
+		style := tableCellStyle
+		prefix := "  "
+		if i == m.selectedAutomation {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		condition := r.Condition
+		if condition == "" {
+			condition = "–"
+		}
+		if !r.Enabled {
+			condition += " (deaktiviert)"
+		}
+		row := fmt.Sprintf("%s%-10s %-40s %s", prefix, automationKindNames[r.Kind],
+			truncate(scriptLabel(*r.Script), 40), truncate(condition, max(10, m.width-62)))
+		b.WriteString(style.Render(row) + "\n")
+	}
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("\n  %d von %d Einträgen", len(rows), len(m.automationRows))) + "\n")
+
+	return boxStyle.Width(m.width - 4).Render(b.String())
+}
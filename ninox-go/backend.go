@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// Speicher-Backends: SQLite-Datei (Standard) oder beliebiger database/sql-Treiber,
+// z.B. eine zentrale PostgreSQL-Instanz mit den Tabellen aus schemaDDL
+// =============================================================================
+
+// storageBackend kapselt die Unterschiede der SQL-Dialekte hinter NinoxDB.
+// Abfragen werden im SQLite-Dialekt mit ?-Platzhaltern geschrieben.
+type storageBackend interface {
+	// driver ist der Name des database/sql-Treibers
+	driver() string
+	// local meldet, ob die Extraktion eine Datei ist (Dateidatum, Hilfstabellen)
+	local() bool
+	// rebind passt eine Abfrage an den Dialekt an
+	rebind(query string) string
+	// columns liefert die Spalten einer Tabelle (leer = Tabelle fehlt)
+	columns(conn *backendConn, table string) map[string]bool
+	// readOnly schaltet die Verbindung für Konsolen-Abfragen auf nur lesend; restore
+	// läuft ohne den Kontext, damit ein Abbruch die Verbindung nicht gesperrt im Pool lässt
+	readOnly(ctx context.Context, conn *sql.Conn) (restore func(), err error)
+}
+
+// sqliteBackend ist die bisherige Extraktion als SQLite-Datei
+type sqliteBackend struct{}
+
+func (sqliteBackend) driver() string             { return "sqlite3" }
+func (sqliteBackend) local() bool                { return true }
+func (sqliteBackend) rebind(query string) string { return query }
+
+func (sqliteBackend) columns(conn *backendConn, table string) map[string]bool {
+	columns := make(map[string]bool)
+	rows, err := conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return columns
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			return columns
+		}
+		columns[name] = true
+	}
+	return columns
+}
+
+func (sqliteBackend) readOnly(ctx context.Context, conn *sql.Conn) (func(), error) {
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = 1"); err != nil {
+		return nil, err
+	}
+	return func() { conn.ExecContext(context.Background(), "PRAGMA query_only = 0") }, nil
+}
+
+// sqlBackend ist eine Extraktion in einer anderen SQL-Datenbank
+type sqlBackend struct {
+	name     string
+	postgres bool // $1-Platzhalter, ILIKE und READ-ONLY-Sitzung
+}
+
+func (b sqlBackend) driver() string { return b.name }
+func (b sqlBackend) local() bool    { return false }
+
+// rebind ersetzt ? durch $1, $2, … und LIKE durch ILIKE (SQLite vergleicht
+// LIKE ohne Beachtung der Groß-/Kleinschreibung). Zeichenketten bleiben unverändert.
+func (b sqlBackend) rebind(query string) string {
+	if !b.postgres {
+		return query
+	}
+	var out strings.Builder
+	n := 0
+	quoted := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '?':
+			n++
+			out.WriteString("$" + strconv.Itoa(n))
+			continue
+		case (c == 'L' || c == 'l') && strings.EqualFold(safeSlice(query, i, i+4), "LIKE") &&
+			(i == 0 || !isIdentChar(query[i-1])) && (i+4 == len(query) || !isIdentChar(query[i+4])):
+			out.WriteString("ILIKE")
+			i += 3
+			continue
+		}
+		out.WriteByte(c)
+	}
+	return out.String()
+}
+
+// safeSlice liefert s[from:to], gekürzt auf die Länge von s
+func safeSlice(s string, from, to int) string {
+	return s[from:min(to, len(s))]
+}
+
+// isIdentChar meldet Zeichen, die zu einem SQL-Bezeichner gehören
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// columns fragt eine leere Ergebnismenge ab; das funktioniert mit jedem Treiber
+func (b sqlBackend) columns(conn *backendConn, table string) map[string]bool {
+	columns := make(map[string]bool)
+	rows, err := conn.Query(fmt.Sprintf("SELECT * FROM %s WHERE 1 = 0", table))
+	if err != nil {
+		return columns
+	}
+	defer rows.Close()
+
+	names, _ := rows.Columns()
+	for _, name := range names {
+		columns[strings.ToLower(name)] = true
+	}
+	return columns
+}
+
+// readOnly nutzt bei PostgreSQL eine nur lesende Sitzung; andere Treiber
+// verlassen sich auf die Prüfung der Abfrage in RunQuery
+func (b sqlBackend) readOnly(ctx context.Context, conn *sql.Conn) (func(), error) {
+	if !b.postgres {
+		return func() {}, nil
+	}
+	if _, err := conn.ExecContext(ctx, "SET SESSION CHARACTERISTICS AS TRANSACTION READ ONLY"); err != nil {
+		return nil, err
+	}
+	return func() {
+		conn.ExecContext(context.Background(), "SET SESSION CHARACTERISTICS AS TRANSACTION READ WRITE")
+	}, nil
+}
+
+// postgresDrivers sind die bekannten Treibernamen für PostgreSQL (pgx, lib/pq)
+var postgresDrivers = []string{"pgx", "postgres"}
+
+// backendFor wählt das Backend anhand des Pfads: http(s)://… für eine per serve
+// bereitgestellte Extraktion, postgres://… bzw. postgresql://… für PostgreSQL,
+// TREIBER:DSN für einen anderen registrierten Treiber, sonst SQLite.
+func backendFor(path string) (storageBackend, string, error) {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return remoteBackend{}, path, nil
+	}
+
+	registered := make(map[string]bool)
+	for _, d := range sql.Drivers() {
+		registered[d] = true
+	}
+
+	if strings.HasPrefix(path, "postgres://") || strings.HasPrefix(path, "postgresql://") {
+		for _, d := range postgresDrivers {
+			if registered[d] {
+				return sqlBackend{name: d, postgres: true}, path, nil
+			}
+		}
+		return nil, "", fmt.Errorf("Kein PostgreSQL-Treiber eingebaut (mit -tags postgres bauen)")
+	}
+
+	if driver, dsn, ok := strings.Cut(path, ":"); ok && driver != "sqlite3" && registered[driver] {
+		for _, d := range postgresDrivers {
+			if d == driver {
+				return sqlBackend{name: driver, postgres: true}, dsn, nil
+			}
+		}
+		return sqlBackend{name: driver}, dsn, nil
+	}
+	return sqliteBackend{}, path, nil
+}
+
+// isFileDB meldet, ob path eine SQLite-Datei bezeichnet (und nicht etwa eine Server-URL)
+func isFileDB(path string) bool {
+	backend, _, err := backendFor(path)
+	return err == nil && backend.local()
+}
+
+// backendConn ist die Verbindung von NinoxDB; alle Abfragen laufen über rebind.
+// *sql.DB ist ein Verbindungspool und darf parallel verwendet werden.
+type backendConn struct {
+	*sql.DB
+	backend storageBackend
+	ctx     context.Context // Kontext für Abfragen ohne eigenen (nil = Background)
+}
+
+// context liefert den Kontext der Verbindung (siehe NinoxDB.WithContext)
+func (c *backendConn) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+func (c *backendConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.DB.QueryContext(c.context(), c.backend.rebind(query), args...)
+}
+
+func (c *backendConn) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.DB.QueryRowContext(c.context(), c.backend.rebind(query), args...)
+}
+
+func (c *backendConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.DB.QueryContext(ctx, c.backend.rebind(query), args...)
+}
+
+func (c *backendConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.DB.QueryRowContext(ctx, c.backend.rebind(query), args...)
+}
+
+func (c *backendConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.DB.ExecContext(c.context(), c.backend.rebind(query), args...)
+}
+
+func (c *backendConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.DB.ExecContext(ctx, c.backend.rebind(query), args...)
+}
@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestRebind(t *testing.T) {
+	pg := sqlBackend{name: "postgres", postgres: true}
+	tests := []struct{ query, want string }{
+		{"SELECT * FROM scripts WHERE id = ? AND code = ?", "SELECT * FROM scripts WHERE id = $1 AND code = $2"},
+		{"SELECT '?' FROM t WHERE a = ?", "SELECT '?' FROM t WHERE a = $1"},
+		{"SELECT 'it''s ?' WHERE a = ?", "SELECT 'it''s ?' WHERE a = $1"},
+		{"WHERE code LIKE ? OR name like ?", "WHERE code ILIKE $1 OR name ILIKE $2"},
+		{"SELECT likes, unlike, 'LIKE' FROM t", "SELECT likes, unlike, 'LIKE' FROM t"},
+		{"WHERE a LIKE(?)", "WHERE a ILIKE($1)"},
+	}
+	for _, tt := range tests {
+		if got := pg.rebind(tt.query); got != tt.want {
+			t.Errorf("rebind(%q) = %q, erwartet %q", tt.query, got, tt.want)
+		}
+	}
+
+	query := "SELECT * FROM t WHERE a LIKE ?"
+	if got := (sqlBackend{name: "mysql"}).rebind(query); got != query {
+		t.Errorf("ohne postgres verändert: %q", got)
+	}
+	if got := (sqliteBackend{}).rebind(query); got != query {
+		t.Errorf("sqlite verändert: %q", got)
+	}
+}
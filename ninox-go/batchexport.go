@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Komplett-Export einer Datenbank aus der Datenbank-Ansicht: Dokumentation,
+// Script-Baum und Diagramme in ein Verzeichnis, im Hintergrund mit Fortschritt
+// =============================================================================
+
+// bundleStep ist ein Schritt des Komplett-Exports; Rückgabe ist eine kurze Zusammenfassung
+type bundleStep struct {
+	name string
+	run  func(db *NinoxDB, d Database, dir string) (string, error)
+}
+
+var bundleSteps = []bundleStep{
+	{"Dokumentation", func(db *NinoxDB, d Database, dir string) (string, error) {
+		n, err := ExportSite(db, []Database{d}, filepath.Join(dir, "html"))
+		return fmt.Sprintf("%d Seiten", n), err
+	}},
+	{"Scripts", func(db *NinoxDB, d Database, dir string) (string, error) {
+		all, err := db.GetAllScripts()
+		if err != nil {
+			return "", err
+		}
+		var scripts []Script
+		for _, s := range all {
+			if s.DatabaseID == d.ID {
+				scripts = append(scripts, s)
+			}
+		}
+		// Mit Frontmatter, damit import-scripts den Baum wieder einlesen kann
+		n, err := ExportScripts(filepath.Join(dir, "scripts"), scripts, true)
+		return fmt.Sprintf("%d Scripts", n), err
+	}},
+	{"Mermaid", func(db *NinoxDB, d Database, dir string) (string, error) {
+		diagram, err := MermaidER(db, []Database{d}, "")
+		if err != nil {
+			return "", err
+		}
+		return "datenmodell.mmd", os.WriteFile(filepath.Join(dir, "datenmodell.mmd"), []byte(diagram), 0o644)
+	}},
+	{"draw.io", func(db *NinoxDB, d Database, dir string) (string, error) {
+		data, err := ExportDrawio(db, []Database{d})
+		if err != nil {
+			return "", err
+		}
+		return "datenmodell.drawio", os.WriteFile(filepath.Join(dir, "datenmodell.drawio"), data, 0o644)
+	}},
+	{"Graphviz", func(db *NinoxDB, d Database, dir string) (string, error) {
+		dot, err := DotGraph(db, []Database{d}, "")
+		if err != nil {
+			return "", err
+		}
+		return "datenmodell.dot", os.WriteFile(filepath.Join(dir, "datenmodell.dot"), []byte(dot), 0o644)
+	}},
+}
+
+// bundleProgressMsg meldet den Beginn eines Export-Schritts
+type bundleProgressMsg struct {
+	step int
+}
+
+// bundleDoneMsg beendet den Komplett-Export
+type bundleDoneMsg struct {
+	dir     string
+	summary []string
+	err     error
+}
+
+// ExportDatabaseBundle schreibt alle Exporte einer Datenbank nach dir. progress
+// wird vor jedem Schritt aufgerufen; ein Abbruch wirkt zwischen den Schritten.
+func ExportDatabaseBundle(ctx context.Context, db *NinoxDB, d Database, dir string, progress func(step int)) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	var summary []string
+	for i, step := range bundleSteps {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+		progress(i)
+		done, err := step.run(db, d, dir)
+		if err != nil {
+			return summary, fmt.Errorf("%s: %w", step.name, err)
+		}
+		summary = append(summary, done)
+	}
+	return summary, nil
+}
+
+// defaultBundleDir schlägt ein Zielverzeichnis nach dem Datenbanknamen vor
+func defaultBundleDir(d Database) string {
+	return safeFileName(d.Name) + "-export"
+}
+
+// startExportDatabase fragt nach dem Zielverzeichnis; läuft der Export schon, bricht x ihn ab
+func (m Model) startExportDatabase() (tea.Model, tea.Cmd) {
+	if m.bundleCancel != nil {
+		m.bundleCancel()
+		return m, nil
+	}
+	if len(m.databases) == 0 {
+		return m, nil
+	}
+	if m.progress != "" {
+		m.status = "Export nicht möglich: " + m.progress
+		return m, nil
+	}
+	m.databaseExporting = true
+	m.sqlExportInput.SetValue(defaultBundleDir(m.databases[m.selectedDB]))
+	m.sqlExportInput.CursorEnd()
+	m.sqlExportInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleDatabaseExportInput verarbeitet die Eingabe des Zielverzeichnisses und startet den Export
+func (m Model) handleDatabaseExportInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch {
+	case key.Matches(msg, keys.Back):
+		m.databaseExporting = false
+		m.sqlExportInput.Blur()
+		return m, nil
+	case key.Matches(msg, keys.Enter):
+		m.databaseExporting = false
+		m.sqlExportInput.Blur()
+		dir := strings.TrimSpace(m.sqlExportInput.Value())
+		if dir == "" {
+			return m, nil
+		}
+		return m.runExportDatabase(m.databases[m.selectedDB], dir)
+	default:
+		m.sqlExportInput, cmd = m.sqlExportInput.Update(msg)
+		return m, cmd
+	}
+}
+
+// runExportDatabase startet den Komplett-Export im Hintergrund
+func (m Model) runExportDatabase(d Database, dir string) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan tea.Msg, 1)
+	m.bundleCancel = cancel
+	m.bundleCh = ch
+	m.bundleName = d.Name
+	m.progress = fmt.Sprintf("⟳ Export %s…", d.Name)
+
+	db := m.db.WithContext(ctx)
+	go func() {
+		defer close(ch)
+		summary, err := ExportDatabaseBundle(ctx, db, d, dir, func(step int) {
+			select {
+			case ch <- bundleProgressMsg{step}:
+			default:
+			}
+		})
+		ch <- bundleDoneMsg{dir: dir, summary: summary, err: err}
+	}()
+
+	return m, waitForReindex(ch)
+}
+
+// handleBundleMsg verarbeitet Fortschritt und Abschluss des Komplett-Exports
+func (m Model) handleBundleMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case bundleProgressMsg:
+		m.progress = fmt.Sprintf("⟳ Export %s: %s (%d/%d) – x bricht ab",
+			m.bundleName, bundleSteps[msg.step].name, msg.step+1, len(bundleSteps))
+		return m, waitForReindex(m.bundleCh)
+
+	case bundleDoneMsg:
+		m.bundleCancel = nil
+		m.bundleCh = nil
+		m.progress = ""
+		switch {
+		case msg.err == context.Canceled:
+			m.status = fmt.Sprintf("Export von %s abgebrochen (unvollständig in %s)", m.bundleName, msg.dir)
+		case msg.err != nil:
+			m.status = fmt.Sprintf("❌ Export fehlgeschlagen: %v", msg.err)
+		default:
+			m.status = fmt.Sprintf("✓ %s exportiert nach %s: %s", m.bundleName, msg.dir, strings.Join(msg.summary, ", "))
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Render-Cache
+// =============================================================================
+
+// renderCache speichert hervorgehobenen Code auf der Festplatte, damit große
+// Extrakte beim erneuten Öffnen nicht wieder durch Chroma laufen müssen.
+// Schlüssel ist der Inhalts-Hash zusammen mit Theme und Sprache.
+// Die Vorschau hebt im Hintergrund hervor, daher ist der Zugriff geschützt.
+type renderCache struct {
+	dir    string            // leer = nur im Speicher
+	mu     sync.Mutex        // schützt memory, order und size
+	memory map[string]string // bereits in dieser Sitzung geladene Einträge
+	order  []string          // Schlüssel in Einfügereihenfolge (für maxMemory)
+	size   int               // Bytes in memory
+
+	maxEntry  int // größere Einträge werden nicht gespeichert (0 = unbegrenzt)
+	maxMemory int // Obergrenze im Speicher; älteste Einträge fallen heraus (0 = unbegrenzt)
+}
+
+// highlightCache ist der Cache der laufenden Sitzung
+var highlightCache = newRenderCache(defaultCacheDir())
+
+// defaultCacheDir liefert das Cache-Verzeichnis; leer, wenn keins verfügbar ist
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "ninox-tui", "render")
+}
+
+func newRenderCache(dir string) *renderCache {
+	return &renderCache{dir: dir, memory: make(map[string]string)}
+}
+
+// cacheKey bildet den Dateinamen aus Inhalt, Theme und Sprache
+func cacheKey(code, theme, language string) string {
+	return scriptHash(code) + "-" + theme + "-" + language
+}
+
+// get liefert einen Eintrag aus dem Speicher oder von der Festplatte
+func (c *renderCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.memory[key]; ok {
+		return s, true
+	}
+	if c.dir == "" {
+		return "", false
+	}
+
+	path := filepath.Join(c.dir, key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	now := time.Now()
+	os.Chtimes(path, now, now) // zuletzt genutzt, siehe prune
+	c.remember(key, string(data))
+	return string(data), true
+}
+
+// remember legt einen Eintrag im Speicher ab und hält maxMemory ein (mu gesperrt)
+func (c *renderCache) remember(key, value string) {
+	if old, ok := c.memory[key]; ok {
+		c.size -= len(old)
+	} else {
+		c.order = append(c.order, key)
+	}
+	c.memory[key] = value
+	c.size += len(value)
+	for c.maxMemory > 0 && c.size > c.maxMemory && len(c.order) > 0 {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+		c.size -= len(c.memory[oldest])
+		delete(c.memory, oldest)
+	}
+}
+
+// put legt einen Eintrag ab. Schreibfehler sind unkritisch und werden ignoriert.
+func (c *renderCache) put(key, value string) {
+	if c.maxEntry > 0 && len(value) > c.maxEntry {
+		return
+	}
+	c.mu.Lock()
+	c.remember(key, value)
+	c.mu.Unlock()
+	if c.dir == "" {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return
+	}
+
+	// Über temporäre Datei schreiben, damit parallele Instanzen keine halben Einträge lesen
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.WriteString(value)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	os.Rename(tmp.Name(), filepath.Join(c.dir, key))
+}
+
+// clear leert den Cache im Speicher und auf der Festplatte
+func (c *renderCache) clear() error {
+	c.mu.Lock()
+	c.memory, c.order, c.size = make(map[string]string), nil, 0
+	c.mu.Unlock()
+	if c.dir == "" {
+		return nil
+	}
+	return os.RemoveAll(c.dir)
+}
+
+// prune löscht auf der Festplatte Einträge, die länger als maxAge nicht genutzt
+// wurden, danach die am längsten ungenutzten, bis höchstens maxBytes übrig sind.
+// Mit stale lassen sich zusätzlich veraltete Einträge anhand des Namens entfernen.
+func (c *renderCache) prune(maxAge time.Duration, maxBytes int64, stale func(name string) bool) {
+	if c.dir == "" {
+		return
+	}
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	type file struct {
+		name string
+		size int64
+		used time.Time
+	}
+	var files []file
+	var total int64
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if time.Since(info.ModTime()) > maxAge || (stale != nil && stale(e.Name())) {
+			os.Remove(filepath.Join(c.dir, e.Name()))
+			continue
+		}
+		files = append(files, file{e.Name(), info.Size(), info.ModTime()})
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].used.Before(files[j].used) })
+	for _, f := range files {
+		if total <= maxBytes {
+			break
+		}
+		os.Remove(filepath.Join(c.dir, f.name))
+		total -= f.size
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderCacheLimits(t *testing.T) {
+	c := newRenderCache("")
+	c.maxEntry, c.maxMemory = 10, 20
+
+	c.put("gross", strings.Repeat("x", 11))
+	if _, ok := c.get("gross"); ok {
+		t.Error("Eintrag über maxEntry gespeichert")
+	}
+	for _, key := range []string{"a", "b", "c"} {
+		c.put(key, strings.Repeat(key, 10))
+	}
+	if _, ok := c.get("a"); ok {
+		t.Error("ältester Eintrag trotz maxMemory behalten")
+	}
+	if _, ok := c.get("c"); !ok || c.size > c.maxMemory {
+		t.Errorf("neuester Eintrag fehlt oder %d Bytes im Speicher", c.size)
+	}
+}
+
+func TestRenderCachePrune(t *testing.T) {
+	dir := t.TempDir()
+	c := newRenderCache(dir)
+	old := time.Now().Add(-48 * time.Hour)
+	for i, name := range []string{"alt", "v1-a", "v2-a", "v2-b", "v2-c"} {
+		c.put(name, "0123456789")
+		used := time.Now().Add(time.Duration(i-10) * time.Minute)
+		if name == "alt" {
+			used = old
+		}
+		os.Chtimes(filepath.Join(dir, name), used, used)
+	}
+
+	c.prune(24*time.Hour, 20, func(name string) bool { return strings.HasPrefix(name, "v1-") })
+
+	for name, want := range map[string]bool{"alt": false, "v1-a": false, "v2-a": false, "v2-b": true, "v2-c": true} {
+		if _, err := os.Stat(filepath.Join(dir, name)); (err == nil) != want {
+			t.Errorf("%s vorhanden = %v, erwartet %v", name, err == nil, want)
+		}
+	}
+}
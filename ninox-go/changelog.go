@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// =============================================================================
+// CHANGELOG.md aus einer Folge von Extraktionen
+// =============================================================================
+
+// scriptKinds benennt Script-Kategorien für das Changelog (übersetzt per docText)
+var scriptKinds = map[string]string{
+	"TRIGGER":         "Trigger",
+	"BUTTON":          "Button",
+	"FORMULA":         "Formel",
+	"GLOBAL_FUNCTION": "Globaler Code",
+	"PERMISSION":      "Berechtigung",
+	"REPORT":          "Drucklayout-Formel",
+	"AUTOMATION":      "Automatisierung",
+}
+
+// changelogEntry formuliert eine Änderung als Satz, z.B.
+// "Feld „Email" zu Kunden hinzugefügt (string)"
+func changelogEntry(c SchemaChange) string {
+	verb := docText(map[changeKind]string{
+		changeAdded:   "hinzugefügt",
+		changeRemoved: "entfernt",
+		changeChanged: "geändert",
+	}[c.Kind])
+
+	switch c.Object {
+	case objectTable:
+		return docf("Tabelle „%s“ %s", c.Table, verb)
+
+	case objectField:
+		place := "in " + c.Table
+		if c.Kind == changeAdded {
+			place = docf("zu %s", c.Table)
+		}
+		entry := docf("Feld „%s“ %s %s", c.Name, place, verb)
+		if c.Detail != "" {
+			entry += " (" + c.Detail + ")"
+		}
+		return entry
+
+	default:
+		kind, ok := scriptKinds[strings.ToUpper(c.Category)]
+		if !ok {
+			kind = "Script"
+		}
+		kind = docText(kind)
+		// Name ist "Element:Typ" oder nur der Typ
+		entry := docf("%s „%s“", kind, c.Name)
+		if element, codeType, ok := strings.Cut(c.Name, ":"); ok {
+			entry = docf("%s „%s“ (%s)", kind, element, codeType)
+		}
+		if c.Table != "" {
+			entry += " in " + c.Table
+		}
+		entry += " " + verb
+		switch c.Kind {
+		case changeChanged:
+			entry += docf(" (%d Zeilen geändert)", c.Lines)
+		default:
+			entry += docf(" (%d Zeilen)", c.Lines)
+		}
+		if by := c.Attribution(); by != "" {
+			entry += " " + by
+		}
+		return entry
+	}
+}
+
+// snapshotDate liefert das Datum einer Extraktion (JJJJ-MM-TT), ersatzweise das Dateidatum
+func snapshotDate(db *NinoxDB) string {
+	if date, err := db.GetLatestExtractionDate(); err == nil && len(date) >= 10 {
+		return date[:10]
+	}
+	if info, err := os.Stat(db.path); err == nil {
+		return info.ModTime().Format("2006-01-02")
+	}
+	return docText("unbekannt")
+}
+
+// writeChangelogSection schreibt die Änderungen eines Standes, gruppiert nach Datenbank
+func writeChangelogSection(b *bytes.Buffer, date, label string, diff *SnapshotDiff) {
+	fmt.Fprintf(b, "## %s (%s)\n\n", date, label)
+	if len(diff.Changes) == 0 {
+		b.WriteString(docText("Keine Änderungen.") + "\n\n")
+		return
+	}
+
+	// Felder und Scripts neuer bzw. entfernter Tabellen stecken in deren Eintrag
+	wholeTables := make(map[string]changeKind)
+	for _, c := range diff.Changes {
+		if c.Object == objectTable {
+			wholeTables[c.Database+"/"+c.Table] = c.Kind
+		}
+	}
+
+	database := ""
+	first := true
+	for _, c := range diff.Changes {
+		if kind, ok := wholeTables[c.Database+"/"+c.Table]; ok && c.Object != objectTable && c.Kind == kind {
+			continue
+		}
+		if first || c.Database != database {
+			if !first {
+				b.WriteString("\n")
+			}
+			first = false
+			database = c.Database
+			fmt.Fprintf(b, "### %s\n\n", database)
+		}
+		fmt.Fprintf(b, "- %s\n", changelogEntry(c))
+	}
+	b.WriteString("\n")
+}
+
+// cmdChangelog erzeugt ein Changelog aus zwei oder mehr Extraktionen (älteste zuerst).
+// Die neuesten Änderungen stehen oben.
+func cmdChangelog(args []string) int {
+	opts := parseCLIOptions(args)
+	applyDocLang(opts.lang)
+	if len(opts.positional) < 2 {
+		fail(exitUsage, "Verwendung: ninox-tui changelog ALT.db [ZWISCHENSTAND.db ...] NEU.db [--lang de|en]")
+	}
+
+	dbs := make([]*NinoxDB, len(opts.positional))
+	for i, path := range opts.positional {
+		dbs[i] = openCLIDB(path)
+		defer dbs[i].Close()
+	}
+
+	var b bytes.Buffer
+	b.WriteString("# Changelog\n\n")
+	changes := 0
+	for i := len(dbs) - 1; i > 0; i-- {
+		diff, err := DiffSnapshots(dbs[i-1], dbs[i])
+		if err != nil {
+			fail(exitDBError, "%v", err)
+		}
+		changes += len(diff.Changes)
+		writeChangelogSection(&b, snapshotDate(dbs[i]), opts.positional[i], diff)
+	}
+
+	if _, err := os.Stdout.Write(b.Bytes()); err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	if changes == 0 {
+		return exitNoResults
+	}
+	return exitOK
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	reflowtruncate "github.com/muesli/reflow/truncate"
+)
+
+// =============================================================================
+// Tastenübersicht (Overlay)
+// =============================================================================
+
+// navigationKeys gelten in allen Listenansichten
+var navigationKeys = []key.Binding{keys.Up, keys.Down, keys.Enter, keys.Back}
+
+// globalKeys sind in jeder Ansicht erreichbar
+var globalKeys = []key.Binding{
+	keys.Crumb, keys.Switch, keys.JumpBack, keys.JumpFwd, keys.DBTab, keys.Reindex, keys.Ignored, keys.Archived, keys.Search, keys.Finder, keys.AllScripts, keys.Stats, keys.SQL, keys.Queries, keys.Roles, keys.Automations, keys.Findings, keys.Identifiers, keys.Changes, keys.Teams, keys.Files, keys.CopyMode, keys.Help, keys.Quit,
+}
+
+// viewKeys listet die zusätzlich gültigen Tasten je Ansicht
+var viewKeys = map[viewMode][]key.Binding{
+	viewDatabases:   {keys.ByValue, keys.Sort, keys.SortDir, keys.Compare, keys.Export, keys.CopyLink, keys.Mermaid},
+	viewTables:      {keys.ByValue, keys.Sort, keys.SortDir, keys.Raw, keys.Layout, keys.Path, keys.CopyLink, keys.Mermaid},
+	viewFields:      {keys.ByValue, keys.History, keys.Sort, keys.SortDir, keys.Tab, keys.Raw, keys.Layout, keys.OptionRefs, keys.RelDir, keys.RelSort, keys.CopyLink, keys.Mermaid},
+	viewScripts:     {keys.Mark, keys.DiffMarked, keys.ByValue, keys.Frequency, keys.History, keys.Sort, keys.SortDir, keys.Tab, keys.Layout, keys.CopyMeta, keys.Edit, keys.CopyLink},
+	viewCode:        {keys.ByValue, keys.History, keys.FindAll, keys.PageUp, keys.PageDown, keys.Copy, keys.Export, keys.CopyMeta, keys.Edit, keys.CopyLink},
+	viewSearch:      {keys.Mark, keys.DiffMarked, keys.ByValue, keys.Frequency, keys.History, keys.Sort, keys.SortDir, keys.Filter, keys.CopyMeta, keys.Edit, keys.CopyLink},
+	viewAllScripts:  {keys.Mark, keys.DiffMarked, keys.ByValue, keys.Frequency, keys.History, keys.Sort, keys.SortDir, keys.Filter, keys.Undo, keys.More, keys.Less, keys.PageUp, keys.PageDown, keys.Export, keys.CopyMeta, keys.Edit, keys.CopyLink},
+	viewSQL:         {keys.Left, keys.Right, keys.Save, keys.Export},
+	viewStats:       {keys.Tab},
+	viewPath:        {keys.PageUp, keys.PageDown},
+	viewRoles:       {keys.Filter},
+	viewAutomations: {keys.Tab},
+	viewFindings:    {keys.Tab, keys.Export},
+	viewChanges:     {keys.Tab},
+	viewIdentifiers: {keys.Filter, keys.Tab},
+	viewDiff:        {keys.Tab, keys.PageUp, keys.PageDown},
+}
+
+// cheatsheetKeys liefert die in der aktuellen Ansicht gültigen Tasten
+func (m Model) cheatsheetKeys() []key.Binding {
+	bindings := append([]key.Binding{}, navigationKeys...)
+	bindings = append(bindings, viewKeys[m.mode]...)
+	return append(bindings, globalKeys...)
+}
+
+// renderCheatsheet rendert die Tastenübersicht als Kasten
+func (m Model) renderCheatsheet() string {
+	var b strings.Builder
+	keyStyle := lipgloss.NewStyle().Bold(true).Foreground(currentTheme.Accent)
+	b.WriteString(titleStyle.Render("⌨ Tasten") + "\n\n")
+
+	for _, binding := range m.cheatsheetKeys() {
+		if !binding.Enabled() {
+			continue
+		}
+		h := binding.Help()
+		b.WriteString(fmt.Sprintf("%s %s\n", keyStyle.Render(fmt.Sprintf("%-8s", h.Key)), h.Desc))
+	}
+	b.WriteString("\n" + mutedStyle.Render("? vollständige Hilfe"))
+
+	return statsBoxStyle.Render(b.String())
+}
+
+// overlayRight legt fg rechtsbündig über bg, beginnend bei Zeile top.
+// bg wird dafür bis zur Terminalhöhe mit Leerzeilen aufgefüllt.
+func overlayRight(bg, fg string, width, height, top int) string {
+	bgLines := strings.Split(bg, "\n")
+	for len(bgLines) < height {
+		bgLines = append(bgLines, "")
+	}
+	fgLines := strings.Split(fg, "\n")
+	fgWidth := lipgloss.Width(fg)
+	left := max(0, width-fgWidth-1)
+
+	for i, line := range fgLines {
+		row := top + i
+		if row >= len(bgLines) {
+			break
+		}
+		base := reflowtruncate.String(bgLines[row], uint(left))
+		pad := left - lipgloss.Width(base)
+		bgLines[row] = base + strings.Repeat(" ", max(0, pad)) + line
+	}
+	return strings.Join(bgLines, "\n")
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// =============================================================================
+// Auswahlwerte von Auswahlfeldern (IDs und Beschriftungen)
+// =============================================================================
+
+// FieldOption ist ein Auswahlwert eines Auswahl- oder Mehrfachauswahlfeldes
+type FieldOption struct {
+	ID      string // Nummer, mit der Scripts vergleichen (z.B. Status = 3)
+	Caption string
+	Color   string
+}
+
+// optionKey identifiziert die Auswahlwerte eines Feldes
+func optionKey(databaseID, tableID, fieldID string) string {
+	return databaseID + "/" + tableID + "/" + fieldID
+}
+
+// HasFieldOptions meldet, ob die Extraktion Auswahlwerte enthält (ältere nicht)
+func (db *NinoxDB) HasFieldOptions() bool {
+	return len(db.tableColumns("field_options")) > 0
+}
+
+// GetFieldOptions lädt alle Auswahlwerte, gruppiert nach Feld in der Reihenfolge aus Ninox
+func (db *NinoxDB) GetFieldOptions() (map[string][]FieldOption, error) {
+	options := make(map[string][]FieldOption)
+	if !db.HasFieldOptions() {
+		return options, nil
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT database_id, table_id, field_id, option_id, COALESCE(caption, ''), COALESCE(color, '')
+		FROM field_options
+		ORDER BY database_id, table_id, field_id, sort_order, CAST(option_id AS INTEGER), option_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dbID, tableID, fieldID string
+		var o FieldOption
+		if err := rows.Scan(&dbID, &tableID, &fieldID, &o.ID, &o.Caption, &o.Color); err != nil {
+			return nil, err
+		}
+		k := optionKey(dbID, tableID, fieldID)
+		options[k] = append(options[k], o)
+	}
+	return options, rows.Err()
+}
+
+// optionMatchExpr liefert einen SQL-Ausdruck, der den ersten zum Suchbegriff passenden
+// Auswahlwert eines Feldes als "3 = Gesperrt" liefert (ohne Tabelle: NULL).
+// Der Ausdruck erwartet einen Parameter für das LIKE-Muster.
+func (db *NinoxDB) optionMatchExpr(alias string) (string, bool) {
+	if !db.HasFieldOptions() {
+		return "NULL", false
+	}
+	return `(SELECT o.option_id || ' = ' || o.caption FROM field_options o
+		WHERE o.database_id = ` + alias + `database_id AND o.table_id = ` + alias + `table_id
+		  AND o.field_id = ` + alias + `field_id AND o.caption LIKE ?
+		ORDER BY o.sort_order LIMIT 1)`, true
+}
+
+// renderFieldOptions rendert die Auswahlwerte des ausgewählten Feldes
+func (m Model) renderFieldOptions() string {
+	if m.selectedField >= len(m.fields) {
+		return ""
+	}
+	f := m.fields[m.selectedField]
+	options := m.fieldOptions[optionKey(f.DatabaseID, f.TableID, f.FieldID)]
+	if len(options) == 0 {
+		return ""
+	}
+
+	name := f.Caption
+	if name == "" {
+		name = f.Name
+	}
+
+	var b strings.Builder
+	b.WriteString("\n" + titleStyle.Render("🔘 Auswahlwerte: "+name) + "\n\n")
+	b.WriteString(tableHeaderStyle.Render(fmt.Sprintf("  %6s  %s", "ID", "Beschriftung")) + "\n")
+	for _, o := range options {
+		b.WriteString(tableCellStyle.Render(fmt.Sprintf("  %6s  %s", o.ID, truncate(o.Caption, max(10, m.width-20)))) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// Nicht-interaktive Unterbefehle
+// =============================================================================
+
+// cliCommands ordnet Unterbefehle ihren Funktionen zu; Rückgabe ist der Exit-Code
+var cliCommands = map[string]func(args []string) int{
+	"diff-script":    cmdDiffScript,
+	"gen-fixture":    cmdGenFixture,
+	"clear-cache":    cmdClearCache,
+	"drawio":         cmdDrawio,
+	"mermaid":        cmdMermaid,
+	"graph":          cmdGraph,
+	"serve":          cmdServe,
+	"extract":        cmdExtract,
+	"import-archive": cmdImportArchive,
+	"diff-snapshot":  cmdDiffSnapshot,
+	"diff":           cmdDiff,
+	"changelog":      cmdChangelog,
+	"path":           cmdPath,
+	"export-scripts": cmdExportScripts,
+	"git-export":     cmdGitExport,
+	"import-scripts": cmdImportScripts,
+	"push":           cmdPush,
+	"option-refs":    cmdOptionRefs,
+	"record-counts":  cmdRecordCounts,
+	"lint":           cmdLint,
+	"list":           cmdList,
+	"digest":         cmdDigest,
+	"export-html":    cmdExportHTML,
+}
+
+// cliOptions enthält die gemeinsamen Optionen der Unterbefehle
+type cliOptions struct {
+	dbPath         string
+	database       string // Name einer einzelnen Datenbank (leer = alle)
+	table          string // Name einer einzelnen Tabelle (list)
+	output         string // Ausgabeformat (--output)
+	format         string // Diagrammformat (graph --format)
+	noColor        bool
+	frontmatter    bool   // Scripts mit Frontmatter exportieren
+	nameTemplate   string // Namensvorlage der Script-Dateien (export-scripts, import-scripts, git-export)
+	plan           string // Änderungsplan schreiben (import-scripts)
+	team           string // Ninox-Team (push)
+	apiURL         string // Basis-URL der Ninox API (push)
+	apiKey         string // API-Key (extract, push; sonst NINOX_API_KEY)
+	counts         bool   // Datensätze je Tabelle mitzählen (extract)
+	update         bool   // nur geänderte Datenbanken neu schreiben (extract)
+	dryRun         bool   // nur anzeigen, nichts schreiben
+	yes            bool   // ohne Rückfrage übernehmen
+	refresh        bool   // live über die API abfragen (record-counts)
+	baseline       string // akzeptierte Befunde (lint)
+	updateBaseline bool   // Baseline neu schreiben (lint)
+	days           int    // Zeitraum in Tagen (digest)
+	webhook        string // Benachrichtigung bei Änderungen (diff-snapshot)
+	list           bool   // Stände des Verlaufs auflisten (diff)
+	lang           string // Sprache exportierter Dokumente (de, en)
+	author         string // Commit-Autor "Name <mail>" (git-export)
+	message        string // Vorlage der Commit-Nachricht (git-export)
+	webhookFormat  string // json, slack oder teams
+	listen         string // Adresse für serve
+	force          bool
+	context        int
+	positional     []string
+}
+
+// parseCLIOptions wertet die gemeinsamen Optionen aus
+func parseCLIOptions(args []string) cliOptions {
+	opts := cliOptions{context: diffContext, output: "text"}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--db":
+			opts.dbPath = argValue(args, &i)
+		case arg == "--config" || arg == "-c":
+			configPathOverride = argValue(args, &i)
+		case arg == "--output":
+			opts.output = argValue(args, &i)
+		case arg == "--format":
+			opts.format = argValue(args, &i)
+		case arg == "--json":
+			opts.output = "json"
+		case arg == "--database":
+			opts.database = argValue(args, &i)
+		case arg == "--table":
+			opts.table = argValue(args, &i)
+		case arg == "--quiet" || arg == "-q":
+			quiet = true
+		case arg == "--no-color":
+			opts.noColor = true
+		case arg == "--frontmatter":
+			opts.frontmatter = true
+		case arg == "--name-template":
+			opts.nameTemplate = argValue(args, &i)
+		case arg == "--plan":
+			opts.plan = argValue(args, &i)
+		case arg == "--team":
+			opts.team = argValue(args, &i)
+		case arg == "--api-url":
+			opts.apiURL = argValue(args, &i)
+		case arg == "--api-key":
+			opts.apiKey = argValue(args, &i)
+		case arg == "--counts":
+			opts.counts = true
+		case arg == "--update":
+			opts.update = true
+		case arg == "--dry-run" || arg == "-n":
+			opts.dryRun = true
+		case arg == "--yes" || arg == "-y":
+			opts.yes = true
+		case arg == "--refresh":
+			opts.refresh = true
+		case arg == "--baseline":
+			opts.baseline = argValue(args, &i)
+		case arg == "--update-baseline":
+			opts.updateBaseline = true
+		case arg == "--allow-write":
+			writeAccess.allow = true
+		case arg == "--write-to":
+			writeAccess.sidecar = argValue(args, &i)
+		case arg == "--state-dir":
+			stateDirOverride = argValue(args, &i)
+		case arg == "--no-cache":
+			highlightCache = newRenderCache("")
+			noRemoteCache = true
+		case arg == "--webhook":
+			opts.webhook = argValue(args, &i)
+		case arg == "--webhook-format":
+			opts.webhookFormat = argValue(args, &i)
+		case arg == "--list":
+			opts.list = true
+		case arg == "--lang":
+			opts.lang = argValue(args, &i)
+		case arg == "--author":
+			opts.author = argValue(args, &i)
+		case arg == "--message":
+			opts.message = argValue(args, &i)
+		case arg == "--listen":
+			opts.listen = argValue(args, &i)
+		case arg == "--days":
+			n, err := strconv.Atoi(argValue(args, &i))
+			if err != nil || n < 1 {
+				fail(exitUsage, "Ungültige Anzahl Tage: %s", args[i])
+			}
+			opts.days = n
+		case arg == "--force" || arg == "-f":
+			opts.force = true
+		case arg == "-U" || arg == "--unified":
+			n, err := strconv.Atoi(argValue(args, &i))
+			if err != nil || n < 0 {
+				fail(exitUsage, "Ungültige Kontextzeilen: %s", args[i])
+			}
+			opts.context = n
+		case strings.HasPrefix(arg, "-U") && len(arg) > 2:
+			n, err := strconv.Atoi(arg[2:])
+			if err != nil || n < 0 {
+				fail(exitUsage, "Ungültige Kontextzeilen: %s", arg)
+			}
+			opts.context = n
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			fail(exitUsage, "Unbekannte Option: %s", arg)
+		default:
+			opts.positional = append(opts.positional, arg)
+		}
+	}
+
+	// Wie in der TUI: --db > NINOX_TUI_DB > db aus der Konfiguration
+	if opts.dbPath == "" {
+		cfg, err := LoadConfig(activeConfigPath())
+		if err != nil {
+			fail(exitUsage, "%v", err)
+		}
+		cfg.ApplyEnv()
+		opts.dbPath = firstNonEmpty(cfg.DB, "ninox_schema.db")
+	}
+	return opts
+}
+
+// openCLIDB öffnet die Datenbank für einen Unterbefehl
+func openCLIDB(path string) *NinoxDB {
+	if _, err := os.Stat(path); os.IsNotExist(err) && isFileDB(path) {
+		fail(exitDBError, "Datenbank nicht gefunden: %s", path)
+	}
+	db, err := NewNinoxDB(path)
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+	if notice := db.CompatNotice(); notice != "" && !quiet {
+		fmt.Fprintln(os.Stderr, "⚠ "+notice)
+	}
+	if cfg, err := LoadConfig(activeConfigPath()); err == nil {
+		setCodeGroups(cfg.CodeGroups)
+		if err := setScriptNaming(cfg.ScriptNameTemplate); err != nil {
+			fail(exitUsage, "%v", err)
+		}
+	}
+	return db
+}
+
+// printDiff gibt einen Diff aus, eingefärbt sofern nicht abgeschaltet
+func printDiff(diff string, noColor bool) {
+	if !noColor {
+		diff = colorizeDiff(diff)
+	}
+	fmt.Print(diff)
+}
+
+// cmdDiffScript vergleicht zwei Scripts anhand ihrer ID
+func cmdDiffScript(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) != 2 {
+		fail(exitUsage, "Verwendung: ninox-tui diff-script ID1 ID2 [-U N] [--no-color] [--db datei]")
+	}
+
+	ids := make([]int, 2)
+	for i, arg := range opts.positional {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			fail(exitUsage, "Ungültige Script-ID: %s", arg)
+		}
+		ids[i] = id
+	}
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+
+	a, err := db.GetScript(ids[0])
+	if err != nil {
+		fail(exitDBError, "Script %d: %v", ids[0], err)
+	}
+	b, err := db.GetScript(ids[1])
+	if err != nil {
+		fail(exitDBError, "Script %d: %v", ids[1], err)
+	}
+
+	// Gleicher Hash: identischer Code, kein Diff nötig
+	if a.Hash == b.Hash {
+		return exitNoResults
+	}
+
+	diff := UnifiedDiff(scriptLabel(*a), scriptLabel(*b), a.Code, b.Code, opts.context)
+	if diff == "" {
+		return exitNoResults
+	}
+	printDiff(diff, opts.noColor)
+	return exitOK
+}
+
+// scriptLabel beschreibt die Herkunft eines Scripts in einer Zeile
+func scriptLabel(s Script) string {
+	parts := []string{s.DatabaseName}
+	if s.TableName != "" {
+		parts = append(parts, s.TableName)
+	}
+	if s.ElementName != "" {
+		parts = append(parts, s.ElementName)
+	}
+	return strings.Join(parts, "/") + ":" + s.CodeType
+}
+
+// cmdClearCache löscht den Render-Cache und den Cache entfernter Abfragen
+func cmdClearCache(args []string) int {
+	parseCLIOptions(args)
+	for _, cache := range []*renderCache{highlightCache, remoteResults()} {
+		if err := cache.clear(); err != nil {
+			fail(exitFailure, "Cache löschen: %v", err)
+		}
+		if !quiet && cache.dir != "" {
+			fmt.Printf("✓ Cache gelöscht: %s\n", cache.dir)
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/muesli/termenv"
+)
+
+// =============================================================================
+// Zwischenablage
+// =============================================================================
+
+// copyToClipboard kopiert Text in die System-Zwischenablage.
+// Ohne lokale Zwischenablage (z.B. per SSH) wird OSC 52 verwendet.
+func copyToClipboard(text string) (via string) {
+	if err := clipboard.WriteAll(text); err == nil {
+		return "Zwischenablage"
+	}
+	termenv.Copy(text)
+	return "OSC 52"
+}
+
+// scriptWithHeader stellt dem Code einen Kommentar-Kopf mit der Herkunft voran
+func scriptWithHeader(s Script, extractedAt string) string {
+	element := s.ElementName
+	if element == "" {
+		element = "(Tabelle)"
+	}
+
+	lines := []string{
+		fmt.Sprintf("// Datenbank: %s", s.DatabaseName),
+		fmt.Sprintf("// Tabelle:   %s", s.TableName),
+		fmt.Sprintf("// Element:   %s", element),
+		fmt.Sprintf("// Typ:       %s (%s)", s.CodeType, s.Group()),
+	}
+	if extractedAt != "" {
+		lines = append(lines, fmt.Sprintf("// Extrahiert: %s", extractedAt))
+	}
+
+	return strings.Join(lines, "\n") + "\n\n" + s.Code
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -689,47 +690,132 @@ func highlightCode(code string) string {
 // Main
 // =============================================================================
 
+// Exit-Codes für die Verwendung in Shell-Skripten
+const (
+	exitOK        = 0 // Erfolgreich, Ergebnisse gefunden
+	exitNoResults = 1 // Keine Ergebnisse
+	exitUsage     = 2 // Aufruffehler
+	exitDBError   = 3 // Datenbank fehlt oder ist nicht lesbar
+	exitFailure   = 4 // Sonstiger Laufzeitfehler
+)
+
+// quiet unterdrückt dekorative Ausgaben (--quiet)
+var quiet bool
+
+func fail(code int, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if !quiet {
+		msg = "❌ " + msg
+	}
+	fmt.Fprintln(os.Stderr, msg)
+	os.Exit(code)
+}
+
+func argValue(args []string, i *int) string {
+	if *i+1 >= len(args) {
+		fail(exitUsage, "Option %s benötigt einen Wert", args[*i])
+	}
+	*i++
+	return args[*i]
+}
+
+// writeScripts schreibt Scripts als Klartext (ohne TUI)
+func writeScripts(w io.Writer, scripts []Script) error {
+	sep := strings.Repeat("=", 60)
+	for _, s := range scripts {
+		elem := s.ElementName
+		if elem == "" {
+			elem = "(Tabelle)"
+		}
+		header := fmt.Sprintf("%s > %s > %s [%s/%s] (%d Zeilen)",
+			s.DatabaseName, s.TableName, elem, s.CodeType, s.CodeCategory, s.LineCount)
+		if !quiet {
+			header = sep + "\n" + header + "\n" + sep
+		}
+		if _, err := fmt.Fprintf(w, "%s\n%s\n\n", header, s.Code); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func main() {
 	dbPath := "ninox_schema.db"
+	filter := ""
+	output := ""
 
 	args := os.Args[1:]
-	for _, arg := range args {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
 		switch arg {
 		case "--help", "-h":
 			printUsage()
-			os.Exit(0)
+			os.Exit(exitOK)
 		case "--dark", "-d":
 			theme = DarkTheme
 		case "--light", "-l":
 			theme = LightTheme
+		case "--filter":
+			filter = argValue(args, &i)
+		case "--output", "-o":
+			output = argValue(args, &i)
+		case "--quiet", "-q":
+			quiet = true
 		default:
 			if !strings.HasPrefix(arg, "-") {
 				dbPath = arg
 			} else {
-				fmt.Printf("Unbekannte Option: %s\n", arg)
-				printUsage()
-				os.Exit(1)
+				fmt.Fprintf(os.Stderr, "Unbekannte Option: %s\n", arg)
+				if !quiet {
+					printUsage()
+				}
+				os.Exit(exitUsage)
 			}
 		}
 	}
 
 	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
-		fmt.Printf("❌ Datenbank nicht gefunden: %s\n", dbPath)
-		fmt.Println("   Bitte zuerst Daten extrahieren.")
-		os.Exit(1)
+		if quiet {
+			fail(exitDBError, "Datenbank nicht gefunden: %s", dbPath)
+		}
+		fail(exitDBError, "Datenbank nicht gefunden: %s\n   Bitte zuerst Daten extrahieren.", dbPath)
 	}
 
 	scripts, err := loadScripts(dbPath)
 	if err != nil {
-		fmt.Printf("❌ Fehler: %v\n", err)
-		os.Exit(1)
+		fail(exitDBError, "Fehler: %v", err)
+	}
+
+	// Nicht-interaktiver Export
+	if output != "" {
+		var w io.Writer = os.Stdout
+		if output != "-" {
+			f, err := os.Create(output)
+			if err != nil {
+				fail(exitFailure, "Fehler: %v", err)
+			}
+			defer f.Close()
+			w = f
+		}
+		filtered := filterScripts(scripts, filter)
+		if err := writeScripts(w, filtered); err != nil {
+			fail(exitFailure, "Fehler: %v", err)
+		}
+		if len(filtered) == 0 {
+			os.Exit(exitNoResults)
+		}
+		return
 	}
 
 	model := NewModel(scripts)
+	if filter != "" {
+		model.filterText = filter
+		model.filterInput.SetValue(filter)
+		model.applyFilter()
+	}
 	p := tea.NewProgram(model, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
-		fmt.Printf("Fehler: %v\n", err)
-		os.Exit(1)
+		fail(exitFailure, "Fehler: %v", err)
 	}
 }
 
@@ -744,6 +830,9 @@ func printUsage() {
 	fmt.Println("Optionen:")
 	fmt.Println("  --dark, -d   Dunkles Farbschema (Standard)")
 	fmt.Println("  --light, -l  Helles Farbschema")
+	fmt.Println("  --filter A   Liste mit Filter öffnen")
+	fmt.Println("  --output F   Gefilterte Scripts in Datei F schreiben (- = stdout), ohne TUI")
+	fmt.Println("  --quiet, -q  Keine dekorativen Ausgaben (für Skripte)")
 	fmt.Println("  --help, -h   Diese Hilfe")
 	fmt.Println("")
 	fmt.Println("Tasten:")
@@ -758,4 +847,8 @@ func printUsage() {
 	fmt.Println("  text         Einfache Suche")
 	fmt.Println("  A AND B      Beide müssen vorkommen")
 	fmt.Println("  A OR B       Einer muss vorkommen")
+	fmt.Println("")
+	fmt.Println("Exit-Codes:")
+	fmt.Println("  0 Ergebnisse gefunden • 1 keine Ergebnisse • 2 Aufruffehler")
+	fmt.Println("  3 Datenbankfehler • 4 sonstiger Fehler")
 }
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Alle Treffer im geöffneten Script (wie die Suchergebnisse im Editor)
+// =============================================================================
+
+// maxMatchRows ist die Höhe der Trefferliste unter dem Code
+const maxMatchRows = 8
+
+// codeMatch ist eine Zeile des Scripts mit Treffer
+type codeMatch struct {
+	line int // 1-basiert
+	text string
+}
+
+// findAllMatches sucht den Begriff (ohne Groß-/Kleinschreibung) zeilenweise im Code
+func findAllMatches(code, query string) []codeMatch {
+	query = strings.ToLower(query)
+	var matches []codeMatch
+	for i, line := range strings.Split(code, "\n") {
+		if strings.Contains(strings.ToLower(line), query) {
+			matches = append(matches, codeMatch{line: i + 1, text: strings.TrimSpace(line)})
+		}
+	}
+	return matches
+}
+
+// showsCodeMatches meldet, ob die Trefferliste zum angezeigten Script gehört
+func (m Model) showsCodeMatches() bool {
+	return m.mode == viewCode && m.codeMatches != nil && m.codeScript != nil && m.codeScript.ID == m.codeMatchScript
+}
+
+// startCodeFind öffnet die Eingabe für die Suche im aktuellen Script
+func (m Model) startCodeFind() (tea.Model, tea.Cmd) {
+	if m.mode != viewCode || m.codeScript == nil {
+		return m, nil
+	}
+	m.codeFinding = true
+	m.codeFindInput.SetValue(m.codeMatchQuery)
+	m.codeFindInput.CursorEnd()
+	m.codeFindInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleCodeFindInput verarbeitet die Eingabe des Suchbegriffs
+func (m Model) handleCodeFindInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch {
+	case key.Matches(msg, keys.Back):
+		m.codeFinding = false
+		m.codeFindInput.Blur()
+		return m, nil
+	case key.Matches(msg, keys.Enter):
+		m.codeFinding = false
+		m.codeFindInput.Blur()
+		query := m.codeFindInput.Value()
+		if query == "" || m.codeScript == nil {
+			m.codeMatches = nil
+			return m, nil
+		}
+		matches := findAllMatches(m.codeScript.Code, query)
+		if len(matches) == 0 {
+			m.status = fmt.Sprintf("Keine Treffer für %q", query)
+			return m, nil
+		}
+		m.codeMatches = matches
+		m.codeMatchQuery = query
+		m.codeMatchScript = m.codeScript.ID
+		m.selectedMatch = 0
+		m.scrollToMatch()
+		return m, nil
+	default:
+		m.codeFindInput, cmd = m.codeFindInput.Update(msg)
+		return m, cmd
+	}
+}
+
+// handleCodeMatchKey bedient die Trefferliste; handled = false reicht die Taste weiter
+func (m Model) handleCodeMatchKey(msg tea.KeyMsg) (model tea.Model, cmd tea.Cmd, handled bool) {
+	switch {
+	case key.Matches(msg, keys.Up):
+		if m.selectedMatch > 0 {
+			m.selectedMatch--
+			m.scrollToMatch()
+		}
+	case key.Matches(msg, keys.Down):
+		if m.selectedMatch < len(m.codeMatches)-1 {
+			m.selectedMatch++
+			m.scrollToMatch()
+		}
+	case key.Matches(msg, keys.Enter), key.Matches(msg, keys.Back):
+		// Liste schließen, die Position im Code bleibt
+		m.codeMatches = nil
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// scrollToMatch zeigt die Zeile des ausgewählten Treffers mit etwas Kontext darüber
+func (m *Model) scrollToMatch() {
+	m.codeView.SetYOffset(max(0, m.codeMatches[m.selectedMatch].line-3))
+}
+
+// matchPanelHeight ist die Höhe der Eingabe bzw. der Trefferliste samt Titelzeile
+func (m Model) matchPanelHeight() int {
+	if m.codeFinding {
+		return 2
+	}
+	if !m.showsCodeMatches() {
+		return 0
+	}
+	return min(len(m.codeMatches), maxMatchRows) + 2
+}
+
+// renderCodeMatches rendert die Trefferliste (Zeilennummer und Ausschnitt)
+func (m Model) renderCodeMatches() string {
+	var b strings.Builder
+	title := fmt.Sprintf("🔎 %d Treffer für %q (↑↓ springen, Enter/Esc schließen)", len(m.codeMatches), m.codeMatchQuery)
+	b.WriteString("\n" + titleStyle.Render(title) + "\n")
+
+	// Ausschnitt um die Auswahl
+	start := max(0, min(m.selectedMatch-maxMatchRows/2, len(m.codeMatches)-maxMatchRows))
+	end := min(len(m.codeMatches), start+maxMatchRows)
+	for i := start; i < end; i++ {
+		match := m.codeMatches[i]
+		style := tableCellStyle
+		prefix := "  "
+		if i == m.selectedMatch {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		row := fmt.Sprintf("%s%5d: %s", prefix, match.line, match.text)
+		b.WriteString(style.Render(truncate(row, max(20, m.width-10))) + "\n")
+	}
+	return b.String()
+}
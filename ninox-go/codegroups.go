@@ -0,0 +1,82 @@
+package main
+
+import "strings"
+
+// =============================================================================
+// Script-Gruppen: verständliche Oberbegriffe für code_type bzw. code_category,
+// einheitlich in Listen, Statistik und Exporten. code_groups in der
+// Konfiguration ergänzt oder ersetzt die Zuordnung.
+// =============================================================================
+
+// Standardgruppen
+const (
+	groupTrigger    = "Trigger"
+	groupFormula    = "Formeln"
+	groupButton     = "Buttons"
+	groupAPI        = "API"
+	groupPrint      = "Druck"
+	groupPermission = "Rechte"
+	groupAutomation = "Automatisierung"
+	groupGlobal     = "Global"
+	groupOther      = "Sonstiges"
+)
+
+// defaultCodeGroups ordnet Code-Typen und Kategorien (kleingeschrieben) einer Gruppe zu.
+// Der Code-Typ hat Vorrang, die Kategorie gilt für unbekannte Typen.
+var defaultCodeGroups = map[string]string{
+	// Code-Typen
+	"aftercreate": groupTrigger, "afterupdate": groupTrigger,
+	"afterdelete": groupTrigger, "beforedelete": groupTrigger,
+	"fn": groupFormula, "constraint": groupFormula, "validation": groupFormula,
+	"visibility": groupFormula, "referenceformat": groupFormula, "color": groupFormula,
+	"dchoicevalues": groupFormula, "dchoicecaption": groupFormula,
+	"dchoicecolor": groupFormula, "dchoiceicon": groupFormula,
+	"onclick": groupButton, "ondoubleclick": groupButton,
+	"webhook":  groupAPI,
+	"schedule": groupAutomation,
+	"printout": groupPrint, "reportfn": groupPrint, "reportvisible": groupPrint,
+	"canread": groupPermission, "canwrite": groupPermission,
+	"cancreate": groupPermission, "candelete": groupPermission,
+	"globalcode": groupGlobal,
+
+	// Kategorien (Go-Extraktor kleingeschrieben, Python-Extraktor groß)
+	"trigger": groupTrigger, "formula": groupFormula, "dchoice": groupFormula,
+	"reference": groupFormula, "button": groupButton, "report": groupPrint,
+	"permission": groupPermission, "automation": groupAutomation,
+	"global_function": groupGlobal, "global": groupGlobal,
+}
+
+// codeGroups ist die wirksame Zuordnung (Standard plus Konfiguration)
+var codeGroups = defaultCodeGroups
+
+// setCodeGroups ergänzt die Standardzuordnung um Einträge der Konfiguration,
+// z.B. {"onClick": "Aktionen", "http": "API"}
+func setCodeGroups(overrides map[string]string) {
+	codeGroups = defaultCodeGroups
+	if len(overrides) == 0 {
+		return
+	}
+	codeGroups = make(map[string]string, len(defaultCodeGroups)+len(overrides))
+	for k, v := range defaultCodeGroups {
+		codeGroups[k] = v
+	}
+	for k, v := range overrides {
+		codeGroups[strings.ToLower(k)] = v
+	}
+}
+
+// codeGroup liefert die Gruppe zu Code-Typ und Kategorie
+func codeGroup(codeType, category string) string {
+	if group, ok := codeGroups[strings.ToLower(codeType)]; ok {
+		return group
+	}
+	if group, ok := codeGroups[strings.ToLower(category)]; ok {
+		return group
+	}
+	return groupOther
+}
+
+// Group liefert die Script-Gruppe, z.B. "Trigger" oder "Druck"
+func (s Script) Group() string {
+	return codeGroup(s.CodeType, s.CodeCategory)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Datenbankvergleich
+// =============================================================================
+
+// compareStatus beschreibt das Ergebnis des Vergleichs einer Tabelle
+type compareStatus int
+
+const (
+	compareSame      compareStatus = iota // gleiche Felder
+	compareChanged                        // in beiden vorhanden, Felder abweichend
+	compareOnlyLeft                       // nur in der linken Datenbank
+	compareOnlyRight                      // nur in der rechten Datenbank
+)
+
+// TableComparison ist eine Zeile im Vergleich zweier Datenbanken
+type TableComparison struct {
+	Name        string
+	Left        *Table
+	Right       *Table
+	LeftFields  []Field
+	RightFields []Field
+	Status      compareStatus
+}
+
+// CompareTables vergleicht die Tabellen zweier Datenbanken anhand ihres Namens
+func (db *NinoxDB) CompareTables(leftID, rightID string) ([]TableComparison, error) {
+	left, err := db.GetTables(leftID)
+	if err != nil {
+		return nil, err
+	}
+	right, err := db.GetTables(rightID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make(map[string]*TableComparison)
+	for i := range left {
+		rows[left[i].Name] = &TableComparison{Name: left[i].Name, Left: &left[i]}
+	}
+	for i := range right {
+		if row, ok := rows[right[i].Name]; ok {
+			row.Right = &right[i]
+		} else {
+			rows[right[i].Name] = &TableComparison{Name: right[i].Name, Right: &right[i]}
+		}
+	}
+
+	result := make([]TableComparison, 0, len(rows))
+	for _, row := range rows {
+		if row.Left != nil {
+			if row.LeftFields, err = db.GetFields(leftID, row.Left.TableID); err != nil {
+				return nil, err
+			}
+		}
+		if row.Right != nil {
+			if row.RightFields, err = db.GetFields(rightID, row.Right.TableID); err != nil {
+				return nil, err
+			}
+		}
+
+		switch {
+		case row.Right == nil:
+			row.Status = compareOnlyLeft
+		case row.Left == nil:
+			row.Status = compareOnlyRight
+		case fieldListText(row.LeftFields) != fieldListText(row.RightFields):
+			row.Status = compareChanged
+		}
+		result = append(result, *row)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+	return result, nil
+}
+
+// fieldListText beschreibt die Felder einer Tabelle zeilenweise für den Vergleich
+func fieldListText(fields []Field) string {
+	var b strings.Builder
+	for _, f := range fields {
+		b.WriteString(f.Name + ": " + f.BaseType)
+		if f.RefTableName != "" {
+			b.WriteString(" → " + f.RefTableName)
+		}
+		if f.HasFormula {
+			b.WriteString(" ƒ")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// handleCompare merkt sich die erste Datenbank und öffnet beim zweiten Aufruf den Vergleich
+func (m Model) handleCompare() (tea.Model, tea.Cmd) {
+	if m.mode != viewDatabases || len(m.databases) == 0 {
+		return m, nil
+	}
+	selected := &m.databases[m.selectedDB]
+
+	if m.compareLeft == nil || m.compareLeft.ID == selected.ID {
+		m.compareLeft = selected
+		m.status = fmt.Sprintf("Vergleich: %s gewählt – zweite Datenbank wählen und c drücken", selected.Name)
+		return m, nil
+	}
+
+	rows, err := m.db.CompareTables(m.compareLeft.ID, selected.ID)
+	if err != nil {
+		m.status = fmt.Sprintf("❌ Vergleich fehlgeschlagen: %v", err)
+		return m, nil
+	}
+	m.compareRight = selected
+	m.compareRows = rows
+	m.selectedCompare = 0
+	m.mode = viewCompare
+	return m, nil
+}
+
+// openCompareDiff zeigt die Feldunterschiede der gewählten Tabelle als Diff
+func (m Model) openCompareDiff() (tea.Model, tea.Cmd) {
+	if m.selectedCompare >= len(m.compareRows) {
+		return m, nil
+	}
+	row := m.compareRows[m.selectedCompare]
+	diff := UnifiedDiff(
+		m.compareLeft.Name+"/"+row.Name, m.compareRight.Name+"/"+row.Name,
+		fieldListText(row.LeftFields), fieldListText(row.RightFields), diffContext)
+	m.openDiff("Felder "+row.Name+": "+m.compareLeft.Name+" ↔ "+m.compareRight.Name, diff)
+	return m, nil
+}
+
+// renderCompare rendert beide Tabellenlisten nebeneinander
+func (m Model) renderCompare() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf("⇄ Vergleich: %s ↔ %s", m.compareLeft.Name, m.compareRight.Name)) + "\n\n")
+
+	paneWidth := max(20, (m.width-16)/2)
+	header := fmt.Sprintf("    %-*s   %-*s", paneWidth, m.compareLeft.Name, paneWidth, m.compareRight.Name)
+	b.WriteString(tableHeaderStyle.Render(header) + "\n")
+
+	changedStyle := tableCellStyle.Copy().Foreground(currentTheme.Accent)
+	onlyLeftStyle := tableCellStyle.Copy().Foreground(diffRemoveStyle.GetForeground())
+	onlyRightStyle := tableCellStyle.Copy().Foreground(diffAddStyle.GetForeground())
+	pane := func(t *Table) string {
+		if t == nil {
+			return fmt.Sprintf("%-*s", paneWidth, "")
+		}
+		label := fmt.Sprintf("%s (%d)", t.Name, t.FieldCount)
+		return fmt.Sprintf("%-*s", paneWidth, truncate(label, paneWidth))
+	}
+
+	visible := max(3, m.height-14)
+	start := max(0, m.selectedCompare-visible+1)
+	end := min(len(m.compareRows), start+visible)
+
+	counts := make(map[compareStatus]int)
+	for _, row := range m.compareRows {
+		counts[row.Status]++
+	}
+
+	for i := start; i < end; i++ {
+		row := m.compareRows[i]
+		marker, style := " ", tableCellStyle
+		switch row.Status {
+		case compareChanged:
+			marker, style = "≠", changedStyle
+		case compareOnlyLeft:
+			marker, style = "−", onlyLeftStyle
+		case compareOnlyRight:
+			marker, style = "+", onlyRightStyle
+		}
+
+		prefix := "  "
+		if i == m.selectedCompare {
+			prefix = "▶ "
+			style = tableCellSelectedStyle
+		}
+		line := fmt.Sprintf("%s%s %s │ %s", prefix, marker, pane(row.Left), pane(row.Right))
+		b.WriteString(style.Render(line) + "\n")
+	}
+
+	summary := fmt.Sprintf("  %d gleich • %d abweichend • %d nur links • %d nur rechts",
+		counts[compareSame], counts[compareChanged], counts[compareOnlyLeft], counts[compareOnlyRight])
+	b.WriteString("\n" + mutedStyle.Render(summary))
+
+	return boxStyle.Width(m.width - 4).Render(b.String())
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Kompatibilität mit älteren und neueren Extraktionen
+// =============================================================================
+
+// optionalScriptColumns fehlen in älteren Extraktionen; ohne sie wird ausgewichen
+var optionalScriptColumns = []string{"code_category", "line_count", "code_hash"}
+
+// knownScriptColumns sind die Spalten der scripts-Tabelle laut schemaDDL
+var knownScriptColumns = map[string]bool{
+	"id": true, "team_id": true, "team_name": true, "database_id": true, "database_name": true,
+	"table_id": true, "table_name": true, "element_id": true, "element_name": true,
+	"code_type": true, "code_category": true, "code": true, "code_original": true,
+	"code_hash": true, "line_count": true, "created_at": true,
+	"modified_by": true, "modified_at": true,
+}
+
+// checkCompat prüft die scripts-Tabelle auf fehlende und zusätzliche Spalten
+// und merkt sich einen Hinweis für die Anzeige
+func (db *NinoxDB) checkCompat() {
+	columns := db.tableColumns("scripts")
+	db.hasCodeHash = columns["code_hash"]
+	db.hasCodeCategory = columns["code_category"]
+	db.hasLineCount = columns["line_count"]
+	db.hasModified = columns["modified_by"] && columns["modified_at"]
+
+	var missing, extra []string
+	for _, c := range optionalScriptColumns {
+		// code_hash fehlt in vielen Extraktionen und wird stillschweigend berechnet
+		if !columns[c] && c != "code_hash" {
+			missing = append(missing, c)
+		}
+	}
+	for c := range columns {
+		if !knownScriptColumns[c] {
+			extra = append(extra, c)
+		}
+	}
+	sort.Strings(extra)
+
+	if len(missing) > 0 {
+		db.compatNotes = append(db.compatNotes, "fehlende Spalten "+strings.Join(missing, ", ")+" (ausgeblendet bzw. berechnet)")
+	}
+	if len(extra) > 0 {
+		db.compatNotes = append(db.compatNotes, "unbekannte Spalten "+strings.Join(extra, ", ")+" (ignoriert)")
+	}
+}
+
+// CompatNotice beschreibt Abweichungen vom erwarteten Schema ("" = keine)
+func (db *NinoxDB) CompatNotice() string {
+	if len(db.compatNotes) == 0 {
+		return ""
+	}
+	return "Ältere/neuere Extraktion: " + strings.Join(db.compatNotes, "; ")
+}
+
+// HasCodeCategory meldet, ob die Extraktion Script-Kategorien enthält
+func (db *NinoxDB) HasCodeCategory() bool {
+	return db.hasCodeCategory
+}
+
+// categoryColumn liefert die Spalte code_category bzw. NULL, falls sie fehlt
+func (db *NinoxDB) categoryColumn(alias string) string {
+	if !db.hasCodeCategory {
+		return "NULL"
+	}
+	return alias + "code_category"
+}
+
+// lineCountColumn liefert line_count bzw. einen Ausdruck, der die Zeilen des Codes zählt
+func (db *NinoxDB) lineCountColumn(alias string) string {
+	if db.hasLineCount {
+		return alias + "line_count"
+	}
+	code := alias + "code"
+	return fmt.Sprintf("(CASE WHEN %[1]s IS NULL OR %[1]s = '' THEN 0 ELSE LENGTH(%[1]s) - LENGTH(REPLACE(%[1]s, '\n', '')) + 1 END)", code)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestConcurrentQueries fragt parallel ab, während Ausblendmuster und
+// Archiv-Umschalter wechseln (go test -race)
+func TestConcurrentQueries(t *testing.T) {
+	db := openFixture(t)
+	if err := db.SetArchived([]string{"ERP"}); err != nil {
+		t.Fatalf("SetArchived: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			view := db.WithContext(ctx)
+			for i := 0; i < 20; i++ {
+				if _, err := view.GetDatabases(); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := view.GetTables("crm001"); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := view.GetAllScripts(); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := view.SearchScripts("Betrag", 50); err != nil {
+					errs <- err
+					return
+				}
+				if w%2 == 0 {
+					db.SetShowArchived(i%2 == 0)
+				}
+			}
+		}(w)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		patterns := [][]string{nil, {"Projekte"}, {"CRM/Rechnungen"}, {"*_TEST"}}
+		for i := 0; i < 20; i++ {
+			if err := db.SetIgnore(patterns[i%len(patterns)]); err != nil {
+				errs <- err
+				return
+			}
+			db.SetShowIgnored(i%3 == 0)
+		}
+	}()
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// =============================================================================
+// Konfiguration
+// =============================================================================
+
+// SavedQuery ist eine benannte SQL-Abfrage, die als eigene Ansicht erscheint
+type SavedQuery struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	SQL         string `json:"sql"`
+}
+
+// Config enthält die Benutzereinstellungen aus der Konfigurationsdatei
+type Config struct {
+	// DB ist die Standard-Datenbank, wenn keine auf der Kommandozeile angegeben ist
+	DB string `json:"db,omitempty"`
+	// Theme ist das Farbschema (dark, light oder ein eigenes aus ThemeFile)
+	Theme string `json:"theme,omitempty"`
+	// ThemeFile enthält eigene Themes (Standard: themes.toml neben der Konfiguration)
+	ThemeFile string `json:"theme_file,omitempty"`
+	// Lang ist die Sprache für Ausgaben und Exporte (de, en)
+	Lang string `json:"lang,omitempty"`
+	// NinoxURL ist die Basis-URL der Ninox-Web-App (Standard: https://app.ninox.com)
+	NinoxURL string `json:"ninox_url,omitempty"`
+	// TeamID wird verwendet, wenn die Extraktion keine Team-ID enthält
+	TeamID string `json:"team_id,omitempty"`
+
+	SavedQueries []SavedQuery `json:"saved_queries,omitempty"`
+
+	// Ignore blendet Datenbanken, Tabellen und Scripts per Glob-Muster aus,
+	// z.B. "*_TEST", "Sandbox*" oder "CRM/Kunden/Test*"
+	Ignore []string `json:"ignore,omitempty"`
+
+	// Archived markiert Datenbanken per Namensmuster als archiviert, z.B. "Alt_*";
+	// sie erscheinen erst nach Umschalten (z) in Navigation, Suche und Statistik
+	Archived []string `json:"archived,omitempty"`
+
+	// CodeGroups ordnet Code-Typen bzw. Kategorien einer Script-Gruppe zu und
+	// ergänzt die Standardgruppen, z.B. {"onClick": "Aktionen", "schedule": "API"}
+	CodeGroups map[string]string `json:"code_groups,omitempty"`
+
+	// Finder wählt die Script-Auswahl (p): "fzf" oder Pfad zu fzf, sonst interne Suche
+	Finder string `json:"finder,omitempty"`
+
+	// Editor öffnet Scripts (e), z.B. "code --wait"; ohne Angabe gilt $VISUAL bzw. $EDITOR
+	Editor string `json:"editor,omitempty"`
+
+	// PreviewLines ist die Zahl der Vorschauzeilen je Script in der Gesamtansicht
+	// (0 = kompakt, eine Zeile je Script; fehlt der Wert, gilt 2)
+	PreviewLines *int `json:"preview_lines,omitempty"`
+
+	// LintBaseline ist die Datei mit akzeptierten Lint-Befunden (lint --update-baseline)
+	LintBaseline string `json:"lint_baseline,omitempty"`
+
+	// Snapshots ist ein Muster älterer Extraktionen, z.B. "~/ninox/archiv/*.db";
+	// die Statistik zeigt daraus die Entwicklung von Codezeilen und Scripts
+	Snapshots string `json:"snapshots,omitempty"`
+
+	// Webhook erhält eine Zusammenfassung, wenn diff-snapshot Änderungen findet;
+	// WebhookFormat ist json (Standard), slack oder teams
+	Webhook       string `json:"webhook,omitempty"`
+	WebhookFormat string `json:"webhook_format,omitempty"`
+
+	// GitAuthor ("Name <mail>") und GitMessage gelten für git-export, sofern
+	// --author bzw. --message fehlen; GitMessage kennt {date}, {source} und {snapshot}
+	GitAuthor  string `json:"git_author,omitempty"`
+	GitMessage string `json:"git_message,omitempty"`
+
+	// ScriptNameTemplate benennt exportierte Script-Dateien (Go-Vorlage), z.B.
+	// "{{.Database}}/{{.Type}}/{{or .Element .Table \"_global\"}}"; gilt für alle Exporte
+	ScriptNameTemplate string `json:"script_name_template,omitempty"`
+
+	path string            // Pfad, aus dem die Konfiguration geladen wurde
+	env  map[string]string // durch Umgebungsvariablen ersetzte Dateiwerte
+}
+
+// defaultConfigPath liefert den Standardpfad der Konfigurationsdatei
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "ninox-tui.json"
+	}
+	return filepath.Join(dir, "ninox-tui", "config.json")
+}
+
+// LoadConfig lädt die Konfiguration. Eine fehlende Datei ergibt eine leere Konfiguration.
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Fehler beim Lesen der Konfiguration: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("Ungültige Konfiguration %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// envOverrides ordnet Umgebungsvariablen den Konfigurationswerten zu.
+// Reihenfolge der Auswertung: Flags > Umgebung > Konfigurationsdatei.
+var envOverrides = []struct {
+	name  string
+	field func(c *Config) *string
+}{
+	{"NINOX_TUI_DB", func(c *Config) *string { return &c.DB }},
+	{"NINOX_TUI_THEME", func(c *Config) *string { return &c.Theme }},
+	{"NINOX_TUI_LANG", func(c *Config) *string { return &c.Lang }},
+	{"NINOX_TUI_URL", func(c *Config) *string { return &c.NinoxURL }},
+	{"NINOX_TUI_TEAM", func(c *Config) *string { return &c.TeamID }},
+	{"NINOX_TUI_FINDER", func(c *Config) *string { return &c.Finder }},
+	{"NINOX_TUI_WEBHOOK", func(c *Config) *string { return &c.Webhook }},
+}
+
+// configPathOverride wird mit --config gesetzt (TUI und Unterbefehle)
+var configPathOverride string
+
+// activeConfigPath liefert die Konfigurationsdatei: --config > NINOX_TUI_CONFIG > Standardpfad
+func activeConfigPath() string {
+	if configPathOverride != "" {
+		return configPathOverride
+	}
+	if path := os.Getenv("NINOX_TUI_CONFIG"); path != "" {
+		return path
+	}
+	return defaultConfigPath()
+}
+
+// ApplyEnv übernimmt gesetzte NINOX_TUI_*-Variablen in die Konfiguration.
+// Die Werte werden nicht gespeichert, da Save nur Dateiwerte schreiben soll.
+func (c *Config) ApplyEnv() {
+	for _, env := range envOverrides {
+		if value, ok := os.LookupEnv(env.name); ok && value != "" {
+			if c.env == nil {
+				c.env = make(map[string]string)
+			}
+			field := env.field(c)
+			c.env[env.name] = *field
+			*field = value
+		}
+	}
+}
+
+// Save schreibt die Konfiguration zurück in ihre Datei
+func (c *Config) Save() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+
+	// Werte aus der Umgebung nicht in die Datei übernehmen
+	file := *c
+	for _, env := range envOverrides {
+		if original, ok := c.env[env.name]; ok {
+			*env.field(&file) = original
+		}
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0o644)
+}
+
+// SaveQuery fügt eine benannte Abfrage hinzu oder ersetzt eine gleichnamige
+func (c *Config) SaveQuery(q SavedQuery) error {
+	for i, existing := range c.SavedQueries {
+		if existing.Name == q.Name {
+			c.SavedQueries[i] = q
+			return c.Save()
+		}
+	}
+	c.SavedQueries = append(c.SavedQueries, q)
+	return c.Save()
+}
+
+// DeepLink baut die URL der Ninox-Web-App für eine Datenbank bzw. Tabelle
+func (c *Config) DeepLink(teamID, databaseID, tableID string) string {
+	base := strings.TrimSuffix(c.NinoxURL, "/")
+	if base == "" {
+		base = "https://app.ninox.com"
+	}
+	if teamID == "" {
+		teamID = c.TeamID
+	}
+
+	link := fmt.Sprintf("%s/#/teams/%s/database/%s", base, teamID, databaseID)
+	if tableID != "" {
+		link += "/module/" + tableID
+	}
+	return link
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Kopiermodus (wie in tmux): die Ansicht wird ohne Farben und Rahmen
+// eingefroren, ein Cursor wählt Zeilen oder ein Rechteck zum Kopieren aus.
+// Mit der Maus markierter Text enthielte Rahmenzeichen und Auffüllung.
+// =============================================================================
+
+// ansiSequence erkennt Steuersequenzen (CSI, OSC) der gerenderten Ansicht
+var ansiSequence = regexp.MustCompile(`\x1b(\[[0-9;?]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\))`)
+
+// copyModeState ist der Zustand des Kopiermodus
+type copyModeState struct {
+	active    bool
+	lines     [][]rune // eingefrorene Ansicht ohne Farben und Rahmen
+	row, col  int      // Cursor
+	anchorRow int      // Beginn der Auswahl (selecting)
+	anchorCol int
+	selecting bool
+	rect      bool // Rechteck statt ganzer Zeilen
+}
+
+// flattenView entfernt Farben und ersetzt Rahmenzeichen (U+2500–U+257F) durch Leerzeichen,
+// damit Spalten erhalten bleiben
+func flattenView(view string) [][]rune {
+	view = ansiSequence.ReplaceAllString(view, "")
+	var lines [][]rune
+	for _, line := range strings.Split(view, "\n") {
+		runes := []rune(line)
+		for i, r := range runes {
+			if r >= 0x2500 && r <= 0x257F {
+				runes[i] = ' '
+			}
+		}
+		lines = append(lines, runes)
+	}
+	return lines
+}
+
+// openCopyMode friert die aktuelle Ansicht ein
+func (m Model) openCopyMode() (tea.Model, tea.Cmd) {
+	lines := flattenView(m.View())
+	// Die Fußzeile bleibt dem Hinweis des Kopiermodus vorbehalten
+	if len(lines) > 1 {
+		lines = lines[:len(lines)-1]
+	}
+	m.copyMode = copyModeState{active: true, lines: lines}
+	// Cursor auf die erste Zeile mit Inhalt
+	for i, line := range lines {
+		if strings.TrimSpace(string(line)) != "" {
+			m.copyMode.row = i
+			break
+		}
+	}
+	return m, nil
+}
+
+// handleCopyModeKey bewegt den Cursor, wählt aus und kopiert
+func (m Model) handleCopyModeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := &m.copyMode
+	lineLen := func() int { return len(c.lines[c.row]) }
+
+	switch msg.String() {
+	case "esc", "q":
+		if c.selecting {
+			c.selecting = false
+			return m, nil
+		}
+		m.copyMode = copyModeState{}
+	case "up", "k":
+		c.row = max(0, c.row-1)
+	case "down", "j":
+		c.row = min(len(c.lines)-1, c.row+1)
+	case "left", "h":
+		c.col = max(0, c.col-1)
+	case "right", "l":
+		c.col++
+	case "w":
+		c.col = nextWord(c.lines[c.row], c.col)
+	case "b":
+		c.col = prevWord(c.lines[c.row], c.col)
+	case "0", "home":
+		c.col = 0
+	case "^":
+		c.col = len(c.lines[c.row]) - len(strings.TrimLeft(string(c.lines[c.row]), " "))
+	case "$", "end":
+		c.col = max(0, len([]rune(strings.TrimRight(string(c.lines[c.row]), " ")))-1)
+	case "g":
+		c.row = 0
+	case "G":
+		c.row = len(c.lines) - 1
+	case "v", " ":
+		c.selecting = !c.selecting
+		c.anchorRow, c.anchorCol = c.row, c.col
+	case "r", "ctrl+v":
+		c.rect = !c.rect
+		if !c.selecting {
+			c.selecting = true
+			c.anchorRow, c.anchorCol = c.row, c.col
+		}
+	case "y", "c", "enter":
+		text := m.copySelection()
+		via := copyToClipboard(text)
+		m.copyMode = copyModeState{}
+		m.status = fmt.Sprintf("✓ Auswahl kopiert (%s): %d Zeilen", via, strings.Count(text, "\n")+1)
+		return m, nil
+	}
+	if c.active {
+		c.col = max(0, min(c.col, max(lineLen(), 1)-1))
+	}
+	return m, nil
+}
+
+// nextWord liefert den Beginn des nächsten Wortes
+func nextWord(line []rune, col int) int {
+	i := col
+	for i < len(line) && line[i] != ' ' {
+		i++
+	}
+	for i < len(line) && line[i] == ' ' {
+		i++
+	}
+	if i >= len(line) {
+		return col
+	}
+	return i
+}
+
+// prevWord liefert den Beginn des vorherigen Wortes
+func prevWord(line []rune, col int) int {
+	i := min(col, len(line)) - 1
+	for i > 0 && line[i] == ' ' {
+		i--
+	}
+	for i > 0 && line[i-1] != ' ' {
+		i--
+	}
+	return max(0, i)
+}
+
+// selectionBounds liefert die ausgewählten Zeilen und Spalten (ohne Auswahl die Cursorzeile)
+func (c copyModeState) selectionBounds() (top, bottom, left, right int) {
+	if !c.selecting {
+		return c.row, c.row, 0, -1
+	}
+	top, bottom = min(c.anchorRow, c.row), max(c.anchorRow, c.row)
+	if !c.rect {
+		return top, bottom, 0, -1
+	}
+	return top, bottom, min(c.anchorCol, c.col), max(c.anchorCol, c.col)
+}
+
+// selected meldet, ob eine Zelle zur Auswahl gehört
+func (c copyModeState) selected(row, col int) bool {
+	top, bottom, left, right := c.selectionBounds()
+	if row < top || row > bottom {
+		return false
+	}
+	return right < 0 || (col >= left && col <= right)
+}
+
+// copySelection liefert den ausgewählten Text: Zeilenenden gekürzt und gemeinsame
+// Einrückung (Rahmen, Innenabstand) entfernt
+func (m Model) copySelection() string {
+	c := m.copyMode
+	top, bottom, left, right := c.selectionBounds()
+	var lines []string
+	for row := top; row <= bottom; row++ {
+		line := c.lines[row]
+		if right >= 0 {
+			from, to := min(left, len(line)), min(right+1, len(line))
+			line = line[from:to]
+		}
+		lines = append(lines, strings.TrimRight(string(line), " "))
+	}
+
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " "))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			lines[i] = line[indent:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderCopyMode zeigt die eingefrorene Ansicht mit Cursor und Auswahl
+func (m Model) renderCopyMode() string {
+	c := m.copyMode
+	selStyle := lipgloss.NewStyle().Reverse(true)
+	cursorStyle := lipgloss.NewStyle().Reverse(true).Bold(true).Foreground(currentTheme.Accent)
+
+	var b strings.Builder
+	for row, line := range c.lines {
+		var run []rune
+		inSel := false
+		flush := func() {
+			if len(run) == 0 {
+				return
+			}
+			if inSel {
+				b.WriteString(selStyle.Render(string(run)))
+			} else {
+				b.WriteString(string(run))
+			}
+			run = run[:0]
+		}
+		// Der Cursor darf hinter dem Zeilenende stehen (leere Zeile)
+		cells := line
+		if row == c.row && c.col >= len(cells) {
+			cells = append(append([]rune{}, cells...), []rune(strings.Repeat(" ", c.col-len(cells)+1))...)
+		}
+		for col, r := range cells {
+			if row == c.row && col == c.col {
+				flush()
+				b.WriteString(cursorStyle.Render(string(r)))
+				continue
+			}
+			sel := c.selecting && c.selected(row, col)
+			if sel != inSel {
+				flush()
+				inSel = sel
+			}
+			run = append(run, r)
+		}
+		flush()
+		b.WriteString("\n")
+	}
+
+	mode := "Zeilen"
+	if c.rect {
+		mode = "Rechteck"
+	}
+	hint := fmt.Sprintf(" KOPIERMODUS (%s) • ←↑↓→/hjkl bewegen • w/b Wort • 0/$ Zeile • v Auswahl • r Rechteck • y kopieren • Esc beenden", mode)
+	b.WriteString(helpStyle.Render(hint))
+	return b.String()
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Absturzbehandlung: Terminal wiederherstellen und Bericht schreiben
+// =============================================================================
+
+// panicMsg trägt eine Panic aus einem Befehl (eigene Goroutine) in die Event-Schleife
+type panicMsg struct {
+	value interface{}
+	stack []byte
+}
+
+// lastMsg beschreibt die zuletzt verarbeitete Nachricht für den Absturzbericht.
+// Nur in der Event-Schleife geschrieben und nach deren Abbruch gelesen.
+var lastMsg string
+
+// crashGuard umschließt das Model. Panics in Update und View landen in
+// runProgram, Panics in Befehlen werden als panicMsg dorthin weitergereicht.
+type crashGuard struct {
+	model tea.Model
+}
+
+func (g crashGuard) Init() tea.Cmd {
+	return guardCmd(g.model.Init())
+}
+
+func (g crashGuard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if p, ok := msg.(panicMsg); ok {
+		panic(p)
+	}
+	lastMsg = fmt.Sprintf("%T", msg)
+	if k, ok := msg.(tea.KeyMsg); ok {
+		lastMsg += fmt.Sprintf(" (%q)", k.String())
+	}
+
+	model, cmd := g.model.Update(msg)
+	return crashGuard{model}, guardCmd(cmd)
+}
+
+func (g crashGuard) View() string {
+	return g.model.View()
+}
+
+// guardCmd fängt Panics eines Befehls ab; gebündelte Befehle werden mit umschlossen
+func guardCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = panicMsg{value: r, stack: debug.Stack()}
+			}
+		}()
+		msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i, c := range batch {
+				batch[i] = guardCmd(c)
+			}
+		}
+		return msg
+	}
+}
+
+// runProgram führt die TUI aus. Bei einer Panic wird das Terminal verlassen
+// (Alt-Screen, Cursor, Eingabemodus), ein Bericht geschrieben und beendet.
+func runProgram(model tea.Model, opts ...tea.ProgramOption) error {
+	p := tea.NewProgram(crashGuard{model}, append(opts, tea.WithoutCatchPanics())...)
+	stopHangup := watchHangup(p)
+	defer stopHangup()
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		stack := debug.Stack()
+		if pm, ok := r.(panicMsg); ok {
+			r, stack = pm.value, pm.stack
+		}
+		restoreTerminal(p)
+
+		fmt.Fprintf(os.Stderr, "❌ Absturz: %v\n", r)
+		if path, err := writeCrashReport(r, stack); err == nil {
+			fmt.Fprintf(os.Stderr, "   Bericht: %s\n", path)
+		} else {
+			fmt.Fprintf(os.Stderr, "   Bericht nicht geschrieben (%v):\n\n%s\n", err, stack)
+		}
+		removeDemoDB()
+		os.Exit(exitFailure)
+	}()
+
+	_, err := p.Run()
+	return err
+}
+
+// restoreTerminal gibt das Terminal frei; die Steuersequenzen greifen auch,
+// falls der Renderer selbst nicht mehr reagiert
+func restoreTerminal(p *tea.Program) {
+	func() {
+		defer func() { recover() }()
+		p.ReleaseTerminal()
+	}()
+	os.Stdout.WriteString("\x1b[?1049l\x1b[?25h\r\n")
+}
+
+// redactArgs entfernt Passwörter aus Datenbank-URLs im Aufruf
+func redactArgs(args []string) string {
+	out := make([]string, len(args))
+	for i, arg := range args {
+		out[i] = arg
+		if u, err := url.Parse(arg); err == nil && u.User != nil {
+			out[i] = u.Redacted()
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// writeCrashReport schreibt den Absturzbericht ins Zustandsverzeichnis
+func writeCrashReport(value interface{}, stack []byte) (string, error) {
+	dir := filepath.Join(stateDir(), "crash")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	path := filepath.Join(dir, "crash-"+now.Format("20060102-150405")+".txt")
+
+	version := "(unbekannt)"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		version = info.Main.Version
+	}
+	var b strings.Builder
+	b.WriteString("ninox-tui Absturzbericht\n\n")
+	fmt.Fprintf(&b, "Zeit:      %s\n", now.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "Version:   %s (%s, %s/%s)\n", version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Aufruf:    %s\n", redactArgs(os.Args))
+	fmt.Fprintf(&b, "Nachricht: %s\n", firstNonEmpty(lastMsg, "(keine)"))
+	fmt.Fprintf(&b, "Panic:     %v\n\n", value)
+	b.Write(stack)
+
+	return path, os.WriteFile(path, []byte(b.String()), 0o644)
+}
@@ -1,8 +1,15 @@
 package main
 
 import (
+	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -11,8 +18,14 @@ import (
 type Database struct {
 	ID         string
 	Name       string
+	TeamID     string
+	TeamName   string
+	Archived   bool
 	TableCount int
 	CodeCount  int
+
+	FieldCount    int
+	RelationCount int
 }
 
 // Table repräsentiert eine Ninox-Tabelle
@@ -23,6 +36,12 @@ type Table struct {
 	Name       string
 	Caption    string
 	FieldCount int
+
+	ScriptCount   int
+	RelationCount int
+	RecordCount   int // Datensätze (-1 = nicht gezählt)
+	FormulaCount  int // Felder mit Formel
+	TriggerCount  int // Trigger der Tabelle und ihrer Felder
 }
 
 // Field repräsentiert ein Ninox-Feld
@@ -51,6 +70,10 @@ type Script struct {
 	CodeCategory string
 	Code         string
 	LineCount    int
+	Hash         string // SHA-256 des Codes (aus der Extraktion oder berechnet)
+	ModifiedBy   string // letzter Bearbeiter laut Ninox API (leer = unbekannt)
+	ModifiedAt   string
+	Recent       uint8 // Änderungen in den letzten Ständen (Bit 0 = letzter Stand)
 }
 
 // Relationship repräsentiert eine Tabellenbeziehung
@@ -72,32 +95,173 @@ type Stats struct {
 	RelationshipsCount int
 	ScriptsCount       int
 	ScriptsByType      map[string]int
+	ScriptsByGroup     map[string]int
 	TopTables          map[string]int
+	CodeLines          map[string]map[string]int // Codezeilen je Datenbank und Tabelle
+	LogicTables        []TableLogic              // Tabellen mit der höchsten Logikdichte
+
+	Scope       string // Beschreibung des Ausschnitts, leer = gesamte Extraktion
+	ScriptsOnly bool   // Nur aus Scripts berechnet: Felder/Verknüpfungen fehlen
 }
 
 // NinoxDB ist der Datenbank-Handler
 type NinoxDB struct {
-	conn *sql.DB
-	path string
+	conn    *backendConn
+	path    string
+	backend storageBackend
+
+	hasCodeHash     bool     // scripts.code_hash vorhanden (neuere Extraktionen)
+	hasCodeCategory bool     // scripts.code_category vorhanden
+	hasLineCount    bool     // scripts.line_count vorhanden (sonst berechnet)
+	hasModified     bool     // scripts.modified_by/modified_at vorhanden
+	compatNotes     []string // Abweichungen vom erwarteten Schema
+
+	*visibility // Ausblenden und Archiv, geteilt mit Kopien aus WithContext
+
+	recency *recencyIndex // Änderungen der letzten Stände, ebenfalls geteilt
+
+	aux *sql.DB // Ziel für Hilfstabellen (nil = nur lesend)
+}
+
+// visibility ist der zur Laufzeit umschaltbare Zustand von NinoxDB. Die TUI
+// schaltet ihn um, während Hintergrundanalyse und serve parallel abfragen.
+type visibility struct {
+	mu sync.RWMutex
+
+	ignored     *ignoredSet // per Muster ausgeblendete Objekte
+	showIgnored bool        // Ausgeblendete vorübergehend anzeigen
+
+	archived     map[string]bool // archivierte Datenbank-IDs
+	showArchived bool            // Archivierte anzeigen
 }
 
-// NewNinoxDB öffnet eine Ninox-SQLite-Datenbank
+// Verbindungspool: SQLite liest parallel, Server-Datenbanken begrenzen Verbindungen selbst
+const (
+	maxOpenConns    = 8
+	maxIdleConns    = 4
+	connMaxIdleTime = 5 * time.Minute
+)
+
+// NewNinoxDB öffnet eine Ninox-SQLite-Datenbank, ohne --allow-write nur lesend.
+// path kann auch eine Server-URL sein (siehe backendFor).
 func NewNinoxDB(path string) (*NinoxDB, error) {
-	conn, err := sql.Open("sqlite3", path)
+	backend, dsn, err := backendFor(path)
+	if err != nil {
+		return nil, err
+	}
+	if backend.local() {
+		dsn = sqliteDSN(path, !writeAccess.allow)
+	}
+	conn, err := sql.Open(backend.driver(), dsn)
 	if err != nil {
 		return nil, fmt.Errorf("Fehler beim Öffnen der DB: %w", err)
 	}
+	conn.SetMaxOpenConns(maxOpenConns)
+	conn.SetMaxIdleConns(maxIdleConns)
+	conn.SetConnMaxIdleTime(connMaxIdleTime)
 
 	// Verbindung testen
 	if err := conn.Ping(); err != nil {
 		return nil, fmt.Errorf("DB nicht erreichbar: %w", err)
 	}
 
-	return &NinoxDB{conn: conn, path: path}, nil
+	db := &NinoxDB{conn: &backendConn{DB: conn, backend: backend}, path: path, backend: backend, visibility: &visibility{}, recency: &recencyIndex{}}
+	db.checkCompat()
+	if err := db.openAux(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// WithContext liefert eine Sicht auf dieselbe Verbindung, deren Abfragen an ctx
+// gebunden sind (Abbruch, Zeitlimit); z.B. je Request in serve oder für den Reindex
+func (db *NinoxDB) WithContext(ctx context.Context) *NinoxDB {
+	view := *db
+	view.conn = &backendConn{DB: db.conn.DB, backend: db.conn.backend, ctx: ctx}
+	return &view
+}
+
+// tableColumns liefert die Spalten einer Tabelle
+func (db *NinoxDB) tableColumns(table string) map[string]bool {
+	return db.backend.columns(db.conn, table)
+}
+
+// scriptHash berechnet den Inhalts-Hash eines Scripts
+func scriptHash(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// scriptColumns liefert die Spaltenliste für scanScript, optional mit Tabellen-Alias
+func (db *NinoxDB) scriptColumns(alias string) string {
+	if alias != "" {
+		alias += "."
+	}
+	hash := "NULL"
+	if db.hasCodeHash {
+		hash = alias + "code_hash"
+	}
+	cols := []string{"id", "database_id", "database_name", "table_id", "table_name",
+		"element_id", "element_name", "code_type"}
+	for i, c := range cols {
+		cols[i] = alias + c
+	}
+	cols = append(cols, db.categoryColumn(alias), alias+"code", db.lineCountColumn(alias), hash)
+	if db.hasModified {
+		cols = append(cols, alias+"modified_by", alias+"modified_at")
+	} else {
+		cols = append(cols, "NULL", "NULL")
+	}
+	return strings.Join(cols, ", ")
+}
+
+// rowScanner wird von *sql.Row und *sql.Rows erfüllt
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanScript liest ein Script in der Spaltenreihenfolge von scriptColumns
+func scanScript(row rowScanner) (Script, error) {
+	var s Script
+	var tableID, tableName, elementID, elementName, codeCategory, hash, modifiedBy, modifiedAt sql.NullString
+	if err := row.Scan(&s.ID, &s.DatabaseID, &s.DatabaseName, &tableID, &tableName,
+		&elementID, &elementName, &s.CodeType, &codeCategory, &s.Code, &s.LineCount, &hash,
+		&modifiedBy, &modifiedAt); err != nil {
+		return s, err
+	}
+	s.ModifiedBy = modifiedBy.String
+	s.ModifiedAt = modifiedAt.String
+	s.TableID = tableID.String
+	s.TableName = tableName.String
+	s.ElementID = elementID.String
+	s.ElementName = elementName.String
+	s.CodeCategory = codeCategory.String
+	s.Hash = hash.String
+	if s.Hash == "" {
+		s.Hash = scriptHash(s.Code)
+	}
+	return s, nil
+}
+
+// scanScripts liest alle Zeilen als Scripts
+func scanScripts(rows *sql.Rows) ([]Script, error) {
+	var scripts []Script
+	for rows.Next() {
+		s, err := scanScript(rows)
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, s)
+	}
+	return scripts, rows.Err()
 }
 
 // Close schließt die Datenbankverbindung
 func (db *NinoxDB) Close() error {
+	if db.aux != nil && db.aux != db.conn.DB {
+		db.aux.Close()
+	}
 	if db.conn != nil {
 		return db.conn.Close()
 	}
@@ -106,10 +270,25 @@ func (db *NinoxDB) Close() error {
 
 // GetDatabases lädt alle Datenbanken
 func (db *NinoxDB) GetDatabases() ([]Database, error) {
+	// Zählungen für die Anzeige, ohne ausgeblendete Objekte
+	fieldFilter := db.ignoreFilter("f.database_id", "f.table_id", "")
+	relFilter := db.ignoreFilter("r.database_id", "r.source_table_id", "")
+	filter := db.ignoreFilter("d.id", "", "")
+
+	// Ältere Extraktionen speichern das Team nur an den Scripts
+	teamID := `(SELECT s.team_id FROM scripts s WHERE s.database_id = d.id LIMIT 1)`
+	teamName := `(SELECT s.team_name FROM scripts s WHERE s.database_id = d.id LIMIT 1)`
+	if db.tableColumns("databases")["team_id"] {
+		teamID, teamName = `COALESCE(d.team_id, `+teamID+`)`, `COALESCE(d.team_name, `+teamName+`)`
+	}
+
 	rows, err := db.conn.Query(`
-		SELECT id, name, table_count, code_count
-		FROM databases
-		ORDER BY name
+		SELECT d.id, d.name, COALESCE(` + teamID + `, ''), COALESCE(` + teamName + `, ''), d.table_count, d.code_count,
+		       (SELECT COUNT(*) FROM fields f WHERE f.database_id = d.id` + fieldFilter + `),
+		       (SELECT COUNT(*) FROM relationships r WHERE r.database_id = d.id` + relFilter + `)
+		FROM databases d
+		WHERE 1 = 1` + filter + `
+		ORDER BY d.name
 	`)
 	if err != nil {
 		return nil, err
@@ -119,9 +298,10 @@ func (db *NinoxDB) GetDatabases() ([]Database, error) {
 	var databases []Database
 	for rows.Next() {
 		var d Database
-		if err := rows.Scan(&d.ID, &d.Name, &d.TableCount, &d.CodeCount); err != nil {
+		if err := rows.Scan(&d.ID, &d.Name, &d.TeamID, &d.TeamName, &d.TableCount, &d.CodeCount, &d.FieldCount, &d.RelationCount); err != nil {
 			return nil, err
 		}
+		d.Archived = db.IsArchived(d.ID)
 		databases = append(databases, d)
 	}
 	return databases, nil
@@ -129,25 +309,46 @@ func (db *NinoxDB) GetDatabases() ([]Database, error) {
 
 // GetTables lädt Tabellen einer Datenbank
 func (db *NinoxDB) GetTables(databaseID string) ([]Table, error) {
+	// Zählungen für die Anzeige, ohne ausgeblendete Objekte
+	scriptFilter := db.ignoreFilter("s.database_id", "s.table_id", "s.id")
+	relFilter := db.ignoreFilter("r.database_id", "r.source_table_id", "")
+	filter := db.ignoreFilter("t.database_id", "t.table_id", "")
+
 	rows, err := db.conn.Query(`
-		SELECT id, database_id, table_id, name, caption, field_count
-		FROM tables
-		WHERE database_id = ?
-		ORDER BY name
+		SELECT t.id, t.database_id, t.table_id, t.name, t.caption, t.field_count,
+		       (SELECT COUNT(*) FROM scripts s
+		        WHERE s.database_id = t.database_id AND s.table_id = t.table_id`+scriptFilter+`),
+		       (SELECT COUNT(*) FROM relationships r
+		        WHERE r.database_id = t.database_id
+		          AND (r.source_table_id = t.table_id OR r.target_table_id = t.table_id)`+relFilter+`),
+		       `+formulaCountSQL+`, `+triggerCountSQL(scriptFilter)+`
+		FROM tables t
+		WHERE t.database_id = ?`+filter+`
+		ORDER BY t.name
 	`, databaseID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	counts, err := db.GetRecordCounts()
+	if err != nil {
+		return nil, err
+	}
+
 	var tables []Table
 	for rows.Next() {
 		var t Table
 		var caption sql.NullString
-		if err := rows.Scan(&t.ID, &t.DatabaseID, &t.TableID, &t.Name, &caption, &t.FieldCount); err != nil {
+		if err := rows.Scan(&t.ID, &t.DatabaseID, &t.TableID, &t.Name, &caption, &t.FieldCount,
+			&t.ScriptCount, &t.RelationCount, &t.FormulaCount, &t.TriggerCount); err != nil {
 			return nil, err
 		}
 		t.Caption = caption.String
+		t.RecordCount = -1
+		if n, ok := counts[layoutKey(t.DatabaseID, t.TableID)]; ok {
+			t.RecordCount = n
+		}
 		tables = append(tables, t)
 	}
 	return tables, nil
@@ -187,11 +388,11 @@ func (db *NinoxDB) GetFields(databaseID, tableID string) ([]Field, error) {
 
 // GetScripts lädt Scripts einer Tabelle
 func (db *NinoxDB) GetScripts(databaseID, tableName string) ([]Script, error) {
+	filter := db.ignoreFilter("database_id", "table_id", "id")
 	rows, err := db.conn.Query(`
-		SELECT id, database_id, database_name, table_id, table_name,
-		       element_id, element_name, code_type, code_category, code, line_count
+		SELECT `+db.scriptColumns("")+`
 		FROM scripts
-		WHERE database_id = ? AND table_name = ?
+		WHERE database_id = ? AND table_name = ?`+filter+`
 		ORDER BY code_type, element_name
 	`, databaseID, tableName)
 	if err != nil {
@@ -199,30 +400,16 @@ func (db *NinoxDB) GetScripts(databaseID, tableName string) ([]Script, error) {
 	}
 	defer rows.Close()
 
-	var scripts []Script
-	for rows.Next() {
-		var s Script
-		var tableID, tableName, elementID, elementName, codeCategory sql.NullString
-		if err := rows.Scan(&s.ID, &s.DatabaseID, &s.DatabaseName, &tableID, &tableName,
-			&elementID, &elementName, &s.CodeType, &codeCategory, &s.Code, &s.LineCount); err != nil {
-			return nil, err
-		}
-		s.TableID = tableID.String
-		s.TableName = tableName.String
-		s.ElementID = elementID.String
-		s.ElementName = elementName.String
-		s.CodeCategory = codeCategory.String
-		scripts = append(scripts, s)
-	}
-	return scripts, nil
+	return db.markRecent(scanScripts(rows))
 }
 
 // GetAllScripts lädt alle Scripts
 func (db *NinoxDB) GetAllScripts() ([]Script, error) {
+	filter := db.ignoreFilter("database_id", "table_id", "id")
 	rows, err := db.conn.Query(`
-		SELECT id, database_id, database_name, table_id, table_name,
-		       element_id, element_name, code_type, code_category, code, line_count
+		SELECT ` + db.scriptColumns("") + `
 		FROM scripts
+		WHERE 1 = 1` + filter + `
 		ORDER BY database_name, table_name, code_type
 	`)
 	if err != nil {
@@ -230,78 +417,204 @@ func (db *NinoxDB) GetAllScripts() ([]Script, error) {
 	}
 	defer rows.Close()
 
-	var scripts []Script
+	return db.markRecent(scanScripts(rows))
+}
+
+// GetScript lädt ein einzelnes Script anhand seiner ID
+func (db *NinoxDB) GetScript(id int) (*Script, error) {
+	s, err := scanScript(db.conn.QueryRow(`
+		SELECT `+db.scriptColumns("")+`
+		FROM scripts
+		WHERE id = ?
+	`, id))
+	if err != nil {
+		return nil, err
+	}
+	scripts, _ := db.markRecent([]Script{s}, nil)
+	return &scripts[0], nil
+}
+
+// GetScriptHashes liefert die Inhalts-Hashes aller Scripts (ID → Hash),
+// ohne bei neueren Extraktionen den Code selbst zu laden
+func (db *NinoxDB) GetScriptHashes() (map[int]string, error) {
+	column := "code"
+	if db.hasCodeHash {
+		column = "code_hash"
+	}
+	rows, err := db.conn.Query("SELECT id, " + column + " FROM scripts")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := make(map[int]string)
 	for rows.Next() {
-		var s Script
-		var tableID, tableName, elementID, elementName, codeCategory sql.NullString
-		if err := rows.Scan(&s.ID, &s.DatabaseID, &s.DatabaseName, &tableID, &tableName,
-			&elementID, &elementName, &s.CodeType, &codeCategory, &s.Code, &s.LineCount); err != nil {
+		var id int
+		var value sql.NullString
+		if err := rows.Scan(&id, &value); err != nil {
 			return nil, err
 		}
-		s.TableID = tableID.String
-		s.TableName = tableName.String
-		s.ElementID = elementID.String
-		s.ElementName = elementName.String
-		s.CodeCategory = codeCategory.String
-		scripts = append(scripts, s)
+		if !db.hasCodeHash || value.String == "" {
+			value.String = scriptHash(value.String)
+		}
+		hashes[id] = value.String
 	}
-	return scripts, nil
+	return hashes, rows.Err()
 }
 
-// SearchScripts sucht in Scripts
+// SearchScripts sucht in Scripts; ein negatives Limit liefert alle Treffer
 func (db *NinoxDB) SearchScripts(query string, limit int) ([]Script, error) {
+	// PostgreSQL lehnt LIMIT -1 ab, daher ohne Begrenzung die Klausel weglassen
+	limitClause, limitArgs := "", []interface{}{}
+	if limit >= 0 {
+		limitClause, limitArgs = " LIMIT ?", []interface{}{limit}
+	}
+
 	// Erst FTS5 versuchen
+	filter := db.ignoreFilter("s.database_id", "s.table_id", "s.id")
 	rows, err := db.conn.Query(`
-		SELECT s.id, s.database_id, s.database_name, s.table_id, s.table_name,
-		       s.element_id, s.element_name, s.code_type, s.code_category, s.code, s.line_count
+		SELECT `+db.scriptColumns("s")+`
 		FROM scripts_fts
 		JOIN scripts s ON scripts_fts.rowid = s.id
-		WHERE scripts_fts MATCH ?
-		ORDER BY rank
-		LIMIT ?
-	`, query, limit)
+		WHERE scripts_fts MATCH ?`+filter+`
+		ORDER BY rank`+limitClause,
+		append([]interface{}{query}, limitArgs...)...)
 
 	if err != nil {
 		// Fallback auf LIKE
+		filter = db.ignoreFilter("database_id", "table_id", "id")
+		like := "%" + query + "%"
 		rows, err = db.conn.Query(`
-			SELECT id, database_id, database_name, table_id, table_name,
-			       element_id, element_name, code_type, code_category, code, line_count
+			SELECT `+db.scriptColumns("")+`
 			FROM scripts
-			WHERE code LIKE ? OR table_name LIKE ? OR element_name LIKE ?
-			ORDER BY database_name, table_name
-			LIMIT ?
-		`, "%"+query+"%", "%"+query+"%", "%"+query+"%", limit)
+			WHERE (code LIKE ? OR table_name LIKE ? OR element_name LIKE ?)`+filter+`
+			ORDER BY database_name, table_name`+limitClause,
+			append([]interface{}{like, like, like}, limitArgs...)...)
 		if err != nil {
 			return nil, err
 		}
 	}
 	defer rows.Close()
 
-	var scripts []Script
+	return db.markRecent(scanScripts(rows))
+}
+
+// FieldMatch ist ein Suchtreffer in den Felddefinitionen
+type FieldMatch struct {
+	Field
+	DatabaseName string
+	TableName    string
+	Option       string // passender Auswahlwert, z.B. "3 = Gesperrt"
+}
+
+// SearchFields sucht in Feldnamen, Beschriftungen, Verweiszielen und Formeltexten
+func (db *NinoxDB) SearchFields(query string, limit int) ([]FieldMatch, error) {
+	filter := db.ignoreFilter("f.database_id", "f.table_id", "")
+	like := "%" + query + "%"
+
+	// Auswahlwerte werden mitdurchsucht, sofern die Extraktion sie enthält
+	option, hasOptions := db.optionMatchExpr("f.")
+	params := []interface{}{}
+	if hasOptions {
+		params = append(params, like)
+	}
+	params = append(params, like, like, like, like)
+	optionCond := ""
+	if hasOptions {
+		optionCond = " OR " + option + " IS NOT NULL"
+		params = append(params, like)
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT f.id, f.database_id, f.table_id, f.field_id, f.name, f.caption, f.base_type,
+		       f.ref_table_name, f.has_formula, COALESCE(d.name, ''), COALESCE(t.name, ''),
+		       `+option+`
+		FROM fields f
+		LEFT JOIN databases d ON d.id = f.database_id
+		LEFT JOIN tables t ON t.database_id = f.database_id AND t.table_id = f.table_id
+		WHERE (f.name LIKE ? OR f.caption LIKE ? OR f.ref_table_name LIKE ?
+		       OR EXISTS (SELECT 1 FROM scripts s
+		                  WHERE s.database_id = f.database_id AND s.table_id = f.table_id
+		                    AND s.element_name = f.name AND COALESCE(`+db.categoryColumn("s.")+`, 'FORMULA') = 'FORMULA'
+		                    AND s.code LIKE ?)`+optionCond+`)`+filter+`
+		ORDER BY d.name, t.name, f.name
+		LIMIT ?
+	`, append(params, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []FieldMatch
 	for rows.Next() {
-		var s Script
-		var tableID, tableName, elementID, elementName, codeCategory sql.NullString
-		if err := rows.Scan(&s.ID, &s.DatabaseID, &s.DatabaseName, &tableID, &tableName,
-			&elementID, &elementName, &s.CodeType, &codeCategory, &s.Code, &s.LineCount); err != nil {
+		var fm FieldMatch
+		var caption, baseType, refTable, option sql.NullString
+		var hasFormula int
+		if err := rows.Scan(&fm.ID, &fm.DatabaseID, &fm.TableID, &fm.FieldID, &fm.Name, &caption, &baseType,
+			&refTable, &hasFormula, &fm.DatabaseName, &fm.TableName, &option); err != nil {
 			return nil, err
 		}
-		s.TableID = tableID.String
-		s.TableName = tableName.String
-		s.ElementID = elementID.String
-		s.ElementName = elementName.String
-		s.CodeCategory = codeCategory.String
-		scripts = append(scripts, s)
+		fm.Option = option.String
+		fm.Caption = caption.String
+		fm.BaseType = baseType.String
+		fm.RefTableName = refTable.String
+		fm.HasFormula = hasFormula == 1
+		matches = append(matches, fm)
+	}
+	return matches, nil
+}
+
+// GetExtractionDate liefert den Zeitpunkt der Extraktion einer Datenbank
+func (db *NinoxDB) GetExtractionDate(databaseID string) (string, error) {
+	var extractedAt sql.NullString
+	err := db.conn.QueryRow(`
+		SELECT extracted_at FROM databases WHERE id = ?
+	`, databaseID).Scan(&extractedAt)
+	if err != nil {
+		return "", err
+	}
+	return extractedAt.String, nil
+}
+
+// GetLatestExtractionDate liefert den jüngsten Extraktionszeitpunkt aller Datenbanken
+func (db *NinoxDB) GetLatestExtractionDate() (string, error) {
+	var extractedAt sql.NullString
+	err := db.conn.QueryRow(`SELECT MAX(extracted_at) FROM databases`).Scan(&extractedAt)
+	if err != nil {
+		return "", err
 	}
-	return scripts, nil
+	return extractedAt.String, nil
+}
+
+// GetTeamID liefert die Team-ID, aus der eine Datenbank extrahiert wurde
+func (db *NinoxDB) GetTeamID(databaseID string) (string, error) {
+	var teamID sql.NullString
+	if db.tableColumns("databases")["team_id"] {
+		err := db.conn.QueryRow(`SELECT team_id FROM databases WHERE id = ?`, databaseID).Scan(&teamID)
+		if err != nil && err != sql.ErrNoRows {
+			return "", err
+		}
+		if teamID.String != "" {
+			return teamID.String, nil
+		}
+	}
+	err := db.conn.QueryRow(`
+		SELECT team_id FROM scripts WHERE database_id = ? LIMIT 1
+	`, databaseID).Scan(&teamID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return teamID.String, err
 }
 
 // GetRelationships lädt Beziehungen für eine Tabelle
 func (db *NinoxDB) GetRelationships(tableName string) ([]Relationship, error) {
+	filter := db.ignoreFilter("database_id", "source_table_id", "")
 	rows, err := db.conn.Query(`
 		SELECT id, database_name, source_table_name, source_field_name,
 		       target_table_name, relationship_type, is_composition
 		FROM relationships
-		WHERE source_table_name = ? OR target_table_name = ?
+		WHERE (source_table_name = ? OR target_table_name = ?)`+filter+`
 		ORDER BY source_table_name, target_table_name
 	`, tableName, tableName)
 	if err != nil {
@@ -326,46 +639,100 @@ func (db *NinoxDB) GetRelationships(tableName string) ([]Relationship, error) {
 	return rels, nil
 }
 
+// GetDatabaseRelationships lädt alle Beziehungen einer Datenbank
+func (db *NinoxDB) GetDatabaseRelationships(databaseID string) ([]Relationship, error) {
+	filter := db.ignoreFilter("database_id", "source_table_id", "")
+	rows, err := db.conn.Query(`
+		SELECT id, database_name, source_table_name, source_field_name,
+		       target_table_name, relationship_type, is_composition
+		FROM relationships
+		WHERE database_id = ?`+filter+`
+		ORDER BY source_table_name, target_table_name
+	`, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rels []Relationship
+	for rows.Next() {
+		var r Relationship
+		var dbName, srcField sql.NullString
+		var isComp int
+		if err := rows.Scan(&r.ID, &dbName, &r.SourceTableName, &srcField,
+			&r.TargetTableName, &r.RelationshipType, &isComp); err != nil {
+			return nil, err
+		}
+		r.DatabaseName = dbName.String
+		r.SourceFieldName = srcField.String
+		r.IsComposition = isComp == 1
+		rels = append(rels, r)
+	}
+	return rels, nil
+}
+
 // GetStats lädt Statistiken
 func (db *NinoxDB) GetStats() (*Stats, error) {
+	return db.GetDatabaseStats("")
+}
+
+// GetDatabaseStats lädt die Statistiken einer Datenbank ("" = alle Datenbanken)
+func (db *NinoxDB) GetDatabaseStats(databaseID string) (*Stats, error) {
 	stats := &Stats{
-		ScriptsByType: make(map[string]int),
-		TopTables:     make(map[string]int),
+		ScriptsByType:  make(map[string]int),
+		ScriptsByGroup: make(map[string]int),
+		TopTables:      make(map[string]int),
+		CodeLines:      make(map[string]map[string]int),
 	}
 
 	// Counts
 	tables := []struct {
-		name  string
-		count *int
+		name                   string
+		dbCol, tableCol, idCol string // Spalten für ausgeblendete Objekte
+		count                  *int
 	}{
-		{"databases", &stats.DatabasesCount},
-		{"tables", &stats.TablesCount},
-		{"fields", &stats.FieldsCount},
-		{"relationships", &stats.RelationshipsCount},
-		{"scripts", &stats.ScriptsCount},
+		{"databases", "id", "", "", &stats.DatabasesCount},
+		{"tables", "database_id", "table_id", "", &stats.TablesCount},
+		{"fields", "database_id", "table_id", "", &stats.FieldsCount},
+		{"relationships", "database_id", "source_table_id", "", &stats.RelationshipsCount},
+		{"scripts", "database_id", "table_id", "id", &stats.ScriptsCount},
+	}
+
+	// scope schränkt zusätzlich auf eine Datenbank ein
+	scope := func(dbCol, tableCol, idCol string) (string, []interface{}) {
+		filter := db.ignoreFilter(dbCol, tableCol, idCol)
+		var args []interface{}
+		if databaseID != "" {
+			filter += " AND " + dbCol + " = ?"
+			args = append(args, databaseID)
+		}
+		return filter, args
 	}
 
 	for _, t := range tables {
-		row := db.conn.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", t.name))
+		filter, args := scope(t.dbCol, t.tableCol, t.idCol)
+		row := db.conn.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE 1 = 1%s", t.name, filter), args...)
 		if err := row.Scan(t.count); err != nil {
 			return nil, err
 		}
 	}
 
-	// Scripts by type
+	// Scripts nach Typ und Gruppe
+	filter, args := scope("database_id", "table_id", "id")
 	rows, err := db.conn.Query(`
-		SELECT code_type, COUNT(*) as count
+		SELECT code_type, COALESCE(`+db.categoryColumn("")+`, ''), COUNT(*) as count
 		FROM scripts
-		GROUP BY code_type
-		ORDER BY count DESC
-	`)
+		WHERE 1 = 1`+filter+`
+		GROUP BY 1, 2
+	`, args...)
 	if err == nil {
 		defer rows.Close()
 		for rows.Next() {
-			var codeType string
+			var codeType, category string
 			var count int
-			if err := rows.Scan(&codeType, &count); err == nil {
-				stats.ScriptsByType[codeType] = count
+			if err := rows.Scan(&codeType, &category, &count); err == nil {
+				stats.ScriptsByType[codeType] += count
+				stats.ScriptsByGroup[codeGroup(codeType, category)] += count
 			}
 		}
 	}
@@ -374,11 +741,11 @@ func (db *NinoxDB) GetStats() (*Stats, error) {
 	rows, err = db.conn.Query(`
 		SELECT table_name, COUNT(*) as count
 		FROM scripts
-		WHERE table_name IS NOT NULL AND table_name != ''
+		WHERE table_name IS NOT NULL AND table_name != ''`+filter+`
 		GROUP BY table_name
 		ORDER BY count DESC
 		LIMIT 10
-	`)
+	`, args...)
 	if err == nil {
 		defer rows.Close()
 		for rows.Next() {
@@ -390,5 +757,262 @@ func (db *NinoxDB) GetStats() (*Stats, error) {
 		}
 	}
 
+	// Codezeilen je Datenbank und Tabelle
+	rows, err = db.conn.Query(`
+		SELECT COALESCE(database_name, ''), COALESCE(table_name, ''), COALESCE(SUM(`+db.lineCountColumn("")+`), 0)
+		FROM scripts
+		WHERE 1 = 1`+filter+`
+		GROUP BY database_name, table_name
+	`, args...)
+	if err == nil {
+		defer rows.Close()
+		for rows.Next() {
+			var database, table string
+			var lines int
+			if err := rows.Scan(&database, &table, &lines); err == nil {
+				addCodeLines(stats.CodeLines, database, table, lines)
+			}
+		}
+	}
+
+	if logic, err := db.GetTableLogic(databaseID); err == nil {
+		stats.LogicTables = logic
+	}
+
 	return stats, nil
 }
+
+// rawJSONColumns sind Spalten, die (falls vorhanden) die JSON-Rohdefinition enthalten
+var rawJSONColumns = map[string]bool{
+	"raw_json":    true,
+	"definition":  true,
+	"schema_json": true,
+}
+
+// GetRawTable lädt alle gespeicherten Spalten einer Tabelle
+func (db *NinoxDB) GetRawTable(databaseID, tableID string) (map[string]interface{}, error) {
+	return db.queryRowMap(`
+		SELECT * FROM tables
+		WHERE database_id = ? AND table_id = ?
+	`, databaseID, tableID)
+}
+
+// GetRawField lädt alle gespeicherten Spalten eines Feldes
+func (db *NinoxDB) GetRawField(databaseID, tableID, fieldID string) (map[string]interface{}, error) {
+	return db.queryRowMap(`
+		SELECT * FROM fields
+		WHERE database_id = ? AND table_id = ? AND field_id = ?
+	`, databaseID, tableID, fieldID)
+}
+
+// queryRowMap liefert die erste Ergebniszeile als Spaltenname → Wert
+func (db *NinoxDB) queryRowMap(query string, args ...interface{}) (map[string]interface{}, error) {
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	values := make([]interface{}, len(cols))
+	ptrs := make([]interface{}, len(cols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	if err := rows.Scan(ptrs...); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(cols))
+	for i, col := range cols {
+		v := values[i]
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		// Gespeichertes JSON eingebettet statt als String ausgeben
+		if str, ok := v.(string); ok && rawJSONColumns[strings.ToLower(col)] && json.Valid([]byte(str)) {
+			v = json.RawMessage(str)
+		}
+		result[col] = v
+	}
+	return result, nil
+}
+
+// QueryResult ist das Ergebnis einer freien SQL-Abfrage
+type QueryResult struct {
+	Columns   []string
+	Rows      [][]string
+	Truncated bool // Mehr Zeilen vorhanden als geladen
+}
+
+// readOnlyPrefixes sind die erlaubten Anfänge einer Konsolen-Abfrage
+var readOnlyPrefixes = []string{"SELECT", "WITH", "EXPLAIN", "PRAGMA", "VALUES"}
+
+// readOnlyPragmas sind die PRAGMAs, die nur lesen (ohne Zuweisung)
+var readOnlyPragmas = map[string]bool{
+	"TABLE_INFO": true, "TABLE_XINFO": true, "TABLE_LIST": true, "INDEX_LIST": true,
+	"INDEX_INFO": true, "INDEX_XINFO": true, "FOREIGN_KEY_LIST": true,
+}
+
+// forbiddenQueryWords sind Schlüsselwörter, die auch innerhalb einer lesenden
+// Abfrage nicht vorkommen dürfen (fremde Datenbankdateien einbinden)
+var forbiddenQueryWords = map[string]bool{"ATTACH": true, "DETACH": true}
+
+// sqlWords zerlegt eine Abfrage in Wörter (groß geschrieben) außerhalb von
+// Zeichenketten, Bezeichnern in Anführungszeichen und Kommentaren; ";" und "="
+// sind eigene Wörter
+func sqlWords(query string) []string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, strings.ToUpper(word.String()))
+			word.Reset()
+		}
+	}
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			flush()
+			end := c
+			if c == '[' {
+				end = ']'
+			}
+			for i++; i < len(query) && query[i] != end; i++ {
+			}
+			words = append(words, "''")
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			flush()
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			flush()
+			if end := strings.Index(query[i+2:], "*/"); end >= 0 {
+				i += end + 3
+			} else {
+				i = len(query)
+			}
+		case c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80:
+			word.WriteByte(c)
+		default:
+			flush()
+			if c == ';' || c == '=' {
+				words = append(words, string(c))
+			}
+		}
+	}
+	flush()
+	return words
+}
+
+// checkReadOnlyQuery lässt nur einzelne Abfragen mit einem der readOnlyPrefixes
+// zu. Mehrere Anweisungen, ATTACH und schreibende PRAGMAs werden abgelehnt, da
+// der Treiber sonst auch die folgenden Anweisungen ausführt.
+func checkReadOnlyQuery(query string) error {
+	words := sqlWords(query)
+	for len(words) > 0 && words[len(words)-1] == ";" {
+		words = words[:len(words)-1]
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("leere Abfrage")
+	}
+	for _, w := range words {
+		switch {
+		case w == ";":
+			return fmt.Errorf("nur eine Anweisung je Abfrage erlaubt")
+		case forbiddenQueryWords[w]:
+			return fmt.Errorf("%s ist nicht erlaubt", w)
+		}
+	}
+	if words[0] == "PRAGMA" {
+		if len(words) < 2 || !readOnlyPragmas[words[1]] || len(words) > 2 && words[2] == "=" {
+			return fmt.Errorf("nur lesende PRAGMAs erlaubt (table_info, index_list, …)")
+		}
+		return nil
+	}
+	for _, prefix := range readOnlyPrefixes {
+		if words[0] == prefix {
+			return nil
+		}
+	}
+	return fmt.Errorf("nur lesende Abfragen erlaubt (%s)", strings.Join(readOnlyPrefixes, ", "))
+}
+
+// RunQuery führt eine beliebige lesende SQL-Abfrage aus.
+// Schreibzugriffe werden zusätzlich vom Backend unterbunden (z.B. PRAGMA query_only).
+func (db *NinoxDB) RunQuery(query string, limit int) (*QueryResult, error) {
+	query = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(query), ";"))
+	if query == "" {
+		return nil, fmt.Errorf("leere Abfrage")
+	}
+	if err := checkReadOnlyQuery(query); err != nil {
+		return nil, err
+	}
+
+	// Kontext der Anfrage (WithContext): Konsole und serve können abbrechen bzw. begrenzen
+	ctx := db.conn.context()
+	conn, err := db.conn.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	restore, err := db.backend.readOnly(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QueryResult{Columns: cols}
+	values := make([]interface{}, len(cols))
+	ptrs := make([]interface{}, len(cols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if limit > 0 && len(result.Rows) >= limit {
+			result.Truncated = true
+			break
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		row := make([]string, len(cols))
+		for i, v := range values {
+			switch val := v.(type) {
+			case nil:
+				row[i] = "NULL"
+			case []byte:
+				row[i] = string(val)
+			default:
+				row[i] = fmt.Sprint(val)
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// openFixture legt die Fixture-Datenbank in einem temporären Verzeichnis an
+func openFixture(t *testing.T) *NinoxDB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixture.db")
+	if err := WriteFixture(path); err != nil {
+		t.Fatalf("WriteFixture: %v", err)
+	}
+	db, err := NewNinoxDB(path)
+	if err != nil {
+		t.Fatalf("NewNinoxDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func databaseNames(t *testing.T, db *NinoxDB) []string {
+	t.Helper()
+	databases, err := db.GetDatabases()
+	if err != nil {
+		t.Fatalf("GetDatabases: %v", err)
+	}
+	var names []string
+	for _, d := range databases {
+		names = append(names, d.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func scriptLabels(scripts []Script) map[string]bool {
+	labels := make(map[string]bool, len(scripts))
+	for _, s := range scripts {
+		labels[scriptLabel(s)] = true
+	}
+	return labels
+}
+
+func TestGetDatabases(t *testing.T) {
+	db := openFixture(t)
+	if got := databaseNames(t, db); len(got) != 2 || got[0] != "CRM" || got[1] != "ERP" {
+		t.Errorf("Datenbanken = %v, erwartet [CRM ERP]", got)
+	}
+}
+
+func TestGetTables(t *testing.T) {
+	db := openFixture(t)
+	tables, err := db.GetTables("crm001")
+	if err != nil {
+		t.Fatalf("GetTables: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, table := range tables {
+		names[table.Name] = true
+	}
+	for _, want := range []string{"Kunden", "Rechnungen", "Projekte"} {
+		if !names[want] {
+			t.Errorf("Tabelle %s fehlt (%v)", want, names)
+		}
+	}
+	if len(tables) != 3 {
+		t.Errorf("%d Tabellen, erwartet 3", len(tables))
+	}
+}
+
+func TestSearchScripts(t *testing.T) {
+	db := openFixture(t)
+	scripts, err := db.SearchScripts("sendEmail", 100)
+	if err != nil {
+		t.Fatalf("SearchScripts: %v", err)
+	}
+	labels := scriptLabels(scripts)
+	for _, want := range []string{"CRM/Kunden/Mail senden:onClick", "CRM/Nachtlauf Mahnungen:schedule"} {
+		if !labels[want] {
+			t.Errorf("%s nicht gefunden (%v)", want, labels)
+		}
+	}
+	for label := range labels {
+		if label == "ERP/Artikel/Preis prüfen:onClick" {
+			t.Errorf("Treffer ohne Suchbegriff: %s", label)
+		}
+	}
+	if all, err := db.SearchScripts("sendEmail", -1); err != nil || len(all) != len(scripts) {
+		t.Errorf("ohne Limit: %d Treffer, err=%v", len(all), err)
+	}
+}
+
+func TestFilterScripts(t *testing.T) {
+	db := openFixture(t)
+	all, err := db.GetAllScripts()
+	if err != nil {
+		t.Fatalf("GetAllScripts: %v", err)
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"http", []string{"CRM/Rechnungen/Export:onClick"}},
+		{"onClick AND Kunden", []string{"CRM/Kunden/Mail senden:onClick", "CRM/Rechnungen/Export:onClick"}},
+		{"canDelete OR Preis prüfen", []string{"CRM/Projekte:canDelete", "ERP/Artikel/Preis prüfen:onClick"}},
+		{"http AND canDelete", nil},
+		{"tabelle=Kunden", []string{"CRM/Kunden/Umsatz:fn", "CRM/Kunden:afterUpdate", "CRM/Kunden/Mail senden:onClick"}},
+		{"typ=onClick AND datenbank=crm", []string{"CRM/Kunden/Mail senden:onClick", "CRM/Rechnungen/Export:onClick"}},
+		{"tabelle=Kund", nil},
+		{"onClick AND NOT Kunden", []string{"ERP/Artikel/Preis prüfen:onClick"}},
+		{"NOT tabelle=Rechnungen AND typ=fn", []string{"CRM/Kunden/Umsatz:fn"}},
+	}
+	for _, tt := range tests {
+		got := scriptLabels(filterScripts(all, tt.filter))
+		if len(got) != len(tt.want) {
+			t.Errorf("%q: %d Treffer %v, erwartet %v", tt.filter, len(got), got, tt.want)
+			continue
+		}
+		for _, want := range tt.want {
+			if !got[want] {
+				t.Errorf("%q: %s fehlt (%v)", tt.filter, want, got)
+			}
+		}
+	}
+
+	if got := filterScripts(all, "  "); len(got) != len(all) {
+		t.Errorf("leerer Filter: %d von %d Scripts", len(got), len(all))
+	}
+}
+
+func TestIgnoreFilter(t *testing.T) {
+	db := openFixture(t)
+	all, err := db.GetAllScripts()
+	if err != nil {
+		t.Fatalf("GetAllScripts: %v", err)
+	}
+
+	if err := db.SetIgnore([]string{"CRM/Rechnungen"}); err != nil {
+		t.Fatalf("SetIgnore: %v", err)
+	}
+	scripts, err := db.GetAllScripts()
+	if err != nil {
+		t.Fatalf("GetAllScripts: %v", err)
+	}
+	for _, s := range scripts {
+		if s.DatabaseName == "CRM" && s.TableName == "Rechnungen" {
+			t.Errorf("ausgeblendetes Script geliefert: %s", scriptLabel(s))
+		}
+	}
+	if len(scripts) == 0 || len(scripts) >= len(all) {
+		t.Errorf("%d von %d Scripts nach Ausblenden", len(scripts), len(all))
+	}
+	tables, _ := db.GetTables("crm001")
+	for _, table := range tables {
+		if table.Name == "Rechnungen" {
+			t.Error("ausgeblendete Tabelle geliefert")
+		}
+	}
+
+	db.SetShowIgnored(true)
+	if scripts, _ := db.GetAllScripts(); len(scripts) != len(all) {
+		t.Errorf("eingeblendet: %d von %d Scripts", len(scripts), len(all))
+	}
+}
+
+func TestArchivedFilter(t *testing.T) {
+	db := openFixture(t)
+	if err := db.SetArchived([]string{"erp*"}); err != nil {
+		t.Fatalf("SetArchived: %v", err)
+	}
+	if got := databaseNames(t, db); len(got) != 1 || got[0] != "CRM" {
+		t.Errorf("Datenbanken = %v, erwartet [CRM]", got)
+	}
+	if !db.IsArchived("erp001") {
+		t.Error("ERP nicht als archiviert markiert")
+	}
+
+	db.SetShowArchived(true)
+	if got := databaseNames(t, db); len(got) != 2 {
+		t.Errorf("eingeblendet: %v", got)
+	}
+}
+
+func TestRefreshFTS(t *testing.T) {
+	allow := writeAccess.allow
+	writeAccess.allow = true
+	t.Cleanup(func() { writeAccess.allow = allow })
+	db := openFixture(t)
+	ctx := context.Background()
+
+	if rebuilt, err := db.RefreshFTS(ctx); err != nil || !rebuilt {
+		t.Fatalf("erster Aufbau: rebuilt=%v, err=%v", rebuilt, err)
+	}
+	if rebuilt, err := db.RefreshFTS(ctx); err != nil || rebuilt {
+		t.Errorf("ohne Änderung neu aufgebaut: rebuilt=%v, err=%v", rebuilt, err)
+	}
+	var digest string
+	if err := db.conn.QueryRow(`SELECT digest FROM tui_fts_state WHERE id = 1`).Scan(&digest); err != nil {
+		t.Errorf("Stand nicht in der Extraktion gespeichert: %v", err)
+	}
+
+	if _, err := db.conn.DB.Exec(`UPDATE scripts SET code = code || ' ', code_hash = 'geändert' WHERE id = 1`); err != nil {
+		t.Fatalf("Script ändern: %v", err)
+	}
+	if rebuilt, err := db.RefreshFTS(ctx); err != nil || !rebuilt {
+		t.Errorf("nach Änderung nicht neu aufgebaut: rebuilt=%v, err=%v", rebuilt, err)
+	}
+}
+
+func TestIgnoreManyScripts(t *testing.T) {
+	allow := writeAccess.allow
+	writeAccess.allow = true
+	t.Cleanup(func() { writeAccess.allow = allow })
+	db := openFixture(t)
+
+	// mehr Scripts als SQLite gebundene Variablen je Abfrage erlaubt
+	if _, err := db.conn.DB.Exec(`
+		WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 40000)
+		INSERT INTO scripts (team_id, database_id, database_name, table_id, table_name, element_name, code_type, code)
+		SELECT 't1', 'crm001', 'CRM', 'A', 'Kunden', 'Test ' || i, 'fn', 'void'
+		FROM n`); err != nil {
+		t.Fatalf("Scripts anlegen: %v", err)
+	}
+	if err := db.SetIgnore([]string{"Test *"}); err != nil {
+		t.Fatalf("SetIgnore: %v", err)
+	}
+	scripts, err := db.GetAllScripts()
+	if err != nil {
+		t.Fatalf("GetAllScripts: %v", err)
+	}
+	for _, s := range scripts {
+		if strings.HasPrefix(s.ElementName, "Test ") {
+			t.Fatalf("ausgeblendetes Script geliefert: %s", scriptLabel(s))
+		}
+	}
+	if len(scripts) == 0 {
+		t.Error("keine Scripts mehr geliefert")
+	}
+}
+
+func TestUpdateScriptCode(t *testing.T) {
+	allow := writeAccess.allow
+	writeAccess.allow = true
+	t.Cleanup(func() { writeAccess.allow = allow })
+	db := openFixture(t)
+
+	before, err := db.SearchScripts("sendEmail", -1)
+	if err != nil || len(before) == 0 {
+		t.Fatalf("SearchScripts: %d Treffer, err=%v", len(before), err)
+	}
+	s := before[0]
+	if err := db.UpdateScriptCode(s.ID, "alert(\"geaendertesWort\")"); err != nil {
+		t.Fatalf("UpdateScriptCode: %v", err)
+	}
+
+	found, err := db.SearchScripts("geaendertesWort", -1)
+	if err != nil || len(found) != 1 || found[0].ID != s.ID {
+		t.Errorf("neuer Code nicht im Index: %v, err=%v", scriptLabels(found), err)
+	}
+	after, _ := db.SearchScripts("sendEmail", -1)
+	if len(after) != len(before)-1 {
+		t.Errorf("alter Code noch im Index: %d statt %d Treffer", len(after), len(before)-1)
+	}
+	// nur mit FTS5 (go test -tags sqlite_fts5)
+	_, err = db.conn.DB.Exec(`INSERT INTO scripts_fts(scripts_fts) VALUES ('integrity-check')`)
+	if err != nil && !strings.Contains(err.Error(), "no such table") {
+		t.Errorf("Volltextindex inkonsistent: %v", err)
+	}
+}
+
+func TestRunQueryContext(t *testing.T) {
+	db := openFixture(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := db.WithContext(ctx).RunQuery("SELECT * FROM scripts", 10); err == nil {
+		t.Error("abgebrochener Kontext ignoriert")
+	}
+	if result, err := db.RunQuery("SELECT name FROM databases", 10); err != nil || len(result.Rows) != 2 {
+		t.Errorf("danach: err=%v", err)
+	}
+}
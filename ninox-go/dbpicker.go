@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Dateiauswahl beim Start: ohne angegebene Datenbank und ohne ninox_schema.db
+// stehen die .db-Dateien des aktuellen Verzeichnisses und zuletzt geöffnete
+// Extraktionen zur Wahl
+// =============================================================================
+
+const (
+	recentDBFile = "recent_dbs.json"
+	maxRecentDBs = 10
+)
+
+// rememberDB merkt sich eine geöffnete Extraktion (neueste zuerst)
+func rememberDB(path string) {
+	if !isFileDB(path) {
+		return
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	var recent []string
+	loadState(recentDBFile, &recent)
+	list := []string{path}
+	for _, p := range recent {
+		if p != path && len(list) < maxRecentDBs {
+			list = append(list, p)
+		}
+	}
+	saveState(recentDBFile, list)
+}
+
+// pickerCandidates liefert die .db-Dateien im aktuellen Verzeichnis und die
+// noch vorhandenen zuletzt geöffneten Extraktionen, ohne Doppelte
+func pickerCandidates() []string {
+	var candidates []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		abs, err := filepath.Abs(path)
+		if err != nil || seen[abs] {
+			return
+		}
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			return
+		}
+		seen[abs] = true
+		candidates = append(candidates, path)
+	}
+
+	local, _ := filepath.Glob("*.db")
+	for _, path := range local {
+		add(path)
+	}
+	var recent []string
+	loadState(recentDBFile, &recent)
+	for _, path := range recent {
+		add(path)
+	}
+	return candidates
+}
+
+// isInteractive meldet, ob Ein- und Ausgabe ein Terminal sind
+func isInteractive() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// dbPicker ist die Auswahlliste vor dem Start der eigentlichen Oberfläche
+type dbPicker struct {
+	paths    []string
+	local    int // die ersten local Einträge liegen im aktuellen Verzeichnis
+	selected int
+	chosen   string
+	done     bool // beendet: Auswahl ausblenden
+}
+
+func (p dbPicker) Init() tea.Cmd {
+	return nil
+}
+
+func (p dbPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+	switch {
+	case key.Matches(keyMsg, keys.Up):
+		if p.selected > 0 {
+			p.selected--
+		}
+	case key.Matches(keyMsg, keys.Down):
+		if p.selected < len(p.paths)-1 {
+			p.selected++
+		}
+	case key.Matches(keyMsg, keys.Enter):
+		p.chosen = p.paths[p.selected]
+		p.done = true
+		return p, tea.Quit
+	case key.Matches(keyMsg, keys.Back), key.Matches(keyMsg, keys.Quit):
+		p.done = true
+		return p, tea.Quit
+	}
+	return p, nil
+}
+
+func (p dbPicker) View() string {
+	if p.done {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("📂 Extraktion öffnen") + "\n\n")
+	for i, path := range p.paths {
+		if i == 0 && p.local > 0 {
+			b.WriteString(mutedStyle.Render("  Aktuelles Verzeichnis") + "\n")
+		}
+		if i == p.local {
+			b.WriteString(mutedStyle.Render("  Zuletzt geöffnet") + "\n")
+		}
+		style := tableCellStyle
+		prefix := "  "
+		if i == p.selected {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		b.WriteString(style.Render(prefix+path) + "\n")
+	}
+	b.WriteString("\n" + mutedStyle.Render("↑↓ Auswahl • Enter öffnen • Esc abbrechen") + "\n")
+	return b.String()
+}
+
+// pickDB lässt eine Extraktion auswählen; "" bei Abbruch oder ohne Kandidaten
+func pickDB() (string, error) {
+	paths := pickerCandidates()
+	if len(paths) == 0 {
+		return "", nil
+	}
+	local := 0
+	for _, path := range paths {
+		if filepath.Dir(path) == "." {
+			local++
+		}
+	}
+
+	result, err := tea.NewProgram(dbPicker{paths: paths, local: local}).Run()
+	if err != nil {
+		return "", fmt.Errorf("Dateiauswahl: %w", err)
+	}
+	return result.(dbPicker).chosen, nil
+}
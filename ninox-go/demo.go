@@ -0,0 +1,54 @@
+package main
+
+import (
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"os"
+)
+
+// =============================================================================
+// Demo-Modus
+// =============================================================================
+
+// demoSQL ist ein kleiner Beispiel-Extrakt (SQL-Dump einer gen-fixture-Datenbank)
+//
+//go:embed demo.sql
+var demoSQL string
+
+// demoDBPath ist die temporäre Demo-Datenbank der laufenden Sitzung
+var demoDBPath string
+
+// removeDemoDB entfernt die Demo-Datenbank. fail und der Absturzpfad beenden
+// mit os.Exit, das keine defer-Anweisungen ausführt, und rufen sie daher selbst auf.
+func removeDemoDB() {
+	if demoDBPath != "" {
+		os.Remove(demoDBPath)
+		demoDBPath = ""
+	}
+}
+
+// openDemoDB schreibt die eingebetteten Beispieldaten in eine temporäre
+// Datenbank; removeDemoDB entfernt sie wieder.
+func openDemoDB() (string, error) {
+	f, err := os.CreateTemp("", "ninox-demo-*.db")
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	f.Close()
+
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Exec(demoSQL); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("Demo-Daten laden: %w", err)
+	}
+	demoDBPath = path
+	return path, nil
+}
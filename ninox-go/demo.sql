@@ -0,0 +1,290 @@
+BEGIN TRANSACTION;
+CREATE TABLE automations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		element_id TEXT NOT NULL,
+		name TEXT,
+		kind TEXT NOT NULL,
+		trigger_condition TEXT,
+		enabled INTEGER DEFAULT 1,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	);
+INSERT INTO "automations" VALUES(1,'crm001','J1','Nachtlauf Mahnungen','schedule','täglich 02:00',1);
+INSERT INTO "automations" VALUES(2,'crm001','W1','Zahlungseingang','webhook','POST /zahlung',1);
+INSERT INTO "automations" VALUES(3,'erp001','J1','Bestandsabgleich','schedule','stündlich',0);
+CREATE TABLE databases (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		team_id TEXT,
+		team_name TEXT,
+		archived INTEGER DEFAULT 0,
+		version INTEGER,
+		color TEXT,
+		icon TEXT,
+		table_count INTEGER DEFAULT 0,
+		code_count INTEGER DEFAULT 0,
+		extracted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+INSERT INTO "databases" VALUES('crm001','CRM','fixture-team','Fixture',0,1,NULL,NULL,3,12,'2026-10-15 23:44:05');
+INSERT INTO "databases" VALUES('erp001','ERP','fixture-ops','Fixture Betrieb',0,1,NULL,NULL,2,3,'2026-10-15 23:44:05');
+CREATE TABLE field_options (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		field_id TEXT NOT NULL,
+		option_id TEXT NOT NULL,
+		caption TEXT,
+		sort_order REAL,
+		color TEXT,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	);
+INSERT INTO "field_options" VALUES(1,'crm001','A','D','1','Neu',0.0,NULL);
+INSERT INTO "field_options" VALUES(2,'crm001','A','D','2','Aktiv',1.0,NULL);
+INSERT INTO "field_options" VALUES(3,'crm001','A','D','3','Gesperrt',2.0,NULL);
+CREATE TABLE fields (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		field_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		caption TEXT,
+		base_type TEXT,
+		is_required INTEGER DEFAULT 0,
+		ref_table_id TEXT,
+		ref_table_name TEXT,
+		ref_database_id TEXT,
+		is_composition INTEGER DEFAULT 0,
+		has_formula INTEGER DEFAULT 0,
+		UNIQUE(database_id, table_id, field_id),
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	);
+INSERT INTO "fields" VALUES(1,'crm001','A','A','Name','Name','string',0,NULL,NULL,NULL,0,0);
+INSERT INTO "fields" VALUES(2,'crm001','A','B','E-Mail','E-Mail','email',0,NULL,NULL,NULL,0,0);
+INSERT INTO "fields" VALUES(3,'crm001','A','C','Umsatz','Umsatz','number',0,NULL,NULL,NULL,0,1);
+INSERT INTO "fields" VALUES(4,'crm001','A','D','Status','Status','choice',0,NULL,NULL,NULL,0,0);
+INSERT INTO "fields" VALUES(5,'crm001','B','A','Nummer','Nummer','string',0,NULL,NULL,NULL,0,0);
+INSERT INTO "fields" VALUES(6,'crm001','B','B','Betrag','Betrag','number',0,NULL,NULL,NULL,0,0);
+INSERT INTO "fields" VALUES(7,'crm001','B','C','Kunde','Kunde','ref',0,'A','Kunden',NULL,0,0);
+INSERT INTO "fields" VALUES(8,'crm001','B','D','Umsatzsteuer','Umsatzsteuer','number',0,NULL,NULL,NULL,0,1);
+INSERT INTO "fields" VALUES(9,'crm001','C','A','Titel','Titel','string',0,NULL,NULL,NULL,0,0);
+INSERT INTO "fields" VALUES(10,'crm001','C','B','Kunde','Kunde','ref',0,'A','Kunden',NULL,0,0);
+INSERT INTO "fields" VALUES(11,'erp001','A','A','Bezeichnung','Bezeichnung','string',0,NULL,NULL,NULL,0,0);
+INSERT INTO "fields" VALUES(12,'erp001','A','B','Preis','Preis','number',0,NULL,NULL,NULL,0,0);
+INSERT INTO "fields" VALUES(13,'erp001','B','A','Artikel','Artikel','ref',0,'A','Artikel',NULL,0,0);
+INSERT INTO "fields" VALUES(14,'erp001','B','B','Bestand','Bestand','number',0,NULL,NULL,NULL,0,0);
+CREATE TABLE layout_elements (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		element_id TEXT NOT NULL,
+		caption TEXT,
+		element_type TEXT,
+		source TEXT NOT NULL,
+		sort_order REAL,
+		tab_caption TEXT,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	);
+INSERT INTO "layout_elements" VALUES(1,'crm001','A','T1','Allgemein','tab','ui',5.0,'Allgemein');
+INSERT INTO "layout_elements" VALUES(2,'crm001','A','A','Name','string','field',10.0,'Allgemein');
+INSERT INTO "layout_elements" VALUES(3,'crm001','A','B','E-Mail','email','field',20.0,'Allgemein');
+INSERT INTO "layout_elements" VALUES(4,'crm001','A','T2','Auswertung','tab','ui',25.0,'Auswertung');
+INSERT INTO "layout_elements" VALUES(5,'crm001','A','C','Umsatz','number','field',30.0,'Auswertung');
+INSERT INTO "layout_elements" VALUES(6,'crm001','A','D','Status','choice','field',40.0,'Auswertung');
+INSERT INTO "layout_elements" VALUES(7,'crm001','A','U1','Mail senden','button','ui',45.0,'Auswertung');
+INSERT INTO "layout_elements" VALUES(8,'crm001','B','A','Nummer','string','field',10.0,NULL);
+INSERT INTO "layout_elements" VALUES(9,'crm001','B','B','Betrag','number','field',20.0,NULL);
+INSERT INTO "layout_elements" VALUES(10,'crm001','B','C','Kunde','ref','field',30.0,NULL);
+INSERT INTO "layout_elements" VALUES(11,'crm001','B','D','Umsatzsteuer','number','field',40.0,NULL);
+INSERT INTO "layout_elements" VALUES(12,'crm001','B','U1','Export','button','ui',45.0,NULL);
+INSERT INTO "layout_elements" VALUES(13,'crm001','C','A','Titel','string','field',10.0,NULL);
+INSERT INTO "layout_elements" VALUES(14,'crm001','C','B','Kunde','ref','field',20.0,NULL);
+INSERT INTO "layout_elements" VALUES(15,'crm001','A','V1','Alle Kunden','view','view',0.0,NULL);
+INSERT INTO "layout_elements" VALUES(16,'crm001','B','V2','Offene Rechnungen','view','view',1.0,NULL);
+INSERT INTO "layout_elements" VALUES(17,'erp001','A','A','Bezeichnung','string','field',10.0,NULL);
+INSERT INTO "layout_elements" VALUES(18,'erp001','A','B','Preis','number','field',20.0,NULL);
+INSERT INTO "layout_elements" VALUES(19,'erp001','A','U1','Preis prüfen','button','ui',25.0,NULL);
+INSERT INTO "layout_elements" VALUES(20,'erp001','B','A','Artikel','ref','field',10.0,NULL);
+INSERT INTO "layout_elements" VALUES(21,'erp001','B','B','Bestand','number','field',20.0,NULL);
+CREATE TABLE permissions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		table_name TEXT,
+		element_id TEXT,
+		element_name TEXT,
+		access TEXT NOT NULL,
+		roles TEXT NOT NULL,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	);
+INSERT INTO "permissions" VALUES(1,'crm001','A','Kunden',NULL,NULL,'write','Admin,Vertrieb');
+INSERT INTO "permissions" VALUES(2,'crm001','A','Kunden','C','Umsatz','read','Admin,Buchhaltung');
+INSERT INTO "permissions" VALUES(3,'crm001','B','Rechnungen',NULL,NULL,'delete','Admin');
+INSERT INTO "permissions" VALUES(4,'crm001','B','Rechnungen','B','Betrag','write','Buchhaltung');
+CREATE TABLE record_counts (
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		record_count INTEGER NOT NULL,
+		counted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (database_id, table_id)
+	);
+INSERT INTO "record_counts" VALUES('crm001','A',1250,'2026-10-15 23:44:05');
+INSERT INTO "record_counts" VALUES('crm001','B',48210,'2026-10-15 23:44:05');
+INSERT INTO "record_counts" VALUES('crm001','C',310,'2026-10-15 23:44:05');
+INSERT INTO "record_counts" VALUES('erp001','A',5400,'2026-10-15 23:44:05');
+INSERT INTO "record_counts" VALUES('erp001','B',5400,'2026-10-15 23:44:05');
+CREATE TABLE relationships (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		database_name TEXT,
+		source_table_id TEXT NOT NULL,
+		source_table_name TEXT NOT NULL,
+		source_field_id TEXT,
+		source_field_name TEXT,
+		target_table_id TEXT,
+		target_table_name TEXT NOT NULL,
+		target_database_id TEXT,
+		target_database_name TEXT,
+		relationship_type TEXT NOT NULL,
+		is_composition INTEGER DEFAULT 0,
+		reverse_field_name TEXT,
+		found_in_code_type TEXT,
+		found_in_code TEXT,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	);
+INSERT INTO "relationships" VALUES(1,'crm001','CRM','B','Rechnungen','C','Kunde','A','Kunden',NULL,NULL,'N_TO_1',0,NULL,NULL,NULL);
+INSERT INTO "relationships" VALUES(2,'crm001','CRM','C','Projekte','B','Kunde','A','Kunden',NULL,NULL,'N_TO_1',0,NULL,NULL,NULL);
+INSERT INTO "relationships" VALUES(3,'erp001','ERP','B','Lager','A','Artikel','A','Artikel',NULL,NULL,'N_TO_1',0,NULL,NULL,NULL);
+CREATE TABLE script_dependencies (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		script_id INTEGER NOT NULL,
+		source_database_id TEXT NOT NULL,
+		source_database_name TEXT,
+		target_database_name TEXT NOT NULL,
+		reference_type TEXT NOT NULL,
+		code_snippet TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (script_id) REFERENCES scripts(id) ON DELETE CASCADE,
+		FOREIGN KEY (source_database_id) REFERENCES databases(id)
+	);
+CREATE TABLE scripts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		team_id TEXT NOT NULL,
+		team_name TEXT,
+		database_id TEXT NOT NULL,
+		database_name TEXT,
+		table_id TEXT,
+		table_name TEXT,
+		element_id TEXT,
+		element_name TEXT,
+		code_type TEXT NOT NULL,
+		code_category TEXT,
+		code TEXT NOT NULL,
+		code_original TEXT,
+		code_hash TEXT,
+		line_count INTEGER DEFAULT 0,
+		modified_by TEXT,
+		modified_at TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	);
+INSERT INTO "scripts" VALUES(1,'fixture-team','Fixture','crm001','CRM',NULL,NULL,'E1',NULL,'globalCode','GLOBAL_FUNCTION','function brutto(netto : number) do
+	netto * 1.19
+end','function brutto(netto : number) do
+	netto * 1.19
+end','77fe7e983b1f815e6240c920f00a26a7256b39241cbff03325d21e26c8050486',3,NULL,NULL,'2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(2,'fixture-team','Fixture','crm001','CRM','A','Kunden','E2','Umsatz','fn','FORMULA','sum(Rechnungen.Betrag)','sum(Rechnungen.Betrag)','4fe322917a1a1e566856263734d7682c83e8fdf778108c8c5ed22e17a2b9d026',1,NULL,NULL,'2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(3,'fixture-team','Fixture','crm001','CRM','A','Kunden','E3',NULL,'afterUpdate','TRIGGER','if Status = 3 then
+	for r in Rechnungen do
+		r.Betrag := 0
+	end
+end','if Status = 3 then
+	for r in Rechnungen do
+		r.Betrag := 0
+	end
+end','91483187b1d03ff00348261b7b92c07747b15becc8cf20094fd99db8a1477e7a',5,NULL,NULL,'2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(4,'fixture-team','Fixture','crm001','CRM','A','Kunden','E4','Mail senden','onClick','BUTTON','sendEmail({
+	to: ''E-Mail'',
+	subject: "Hallo " + Name
+})','sendEmail({
+	to: ''E-Mail'',
+	subject: "Hallo " + Name
+})','9f97619423dd207cc36dd3ed57d3778a895def2ed04eee0a699a2f54fb6859fc',4,'anna.berger@example.com','2026-10-12 09:14:00','2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(5,'fixture-team','Fixture','crm001','CRM','B','Rechnungen','E5','Umsatzsteuer','fn','FORMULA','Betrag * 0.19','Betrag * 0.19','a13cdf9c6cb7f6217a488fcfd5f3a1ed4db3043872ed74f18f504715bb411d91',1,NULL,NULL,'2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(6,'fixture-team','Fixture','crm001','CRM','B','Rechnungen','E6',NULL,'afterCreate','TRIGGER','Nummer := "R-" + format(now(), "YYYY") + "-" + Nr','Nummer := "R-" + format(now(), "YYYY") + "-" + Nr','db8204d53ad45e3285fe8470560a913212ef6bd2a184e9c0d13d7c5321a43ae1',1,NULL,NULL,'2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(7,'fixture-team','Fixture','crm001','CRM','B','Rechnungen','E7','Export','onClick','BUTTON','let k := select Kunden;
+for x in k do
+	http("POST", "https://example.com/api", {}, x)
+end','let k := select Kunden;
+for x in k do
+	http("POST", "https://example.com/api", {}, x)
+end','ee41f798c370f7d59803b06c015d5286745f02aa9af1fb1db757472e2923cd20',4,'jonas.weber@example.com','2026-10-14 16:02:00','2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(8,'fixture-team','Fixture','crm001','CRM','C','Projekte','E8',NULL,'canDelete','PERMISSION','userHasRole("Admin")','userHasRole("Admin")','21e4143e515f376b4a61a60e3979298793a171f92a3f92298e1989fec530dbb9',1,NULL,NULL,'2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(9,'fixture-team','Fixture','crm001','CRM','B','Rechnungen','E9','Rechnung › Summe','reportFn','REPORT','format(Betrag + Umsatzsteuer, "#,##0.00 €")','format(Betrag + Umsatzsteuer, "#,##0.00 €")','876ff1ff8337bc539d76c8db5eb3fff8cf4929654916733f252d6b5b6b58ef17',1,NULL,NULL,'2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(10,'fixture-team','Fixture','crm001','CRM','B','Rechnungen','E10','Rechnung › Mahnhinweis','reportVisibility','REPORT','Kunde.Status = 3','Kunde.Status = 3','545068481548ffd555beb4803babb618f0a78940eaf2394793f0b6c4cd3f6efc',1,NULL,NULL,'2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(11,'fixture-team','Fixture','crm001','CRM',NULL,NULL,'J1','Nachtlauf Mahnungen','schedule','AUTOMATION','for r in select Rechnungen where Betrag > 1000 do
+	sendEmail({
+		to: "buchhaltung@example.com",
+		subject: "Offen: " + r.Nummer
+	})
+end','for r in select Rechnungen where Betrag > 1000 do
+	sendEmail({
+		to: "buchhaltung@example.com",
+		subject: "Offen: " + r.Nummer
+	})
+end','3b51480dc603356eda4d6323c8a8e4c4f321496af60da89809da628eefee7508',6,NULL,NULL,'2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(12,'fixture-team','Fixture','crm001','CRM',NULL,NULL,'W1','Zahlungseingang','webhook','AUTOMATION','let r := first(select Rechnungen where Nummer = $request.nummer);
+r.Betrag := 0','let r := first(select Rechnungen where Nummer = $request.nummer);
+r.Betrag := 0','2b49a66fa824a1fff07915f8797cc4766658f3a991eeaa14e703ba52adf2800e',2,NULL,NULL,'2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(13,'fixture-ops','Fixture Betrieb','erp001','ERP','A','Artikel','E1','Preis prüfen','onClick','BUTTON','if Preis < 0 then
+	alert("Preis ungültig")
+end','if Preis < 0 then
+	alert("Preis ungültig")
+end','f8bbf82ddc1fe3915078060d92d23f9619a5a1653bf34cfdd35e651baaa4bc80',3,'anna.berger@example.com','2026-09-30 11:45:00','2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(14,'fixture-ops','Fixture Betrieb','erp001','ERP','B','Lager','E2',NULL,'afterUpdate','TRIGGER','do as database ''CRM''
+	select Kunden
+end','do as database ''CRM''
+	select Kunden
+end','cdee6f5e4717b86c12960b63fa23f3b65713743c4909b034eb03065f0a53c5d8',3,NULL,NULL,'2026-10-15 23:44:05');
+INSERT INTO "scripts" VALUES(15,'fixture-ops','Fixture Betrieb','erp001','ERP',NULL,NULL,'J1','Bestandsabgleich','schedule','AUTOMATION','for l in select Lager where Bestand < 0 do
+	l.Bestand := 0
+end','for l in select Lager where Bestand < 0 do
+	l.Bestand := 0
+end','56cb649bfe2e0c21ac932ede9f752d4853e01d79b8e2f6d34a2ffda14623137e',3,NULL,NULL,'2026-10-15 23:44:05');
+CREATE TABLE tables (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		caption TEXT,
+		icon TEXT,
+		hidden INTEGER DEFAULT 0,
+		field_count INTEGER DEFAULT 0,
+		UNIQUE(database_id, table_id),
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	);
+INSERT INTO "tables" VALUES(1,'crm001','A','Kunden','Kunden',NULL,0,4);
+INSERT INTO "tables" VALUES(2,'crm001','B','Rechnungen','Rechnungen',NULL,0,4);
+INSERT INTO "tables" VALUES(3,'crm001','C','Projekte','Projekte',NULL,0,2);
+INSERT INTO "tables" VALUES(4,'erp001','A','Artikel','Artikel',NULL,0,2);
+INSERT INTO "tables" VALUES(5,'erp001','B','Lager','Lager',NULL,0,2);
+CREATE INDEX idx_scripts_team ON scripts(team_id);
+CREATE INDEX idx_scripts_db ON scripts(database_id);
+CREATE INDEX idx_scripts_table ON scripts(table_name);
+CREATE INDEX idx_scripts_type ON scripts(code_type);
+CREATE INDEX idx_relationships_source ON relationships(source_table_name);
+CREATE INDEX idx_relationships_target ON relationships(target_table_name);
+CREATE INDEX idx_fields_ref ON fields(ref_table_name);
+CREATE INDEX idx_permissions_table ON permissions(database_id, table_id);
+CREATE INDEX idx_layout_table ON layout_elements(database_id, table_id);
+CREATE INDEX idx_field_options ON field_options(database_id, table_id, field_id);
+CREATE INDEX idx_automations_db ON automations(database_id);
+DELETE FROM "sqlite_sequence";
+INSERT INTO "sqlite_sequence" VALUES('tables',5);
+INSERT INTO "sqlite_sequence" VALUES('fields',14);
+INSERT INTO "sqlite_sequence" VALUES('relationships',3);
+INSERT INTO "sqlite_sequence" VALUES('scripts',15);
+INSERT INTO "sqlite_sequence" VALUES('layout_elements',21);
+INSERT INTO "sqlite_sequence" VALUES('permissions',4);
+INSERT INTO "sqlite_sequence" VALUES('field_options',3);
+INSERT INTO "sqlite_sequence" VALUES('automations',3);
+COMMIT;
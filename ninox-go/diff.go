@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Unified Diff
+// =============================================================================
+
+// diffContext ist die Standardanzahl an Kontextzeilen (wie diff -U3)
+const diffContext = 3
+
+// diffOp ist eine einzelne Zeilenoperation im Diff
+type diffOp struct {
+	kind  byte // ' ' gleich, '-' entfernt, '+' hinzugefügt
+	text  string
+	lineA int // 1-basiert, 0 wenn nicht in A
+	lineB int // 1-basiert, 0 wenn nicht in B
+}
+
+// diffLines berechnet die Zeilenoperationen über die längste gemeinsame Teilfolge
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+
+	// lcs[i][j] = Länge der LCS von a[i:] und b[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i], i + 1, j + 1})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i], i + 1, 0})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j], 0, j + 1})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{'-', a[i], i + 1, 0})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{'+', b[j], 0, j + 1})
+	}
+	return ops
+}
+
+// splitLines zerlegt Text in Zeilen; leerer Text ergibt keine Zeilen
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// UnifiedDiff erzeugt einen Unified Diff mit der angegebenen Anzahl Kontextzeilen.
+// Bei identischem Inhalt wird ein leerer String geliefert.
+func UnifiedDiff(nameA, nameB, a, b string, context int) string {
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	// Indizes der geänderten Operationen
+	var changed []int
+	for i, op := range ops {
+		if op.kind != ' ' {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", nameA, nameB)
+
+	// Änderungen mit überlappendem Kontext zu Hunks zusammenfassen
+	for k := 0; k < len(changed); {
+		start := max(0, changed[k]-context)
+		end := min(len(ops), changed[k]+context+1)
+		k++
+		for k < len(changed) && changed[k]-context <= end {
+			end = min(len(ops), changed[k]+context+1)
+			k++
+		}
+		writeHunk(&out, ops, start, end)
+	}
+	return out.String()
+}
+
+// writeHunk schreibt den Hunk ops[start:end] inklusive @@-Kopfzeile
+func writeHunk(out *strings.Builder, all []diffOp, start, end int) {
+	ops := all[start:end]
+	startA, startB, countA, countB := 0, 0, 0, 0
+	for _, op := range ops {
+		if op.kind != '+' {
+			if startA == 0 {
+				startA = op.lineA
+			}
+			countA++
+		}
+		if op.kind != '-' {
+			if startB == 0 {
+				startB = op.lineB
+			}
+			countB++
+		}
+	}
+
+	// Leere Seite: letzte Zeile derselben Seite vor dem Hunk angeben (wie GNU diff)
+	if countA == 0 {
+		startA = lineBefore(all[:start], true)
+	}
+	if countB == 0 {
+		startB = lineBefore(all[:start], false)
+	}
+
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(startA, countA), hunkRange(startB, countB))
+	for _, op := range ops {
+		out.WriteByte(op.kind)
+		out.WriteString(op.text)
+		out.WriteByte('\n')
+	}
+}
+
+// lineBefore ermittelt die letzte Zeilennummer einer Seite vor einem Hunk (0 am Anfang)
+func lineBefore(ops []diffOp, sideA bool) int {
+	for i := len(ops) - 1; i >= 0; i-- {
+		if sideA && ops[i].lineA > 0 {
+			return ops[i].lineA
+		}
+		if !sideA && ops[i].lineB > 0 {
+			return ops[i].lineB
+		}
+	}
+	return 0
+}
+
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// Farben für Diff-Zeilen (unabhängig vom Theme gut lesbar)
+var (
+	diffAddStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#00AF00"))
+	diffRemoveStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#D70000"))
+	diffFileStyle   = lipgloss.NewStyle().Bold(true)
+)
+
+// colorizeDiff färbt einen Unified Diff für die Terminal-Ausgabe ein
+func colorizeDiff(diff string) string {
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	hunkStyle := lipgloss.NewStyle().Foreground(currentTheme.Primary)
+
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = diffFileStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = diffAddStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = diffRemoveStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// openDiff zeigt einen Unified Diff in der Diff-Ansicht an
+func (m *Model) openDiff(title, diff string) {
+	if diff == "" {
+		diff = "(keine Unterschiede)\n"
+	} else {
+		diff = colorizeDiff(diff)
+	}
+	m.diffTitle = title
+	m.scriptDiff = nil
+	m.codeView.SetContent(diff)
+	m.codeView.GotoTop()
+	if m.mode != viewDiff {
+		m.prevMode = m.mode
+		m.mode = viewDiff
+	}
+}
+
+// renderDiff rendert die Diff-Ansicht
+func (m Model) renderDiff() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("± "+m.diffTitle) + "\n\n")
+	b.WriteString(m.codeView.View())
+
+	scrollInfo := fmt.Sprintf(" %d%% ", int(m.codeView.ScrollPercent()*100))
+	b.WriteString("\n" + mutedStyle.Render(scrollInfo))
+
+	return codeBoxStyle.Width(m.width - 4).Render(b.String())
+}
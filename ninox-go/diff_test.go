@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+// Erwartete Ausgaben stammen aus GNU diff -U<n> (ohne die Kopfzeilen ---/+++)
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		context int
+		want    string
+	}{
+		{"Einfügen ohne Kontext", "p\nq\nr\n", "n1\nn2\np\nq\nr\nnew\n", 0,
+			"@@ -0,0 +1,2 @@\n+n1\n+n2\n@@ -3,0 +6 @@\n+new\n"},
+		{"Einfügen mit Kontext", "p\nq\nr\n", "n1\nn2\np\nq\nr\nnew\n", 3,
+			"@@ -1,3 +1,6 @@\n+n1\n+n2\n p\n q\n r\n+new\n"},
+		{"Löschen ohne Kontext", "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n", "a\nc\nd\ne\nf\ng\nh\nj\n", 0,
+			"@@ -2 +1,0 @@\n-b\n@@ -9 +7,0 @@\n-i\n"},
+		{"Löschen mit Kontext", "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n", "a\nc\nd\ne\nf\ng\nh\nj\n", 1,
+			"@@ -1,3 +1,2 @@\n a\n-b\n c\n@@ -8,3 +7,2 @@\n h\n-i\n j\n"},
+		{"Ändern und Anhängen", "a\nb\nc\nd\ne\n", "a\nB\nc\nd\ne\nf\n", 1,
+			"@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n@@ -5 +5,2 @@\n e\n+f\n"},
+		{"neue Datei", "", "x\ny\n", 3, "@@ -0,0 +1,2 @@\n+x\n+y\n"},
+		{"gelöschte Datei", "x\ny\n", "", 3, "@@ -1,2 +0,0 @@\n-x\n-y\n"},
+	}
+	for _, tt := range tests {
+		want := "--- a\n+++ b\n" + tt.want
+		if got := UnifiedDiff("a", "b", tt.a, tt.b, tt.context); got != want {
+			t.Errorf("%s:\n%s\nerwartet:\n%s", tt.name, got, want)
+		}
+	}
+
+	if got := UnifiedDiff("a", "b", "x\n", "x\n", 3); got != "" {
+		t.Errorf("identischer Inhalt: %q", got)
+	}
+}
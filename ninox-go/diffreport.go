@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"time"
+)
+
+// =============================================================================
+// HTML-Bericht für Schema-Vergleiche (eigenständige Datei)
+// =============================================================================
+
+const diffReportCSS = `
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.4em; margin-bottom: 0.2em; }
+.meta { color: #666; margin-bottom: 1.5em; }
+.summary span { display: inline-block; margin-right: 1.5em; font-weight: bold; }
+details { margin: 0.4em 0 0.4em 1em; }
+summary { cursor: pointer; font-weight: bold; padding: 0.2em 0; }
+ul { list-style: none; padding-left: 1em; margin: 0.3em 0; }
+li { margin: 0.2em 0; }
+.k-added { color: #1a7f37; } .k-removed { color: #cf222e; } .k-changed { color: #9a6700; }
+.detail { color: #666; }
+table.diff { border-collapse: collapse; width: 100%; font-family: Menlo, Consolas, monospace; font-size: 0.85em; margin: 0.4em 0 1em 0; table-layout: fixed; }
+table.diff td { padding: 0 0.4em; vertical-align: top; white-space: pre-wrap; word-break: break-all; }
+table.diff td.num { width: 3em; color: #999; text-align: right; user-select: none; }
+table.diff td.del { background: #ffebe9; } table.diff td.add { background: #e6ffec; }
+table.diff td.gap { color: #999; text-align: center; background: #f6f8fa; }
+`
+
+// SnapshotDiffHTML erzeugt einen eigenständigen HTML-Bericht mit aufklappbaren
+// Abschnitten pro Datenbank und Tabelle sowie Script-Diffs nebeneinander
+func SnapshotDiffHTML(diff *SnapshotDiff) []byte {
+	var b bytes.Buffer
+	esc := html.EscapeString
+
+	counts := map[changeKind]int{}
+	for _, c := range diff.Changes {
+		counts[c.Kind]++
+	}
+
+	title := docText("Schema-Vergleich")
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"" + docLang + "\">\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<title>" + title + "</title>\n<style>" + diffReportCSS + "</style>\n</head>\n<body>\n")
+	b.WriteString("<h1>" + title + "</h1>\n")
+	fmt.Fprintf(&b, "<div class=\"meta\">%s: %s<br>%s: %s<br>%s: %s</div>\n",
+		docText("Alt"), esc(diff.Old), docText("Neu"), esc(diff.New), docText("Erstellt"), docTime(time.Now()))
+	fmt.Fprintf(&b, "<div class=\"summary\"><span class=\"k-added\">+%d %s</span>"+
+		"<span class=\"k-removed\">−%d %s</span><span class=\"k-changed\">~%d %s</span></div>\n",
+		counts[changeAdded], docText("hinzugefügt"), counts[changeRemoved], docText("entfernt"),
+		counts[changeChanged], docText("geändert"))
+
+	if len(diff.Changes) == 0 {
+		b.WriteString("<p>" + docText("Keine Unterschiede.") + "</p>\n")
+	}
+
+	// Änderungen sind nach Datenbank und Tabelle sortiert: Gruppen beim Wechsel öffnen
+	for i := 0; i < len(diff.Changes); {
+		database := diff.Changes[i].Database
+		end := i
+		for end < len(diff.Changes) && diff.Changes[end].Database == database {
+			end++
+		}
+		fmt.Fprintf(&b, "<details open>\n<summary>%s (%d)</summary>\n", esc(database), end-i)
+
+		for j := i; j < end; {
+			table := diff.Changes[j].Table
+			tableEnd := j
+			for tableEnd < end && diff.Changes[tableEnd].Table == table {
+				tableEnd++
+			}
+			label := table
+			if label == "" {
+				label = "(global)"
+			}
+			fmt.Fprintf(&b, "<details open>\n<summary>%s (%d)</summary>\n<ul>\n", esc(label), tableEnd-j)
+			for _, c := range diff.Changes[j:tableEnd] {
+				writeChangeHTML(&b, c)
+			}
+			b.WriteString("</ul>\n</details>\n")
+			j = tableEnd
+		}
+
+		b.WriteString("</details>\n")
+		i = end
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	return b.Bytes()
+}
+
+// changeClasses ordnet den Änderungsarten CSS-Klassen zu
+var changeClasses = map[changeKind]string{
+	changeAdded:   "k-added",
+	changeRemoved: "k-removed",
+	changeChanged: "k-changed",
+}
+
+// writeChangeHTML schreibt einen Listeneintrag, bei geänderten Scripts mit Diff
+func writeChangeHTML(b *bytes.Buffer, c SchemaChange) {
+	esc := html.EscapeString
+	name := c.Name
+	if c.Object == objectTable {
+		name = c.Table
+	}
+	fmt.Fprintf(b, "<li><span class=\"%s\">%c %s %s</span>", changeClasses[c.Kind], c.Kind, esc(docText(c.Object)), esc(name))
+	if c.Detail != "" {
+		fmt.Fprintf(b, " <span class=\"detail\">(%s)</span>", esc(c.Detail))
+	}
+	if by := c.Attribution(); by != "" {
+		fmt.Fprintf(b, " <span class=\"detail\">%s</span>", esc(by))
+	}
+	if c.Object == objectScript && c.Kind == changeChanged {
+		b.WriteString("\n")
+		writeSideBySideHTML(b, c.OldCode, c.NewCode)
+	}
+	b.WriteString("</li>\n")
+}
+
+// sideRow ist eine Zeile der Gegenüberstellung; Zeilennummer 0 = leer
+type sideRow struct {
+	lineA, lineB int
+	textA, textB string
+	kind         byte // ' ' gleich, '~' geändert, '-' nur links, '+' nur rechts
+}
+
+// sideBySideRows paart entfernte und hinzugefügte Zeilen zu Zeilen nebeneinander
+func sideBySideRows(a, b string) []sideRow {
+	ops := diffLines(splitLines(a), splitLines(b))
+	var rows []sideRow
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			rows = append(rows, sideRow{ops[i].lineA, ops[i].lineB, ops[i].text, ops[i].text, ' '})
+			i++
+			continue
+		}
+
+		var removed, added []diffOp
+		for ; i < len(ops) && ops[i].kind == '-'; i++ {
+			removed = append(removed, ops[i])
+		}
+		for ; i < len(ops) && ops[i].kind == '+'; i++ {
+			added = append(added, ops[i])
+		}
+		for k := 0; k < max(len(removed), len(added)); k++ {
+			var row sideRow
+			if k < len(removed) {
+				row.lineA, row.textA = removed[k].lineA, removed[k].text
+			}
+			if k < len(added) {
+				row.lineB, row.textB = added[k].lineB, added[k].text
+			}
+			switch {
+			case row.lineA != 0 && row.lineB != 0:
+				row.kind = '~'
+			case row.lineA != 0:
+				row.kind = '-'
+			default:
+				row.kind = '+'
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// writeSideBySideHTML schreibt den Diff zweier Fassungen als zweispaltige Tabelle.
+// Unveränderte Abschnitte werden bis auf diffContext Zeilen Kontext ausgelassen.
+func writeSideBySideHTML(b *bytes.Buffer, a, c string) {
+	rows := sideBySideRows(a, c)
+
+	// Zeilen in der Nähe einer Änderung sichtbar lassen
+	visible := make([]bool, len(rows))
+	for i, row := range rows {
+		if row.kind == ' ' {
+			continue
+		}
+		for k := max(0, i-diffContext); k <= min(len(rows)-1, i+diffContext); k++ {
+			visible[k] = true
+		}
+	}
+
+	cell := func(line int, text, class string) string {
+		if line == 0 {
+			return `<td class="num"></td><td></td>`
+		}
+		if class != "" {
+			class = ` class="` + class + `"`
+		}
+		return fmt.Sprintf(`<td class="num">%d</td><td%s>%s</td>`, line, class, html.EscapeString(text))
+	}
+
+	b.WriteString("<table class=\"diff\">\n")
+	skipped := false
+	for i, row := range rows {
+		if !visible[i] {
+			if !skipped {
+				b.WriteString("<tr><td class=\"gap\" colspan=\"4\">…</td></tr>\n")
+				skipped = true
+			}
+			continue
+		}
+		skipped = false
+
+		classA, classB := "", ""
+		if row.kind == '~' || row.kind == '-' {
+			classA = "del"
+		}
+		if row.kind == '~' || row.kind == '+' {
+			classB = "add"
+		}
+		b.WriteString("<tr>" + cell(row.lineA, row.textA, classA) + cell(row.lineB, row.textB, classB) + "</tr>\n")
+	}
+	b.WriteString("</table>\n")
+}
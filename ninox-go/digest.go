@@ -0,0 +1,310 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// =============================================================================
+// digest: Zusammenfassung der letzten N Tage aus der Snapshot-Historie
+// (neue Tabellen, geänderte Scripts, neue Lint-Befunde) als Markdown oder HTML
+// =============================================================================
+
+// defaultDigestDays ist der Zeitraum ohne --days (Wochenbericht)
+const defaultDigestDays = 7
+
+// digestReport enthält die Abschnitte des Berichts
+type digestReport struct {
+	From, To     string // Datum des Vergleichsstands und der aktuellen Extraktion
+	BasePath     string
+	Tables       []SchemaChange // hinzugefügte und entfernte Tabellen
+	Scripts      []SchemaChange
+	Fields       []SchemaChange
+	NewFindings  []lintFinding
+	GoneFindings int // seit dem Vergleichsstand behobene Befunde
+}
+
+// empty meldet, ob es nichts zu berichten gibt
+func (r *digestReport) empty() bool {
+	return len(r.Tables)+len(r.Scripts)+len(r.Fields)+len(r.NewFindings)+r.GoneFindings == 0
+}
+
+// digestBase wählt den Vergleichsstand: die jüngste Extraktion, die mindestens
+// days Tage älter ist als die aktuelle, sonst die älteste vorhandene
+func digestBase(paths []string, current *NinoxDB, days int) (string, string, error) {
+	to, err := time.Parse("2006-01-02", snapshotDate(current))
+	if err != nil {
+		return "", "", fmt.Errorf("Datum der Extraktion %s unbekannt", current.path)
+	}
+	cutoff := to.AddDate(0, 0, -days)
+	currentPath, _ := filepath.Abs(current.path)
+
+	var basePath, oldestPath string
+	var base, oldest time.Time
+	for _, path := range paths {
+		if abs, _ := filepath.Abs(path); abs == currentPath {
+			continue
+		}
+		db, err := NewNinoxDB(path)
+		if err != nil {
+			return "", "", fmt.Errorf("%s: %w", path, err)
+		}
+		date, err := time.Parse("2006-01-02", snapshotDate(db))
+		db.Close()
+		if err != nil || !date.Before(to) {
+			continue
+		}
+		if !date.After(cutoff) && (basePath == "" || date.After(base)) {
+			basePath, base = path, date
+		}
+		if oldestPath == "" || date.Before(oldest) {
+			oldestPath, oldest = path, date
+		}
+	}
+	switch {
+	case basePath != "":
+		return basePath, base.Format("2006-01-02"), nil
+	case oldestPath != "":
+		return oldestPath, oldest.Format("2006-01-02"), nil
+	}
+	return "", "", fmt.Errorf("Keine ältere Extraktion gefunden (Dateien angeben oder snapshots in der Konfiguration)")
+}
+
+// lintDB prüft alle Scripts einer Extraktion; per Kommentar unterdrückte Befunde entfallen
+func lintDB(db *NinoxDB) ([]lintFinding, error) {
+	lc, err := newLintContext(db)
+	if err != nil {
+		return nil, err
+	}
+	scripts, err := db.GetAllScripts()
+	if err != nil {
+		return nil, err
+	}
+	active, _ := activeFindings(runLint(scripts, lc))
+	return active, nil
+}
+
+// buildDigest vergleicht den Vergleichsstand mit der aktuellen Extraktion.
+// Befunde gelten als neu, wenn sie im Vergleichsstand fehlen (Abgleich wie bei
+// der Baseline über Regel, Script und Codezeile) und nicht in baseline stehen.
+func buildDigest(oldDB, newDB *NinoxDB, baseline map[baselineEntry]bool) (*digestReport, error) {
+	diff, err := DiffSnapshots(oldDB, newDB)
+	if err != nil {
+		return nil, err
+	}
+	// Felder und Scripts neuer bzw. entfernter Tabellen stecken wie im Changelog in deren Eintrag
+	wholeTables := make(map[string]changeKind)
+	for _, c := range diff.Changes {
+		if c.Object == objectTable {
+			wholeTables[c.Database+"/"+c.Table] = c.Kind
+		}
+	}
+
+	r := &digestReport{}
+	for _, c := range diff.Changes {
+		if kind, ok := wholeTables[c.Database+"/"+c.Table]; ok && c.Object != objectTable && c.Kind == kind {
+			continue
+		}
+		switch c.Object {
+		case objectTable:
+			r.Tables = append(r.Tables, c)
+		case objectScript:
+			r.Scripts = append(r.Scripts, c)
+		default:
+			r.Fields = append(r.Fields, c)
+		}
+	}
+
+	before, err := lintDB(oldDB)
+	if err != nil {
+		return nil, err
+	}
+	after, err := lintDB(newDB)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[baselineEntry]bool)
+	for _, f := range before {
+		known[baselineEntryOf(f)] = true
+	}
+	current := make(map[baselineEntry]bool)
+	for _, f := range after {
+		e := baselineEntryOf(f)
+		current[e] = true
+		if !known[e] && !baseline[e] {
+			r.NewFindings = append(r.NewFindings, f)
+		}
+	}
+	for e := range known {
+		if !current[e] {
+			r.GoneFindings++
+		}
+	}
+	return r, nil
+}
+
+// digestSection ist ein Abschnitt mit Schema-Änderungen
+type digestSection struct {
+	title   string
+	changes []SchemaChange
+}
+
+// sections liefert die Abschnitte mit Schema-Änderungen in Ausgabereihenfolge
+func (r *digestReport) sections() []digestSection {
+	return []digestSection{{docText("Tabellen"), r.Tables}, {"Scripts", r.Scripts}, {docText("Felder"), r.Fields}}
+}
+
+// digestTitle ist die Überschrift des Berichts
+func (r *digestReport) digestTitle() string {
+	return fmt.Sprintf("Ninox-Digest %s – %s", r.From, r.To)
+}
+
+// digestSummary fasst die Abschnitte in einer Zeile zusammen
+func (r *digestReport) digestSummary() string {
+	changed := 0
+	for _, c := range r.Scripts {
+		if c.Kind == changeChanged {
+			changed++
+		}
+	}
+	newTables := 0
+	for _, c := range r.Tables {
+		if c.Kind == changeAdded {
+			newTables++
+		}
+	}
+	return docf("%d neue Tabellen · %d Script-Änderungen (%d geändert) · %d Feld-Änderungen · %d neue Befunde, %d behoben",
+		newTables, len(r.Scripts), changed, len(r.Fields), len(r.NewFindings), r.GoneFindings)
+}
+
+// writeDigestMarkdown schreibt den Bericht als Markdown (z.B. für Chat oder Mail)
+func writeDigestMarkdown(b *bytes.Buffer, r *digestReport) {
+	fmt.Fprintf(b, "# %s\n\n", r.digestTitle())
+	fmt.Fprintf(b, "%s.\n\n**%s**\n\n", docf("Vergleich mit %s", r.BasePath), r.digestSummary())
+	if r.empty() {
+		b.WriteString(docText("Keine Änderungen.") + "\n")
+		return
+	}
+
+	for _, s := range r.sections() {
+		if len(s.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "## %s\n\n", s.title)
+		for _, c := range s.changes {
+			fmt.Fprintf(b, "- %s: %s\n", c.Database, changelogEntry(c))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.NewFindings) > 0 {
+		b.WriteString("## " + docText("Neue Befunde") + "\n\n")
+		for _, f := range r.NewFindings {
+			fmt.Fprintf(b, "- **%s** `%s` %s (%s)\n", docText(severityNames[f.Severity]), f.location(), f.Message, f.Rule)
+		}
+		b.WriteString("\n")
+	}
+}
+
+// writeDigestHTML schreibt den Bericht als eigenständige HTML-Datei (Stil wie diff-snapshot)
+func writeDigestHTML(b *bytes.Buffer, r *digestReport) {
+	esc := html.EscapeString
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"" + docLang + "\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(b, "<title>%s</title>\n<style>%s</style>\n</head>\n<body>\n", esc(r.digestTitle()), diffReportCSS)
+	fmt.Fprintf(b, "<h1>%s</h1>\n", esc(r.digestTitle()))
+	fmt.Fprintf(b, "<div class=\"meta\">%s<br>%s: %s</div>\n",
+		esc(docf("Vergleich mit %s", r.BasePath)), docText("Erstellt"), docTime(time.Now()))
+	fmt.Fprintf(b, "<div class=\"summary\"><span>%s</span></div>\n", esc(r.digestSummary()))
+	if r.empty() {
+		b.WriteString("<p>" + docText("Keine Änderungen.") + "</p>\n")
+	}
+
+	for _, s := range r.sections() {
+		if len(s.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "<h2>%s</h2>\n<ul>\n", s.title)
+		for _, c := range s.changes {
+			fmt.Fprintf(b, "<li class=\"%s\">%s: %s</li>\n", changeClasses[c.Kind], esc(c.Database), esc(changelogEntry(c)))
+		}
+		b.WriteString("</ul>\n")
+	}
+
+	if len(r.NewFindings) > 0 {
+		b.WriteString("<h2>" + docText("Neue Befunde") + "</h2>\n<ul>\n")
+		for _, f := range r.NewFindings {
+			fmt.Fprintf(b, "<li><b>%s</b> <code>%s</code> %s <span class=\"detail\">(%s)</span></li>\n",
+				esc(docText(severityNames[f.Severity])), esc(f.location()), esc(f.Message), esc(f.Rule))
+		}
+		b.WriteString("</ul>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+}
+
+// cmdDigest fasst die Änderungen der letzten Tage zusammen. Ältere Extraktionen
+// kommen aus den Argumenten oder dem Muster snapshots der Konfiguration.
+func cmdDigest(args []string) int {
+	opts := parseCLIOptions(args)
+	applyDocLang(opts.lang)
+	if opts.output != "text" && opts.output != "markdown" && opts.output != "html" {
+		fail(exitUsage, "Unbekanntes Ausgabeformat: %s (erlaubt: markdown, html)", opts.output)
+	}
+	days := opts.days
+	if days == 0 {
+		days = defaultDigestDays
+	}
+
+	cfg, _ := LoadConfig(activeConfigPath())
+	paths := opts.positional
+	if len(paths) == 0 && cfg != nil && cfg.Snapshots != "" {
+		var err error
+		if paths, err = filepath.Glob(expandHome(cfg.Snapshots)); err != nil {
+			fail(exitUsage, "Ungültiges Muster %s: %v", cfg.Snapshots, err)
+		}
+	}
+	if len(paths) == 0 {
+		fail(exitUsage, "Verwendung: ninox-tui digest [ALT.db ...] [--days N] [--output markdown|html] [--db DATEI]\n"+
+			"(ohne Dateien gilt snapshots aus der Konfiguration)")
+	}
+
+	newDB := openCLIDB(opts.dbPath)
+	defer newDB.Close()
+	basePath, from, err := digestBase(paths, newDB, days)
+	if err != nil {
+		fail(exitNoResults, "%v", err)
+	}
+	oldDB := openCLIDB(basePath)
+	defer oldDB.Close()
+
+	// In der Baseline akzeptierte Befunde gelten nicht als neu
+	baseline := map[baselineEntry]bool{}
+	if cfg != nil && cfg.LintBaseline != "" {
+		if baseline, err = loadBaseline(cfg.LintBaseline); err != nil {
+			fail(exitFailure, "%v", err)
+		}
+	}
+
+	report, err := buildDigest(oldDB, newDB, baseline)
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+	report.From, report.To, report.BasePath = from, snapshotDate(newDB), basePath
+
+	var b bytes.Buffer
+	if opts.output == "html" {
+		writeDigestHTML(&b, report)
+	} else {
+		writeDigestMarkdown(&b, report)
+	}
+	if _, err := os.Stdout.Write(b.Bytes()); err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	if report.empty() {
+		return exitNoResults
+	}
+	return exitOK
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Sprache exportierter Dokumente (Website, Vergleichsbericht, Changelog, Digest).
+// Die Oberfläche bleibt deutsch; Überschriften und Beschriftungen der Dokumente
+// folgen --lang bzw. lang aus Konfiguration oder NINOX_TUI_LANG.
+// =============================================================================
+
+// docLang ist die Sprache der erzeugten Dokumente ("de" oder "en")
+var docLang = "de"
+
+// docEnglish übersetzt die deutschen Beschriftungen der Dokumente. Schlüssel ist
+// der deutsche Text (bei Formaten das Format), fehlende bleiben deutsch.
+var docEnglish = map[string]string{
+	// Objekte und Spalten
+	"Datenbank":     "Database",
+	"Tabelle":       "Table",
+	"Tabellen":      "Tables",
+	"Feld":          "Field",
+	"Felder":        "Fields",
+	"Typ":           "Type",
+	"Kategorie":     "Category",
+	"Gruppe":        "Group",
+	"Formeln":       "Formulas",
+	"Druck":         "Print",
+	"Rechte":        "Permissions",
+	"Sonstiges":     "Other",
+	"Zeilen":        "Lines",
+	"Formel":        "Formula",
+	"Verweist auf":  "References",
+	"Verknüpfungen": "Relationships",
+	"ja":            "yes",
+	"(Tabelle)":     "(table)",
+	"(Datenbank)":   "(database)",
+	"unbekannt":     "unknown",
+
+	// Website (export-html)
+	"Ninox-Schema":                             "Ninox schema",
+	"Übersicht":                                "Overview",
+	"Globale Scripts":                          "Global scripts",
+	"Keine Scripts.":                           "No scripts.",
+	"Extraktion vom %s":                        "Extracted on %s",
+	"%s · %s · %d Zeilen · #%s":                "%s · %s · %d lines · #%s",
+	"Erstellt mit ninox-tui export-html am %s": "Generated by ninox-tui export-html on %s",
+
+	// Einzelnes Script als HTML
+	"Geändert":                     "Modified",
+	"Extrahiert":                   "Extracted",
+	"Erstellt mit ninox-tui am %s": "Generated by ninox-tui on %s",
+
+	// Änderungen (diff-snapshot, diff, changelog, digest)
+	"hinzugefügt":           "added",
+	"entfernt":              "removed",
+	"geändert":              "changed",
+	"Schema-Vergleich":      "Schema comparison",
+	"Alt":                   "Old",
+	"Neu":                   "New",
+	"Erstellt":              "Created",
+	"Keine Unterschiede.":   "No differences.",
+	"Keine Änderungen.":     "No changes.",
+	"%d Zeilen":             "%d lines",
+	"%d Zeilen geändert":    "%d lines changed",
+	"Typ %s → %s":           "type %s → %s",
+	"Verweis %s → %s":       "reference %s → %s",
+	"Formel hinzugefügt":    "formula added",
+	"Formel entfernt":       "formula removed",
+	"Beschriftung %q → %q":  "caption %q → %q",
+	"Tabelle „%s“ %s":       "Table “%s” %s",
+	"Feld „%s“ %s %s":       "Field “%s” %[3]s %[2]s",
+	"zu %s":                 "to %s",
+	"%s „%s“":               "%s “%s”",
+	"%s „%s“ (%s)":          "%s “%s” (%s)",
+	" (%d Zeilen geändert)": " (%d lines changed)",
+	" (%d Zeilen)":          " (%d lines)",
+	"Globaler Code":         "Global code",
+	"Berechtigung":          "Permission",
+	"Drucklayout-Formel":    "Print layout formula",
+	"Automatisierung":       "Automation",
+	"Vergleich mit %s":      "Compared with %s",
+	"Neue Befunde":          "New findings",
+	"hinweis":               "info",
+	"warnung":               "warning",
+	"fehler":                "error",
+	"%d neue Tabellen · %d Script-Änderungen (%d geändert) · %d Feld-Änderungen · %d neue Befunde, %d behoben": "%d new tables · %d script changes (%d modified) · %d field changes · %d new findings, %d resolved",
+}
+
+// docText übersetzt eine Beschriftung in die Dokumentsprache
+func docText(de string) string {
+	if docLang == "en" {
+		if en, ok := docEnglish[de]; ok {
+			return en
+		}
+	}
+	return de
+}
+
+// docf formatiert wie fmt.Sprintf mit dem Format in der Dokumentsprache
+func docf(format string, args ...any) string {
+	return fmt.Sprintf(docText(format), args...)
+}
+
+// docTime formatiert einen Zeitpunkt für Dokumente (deutsch 02.01.2006, englisch ISO)
+func docTime(t time.Time) string {
+	if docLang == "en" {
+		return t.Format("2006-01-02 15:04")
+	}
+	return t.Format("02.01.2006 15:04")
+}
+
+// docAttribution formuliert den letzten Bearbeiter in der Dokumentsprache
+func docAttribution(by, at string) string {
+	if docLang != "en" || by == "" {
+		return attribution(by, at)
+	}
+	if at == "" {
+		return "by " + by
+	}
+	return fmt.Sprintf("by %s on %s", by, strings.Replace(at[:min(16, len(at))], "T", " ", 1))
+}
+
+// setDocLang wählt die Dokumentsprache ("" = deutsch)
+func setDocLang(lang string) error {
+	switch strings.ToLower(lang) {
+	case "", "de":
+		docLang = "de"
+	case "en":
+		docLang = "en"
+	default:
+		return fmt.Errorf("Unbekannte Sprache: %s (erlaubt: de, en)", lang)
+	}
+	return nil
+}
+
+// applyDocLang setzt die Dokumentsprache eines Unterbefehls:
+// --lang > NINOX_TUI_LANG > lang der Konfiguration
+func applyDocLang(flag string) {
+	lang := flag
+	if lang == "" {
+		if cfg, err := LoadConfig(activeConfigPath()); err == nil {
+			cfg.ApplyEnv()
+			lang = cfg.Lang
+		}
+	}
+	if err := setDocLang(lang); err != nil {
+		fail(exitUsage, "%v", err)
+	}
+}
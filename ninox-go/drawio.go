@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// =============================================================================
+// Export des Datenmodells als draw.io-Diagramm (diagrams.net)
+// =============================================================================
+
+// Maße der Tabellen-Shapes im Diagramm
+const (
+	drawioTableWidth = 220
+	drawioRowHeight  = 26
+	drawioColumns    = 4
+	drawioGapX       = 80
+	drawioGapY       = 60
+)
+
+const (
+	drawioTableStyle = "swimlane;fontStyle=1;childLayout=stackLayout;horizontal=1;startSize=26;" +
+		"horizontalStack=0;resizeParent=1;resizeParentMax=0;resizeLast=0;collapsible=1;marginBottom=0;html=0;"
+	drawioFieldStyle = "text;strokeColor=none;fillColor=none;align=left;verticalAlign=middle;" +
+		"spacingLeft=4;spacingRight=4;overflow=hidden;rotatable=0;points=[[0,0.5],[1,0.5]];portConstraint=eastwest;html=0;"
+	drawioEdgeStyle = "edgeStyle=entityRelationEdgeStyle;rounded=0;html=0;endArrow=ERmandOne;startArrow=ERmany;" +
+		"endFill=0;startFill=0;"
+	drawioCompositionStyle = "edgeStyle=entityRelationEdgeStyle;rounded=0;html=0;endArrow=diamondThin;startArrow=ERmany;" +
+		"endFill=1;startFill=0;endSize=14;"
+)
+
+// selectDatabases liefert alle Datenbanken oder nur die mit dem angegebenen Namen
+func selectDatabases(db *NinoxDB, name string) ([]Database, error) {
+	databases, err := db.GetDatabases()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return databases, nil
+	}
+	for _, d := range databases {
+		if strings.EqualFold(d.Name, name) {
+			return []Database{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("Datenbank nicht gefunden: %s", name)
+}
+
+// ExportDrawio erzeugt eine draw.io-Datei mit einer Seite pro Datenbank.
+// Tabellen werden als Entitäten mit ihren Feldern dargestellt, Verknüpfungen
+// als Kanten von der verweisenden zur referenzierten Tabelle.
+func ExportDrawio(db *NinoxDB, databases []Database) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<mxfile host="ninox-tui" type="device">` + "\n")
+
+	for _, d := range databases {
+		if err := writeDrawioPage(&b, db, d); err != nil {
+			return nil, fmt.Errorf("Datenbank %s: %w", d.Name, err)
+		}
+	}
+
+	b.WriteString("</mxfile>\n")
+	return b.Bytes(), nil
+}
+
+// writeDrawioPage schreibt die Seite einer Datenbank
+func writeDrawioPage(b *bytes.Buffer, db *NinoxDB, d Database) error {
+	tables, err := db.GetTables(d.ID)
+	if err != nil {
+		return err
+	}
+	rels, err := db.GetDatabaseRelationships(d.ID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(b, "  <diagram id=%s name=%s>\n", xmlAttr("db-"+d.ID), xmlAttr(d.Name))
+	b.WriteString(`    <mxGraphModel grid="1" gridSize="10" guides="1" tooltips="1" connect="1" arrows="1" fold="1" page="1" pageScale="1" math="0" shadow="0">` + "\n")
+	b.WriteString("      <root>\n")
+	b.WriteString(`        <mxCell id="0"/>` + "\n")
+	b.WriteString(`        <mxCell id="1" parent="0"/>` + "\n")
+
+	// Tabellen im Raster anordnen; die Zeilenhöhe richtet sich nach der längsten Tabelle
+	cellIDs := make(map[string]string, len(tables))
+	x, y, rowHeight := 0, 0, 0
+	for i, t := range tables {
+		fields, err := db.GetFields(d.ID, t.TableID)
+		if err != nil {
+			return err
+		}
+		if i > 0 && i%drawioColumns == 0 {
+			x = 0
+			y += rowHeight + drawioGapY
+			rowHeight = 0
+		}
+
+		id := "t-" + t.TableID
+		cellIDs[t.Name] = id
+		height := drawioRowHeight * (len(fields) + 1)
+		rowHeight = max(rowHeight, height)
+
+		fmt.Fprintf(b, "        <mxCell id=%s value=%s style=%s vertex=\"1\" parent=\"1\">\n",
+			xmlAttr(id), xmlAttr(t.Name), xmlAttr(drawioTableStyle))
+		fmt.Fprintf(b, "          <mxGeometry x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" as=\"geometry\"/>\n",
+			x, y, drawioTableWidth, height)
+		b.WriteString("        </mxCell>\n")
+
+		for j, f := range fields {
+			label := f.Name
+			if f.BaseType != "" {
+				label += " : " + f.BaseType
+			}
+			if f.RefTableName != "" {
+				label += " → " + f.RefTableName
+			}
+			fmt.Fprintf(b, "        <mxCell id=%s value=%s style=%s vertex=\"1\" parent=%s>\n",
+				xmlAttr(id+"-"+f.FieldID), xmlAttr(label), xmlAttr(drawioFieldStyle), xmlAttr(id))
+			fmt.Fprintf(b, "          <mxGeometry y=\"%d\" width=\"%d\" height=\"%d\" as=\"geometry\"/>\n",
+				drawioRowHeight*(j+1), drawioTableWidth, drawioRowHeight)
+			b.WriteString("        </mxCell>\n")
+		}
+
+		x += drawioTableWidth + drawioGapX
+	}
+
+	// Verknüpfungen; Ziele in anderen Datenbanken fehlen auf der Seite
+	for _, r := range rels {
+		source, target := cellIDs[r.SourceTableName], cellIDs[r.TargetTableName]
+		if source == "" || target == "" {
+			continue
+		}
+		style := drawioEdgeStyle
+		if r.IsComposition {
+			style = drawioCompositionStyle
+		}
+		fmt.Fprintf(b, "        <mxCell id=%s value=%s style=%s edge=\"1\" parent=\"1\" source=%s target=%s>\n",
+			xmlAttr(fmt.Sprintf("r-%d", r.ID)), xmlAttr(r.SourceFieldName), xmlAttr(style), xmlAttr(source), xmlAttr(target))
+		b.WriteString("          <mxGeometry relative=\"1\" as=\"geometry\"/>\n")
+		b.WriteString("        </mxCell>\n")
+	}
+
+	b.WriteString("      </root>\n")
+	b.WriteString("    </mxGraphModel>\n")
+	b.WriteString("  </diagram>\n")
+	return nil
+}
+
+// xmlAttr maskiert einen Wert als XML-Attribut (inklusive Anführungszeichen)
+func xmlAttr(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return `"` + b.String() + `"`
+}
+
+// cmdDrawio exportiert das Datenmodell als draw.io-Datei
+func cmdDrawio(args []string) int {
+	opts := parseCLIOptions(args)
+	path := "datenmodell.drawio"
+	if len(opts.positional) > 0 {
+		path = opts.positional[0]
+	}
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+
+	databases, err := selectDatabases(db, opts.database)
+	if err != nil {
+		fail(exitNoResults, "%v", err)
+	}
+	data, err := ExportDrawio(db, databases)
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+
+	if path == "-" {
+		os.Stdout.Write(data)
+		return exitOK
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	if !quiet {
+		fmt.Printf("✓ draw.io-Diagramm geschrieben: %s (%d Datenbanken)\n", path, len(databases))
+	}
+	return exitOK
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Script im externen Editor öffnen ($VISUAL, $EDITOR); mit --allow-write
+// werden Änderungen in die scripts-Tabelle der Extraktion zurückgeschrieben
+// =============================================================================
+
+// editorDoneMsg meldet das Ende des Editors
+type editorDoneMsg struct {
+	script Script
+	path   string
+	err    error
+}
+
+// editorCommand liefert den Editor: editor aus der Konfiguration, $VISUAL, $EDITOR,
+// sonst vi bzw. notepad. Argumente sind erlaubt, z.B. "code --wait".
+func editorCommand(configured string) []string {
+	editor := firstNonEmpty(configured, os.Getenv("VISUAL"), os.Getenv("EDITOR"))
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+	return strings.Fields(editor)
+}
+
+// openInEditor schreibt das aktive Script in eine temporäre Datei und
+// unterbricht die Oberfläche, bis der Editor beendet ist
+func (m Model) openInEditor() (tea.Model, tea.Cmd) {
+	s := m.activeScript()
+	if s == nil {
+		return m, nil
+	}
+
+	// Dateiname wie beim Export, damit Editor und Fenstertitel das Script erkennen lassen
+	name := strings.ReplaceAll(strings.TrimSuffix(scriptFilePath(*s), scriptFileExt), string(os.PathSeparator), "-")
+	f, err := os.CreateTemp("", "ninox-"+name+"-*"+scriptFileExt)
+	if err != nil {
+		m.status = fmt.Sprintf("❌ %v", err)
+		return m, nil
+	}
+	_, werr := f.WriteString(s.Code)
+	if cerr := f.Close(); werr == nil {
+		werr = cerr
+	}
+	if werr != nil {
+		os.Remove(f.Name())
+		m.status = fmt.Sprintf("❌ %v", werr)
+		return m, nil
+	}
+
+	args := editorCommand(m.config.Editor)
+	cmd := exec.Command(args[0], append(args[1:], f.Name())...)
+	script, path := *s, f.Name()
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorDoneMsg{script: script, path: path, err: err}
+	})
+}
+
+// handleEditorDone übernimmt die Änderungen, sofern die Extraktion beschreibbar ist
+func (m Model) handleEditorDone(msg editorDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		os.Remove(msg.path)
+		m.status = fmt.Sprintf("❌ Editor: %v", msg.err)
+		return m, nil
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.status = fmt.Sprintf("❌ %v", err)
+		return m, nil
+	}
+	code := string(data)
+	// Viele Editoren ergänzen einen Zeilenumbruch am Dateiende
+	if !strings.HasSuffix(msg.script.Code, "\n") {
+		code = strings.TrimSuffix(code, "\n")
+	}
+	if code == msg.script.Code {
+		os.Remove(msg.path)
+		m.status = "Script unverändert"
+		return m, nil
+	}
+
+	if !m.db.Writable() {
+		m.status = fmt.Sprintf("Änderungen nicht übernommen (nur lesend, --allow-write) – gespeichert in %s", msg.path)
+		return m, nil
+	}
+	if err := m.db.UpdateScriptCode(msg.script.ID, code); err != nil {
+		m.status = fmt.Sprintf("❌ Nicht gespeichert (%v) – Änderungen in %s", err, msg.path)
+		return m, nil
+	}
+	os.Remove(msg.path)
+
+	m.replaceScriptCode(msg.script.ID, code)
+	m.status = fmt.Sprintf("✓ %s gespeichert", scriptLabel(msg.script))
+	return m, nil
+}
+
+// replaceScriptCode übernimmt geänderten Code in alle geladenen Script-Listen
+func (m *Model) replaceScriptCode(id int, code string) {
+	update := func(s *Script) {
+		if s.ID == id {
+			s.Code = code
+			s.LineCount = strings.Count(code, "\n") + 1
+			s.Hash = scriptHash(code)
+		}
+	}
+	for _, list := range [][]Script{m.allScripts, m.filteredScripts, m.filterBase, m.scripts, m.searchResults, m.searchWithin} {
+		for i := range list {
+			update(&list[i])
+		}
+	}
+	if m.codeScript != nil && m.codeScript.ID == id {
+		script := *m.codeScript
+		update(&script)
+		m.codeScript = &script
+		m.codeView.SetContent(highlightCode(script.Code))
+	}
+}
+
+// UpdateScriptCode schreibt geänderten Code in die Extraktion (nur mit --allow-write)
+// und aktualisiert Hash, Zeilenzahl und den Eintrag im Volltextindex
+func (db *NinoxDB) UpdateScriptCode(id int, code string) error {
+	if !db.Writable() {
+		return errReadOnly
+	}
+	set := []string{"code = ?"}
+	args := []interface{}{code}
+	if db.hasCodeHash {
+		set = append(set, "code_hash = ?")
+		args = append(args, scriptHash(code))
+	}
+	if db.hasLineCount {
+		set = append(set, "line_count = ?")
+		args = append(args, strings.Count(code, "\n")+1)
+	}
+
+	ctx := db.conn.context()
+	tx, err := db.conn.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Der Volltextindex liest den Inhalt aus scripts (external content): die alte
+	// Zeile wird mit ihren bisherigen Werten ausgetragen und danach neu eingetragen,
+	// statt den ganzen Index neu aufzubauen
+	var fts string
+	hasFTS := tx.QueryRowContext(ctx,
+		`SELECT name FROM sqlite_master WHERE type = 'table' AND name = 'scripts_fts'`).Scan(&fts) == nil
+	if hasFTS {
+		_, err := tx.ExecContext(ctx, `INSERT INTO scripts_fts(scripts_fts, rowid, `+ftsColumns+`)
+			SELECT 'delete', id, `+ftsColumns+` FROM scripts WHERE id = ?`, id)
+		if err != nil && !strings.Contains(err.Error(), "fts5") {
+			return err
+		}
+		hasFTS = err == nil // SQLite ohne FTS5: Suche nutzt LIKE
+	}
+	if _, err := tx.ExecContext(ctx, db.conn.backend.rebind(`UPDATE scripts SET `+strings.Join(set, ", ")+` WHERE id = ?`), append(args, id)...); err != nil {
+		return err
+	}
+	if hasFTS {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO scripts_fts(rowid, `+ftsColumns+`)
+			SELECT id, `+ftsColumns+` FROM scripts WHERE id = ?`, id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ftsColumns sind die Spalten des Volltextindex scripts_fts (siehe schema.go)
+const ftsColumns = "code, team_name, database_name, table_name, element_name, code_type"
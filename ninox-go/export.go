@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// =============================================================================
+// Export von Abfrageergebnissen
+// =============================================================================
+
+// exportFormats ordnet Dateiendungen den Exportfunktionen zu
+var exportFormats = map[string]func(*QueryResult) ([]byte, error){
+	".csv":  resultToCSV,
+	".json": resultToJSON,
+	".md":   resultToMarkdown,
+}
+
+// ExportResult schreibt ein Abfrageergebnis; das Format ergibt sich aus der Dateiendung
+func ExportResult(path string, res *QueryResult) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	format, ok := exportFormats[ext]
+	if !ok {
+		return fmt.Errorf("unbekanntes Exportformat %q (erlaubt: .csv, .json, .md)", ext)
+	}
+
+	data, err := format(res)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func resultToCSV(res *QueryResult) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(res.Columns); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(res.Rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resultJSON hält Spalten und Zeilen getrennt: so bleiben Reihenfolge und
+// doppelte Spaltennamen (SELECT a.name, b.name) erhalten
+type resultJSON struct {
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
+func resultToJSON(res *QueryResult) ([]byte, error) {
+	out := resultJSON{Columns: res.Columns, Rows: res.Rows}
+	if out.Columns == nil {
+		out.Columns = []string{}
+	}
+	if out.Rows == nil {
+		out.Rows = [][]string{}
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func resultToMarkdown(res *QueryResult) ([]byte, error) {
+	var b bytes.Buffer
+
+	cell := func(s string) string {
+		s = strings.ReplaceAll(s, "|", "\\|")
+		return strings.ReplaceAll(strings.ReplaceAll(s, "\r", ""), "\n", "<br>")
+	}
+
+	headers := make([]string, len(res.Columns))
+	seps := make([]string, len(res.Columns))
+	for i, col := range res.Columns {
+		headers[i] = cell(col)
+		seps[i] = "---"
+	}
+	b.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	b.WriteString("| " + strings.Join(seps, " | ") + " |\n")
+
+	for _, row := range res.Rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = cell(v)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return b.Bytes(), nil
+}
+
+// exportFileName leitet einen Dateinamen aus einem Titel ab
+func exportFileName(title, ext string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r == ' ':
+			return '_'
+		}
+		return -1
+	}, title)
+	if name == "" {
+		name = "abfrage"
+	}
+	return name + ext
+}
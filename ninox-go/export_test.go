@@ -0,0 +1,30 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestResultToJSON(t *testing.T) {
+	res := &QueryResult{
+		Columns: []string{"zeta", "name", "name"},
+		Rows:    [][]string{{"1", "Kunden", "Rechnungen"}},
+	}
+	data, err := resultToJSON(res)
+	if err != nil {
+		t.Fatalf("resultToJSON: %v", err)
+	}
+	var got resultJSON
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("ungültiges JSON: %v\n%s", err, data)
+	}
+	if !reflect.DeepEqual(got.Columns, res.Columns) || !reflect.DeepEqual(got.Rows, res.Rows) {
+		t.Errorf("Spalten/Zeilen verändert: %+v", got)
+	}
+
+	empty, _ := resultToJSON(&QueryResult{Columns: []string{"a"}})
+	if err := json.Unmarshal(empty, &got); err != nil || got.Rows == nil || len(got.Rows) != 0 {
+		t.Errorf("leeres Ergebnis: %s", empty)
+	}
+}
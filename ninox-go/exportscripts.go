@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Export der Scripts als Dateien (optional mit Frontmatter zur Zuordnung)
+// =============================================================================
+
+// scriptFileExt ist die Endung exportierter Scripts
+const scriptFileExt = ".ninox"
+
+// legacyScriptFileExt ist die Endung früherer Exporte; import-scripts liest sie
+// weiterhin, git-export ersetzt sie
+const legacyScriptFileExt = ".nx"
+
+// isScriptFile meldet, ob ein Pfad ein exportiertes Script ist
+func isScriptFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == scriptFileExt || ext == legacyScriptFileExt
+}
+
+// frontmatterMarker begrenzt den Frontmatter-Block; jede Zeile ist ein Ninox-Kommentar
+const frontmatterMarker = "// ---"
+
+// scriptFrontmatter ordnet eine exportierte Datei eindeutig einem Script zu
+type scriptFrontmatter struct {
+	ID         int    `json:"id"`
+	DatabaseID string `json:"database_id"`
+	Database   string `json:"database"`
+	TableID    string `json:"table_id,omitempty"`
+	Table      string `json:"table,omitempty"`
+	ElementID  string `json:"element_id,omitempty"`
+	Element    string `json:"element,omitempty"`
+	Type       string `json:"type"`
+	Category   string `json:"category,omitempty"`
+	Hash       string `json:"hash"` // Hash des Codes beim Export
+}
+
+// formatFrontmatter erzeugt den Frontmatter-Block eines Scripts (mit abschließender Leerzeile)
+func formatFrontmatter(s Script) string {
+	data, _ := json.MarshalIndent(scriptFrontmatter{
+		ID: s.ID, DatabaseID: s.DatabaseID, Database: s.DatabaseName,
+		TableID: s.TableID, Table: s.TableName, ElementID: s.ElementID, Element: s.ElementName,
+		Type: s.CodeType, Category: s.CodeCategory, Hash: s.Hash,
+	}, "", "  ")
+
+	var b strings.Builder
+	b.WriteString(frontmatterMarker + "\n")
+	for _, line := range strings.Split(string(data), "\n") {
+		b.WriteString("// " + line + "\n")
+	}
+	b.WriteString(frontmatterMarker + "\n\n")
+	return b.String()
+}
+
+// parseFrontmatter trennt Frontmatter und Code einer exportierten Datei.
+// Ohne Frontmatter ist fm nil und code der gesamte Inhalt.
+func parseFrontmatter(content string) (fm *scriptFrontmatter, code string, err error) {
+	if !strings.HasPrefix(content, frontmatterMarker+"\n") {
+		return nil, content, nil
+	}
+
+	var data strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(content[len(frontmatterMarker)+1:]))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	consumed := len(frontmatterMarker) + 1
+	for scanner.Scan() {
+		line := scanner.Text()
+		consumed += len(line) + 1
+		if line == frontmatterMarker {
+			fm = &scriptFrontmatter{}
+			if err := json.Unmarshal([]byte(data.String()), fm); err != nil {
+				return nil, content, fmt.Errorf("Frontmatter ungültig: %w", err)
+			}
+			code = content[min(consumed, len(content)):]
+			return fm, strings.TrimPrefix(code, "\n"), nil
+		}
+		data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "//"), " ") + "\n")
+	}
+	return nil, content, fmt.Errorf("Frontmatter nicht abgeschlossen (%q fehlt)", frontmatterMarker)
+}
+
+// safeFileName ersetzt in Dateinamen unzulässige Zeichen
+func safeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 32 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
+
+// scriptFilePath liefert den relativen Pfad eines Scripts:
+// Datenbank/Tabelle/Element_Typ.ninox (globaler Code unter _global/Typ.ninox,
+// Tabellen-Scripts als _tabelle_Typ.ninox)
+func scriptFilePath(s Script) string {
+	table := s.TableName
+	if table == "" {
+		table = "_global"
+	}
+	name := safeFileName(s.CodeType)
+	switch {
+	case s.ElementName != "":
+		name = safeFileName(s.ElementName) + "_" + name
+	case s.TableName != "":
+		name = "_tabelle_" + name
+	}
+	return filepath.Join(safeFileName(s.DatabaseName), safeFileName(table), name+scriptFileExt)
+}
+
+// ExportScripts schreibt jedes Script in eine eigene Datei unter dir,
+// benannt nach der Namensvorlage (siehe planScriptPaths)
+func ExportScripts(dir string, scripts []Script, frontmatter bool) (int, error) {
+	paths, err := planScriptPaths(scripts)
+	if err != nil {
+		return 0, err
+	}
+	for i, s := range scripts {
+		path := filepath.Join(dir, paths[i])
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return 0, err
+		}
+		content := s.Code
+		if frontmatter {
+			content = formatFrontmatter(s) + content
+		}
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return 0, err
+		}
+	}
+	return len(scripts), nil
+}
+
+// defaultExportDir ist das vorgeschlagene Zielverzeichnis beim Export aus der Gesamtansicht
+const defaultExportDir = "ninox-scripts"
+
+// startExportScripts fragt nach dem Zielverzeichnis für die gefilterten Scripts
+func (m Model) startExportScripts() (tea.Model, tea.Cmd) {
+	if len(m.filteredScripts) == 0 {
+		return m, nil
+	}
+	m.scriptsExporting = true
+	m.sqlExportInput.SetValue(defaultExportDir)
+	m.sqlExportInput.CursorEnd()
+	m.sqlExportInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleScriptsExportInput verarbeitet die Eingabe des Zielverzeichnisses
+func (m Model) handleScriptsExportInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch {
+	case key.Matches(msg, keys.Back):
+		m.scriptsExporting = false
+		m.sqlExportInput.Blur()
+		return m, nil
+	case key.Matches(msg, keys.Enter):
+		m.scriptsExporting = false
+		m.sqlExportInput.Blur()
+		dir := strings.TrimSpace(m.sqlExportInput.Value())
+		if dir == "" {
+			return m, nil
+		}
+		n, err := ExportScripts(dir, m.filteredScripts, false)
+		if err != nil {
+			m.status = fmt.Sprintf("❌ Export fehlgeschlagen: %v", err)
+		} else {
+			m.status = fmt.Sprintf("✓ %d Scripts nach %s exportiert", n, dir)
+		}
+		return m, nil
+	default:
+		m.sqlExportInput, cmd = m.sqlExportInput.Update(msg)
+		return m, cmd
+	}
+}
+
+// cmdExportScripts exportiert die Scripts in ein Verzeichnis
+func cmdExportScripts(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) != 1 {
+		fail(exitUsage, "Verwendung: ninox-tui export-scripts VERZEICHNIS [--frontmatter] [--name-template VORLAGE] [--dry-run] [--database NAME] [--db DATEI]")
+	}
+	dir := opts.positional[0]
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	applyNameTemplate(opts)
+
+	databases, err := selectDatabases(db, opts.database)
+	if err != nil {
+		fail(exitNoResults, "%v", err)
+	}
+	wanted := make(map[string]bool)
+	for _, d := range databases {
+		wanted[d.ID] = true
+	}
+
+	all, err := db.GetAllScripts()
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+	var scripts []Script
+	for _, s := range all {
+		if wanted[s.DatabaseID] {
+			scripts = append(scripts, s)
+		}
+	}
+	if len(scripts) == 0 {
+		fail(exitNoResults, "Keine Scripts gefunden")
+	}
+
+	if opts.dryRun {
+		paths, err := planScriptPaths(scripts)
+		if err != nil {
+			fail(exitUsage, "%v", err)
+		}
+		for i, rel := range paths {
+			fmt.Printf("%s\t%s\n", filepath.ToSlash(filepath.Join(dir, rel)), scriptLabel(scripts[i]))
+		}
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "%d Scripts, nichts geschrieben (--dry-run)\n", len(paths))
+		}
+		return exitOK
+	}
+
+	n, err := ExportScripts(dir, scripts, opts.frontmatter)
+	if err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	if !quiet {
+		fmt.Printf("✓ %d Scripts exportiert nach %s\n", n, dir)
+	}
+	return exitOK
+}
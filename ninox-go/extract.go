@@ -0,0 +1,981 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// extract: Schema und Scripts direkt über die Ninox REST API in eine
+// Schema-Datenbank schreiben (entspricht ninox_api_extractor.py)
+// =============================================================================
+
+// Code-Schlüssel je Ebene und ihre Kategorie
+var (
+	databaseCodeFields = []codeField{
+		{"afterOpen", "trigger"}, {"beforeOpen", "trigger"}, {"globalCode", "global"},
+	}
+	tableCodeFields = []codeField{
+		{"afterCreate", "trigger"}, {"afterUpdate", "trigger"}, {"afterDelete", "trigger"}, {"beforeDelete", "trigger"},
+		{"canRead", "permission"}, {"canWrite", "permission"}, {"canCreate", "permission"}, {"canDelete", "permission"},
+		{"printout", "report"},
+	}
+	fieldCodeFields = []codeField{
+		{"fn", "formula"}, {"afterUpdate", "trigger"}, {"afterCreate", "trigger"}, {"constraint", "validation"},
+		{"dchoiceValues", "dchoice"}, {"dchoiceCaption", "dchoice"}, {"dchoiceColor", "dchoice"}, {"dchoiceIcon", "dchoice"},
+		{"referenceFormat", "reference"}, {"visibility", "visibility"},
+		{"onClick", "button"}, {"onDoubleClick", "button"},
+		{"canRead", "permission"}, {"canWrite", "permission"}, {"validation", "validation"}, {"color", "other"},
+	}
+)
+
+// codeField ist ein Schema-Schlüssel mit Code
+type codeField struct{ key, category string }
+
+// Rollenlisten je Ebene (Schema-Schlüssel → Recht)
+var (
+	tableRoleFields = [][2]string{{"readRoles", "read"}, {"writeRoles", "write"}, {"createRoles", "create"}, {"deleteRoles", "delete"}}
+	fieldRoleFields = [][2]string{{"readRoles", "read"}, {"writeRoles", "write"}}
+)
+
+// Druckvorlagen, Automatisierungen und letzte Bearbeiter (Schlüssel wie im Python-Extraktor)
+var (
+	reportKeys                = []string{"reports", "printLayouts"}
+	reportCodeFields          = []string{"fn", "formula", "expression", "visibility"}
+	automationKeys            = [][2]string{{"jobs", "schedule"}, {"schedules", "schedule"}, {"automations", "schedule"}, {"webhooks", "webhook"}}
+	automationCodeFields      = []string{"code", "script", "fn"}
+	automationConditionFields = []string{"cron", "schedule", "interval", "event", "trigger", "url", "path"}
+	modifiedByFields          = []string{"modifiedBy", "modifiedByUser", "lastModifiedBy", "updatedBy"}
+	modifiedAtFields          = []string{"modifiedAt", "lastModified", "updatedAt", "modified"}
+)
+
+// tableReferencePatterns finden Tabellen-Referenzen in Formeln (FORMULA_REF)
+var tableReferencePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)select\s+([A-Za-z_][A-Za-z0-9_äöüÄÖÜß]*)`),
+	regexp.MustCompile(`(?i)select\s+'([^']+)'`),
+	regexp.MustCompile(`(?i)select\s+"([^"]+)"`),
+	regexp.MustCompile(`(?i)first\s*\(\s*([A-Za-z_][A-Za-z0-9_äöüÄÖÜß]*)`),
+	regexp.MustCompile(`(?i)first\s*\(\s*'([^']+)'`),
+	regexp.MustCompile(`(?i)last\s*\(\s*([A-Za-z_][A-Za-z0-9_äöüÄÖÜß]*)`),
+	regexp.MustCompile(`(?i)count\s*\(\s*([A-Za-z_][A-Za-z0-9_äöüÄÖÜß]*)`),
+	regexp.MustCompile(`(?i)(?:sum|max|min|avg|cnt)\s*\(\s*([A-Za-z_][A-Za-z0-9_äöüÄÖÜß]*)\.([A-Za-z_][A-Za-z0-9_äöüÄÖÜß]*)`),
+}
+
+// notTableNames sind Schlüsselwörter, die die Muster fälschlich als Tabelle erkennen
+var notTableNames = map[string]bool{
+	"this": true, "true": true, "false": true, "null": true, "void": true, "let": true,
+	"var": true, "end": true, "for": true, "if": true, "do": true, "then": true, "else": true,
+}
+
+// databaseReferencePatterns finden Zugriffe auf andere Datenbanken (script_dependencies)
+var databaseReferencePatterns = []struct {
+	re          *regexp.Regexp
+	kind        string
+	after       int // Zeichen nach dem Treffer im Ausschnitt
+	fixedTarget string
+}{
+	{regexp.MustCompile(`(?i)do\s+as\s+database\s+['"]([^'"]+)['"]`), "do as database", 50, ""},
+	{regexp.MustCompile(`(?i)do\s+as\s+server\b`), "do as server", 50, "(server)"},
+	{regexp.MustCompile(`(?i)openDatabase\s*\(\s*['"]([^'"]+)['"]`), "openDatabase", 30, ""},
+}
+
+// extractStats zählt die extrahierten Objekte
+type extractStats struct {
+	teams, databases, tables, fields, relationships, scripts int
+	unchanged, removed                                       int // nur bei --update
+}
+
+// extractScript ist eine Code-Stelle vor dem Speichern
+type extractScript struct {
+	tableID, tableName, elementID, elementName string
+	codeType, category, code                   string
+	modifiedBy, modifiedAt                     string
+}
+
+// extractRelationship ist eine Verknüpfung vor dem Speichern; vergleichbar zum Entfernen von Dubletten
+type extractRelationship struct {
+	sourceTableID, sourceTableName, sourceFieldID, sourceFieldName string
+	targetTableID, targetTableName, targetDatabaseID, targetDBName string
+	kind                                                           string
+	composition                                                    bool
+	codeType, code                                                 string
+}
+
+// extractor schreibt die Datenbanken eines oder mehrerer Teams in eine Schema-Datenbank.
+// Ohne api (Archiv-Import) entfallen Ansichten und Datensatz-Zählung.
+type extractor struct {
+	api      *ninoxAPI
+	tx       *sql.Tx
+	teamID   string
+	teamName string
+	counts   bool   // Datensätze je Tabelle zählen (ein Request je Tabelle)
+	update   bool   // bestehende Schema-DB abgleichen statt neu schreiben
+	source   string // Herkunft des Standes im Verlauf (extract, import-archive …)
+	stats    extractStats
+}
+
+// Teams listet die Teams des API-Keys
+func (a *ninoxAPI) Teams() ([]map[string]any, error) {
+	var teams []map[string]any
+	err := a.request(http.MethodGet, "teams", nil, &teams)
+	return teams, err
+}
+
+// Databases listet die Datenbanken des Teams
+func (a *ninoxAPI) Databases() ([]map[string]any, error) {
+	var databases []map[string]any
+	err := a.request(http.MethodGet, fmt.Sprintf("teams/%s/databases", a.teamID), nil, &databases)
+	return databases, err
+}
+
+// DatabaseSchema lädt Einstellungen und Schema einer Datenbank mit lesbaren Scripts
+func (a *ninoxAPI) DatabaseSchema(databaseID string) (map[string]any, error) {
+	var data map[string]any
+	err := a.request(http.MethodGet, fmt.Sprintf("teams/%s/databases/%s?formatScripts=T", a.teamID, databaseID), nil, &data)
+	return data, err
+}
+
+// Views lädt die Ansichten einer Datenbank; ohne Berechtigung bleibt die Liste leer
+func (a *ninoxAPI) Views(databaseID string) []map[string]any {
+	var views []map[string]any
+	if a.request(http.MethodGet, fmt.Sprintf("teams/%s/databases/%s/views", a.teamID, databaseID), nil, &views) != nil {
+		return nil
+	}
+	return views
+}
+
+// jsonObject liefert v als JSON-Objekt (nil, wenn es keins ist)
+func jsonObject(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// jsonText liefert einen Text- oder Zahlenwert als String
+func jsonText(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "true"
+		}
+	}
+	return ""
+}
+
+// jsonTrue wertet Schema-Flags aus (true, 1, "T")
+func jsonTrue(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case json.Number:
+		return v.String() != "0"
+	case string:
+		return v != ""
+	}
+	return false
+}
+
+// jsonCode liefert Code, sofern der Wert nicht leerer Text ist
+func jsonCode(v any) string {
+	code, _ := v.(string)
+	if strings.TrimSpace(code) == "" {
+		return ""
+	}
+	return code
+}
+
+// jsonNumber liefert eine Zahl oder NULL
+func jsonNumber(v any) any {
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+		f, _ := n.Float64()
+		return f
+	}
+	return nil
+}
+
+// schemaKeys liefert die Schlüssel in Ninox-Reihenfolge (A … Z, AA …)
+func schemaKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// schemaItems liefert die Objekte einer Sammlung, die als Objekt oder Liste vorliegt
+func schemaItems(v any) (ids []string, items []map[string]any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for _, k := range schemaKeys(v) {
+			if item := jsonObject(v[k]); item != nil {
+				ids, items = append(ids, k), append(items, item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if item := jsonObject(item); item != nil {
+				ids, items = append(ids, fmt.Sprint(i)), append(items, item)
+			}
+		}
+	}
+	return ids, items
+}
+
+// elementModification liefert Benutzer und Zeitpunkt der letzten Änderung eines Elements.
+// Benutzer kommen als Text oder Objekt, Zeitpunkte als ISO-Text oder Epoch-(Milli)Sekunden.
+func elementModification(data map[string]any) (user, when string) {
+	for _, k := range modifiedByFields {
+		switch v := data[k].(type) {
+		case map[string]any:
+			user = firstNonEmpty(jsonText(v["email"]), jsonText(v["name"]), jsonText(v["id"]))
+		default:
+			user = jsonText(v)
+		}
+		if user != "" {
+			break
+		}
+	}
+	for _, k := range modifiedAtFields {
+		switch v := data[k].(type) {
+		case json.Number:
+			f, err := v.Float64()
+			if err != nil || f == 0 {
+				continue
+			}
+			if f > 1e11 {
+				f /= 1000
+			}
+			when = time.Unix(int64(f), 0).Format("2006-01-02 15:04:05")
+		default:
+			when = jsonText(v)
+		}
+		if when != "" {
+			break
+		}
+	}
+	return user, when
+}
+
+// roleNames normalisiert eine Rollenliste (Liste, Objekt mit Flags oder kommagetrennter Text)
+func roleNames(v any) []string {
+	var roles []string
+	switch v := v.(type) {
+	case map[string]any:
+		for role, enabled := range v {
+			if jsonTrue(enabled) {
+				roles = append(roles, role)
+			}
+		}
+	case []any:
+		for _, role := range v {
+			if r := jsonText(role); r != "" {
+				roles = append(roles, r)
+			}
+		}
+	case string:
+		for _, role := range strings.Split(v, ",") {
+			if r := strings.TrimSpace(role); r != "" {
+				roles = append(roles, r)
+			}
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// formulaReferences findet Tabellennamen in Code: bekannte Tabellen und plausible Namen
+func formulaReferences(code string, known map[string]bool) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	for _, re := range tableReferencePatterns {
+		for _, m := range re.FindAllStringSubmatch(code, -1) {
+			name := m[1]
+			if seen[name] {
+				continue
+			}
+			if known[name] || (len([]rune(name)) > 2 && !notTableNames[strings.ToLower(name)]) {
+				seen[name] = true
+				refs = append(refs, name)
+			}
+		}
+	}
+	return refs
+}
+
+// databaseReference ist ein Zugriff auf eine andere Datenbank
+type databaseReference struct{ target, kind, snippet string }
+
+// databaseReferences findet do as database, do as server und openDatabase
+func databaseReferences(code string) []databaseReference {
+	var refs []databaseReference
+	for _, p := range databaseReferencePatterns {
+		for _, m := range p.re.FindAllStringSubmatchIndex(code, -1) {
+			target := p.fixedTarget
+			if target == "" {
+				target = code[m[2]:m[3]]
+			}
+			start, end := max(0, m[0]-20), min(len(code), m[1]+p.after)
+			snippet := strings.TrimSpace(strings.ReplaceAll(code[start:end], "\n", " "))
+			refs = append(refs, databaseReference{target, p.kind, strings.ToValidUTF8(snippet, "")})
+		}
+	}
+	return refs
+}
+
+// reportFormulas sammelt die Formeln einer Druckvorlage rekursiv aus ihren Elementen.
+// element_id ist "Vorlage/Element", element_name "Vorlage › Element".
+func reportFormulas(reportID string, report map[string]any) []extractScript {
+	reportName := firstNonEmpty(jsonText(report["caption"]), jsonText(report["name"]), reportID)
+	var formulas []extractScript
+
+	var walk func(elementID, name string, data map[string]any)
+	walk = func(elementID, name string, data map[string]any) {
+		for _, key := range reportCodeFields {
+			if code := jsonCode(data[key]); code != "" {
+				codeType := "report" + strings.ToUpper(key[:1]) + key[1:]
+				formulas = append(formulas, extractScript{elementID: elementID, elementName: name, codeType: codeType, category: "report", code: code})
+			}
+		}
+		for _, childKey := range []string{"elements", "items", "children"} {
+			ids, children := schemaItems(data[childKey])
+			for i, child := range children {
+				childID := firstNonEmpty(jsonText(child["id"]), ids[i])
+				caption := firstNonEmpty(jsonText(child["caption"]), jsonText(child["name"]), childID)
+				walk(reportID+"/"+childID, reportName+" › "+caption, child)
+			}
+		}
+	}
+	walk(reportID, reportName, report)
+	return formulas
+}
+
+// schemaReports liefert die Druckvorlagen eines Schema-Objekts mit ihren IDs
+func schemaReports(data map[string]any) (ids []string, reports []map[string]any) {
+	for _, key := range reportKeys {
+		keyIDs, items := schemaItems(data[key])
+		for i, report := range items {
+			ids = append(ids, firstNonEmpty(jsonText(report["id"]), keyIDs[i]))
+			reports = append(reports, report)
+		}
+	}
+	return ids, reports
+}
+
+// extractTeam extrahiert die (ausgewählten) Datenbanken des Teams
+func (e *extractor) extractTeam(only string) error {
+	databases, err := e.api.Databases()
+	if err != nil {
+		return err
+	}
+	e.stats.teams++
+	if !quiet {
+		fmt.Printf("Team %s: %d Datenbanken\n", e.teamName, len(databases))
+	}
+	remote := make(map[string]bool)
+	for _, info := range databases {
+		id, name := jsonText(info["id"]), jsonText(info["name"])
+		name = firstNonEmpty(name, id)
+		remote[id] = true
+		if only != "" && !strings.EqualFold(only, id) && !strings.EqualFold(only, name) {
+			continue
+		}
+		if !quiet && !e.update {
+			fmt.Printf("  Extrahiere %s (%s)\n", name, id)
+		}
+		// Wie der Python-Extraktor: eine fehlerhafte Datenbank bricht nicht alles ab
+		extract := e.extractDatabase
+		if e.update {
+			extract = e.syncDatabase
+		}
+		if err := extract(id, name); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %s: %v\n", name, err)
+			continue
+		}
+		e.stats.databases++
+	}
+	if e.update && only == "" {
+		return e.removeVanished(remote)
+	}
+	return nil
+}
+
+// extractDatabase schreibt eine Datenbank mit Tabellen, Feldern, Scripts und Verknüpfungen
+func (e *extractor) extractDatabase(dbID, dbName string) error {
+	data, err := e.api.DatabaseSchema(dbID)
+	if err != nil {
+		return err
+	}
+	settings := jsonObject(data["settings"])
+	schema := jsonObject(data["schema"])
+	if schema == nil {
+		return fmt.Errorf("Antwort enthält kein Schema")
+	}
+	return e.addDatabase(dbID, firstNonEmpty(jsonText(settings["name"]), dbName), settings, schema)
+}
+
+// addDatabase schreibt ein geladenes Schema; Fehler hinterlassen keine Teilergebnisse
+func (e *extractor) addDatabase(dbID, dbName string, settings, schema map[string]any) error {
+	// Savepoint: Teilergebnisse einer fehlgeschlagenen Datenbank verwerfen
+	if _, err := e.tx.Exec(`SAVEPOINT extract_database`); err != nil {
+		return err
+	}
+	var stats extractStats
+	var err error
+	if e.update {
+		// Gezählte Datensätze bleiben ohne --counts erhalten
+		err = deleteDatabaseRows(e.tx, dbID, !e.counts)
+	}
+	if err == nil {
+		stats, err = e.writeDatabase(dbID, dbName, settings, schema)
+	}
+	if err != nil {
+		e.tx.Exec(`ROLLBACK TO extract_database`)
+		e.tx.Exec(`RELEASE extract_database`)
+		return err
+	}
+	if _, err := e.tx.Exec(`RELEASE extract_database`); err != nil {
+		return err
+	}
+	e.stats.tables += stats.tables
+	e.stats.fields += stats.fields
+	e.stats.relationships += stats.relationships
+	e.stats.scripts += stats.scripts
+	return nil
+}
+
+func (e *extractor) writeDatabase(dbID, dbName string, settings, schema map[string]any) (extractStats, error) {
+	var stats extractStats
+	tx := e.tx
+	_, err := tx.Exec(`INSERT INTO databases (id, name, team_id, team_name, archived, version, color, icon,
+			schema_hash, last_synced)
+		VALUES (?, ?, ?, ?, 0, ?, ?, ?, ?, CURRENT_TIMESTAMP)`,
+		dbID, dbName, e.teamID, e.teamName, jsonNumber(schema["version"]),
+		nullIfEmpty(jsonText(settings["color"])), nullIfEmpty(jsonText(settings["icon"])),
+		schemaHash(settings, schema))
+	if err != nil {
+		return stats, err
+	}
+
+	// Tabellennamen für Verweise über ID oder UUID
+	types := jsonObject(schema["types"])
+	tableNames := make(map[string]string)
+	tableByUUID := make(map[string]string)
+	known := make(map[string]bool)
+	for _, id := range schemaKeys(types) {
+		t := jsonObject(types[id])
+		name := firstNonEmpty(jsonText(t["caption"]), id)
+		tableNames[id] = name
+		known[name] = true
+		if uuid := jsonText(t["uuid"]); uuid != "" {
+			tableByUUID[uuid] = name
+		}
+	}
+
+	var scripts []extractScript
+	addScript := func(s extractScript, owner map[string]any) {
+		s.modifiedBy, s.modifiedAt = elementModification(owner)
+		scripts = append(scripts, s)
+	}
+	var relationships []extractRelationship
+	seenRel := make(map[extractRelationship]bool)
+	addRelationship := func(r extractRelationship) {
+		if !seenRel[r] {
+			seenRel[r] = true
+			relationships = append(relationships, r)
+		}
+	}
+
+	for _, f := range databaseCodeFields {
+		if code := jsonCode(schema[f.key]); code != "" {
+			addScript(extractScript{codeType: f.key, category: f.category, code: code}, schema)
+		}
+	}
+
+	for _, tableID := range schemaKeys(types) {
+		t := jsonObject(types[tableID])
+		if t == nil {
+			continue
+		}
+		tableName := tableNames[tableID]
+		fields := jsonObject(t["fields"])
+		hidden := 0
+		if jsonTrue(t["hidden"]) {
+			hidden = 1
+		}
+		_, err := tx.Exec(`INSERT INTO tables (database_id, table_id, name, caption, icon, hidden, field_count)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`, dbID, tableID, tableName, tableName, jsonText(t["icon"]), hidden, len(fields))
+		if err != nil {
+			return stats, err
+		}
+		stats.tables++
+
+		for _, f := range tableCodeFields {
+			if code := jsonCode(t[f.key]); code != "" {
+				addScript(extractScript{tableID: tableID, tableName: tableName, codeType: f.key, category: f.category, code: code}, t)
+			}
+		}
+		if err := insertPermissions(tx, dbID, tableID, tableName, "", "", t, tableRoleFields); err != nil {
+			return stats, err
+		}
+
+		for _, fieldID := range schemaKeys(fields) {
+			f := jsonObject(fields[fieldID])
+			if f == nil {
+				continue
+			}
+			fieldName := firstNonEmpty(jsonText(f["caption"]), fieldID)
+			baseType := jsonText(f["base"])
+			refTableID, refUUID := jsonText(f["refTypeId"]), jsonText(f["refTypeUUID"])
+			refDBID, refDBName := jsonText(f["dbId"]), jsonText(f["dbName"])
+			composition := jsonTrue(f["composition"])
+
+			refTable := ""
+			switch {
+			case refTableID != "":
+				refTable = firstNonEmpty(tableNames[refTableID], refTableID)
+			case refUUID != "":
+				refTable = firstNonEmpty(tableByUUID[refUUID], refUUID)
+			}
+
+			_, err := tx.Exec(`INSERT INTO fields (database_id, table_id, field_id, name, caption,
+					base_type, is_required, ref_table_id, ref_table_name, ref_database_id, is_composition, has_formula)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				dbID, tableID, fieldID, fieldName, fieldName, baseType, boolInt(jsonTrue(f["required"])),
+				nullIfEmpty(refTableID), nullIfEmpty(refTable), nullIfEmpty(refDBID),
+				boolInt(composition), boolInt(jsonCode(f["fn"]) != ""))
+			if err != nil {
+				return stats, err
+			}
+			stats.fields++
+
+			if err := insertFieldOptions(tx, dbID, tableID, fieldID, f["values"]); err != nil {
+				return stats, err
+			}
+			if err := insertPermissions(tx, dbID, tableID, tableName, fieldID, fieldName, f, fieldRoleFields); err != nil {
+				return stats, err
+			}
+
+			if baseType == "ref" && refTable != "" {
+				kind := "N:1"
+				if refDBID != "" {
+					kind = "CROSS_DB"
+				}
+				addRelationship(extractRelationship{
+					sourceTableID: tableID, sourceTableName: tableName, sourceFieldID: fieldID, sourceFieldName: fieldName,
+					targetTableID: refTableID, targetTableName: refTable, targetDatabaseID: refDBID, targetDBName: refDBName,
+					kind: kind, composition: composition,
+				})
+			}
+
+			for _, cf := range fieldCodeFields {
+				code := jsonCode(f[cf.key])
+				// Sehr kurze Formeln (Konstanten) auslassen
+				if code == "" || (cf.key == "fn" && len(code) < 3) {
+					continue
+				}
+				addScript(extractScript{tableID: tableID, tableName: tableName, elementID: fieldID, elementName: fieldName,
+					codeType: cf.key, category: cf.category, code: code}, f)
+			}
+		}
+
+		// Layout-Elemente (Schaltflächen, Reiter, Texte …) samt Code
+		uis := jsonObject(t["uis"])
+		for _, uiID := range schemaKeys(uis) {
+			ui := jsonObject(uis[uiID])
+			uiName := firstNonEmpty(jsonText(ui["caption"]), uiID)
+			for _, cf := range fieldCodeFields {
+				if code := jsonCode(ui[cf.key]); code != "" {
+					addScript(extractScript{tableID: tableID, tableName: tableName, elementID: uiID, elementName: uiName,
+						codeType: cf.key, category: cf.category, code: code}, ui)
+				}
+			}
+		}
+		if err := insertLayout(tx, dbID, tableID, t); err != nil {
+			return stats, err
+		}
+
+		if e.counts && e.api != nil {
+			if n, err := e.api.CountRecords(dbID, tableName); err == nil {
+				_, err := tx.Exec(`INSERT OR REPLACE INTO record_counts (database_id, table_id, record_count)
+					VALUES (?, ?, ?)`, dbID, tableID, n)
+				if err != nil {
+					return stats, err
+				}
+			} else {
+				fmt.Fprintf(os.Stderr, "⚠ Datensätze von %s nicht gezählt: %v\n", tableName, err)
+			}
+		}
+
+		// Druckvorlagen der Tabelle samt eingebetteter Formeln
+		ids, reports := schemaReports(t)
+		for i, report := range reports {
+			for _, s := range reportFormulas(ids[i], report) {
+				s.tableID, s.tableName = tableID, tableName
+				addScript(s, report)
+			}
+		}
+	}
+
+	// Druckvorlagen auf Datenbank-Ebene (Tabelle über "type" bzw. "typeId")
+	ids, reports := schemaReports(schema)
+	for i, report := range reports {
+		tableID := firstNonEmpty(jsonText(report["type"]), jsonText(report["typeId"]))
+		for _, s := range reportFormulas(ids[i], report) {
+			s.tableID, s.tableName = tableID, tableNames[tableID]
+			addScript(s, report)
+		}
+	}
+
+	// Ansichten der Datenbank (nur über die API)
+	var views []map[string]any
+	if e.api != nil {
+		views = e.api.Views(dbID)
+	}
+	for order, view := range views {
+		tableID := jsonText(view["type"])
+		if tableID == "" {
+			continue
+		}
+		sortOrder := jsonNumber(view["order"])
+		if sortOrder == nil {
+			sortOrder = order
+		}
+		_, err := tx.Exec(`INSERT INTO layout_elements (database_id, table_id, element_id, caption,
+				element_type, source, sort_order)
+			VALUES (?, ?, ?, ?, 'view', 'view', ?)`,
+			dbID, tableID, firstNonEmpty(jsonText(view["id"]), fmt.Sprint(order)), jsonText(view["caption"]), sortOrder)
+		if err != nil {
+			return stats, err
+		}
+	}
+
+	// Zeitgesteuerte Scripts und Webhooks (Datenbank-Ebene)
+	for _, ak := range automationKeys {
+		keyIDs, jobs := schemaItems(schema[ak[0]])
+		for i, job := range jobs {
+			jobID := firstNonEmpty(jsonText(job["id"]), keyIDs[i])
+			name := firstNonEmpty(jsonText(job["caption"]), jsonText(job["name"]), jobID)
+			condition := ""
+			for _, k := range automationConditionFields {
+				if condition = jsonText(job[k]); condition != "" {
+					break
+				}
+			}
+			_, err := tx.Exec(`INSERT INTO automations (database_id, element_id, name, kind, trigger_condition, enabled)
+				VALUES (?, ?, ?, ?, ?, ?)`, dbID, jobID, name, ak[1], nullIfEmpty(condition), boolInt(!jsonTrue(job["disabled"])))
+			if err != nil {
+				return stats, err
+			}
+			for _, k := range automationCodeFields {
+				if code := jsonCode(job[k]); code != "" {
+					tableID := firstNonEmpty(jsonText(job["type"]), jsonText(job["typeId"]))
+					addScript(extractScript{tableID: tableID, tableName: tableNames[tableID], elementID: jobID, elementName: name,
+						codeType: ak[1], category: "automation", code: code}, job)
+					break
+				}
+			}
+		}
+	}
+
+	// Scripts speichern, Zugriffe auf andere Datenbanken und Formel-Referenzen sammeln
+	for _, s := range scripts {
+		res, err := tx.Exec(`INSERT INTO scripts (team_id, team_name, database_id, database_name,
+				table_id, table_name, element_id, element_name, code_type, code_category,
+				code, code_original, code_hash, line_count, modified_by, modified_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULL, ?, ?, ?, ?)`,
+			e.teamID, e.teamName, dbID, dbName, nullIfEmpty(s.tableID), nullIfEmpty(s.tableName),
+			nullIfEmpty(s.elementID), nullIfEmpty(s.elementName), s.codeType, s.category,
+			s.code, scriptHash(s.code), strings.Count(s.code, "\n")+1, nullIfEmpty(s.modifiedBy), nullIfEmpty(s.modifiedAt))
+		if err != nil {
+			return stats, err
+		}
+		stats.scripts++
+		scriptID, _ := res.LastInsertId()
+
+		for _, ref := range databaseReferences(s.code) {
+			_, err := tx.Exec(`INSERT INTO script_dependencies (script_id, source_database_id, source_database_name,
+					target_database_name, reference_type, code_snippet)
+				VALUES (?, ?, ?, ?, ?, ?)`, scriptID, dbID, dbName, ref.target, ref.kind, ref.snippet)
+			if err != nil {
+				return stats, err
+			}
+		}
+
+		for _, table := range formulaReferences(s.code, known) {
+			code := s.code
+			if r := []rune(code); len(r) > 500 {
+				code = string(r[:500])
+			}
+			addRelationship(extractRelationship{
+				sourceTableID: s.tableID, sourceTableName: firstNonEmpty(s.tableName, "(Database)"),
+				sourceFieldID: s.elementID, sourceFieldName: firstNonEmpty(s.elementName, s.codeType),
+				targetTableName: table, kind: "FORMULA_REF", codeType: s.codeType, code: code,
+			})
+		}
+	}
+
+	for _, r := range relationships {
+		_, err := tx.Exec(`INSERT INTO relationships (database_id, database_name, source_table_id,
+				source_table_name, source_field_id, source_field_name, target_table_id, target_table_name,
+				target_database_id, target_database_name, relationship_type, is_composition,
+				found_in_code_type, found_in_code)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			dbID, dbName, r.sourceTableID, r.sourceTableName, r.sourceFieldID, r.sourceFieldName,
+			r.targetTableID, r.targetTableName, nullIfEmpty(r.targetDatabaseID), nullIfEmpty(r.targetDBName),
+			r.kind, boolInt(r.composition), nullIfEmpty(r.codeType), nullIfEmpty(r.code))
+		if err != nil {
+			return stats, err
+		}
+		stats.relationships++
+	}
+
+	_, err = tx.Exec(`UPDATE databases SET table_count = ?, code_count = ? WHERE id = ?`, stats.tables, stats.scripts, dbID)
+	return stats, err
+}
+
+// insertFieldOptions speichert die Auswahlwerte eines Feldes (choice, multi)
+func insertFieldOptions(tx *sql.Tx, dbID, tableID, fieldID string, values any) error {
+	options := jsonObject(values)
+	for _, optionID := range schemaKeys(options) {
+		option := jsonObject(options[optionID])
+		if option == nil {
+			option = map[string]any{"caption": options[optionID]}
+		}
+		_, err := tx.Exec(`INSERT INTO field_options (database_id, table_id, field_id, option_id, caption, sort_order, color)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`, dbID, tableID, fieldID, optionID, jsonText(option["caption"]),
+			jsonNumber(option["order"]), nullIfEmpty(jsonText(option["color"])))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertPermissions speichert die Rollenlisten einer Tabelle bzw. eines Feldes
+func insertPermissions(tx *sql.Tx, dbID, tableID, tableName, elementID, elementName string, data map[string]any, fields [][2]string) error {
+	for _, rf := range fields {
+		roles := roleNames(data[rf[0]])
+		if len(roles) == 0 {
+			continue
+		}
+		_, err := tx.Exec(`INSERT INTO permissions (database_id, table_id, table_name, element_id, element_name, access, roles)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			dbID, tableID, tableName, nullIfEmpty(elementID), nullIfEmpty(elementName), rf[1], strings.Join(roles, ","))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertLayout speichert Felder und Layout-Elemente einer Tabelle in Formular-Reihenfolge;
+// Elemente nach einem Reiter gehören zu diesem
+func insertLayout(tx *sql.Tx, dbID, tableID string, t map[string]any) error {
+	type element struct {
+		order  float64
+		source string
+		id     string
+		data   map[string]any
+	}
+	var elements []element
+	for _, src := range [][2]string{{"field", "fields"}, {"ui", "uis"}} {
+		items := jsonObject(t[src[1]])
+		for _, id := range schemaKeys(items) {
+			data := jsonObject(items[id])
+			var order float64
+			if n, ok := data["order"].(json.Number); ok {
+				order, _ = n.Float64()
+			}
+			elements = append(elements, element{order, src[0], id, data})
+		}
+	}
+	sort.SliceStable(elements, func(i, j int) bool {
+		if elements[i].order != elements[j].order {
+			return elements[i].order < elements[j].order
+		}
+		return elements[i].id < elements[j].id
+	})
+
+	tab := ""
+	for _, e := range elements {
+		elementType := firstNonEmpty(jsonText(e.data["base"]), jsonText(e.data["uiType"]))
+		caption := firstNonEmpty(jsonText(e.data["caption"]), e.id)
+		if elementType == "tab" {
+			tab = caption
+		}
+		_, err := tx.Exec(`INSERT INTO layout_elements (database_id, table_id, element_id, caption,
+				element_type, source, sort_order, tab_caption)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			dbID, tableID, e.id, caption, elementType, e.source, e.order, nullIfEmpty(tab))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// boolInt speichert Wahrheitswerte wie SQLite als 0/1
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// teamName ermittelt den Namen eines Teams (Fallback: ID)
+func teamName(api *ninoxAPI, teams []map[string]any) string {
+	for _, t := range teams {
+		if jsonText(t["id"]) == api.teamID {
+			return firstNonEmpty(jsonText(t["name"]), api.teamID)
+		}
+	}
+	return api.teamID
+}
+
+// cmdExtract liest Schema und Scripts über die Ninox API und schreibt die Schema-Datenbank.
+// Geschrieben wird in eine temporäre Datei, die bestehende wird erst bei Erfolg ersetzt.
+func cmdExtract(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) > 0 {
+		fail(exitUsage, "Verwendung: ninox-tui extract --api-key KEY --team ID[,ID] [--api-url URL] [--database NAME|ID] [--counts] [--update] [--db DATEI]")
+	}
+	if !isFileDB(opts.dbPath) {
+		fail(exitUsage, "extract schreibt eine SQLite-Datei: %s", opts.dbPath)
+	}
+
+	cfg, err := LoadConfig(activeConfigPath())
+	if err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	cfg.ApplyEnv()
+
+	teamIDs := firstNonEmpty(opts.team, os.Getenv("NINOX_TEAM_ID"), cfg.TeamID)
+	apiKey := firstNonEmpty(opts.apiKey, os.Getenv("NINOX_API_KEY"))
+	apiURL := firstNonEmpty(opts.apiURL, os.Getenv("NINOX_DOMAIN"), defaultAPIURL)
+	if teamIDs == "" || apiKey == "" {
+		fail(exitUsage, "Team-ID (--team bzw. NINOX_TEAM_ID) und API-Key (--api-key bzw. NINOX_API_KEY) werden benötigt")
+	}
+
+	// --update gleicht eine bestehende Schema-DB ab, ohne sie wird vollständig extrahiert
+	write, verb := writeSchemaFile, "geschrieben"
+	if opts.update {
+		if _, err := os.Stat(opts.dbPath); err == nil {
+			write, verb = updateSchemaFile, "abgeglichen"
+		} else if !quiet {
+			fmt.Printf("%s existiert noch nicht, extrahiere vollständig\n", opts.dbPath)
+		}
+	}
+
+	var teams []map[string]any
+	stats, err := write(opts.dbPath, func(e *extractor) error {
+		e.counts = opts.counts
+		for _, teamID := range strings.Split(teamIDs, ",") {
+			e.api = newNinoxAPI(apiURL, strings.TrimSpace(teamID), apiKey)
+			if teams == nil {
+				var err error
+				if teams, err = e.api.Teams(); err != nil {
+					return fmt.Errorf("Teams abrufen: %w", err)
+				}
+			}
+			e.teamID, e.teamName = e.api.teamID, teamName(e.api, teams)
+			if err := e.extractTeam(opts.database); err != nil {
+				return fmt.Errorf("Team %s: %w", e.teamName, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	if stats.databases == 0 && stats.removed == 0 {
+		fail(exitNoResults, "Keine Datenbank extrahiert")
+	}
+
+	if !quiet {
+		fmt.Printf("✓ %s %s: %s\n", opts.dbPath, verb, stats)
+	}
+	return exitOK
+}
+
+func (s extractStats) String() string {
+	text := fmt.Sprintf("%d Teams, %d Datenbanken, %d Tabellen, %d Felder, %d Verknüpfungen, %d Scripts",
+		s.teams, s.databases, s.tables, s.fields, s.relationships, s.scripts)
+	if s.unchanged > 0 || s.removed > 0 {
+		text += fmt.Sprintf(" (%d Datenbanken unverändert, %d entfernt)", s.unchanged, s.removed)
+	}
+	return text
+}
+
+// writeSchemaFile legt eine neue Schema-Datenbank an und lässt fill sie befüllen.
+// Geschrieben wird in eine temporäre Datei, eine bestehende wird erst bei Erfolg
+// ersetzt; ihr Verlauf wird übernommen. Ohne extrahierte Datenbank bleibt die
+// bestehende Datei unverändert.
+func writeSchemaFile(path string, fill func(e *extractor) error) (extractStats, error) {
+	tmp := path + ".extract"
+	os.Remove(tmp)
+	defer os.Remove(tmp)
+	conn, err := sql.Open("sqlite3", tmp)
+	if err != nil {
+		return extractStats{}, err
+	}
+	defer conn.Close()
+	// Eine Verbindung: ATTACH gilt nur für die Verbindung, auf der es ausgeführt wird
+	conn.SetMaxOpenConns(1)
+	if _, err := createSchema(conn); err != nil {
+		return extractStats{}, fmt.Errorf("Schema anlegen: %w", err)
+	}
+	_, statErr := os.Stat(path)
+	previous := statErr == nil
+	if previous {
+		if _, err := conn.Exec(`ATTACH DATABASE ? AS prev`, path); err != nil {
+			return extractStats{}, fmt.Errorf("%s öffnen: %w", path, err)
+		}
+	}
+	tx, err := conn.Begin()
+	if err != nil {
+		return extractStats{}, err
+	}
+	defer tx.Rollback()
+
+	e := &extractor{tx: tx, source: "extract"}
+	if err := fill(e); err != nil {
+		return e.stats, err
+	}
+	if e.stats.databases == 0 {
+		return e.stats, nil
+	}
+	if previous {
+		if err := carryHistory(tx); err != nil {
+			return e.stats, err
+		}
+	}
+	if _, err := recordSnapshot(tx, "main", e.source, ""); err != nil {
+		return e.stats, fmt.Errorf("Stand sichern: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return e.stats, err
+	}
+	if err := conn.Close(); err != nil {
+		return e.stats, err
+	}
+	return e.stats, os.Rename(tmp, path)
+}
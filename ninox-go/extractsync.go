@@ -0,0 +1,293 @@
+package main
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// =============================================================================
+// extract --update: bestehende Schema-DB mit der API abgleichen. Jedes Schema
+// wird geladen, aber nur geänderte Datenbanken werden neu geschrieben; Ansichten
+// und Datensätze werden für unveränderte Datenbanken nicht abgefragt.
+// =============================================================================
+
+// databaseScopedTables enthalten Zeilen je Datenbank (Spalte database_id)
+var databaseScopedTables = []string{
+	"tables", "fields", "relationships", "scripts", "layout_elements",
+	"field_options", "automations", "permissions",
+}
+
+// schemaHash ist der Fingerabdruck eines Schemas samt Einstellungen.
+// json.Marshal sortiert die Schlüssel, gleiche Schemas ergeben denselben Hash.
+func schemaHash(settings, schema map[string]any) string {
+	data, _ := json.Marshal(map[string]any{"settings": settings, "schema": schema})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// deleteDatabaseRows entfernt eine Datenbank mit allen zugehörigen Zeilen
+func deleteDatabaseRows(tx *sql.Tx, dbID string, keepCounts bool) error {
+	stmts := []string{`DELETE FROM script_dependencies WHERE source_database_id = ?`}
+	for _, table := range databaseScopedTables {
+		stmts = append(stmts, fmt.Sprintf(`DELETE FROM %s WHERE database_id = ?`, table))
+	}
+	if !keepCounts {
+		stmts = append(stmts, `DELETE FROM record_counts WHERE database_id = ?`)
+	}
+	stmts = append(stmts, `DELETE FROM databases WHERE id = ?`)
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt, dbID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scriptKey identifiziert eine Code-Stelle über Extraktionen hinweg
+type scriptKey struct{ tableID, elementID, codeType string }
+
+// localScriptHashes liefert die Code-Hashes der Scripts einer Datenbank
+func localScriptHashes(tx *sql.Tx, dbID string) (map[scriptKey]string, error) {
+	rows, err := tx.Query(`SELECT COALESCE(table_id, ''), COALESCE(element_id, ''), code_type, COALESCE(code_hash, '')
+		FROM scripts WHERE database_id = ?`, dbID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	hashes := make(map[scriptKey]string)
+	for rows.Next() {
+		var k scriptKey
+		var hash string
+		if err := rows.Scan(&k.tableID, &k.elementID, &k.codeType, &hash); err != nil {
+			return nil, err
+		}
+		hashes[k] = hash
+	}
+	return hashes, rows.Err()
+}
+
+// scriptChanges vergleicht die Scripts vor und nach dem Abgleich
+func scriptChanges(before, after map[scriptKey]string) (added, changed, removed int) {
+	for k, hash := range after {
+		old, ok := before[k]
+		switch {
+		case !ok:
+			added++
+		case old != hash:
+			changed++
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			removed++
+		}
+	}
+	return added, changed, removed
+}
+
+// syncDatabase lädt das Schema und schreibt die Datenbank nur neu, wenn es sich geändert hat
+func (e *extractor) syncDatabase(dbID, dbName string) error {
+	data, err := e.api.DatabaseSchema(dbID)
+	if err != nil {
+		return err
+	}
+	settings := jsonObject(data["settings"])
+	schema := jsonObject(data["schema"])
+	if schema == nil {
+		return fmt.Errorf("Antwort enthält kein Schema")
+	}
+	dbName = firstNonEmpty(jsonText(settings["name"]), dbName)
+
+	var local sql.NullString
+	err = e.tx.QueryRow(`SELECT schema_hash FROM databases WHERE id = ?`, dbID).Scan(&local)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	exists := err == nil
+	if exists && local.String == schemaHash(settings, schema) {
+		_, err := e.tx.Exec(`UPDATE databases SET team_id = ?, team_name = ?, last_synced = CURRENT_TIMESTAMP
+			WHERE id = ?`, e.teamID, e.teamName, dbID)
+		if err != nil {
+			return err
+		}
+		e.stats.unchanged++
+		if !quiet {
+			fmt.Printf("  %s (%s) unverändert\n", dbName, dbID)
+		}
+		if e.counts {
+			return e.recountDatabase(dbID)
+		}
+		return nil
+	}
+
+	before, err := localScriptHashes(e.tx, dbID)
+	if err != nil {
+		return err
+	}
+	if err := e.addDatabase(dbID, dbName, settings, schema); err != nil {
+		return err
+	}
+	// Ohne --counts bleiben gezählte Datensätze erhalten, außer von entfernten Tabellen
+	_, err = e.tx.Exec(`DELETE FROM record_counts WHERE database_id = ?
+		AND table_id NOT IN (SELECT table_id FROM tables WHERE database_id = ?)`, dbID, dbID)
+	if err != nil {
+		return err
+	}
+	after, err := localScriptHashes(e.tx, dbID)
+	if err != nil {
+		return err
+	}
+	if !quiet {
+		added, changed, removed := scriptChanges(before, after)
+		label := "geändert"
+		if !exists {
+			label = "neu"
+		}
+		fmt.Printf("  %s (%s) %s: Scripts +%d ~%d -%d\n", dbName, dbID, label, added, changed, removed)
+	}
+	return nil
+}
+
+// recountDatabase zählt die Datensätze der Tabellen einer unveränderten Datenbank neu
+func (e *extractor) recountDatabase(dbID string) error {
+	rows, err := e.tx.Query(`SELECT table_id, name FROM tables WHERE database_id = ?`, dbID)
+	if err != nil {
+		return err
+	}
+	var tables [][2]string
+	for rows.Next() {
+		var t [2]string
+		if err := rows.Scan(&t[0], &t[1]); err != nil {
+			rows.Close()
+			return err
+		}
+		tables = append(tables, t)
+	}
+	rows.Close()
+
+	for _, t := range tables {
+		n, err := e.api.CountRecords(dbID, t[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ Datensätze von %s nicht gezählt: %v\n", t[1], err)
+			continue
+		}
+		_, err = e.tx.Exec(`INSERT OR REPLACE INTO record_counts (database_id, table_id, record_count, counted_at)
+			VALUES (?, ?, ?, CURRENT_TIMESTAMP)`, dbID, t[0], n)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeVanished entfernt Datenbanken des Teams, die es in Ninox nicht mehr gibt
+func (e *extractor) removeVanished(remote map[string]bool) error {
+	rows, err := e.tx.Query(`SELECT id, name FROM databases WHERE team_id = ?`, e.teamID)
+	if err != nil {
+		return err
+	}
+	var vanished [][2]string
+	for rows.Next() {
+		var d [2]string
+		if err := rows.Scan(&d[0], &d[1]); err != nil {
+			rows.Close()
+			return err
+		}
+		if !remote[d[0]] {
+			vanished = append(vanished, d)
+		}
+	}
+	rows.Close()
+
+	for _, d := range vanished {
+		if err := deleteDatabaseRows(e.tx, d[0], false); err != nil {
+			return err
+		}
+		e.stats.removed++
+		if !quiet {
+			fmt.Printf("  %s (%s) entfernt\n", d[1], d[0])
+		}
+	}
+	return nil
+}
+
+// addSyncColumns ergänzt ältere Schema-DBs (Python-Extraktor) um die Spalten des Abgleichs
+// (columns sind die vorhandenen Spalten von databases)
+func addSyncColumns(tx *sql.Tx, columns map[string]bool) error {
+	for _, col := range [][2]string{{"schema_hash", "TEXT"}, {"last_synced", "TIMESTAMP"}} {
+		if columns[col[0]] {
+			continue
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`ALTER TABLE databases ADD COLUMN %s %s`, col[0], col[1])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqliteColumns liefert die Spalten einer Tabelle der Schema-Datei
+func sqliteColumns(conn *sql.DB, table string) map[string]bool {
+	return sqliteBackend{}.columns(&backendConn{DB: conn, backend: sqliteBackend{}}, table)
+}
+
+// updateSchemaFile gleicht eine bestehende Schema-Datenbank in einer Transaktion ab;
+// bei einem Fehler bleibt sie unverändert (SQLite nimmt auch die Schemaänderungen zurück)
+func updateSchemaFile(path string, fill func(e *extractor) error) (extractStats, error) {
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return extractStats{}, err
+	}
+	defer conn.Close()
+	hadFTS := len(sqliteColumns(conn, "scripts_fts")) > 0
+	columns := sqliteColumns(conn, "databases")
+
+	tx, err := conn.Begin()
+	if err != nil {
+		return extractStats{}, err
+	}
+	defer tx.Rollback()
+
+	// Fehlende Tabellen ergänzen; ein neu angelegter Suchindex muss die vorhandenen
+	// Scripts aufnehmen, sonst verfehlen ihn die Lösch-Trigger
+	fts, err := createSchema(tx)
+	if err != nil {
+		return extractStats{}, fmt.Errorf("Schema anlegen: %w", err)
+	}
+	if fts && !hadFTS {
+		if _, err := tx.Exec(`INSERT INTO scripts_fts(scripts_fts) VALUES ('rebuild')`); err != nil {
+			return extractStats{}, fmt.Errorf("Suchindex anlegen: %w", err)
+		}
+	}
+	if err := addSyncColumns(tx, columns); err != nil {
+		return extractStats{}, fmt.Errorf("Schema ergänzen: %w", err)
+	}
+
+	// Ohne Verlauf den bisherigen Inhalt als ersten Stand sichern
+	var snapshots int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM snapshots`).Scan(&snapshots); err != nil {
+		return extractStats{}, err
+	}
+	if snapshots == 0 {
+		var extractedAt sql.NullString
+		tx.QueryRow(`SELECT MAX(extracted_at) FROM databases`).Scan(&extractedAt)
+		if _, err := recordSnapshot(tx, "main", snapshotPrevious, extractedAt.String); err != nil {
+			return extractStats{}, fmt.Errorf("Bisherigen Stand sichern: %w", err)
+		}
+	}
+
+	e := &extractor{tx: tx, update: true, source: "extract --update"}
+	if err := fill(e); err != nil {
+		return e.stats, err
+	}
+	if e.stats.databases == 0 && e.stats.removed == 0 {
+		return e.stats, nil
+	}
+	if _, err := recordSnapshot(tx, "main", e.source, ""); err != nil {
+		return e.stats, fmt.Errorf("Stand sichern: %w", err)
+	}
+	return e.stats, tx.Commit()
+}
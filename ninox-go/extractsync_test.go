@@ -0,0 +1,41 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// Ein fehlgeschlagener Abgleich lässt auch die Schemaänderungen nicht zurück
+func TestUpdateSchemaFileRollback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alt.db")
+	if err := WriteFixture(path); err != nil {
+		t.Fatalf("WriteFixture: %v", err)
+	}
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	// Stand einer älteren Extraktion: ohne Abgleich-Spalten und Rechte
+	for _, stmt := range []string{
+		`ALTER TABLE databases DROP COLUMN schema_hash`,
+		`DROP TABLE permissions`,
+	} {
+		if _, err := conn.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	failed := errors.New("API nicht erreichbar")
+	if _, err := updateSchemaFile(path, func(e *extractor) error { return failed }); !errors.Is(err, failed) {
+		t.Fatalf("updateSchemaFile: %v", err)
+	}
+	if sqliteColumns(conn, "databases")["schema_hash"] {
+		t.Error("Spalte schema_hash trotz Fehler angelegt")
+	}
+	if len(sqliteColumns(conn, "permissions")) > 0 {
+		t.Error("Tabelle permissions trotz Fehler angelegt")
+	}
+}
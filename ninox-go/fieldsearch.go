@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Feldtreffer in der Suche
+// =============================================================================
+
+// maxFieldMatches begrenzt die Feldtreffer unter den Script-Treffern
+const maxFieldMatches = 20
+
+// searchResultCount liefert die Zahl der auswählbaren Treffer (Scripts, dann Felder)
+func (m Model) searchResultCount() int {
+	return len(m.searchResults) + len(m.searchFields)
+}
+
+// selectedFieldMatch liefert den ausgewählten Feldtreffer oder nil
+func (m Model) selectedFieldMatch() *FieldMatch {
+	i := m.selectedSearch - len(m.searchResults)
+	if i < 0 || i >= len(m.searchFields) {
+		return nil
+	}
+	return &m.searchFields[i]
+}
+
+// openFieldMatch öffnet die Feldliste der Tabelle mit dem gefundenen Feld
+func (m Model) openFieldMatch(fm FieldMatch) (tea.Model, tea.Cmd) {
+	var db *Database
+	for i := range m.databases {
+		if m.databases[i].ID == fm.DatabaseID {
+			db = &m.databases[i]
+			m.selectedDB = i
+			break
+		}
+	}
+	if db == nil {
+		return m, nil
+	}
+
+	tables, err := m.db.GetTables(db.ID)
+	if err != nil {
+		m.status = fmt.Sprintf("❌ %v", err)
+		return m, nil
+	}
+	m.currentDB = db
+	m.tables = tables
+	m.applySort(viewTables)
+	m.selectedTable = 0
+	for i := range m.tables {
+		if m.tables[i].TableID == fm.TableID {
+			m.selectedTable = i
+		}
+	}
+	if m.selectedTable >= len(m.tables) {
+		return m, nil
+	}
+	m.currentTable = &m.tables[m.selectedTable]
+
+	if fields, err := m.db.GetFields(db.ID, fm.TableID); err == nil {
+		m.fields = fields
+		m.applySort(viewFields)
+	}
+	if scripts, err := m.db.GetScripts(db.ID, m.currentTable.Name); err == nil {
+		m.scripts = scripts
+		m.applySort(viewScripts)
+		m.selectedScript = 0
+	}
+	if rels, err := m.db.GetRelationships(m.currentTable.Name); err == nil {
+		m.relationships = rels
+	}
+
+	m.selectedField = 0
+	for i, f := range m.fields {
+		if f.FieldID == fm.FieldID {
+			m.selectedField = i
+		}
+	}
+	m.prevMode = viewSearch
+	m.mode = viewFields
+	return m, nil
+}
+
+// renderFieldMatches rendert den Abschnitt mit Feldtreffern der Suche
+func (m Model) renderFieldMatches() string {
+	if len(m.searchFields) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n" + fmt.Sprintf("  %d Feldtreffer\n\n", len(m.searchFields)))
+
+	header := fmt.Sprintf("  %-30s %-20s %-12s %s", "Datenbank.Tabelle", "Feld", "Typ", "Beschriftung")
+	b.WriteString(tableHeaderStyle.Render(header) + "\n")
+
+	for i, fm := range m.searchFields {
+		style := tableCellStyle
+		prefix := "  "
+		if len(m.searchResults)+i == m.selectedSearch {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+
+		typ := fm.BaseType
+		if fm.HasFormula {
+			typ += " ƒ"
+		}
+		caption := fm.Caption
+		if fm.Option != "" {
+			caption = "🔘 " + fm.Option
+		}
+		row := fmt.Sprintf("%s%-28s %-20s %-12s %s",
+			prefix,
+			truncate(fm.DatabaseName+"."+fm.TableName, 28),
+			truncate(fm.Name, 20),
+			truncate(typ, 12),
+			truncate(caption, 30))
+		b.WriteString(style.Render(row) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Script-Auswahl über externes fzf (Rückfall: interne Suche)
+// =============================================================================
+
+// finderDoneMsg liefert die Auswahl aus fzf
+type finderDoneMsg struct {
+	output string
+	err    error
+}
+
+// fzfPath liefert den Pfad zu fzf, sofern in der Konfiguration gewählt und installiert.
+// Finder ist "fzf" (Suche im PATH) oder ein Pfad zur ausführbaren Datei.
+func fzfPath(finder string) string {
+	if finder == "" || finder == "intern" {
+		return ""
+	}
+	path, err := exec.LookPath(finder)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// fzfCandidates erzeugt die Eingabezeilen für fzf: "ID<Tab>Herkunft"
+func fzfCandidates(scripts []Script) string {
+	var b strings.Builder
+	for _, s := range scripts {
+		fmt.Fprintf(&b, "%d\t%s\n", s.ID, scriptLabel(s))
+	}
+	return b.String()
+}
+
+// openFinder übergibt die Script-Liste an fzf; ohne fzf öffnet sich die interne Suche
+func (m Model) openFinder() (tea.Model, tea.Cmd) {
+	path := fzfPath(m.config.Finder)
+	if path == "" {
+		if m.config.Finder != "" && m.config.Finder != "intern" {
+			m.status = fmt.Sprintf("%s nicht gefunden – interne Suche", m.config.Finder)
+		}
+		m.searchWithin = nil
+		m.searching = true
+		m.searchInput.Focus()
+		return m, textinput.Blink
+	}
+
+	var out bytes.Buffer
+	cmd := exec.Command(path, "--delimiter=\t", "--with-nth=2..", "--prompt=Script> ", "--no-multi")
+	cmd.Stdin = strings.NewReader(fzfCandidates(m.allScripts))
+	cmd.Stdout = &out
+
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return finderDoneMsg{output: out.String(), err: err}
+	})
+}
+
+// handleFinderDone öffnet das in fzf gewählte Script
+func (m Model) handleFinderDone(msg finderDoneMsg) (tea.Model, tea.Cmd) {
+	var exitErr *exec.ExitError
+	if errors.As(msg.err, &exitErr) && (exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130) {
+		return m, nil // kein Treffer oder abgebrochen
+	}
+	if msg.err != nil {
+		m.status = fmt.Sprintf("❌ fzf: %v", msg.err)
+		return m, nil
+	}
+
+	idText, _, _ := strings.Cut(strings.TrimSpace(msg.output), "\t")
+	id, err := strconv.Atoi(idText)
+	if err != nil {
+		return m, nil
+	}
+	for _, s := range m.allScripts {
+		if s.ID == id {
+			m.recordJump()
+			script := s
+			m.codeScript = &script
+			m.codeView.SetContent(highlightCode(script.Code))
+			m.codeView.GotoTop()
+			if m.mode != viewCode {
+				m.prevMode = m.mode
+			}
+			m.mode = viewCode
+			return m, nil
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Befunde: alle Lint-Regeln (Performance, Zugangsdaten, Verweise) in einer Ansicht
+// =============================================================================
+
+// findingSeverityFilters ist die Reihenfolge, in der Tab die Mindest-Schwere durchschaltet
+var findingSeverityFilters = []lintSeverity{severityInfo, severityWarning, severityError}
+
+// findingJSON ist ein Befund im JSON-Export (TUI und lint --output json)
+type findingJSON struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Database string `json:"database"`
+	Table    string `json:"table,omitempty"`
+	Element  string `json:"element,omitempty"`
+	CodeType string `json:"codeType"`
+	Line     int    `json:"line"`
+	Location string `json:"location"`
+	Message  string `json:"message"`
+
+	SuppressedBy string `json:"suppressedBy,omitempty"`
+}
+
+// findingsJSON wandelt Befunde in ihre JSON-Form
+func findingsJSON(findings []lintFinding) ([]byte, error) {
+	out := make([]findingJSON, 0, len(findings))
+	for _, f := range findings {
+		out = append(out, findingJSON{
+			Rule:     f.Rule,
+			Severity: severityNames[f.Severity],
+			Database: f.Script.DatabaseName,
+			Table:    f.Script.TableName,
+			Element:  f.Script.ElementName,
+			CodeType: f.Script.CodeType,
+			Line:     f.Line,
+			Location: f.location(),
+			Message:  f.Message,
+
+			SuppressedBy: f.SuppressedBy,
+		})
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// visibleFindings liefert die Befunde ab der gewählten Schwere
+func (m Model) visibleFindings() []lintFinding {
+	var findings []lintFinding
+	for _, f := range m.findings {
+		if f.Severity >= m.findingSeverity {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// openFindings prüft alle Scripts und öffnet die Befund-Ansicht
+func (m Model) openFindings() (tea.Model, tea.Cmd) {
+	if m.mode == viewFindings {
+		return m, nil
+	}
+	lc, err := newLintContext(m.db)
+	if err != nil {
+		m.status = fmt.Sprintf("Fehler beim Prüfen der Scripts: %v", err)
+		return m, nil
+	}
+
+	findings := runLint(m.allScripts, lc)
+	if path := m.config.LintBaseline; path != "" {
+		baseline, err := loadBaseline(path)
+		if err != nil {
+			m.status = fmt.Sprintf("Baseline nicht geladen: %v", err)
+		} else {
+			applyBaseline(findings, baseline)
+		}
+	}
+	findings, m.findingsSuppressed = activeFindings(findings)
+
+	// Nach Datenbank gruppieren, innerhalb nach Schwere und Fundstelle (stabil)
+	var grouped []lintFinding
+	for _, d := range m.databases {
+		for _, f := range findings {
+			if f.Script.DatabaseID == d.ID {
+				grouped = append(grouped, f)
+			}
+		}
+	}
+
+	m.findings = grouped
+	m.findingsReturn = m.mode
+	m.selectedFinding = 0
+	m.mode = viewFindings
+	return m, nil
+}
+
+// closeFindings kehrt zur Ansicht vor dem Öffnen zurück
+func (m Model) closeFindings() (tea.Model, tea.Cmd) {
+	m.mode = m.findingsReturn
+	m.prevMode = m.findingsReturn
+	return m, nil
+}
+
+// cycleFindingSeverity schaltet die Mindest-Schwere um (alle → Warnungen → Fehler)
+func (m Model) cycleFindingSeverity() (tea.Model, tea.Cmd) {
+	for i, s := range findingSeverityFilters {
+		if s == m.findingSeverity {
+			m.findingSeverity = findingSeverityFilters[(i+1)%len(findingSeverityFilters)]
+			break
+		}
+	}
+	m.selectedFinding = 0
+	return m, nil
+}
+
+// openFindingScript zeigt das Script des ausgewählten Befunds an der Fundstelle
+func (m Model) openFindingScript() (tea.Model, tea.Cmd) {
+	findings := m.visibleFindings()
+	if m.selectedFinding >= len(findings) {
+		return m, nil
+	}
+	f := findings[m.selectedFinding]
+	script := *f.Script
+	m.codeScript = &script
+	m.codeView.SetContent(highlightCode(script.Code))
+	m.codeView.SetYOffset(max(0, f.Line-3))
+	m.prevMode = viewFindings
+	m.mode = viewCode
+	return m, nil
+}
+
+// startExportFindings fragt nach dem Zieldateinamen (.json oder .sarif)
+func (m Model) startExportFindings() (tea.Model, tea.Cmd) {
+	if len(m.visibleFindings()) == 0 {
+		return m, nil
+	}
+	m.findingsExporting = true
+	m.sqlExportInput.SetValue(exportFileName("befunde", ".json"))
+	m.sqlExportInput.CursorEnd()
+	m.sqlExportInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleFindingsExportInput verarbeitet die Eingabe des Export-Dateinamens
+func (m Model) handleFindingsExportInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch {
+	case key.Matches(msg, keys.Back):
+		m.findingsExporting = false
+		m.sqlExportInput.Blur()
+		return m, nil
+	case key.Matches(msg, keys.Enter):
+		m.findingsExporting = false
+		m.sqlExportInput.Blur()
+		path := strings.TrimSpace(m.sqlExportInput.Value())
+		if path == "" {
+			return m, nil
+		}
+		findings := m.visibleFindings()
+		encode := findingsJSON
+		if strings.EqualFold(filepath.Ext(path), ".sarif") {
+			encode = findingsSARIF
+		}
+		data, err := encode(findings)
+		if err == nil {
+			err = os.WriteFile(path, append(data, '\n'), 0o644)
+		}
+		if err != nil {
+			m.status = fmt.Sprintf("❌ Export fehlgeschlagen: %v", err)
+		} else {
+			m.status = fmt.Sprintf("✓ %d Befunde nach %s exportiert", len(findings), path)
+		}
+		return m, nil
+	default:
+		m.sqlExportInput, cmd = m.sqlExportInput.Update(msg)
+		return m, cmd
+	}
+}
+
+// renderFindings rendert die Befunde gruppiert nach Datenbank
+func (m Model) renderFindings() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("⚑ Befunde") + "\n\n")
+
+	counts := make(map[lintSeverity]int)
+	for _, f := range m.findings {
+		counts[f.Severity]++
+	}
+	var tabs []string
+	for _, s := range findingSeverityFilters {
+		n := 0
+		for sev, c := range counts {
+			if sev >= s {
+				n += c
+			}
+		}
+		label := fmt.Sprintf("ab %s (%d)", severityNames[s], n)
+		if s == severityInfo {
+			label = fmt.Sprintf("Alle (%d)", n)
+		}
+		if s == m.findingSeverity {
+			label = "[" + label + "]"
+		}
+		tabs = append(tabs, label)
+	}
+	hint := "  (Tab wechseln)"
+	if m.findingsSuppressed > 0 {
+		hint = fmt.Sprintf(" · %d unterdrückt%s", m.findingsSuppressed, hint)
+	}
+	b.WriteString(mutedStyle.Render("  "+strings.Join(tabs, " · ")+hint) + "\n\n")
+
+	if m.findingsExporting {
+		b.WriteString("  " + m.sqlExportInput.View() + "\n\n")
+	}
+
+	findings := m.visibleFindings()
+	if len(findings) == 0 {
+		b.WriteString(mutedStyle.Render("  Keine Befunde.") + "\n")
+		return boxStyle.Width(m.width - 4).Render(b.String())
+	}
+
+	height := max(3, m.height-14)
+	start := 0
+	if m.selectedFinding >= height {
+		start = m.selectedFinding - height + 1
+	}
+	end := min(len(findings), start+height)
+
+	for i := start; i < end; i++ {
+		f := findings[i]
+		if i == start || findings[i-1].Script.DatabaseID != f.Script.DatabaseID {
+			b.WriteString(tableHeaderStyle.Render("  "+f.Script.DatabaseName) + "\n")
+		}
+		style := tableCellStyle
+		prefix := "  "
+		if i == m.selectedFinding {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		label := strings.TrimPrefix(f.location(), f.Script.DatabaseName+"/")
+		row := fmt.Sprintf("%s%-8s %-36s %-22s %s", prefix, severityNames[f.Severity],
+			truncate(label, 36), f.Rule, truncate(f.Message, max(10, m.width-78)))
+		b.WriteString(style.Render(row) + "\n")
+	}
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("\n  %d von %d Befunden", len(findings), len(m.findings))) + "\n")
+
+	return boxStyle.Width(m.width - 4).Render(b.String())
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Fixture-Datenbank für Entwicklung und Tests
+// =============================================================================
+
+type fixtureTable struct {
+	id, name string
+	fields   []fixtureField
+	uis      []fixtureUI // Layout-Elemente; Felder stehen an Position 10, 20, …
+	records  int         // Anzahl Datensätze
+}
+
+type fixtureUI struct {
+	id, name, baseType string
+	order              int
+}
+
+type fixtureField struct {
+	id, name, baseType, refTable string
+	formula                      bool
+	options                      []string // Auswahlwerte, IDs ab 1
+}
+
+type fixtureScript struct {
+	table, element, codeType, category, code string
+}
+
+type fixturePermission struct {
+	table, element, access, roles string
+}
+
+type fixtureView struct {
+	table, name string
+}
+
+type fixtureAutomation struct {
+	id, name, kind, condition, code string
+	disabled                        bool
+}
+
+type fixtureDatabase struct {
+	id, name    string
+	team        fixtureTeam
+	tables      []fixtureTable
+	scripts     []fixtureScript
+	permissions []fixturePermission
+	views       []fixtureView
+	automations []fixtureAutomation
+}
+
+// fixtureTeam ist ein Ninox-Team; die Fixture verteilt die Datenbanken auf zwei
+type fixtureTeam struct{ id, name string }
+
+var (
+	fixtureSales = fixtureTeam{"fixture-team", "Fixture"}
+	fixtureOps   = fixtureTeam{"fixture-ops", "Fixture Betrieb"}
+)
+
+// fixtureData beschreibt den bekannten Inhalt der Fixture-Datenbank
+var fixtureData = []fixtureDatabase{
+	{
+		id: "crm001", name: "CRM", team: fixtureSales,
+		tables: []fixtureTable{
+			{id: "A", name: "Kunden", fields: []fixtureField{
+				{id: "A", name: "Name", baseType: "string"},
+				{id: "B", name: "E-Mail", baseType: "email"},
+				{id: "C", name: "Umsatz", baseType: "number", formula: true},
+				{id: "D", name: "Status", baseType: "choice", options: []string{"Neu", "Aktiv", "Gesperrt"}},
+			}, uis: []fixtureUI{
+				{"T1", "Allgemein", "tab", 5},
+				{"T2", "Auswertung", "tab", 25},
+				{"U1", "Mail senden", "button", 45},
+			}, records: 1250},
+			{id: "B", name: "Rechnungen", fields: []fixtureField{
+				{id: "A", name: "Nummer", baseType: "string"},
+				{id: "B", name: "Betrag", baseType: "number"},
+				{id: "C", name: "Kunde", baseType: "ref", refTable: "Kunden"},
+				{id: "D", name: "Umsatzsteuer", baseType: "number", formula: true},
+			}, uis: []fixtureUI{
+				{"U1", "Export", "button", 45},
+			}, records: 48210},
+			{id: "C", name: "Projekte", fields: []fixtureField{
+				{id: "A", name: "Titel", baseType: "string"},
+				{id: "B", name: "Kunde", baseType: "ref", refTable: "Kunden"},
+			}, records: 310},
+		},
+		scripts: []fixtureScript{
+			{"", "", "globalCode", "GLOBAL_FUNCTION", "function brutto(netto : number) do\n\tnetto * 1.19\nend"},
+			{"Kunden", "Umsatz", "fn", "FORMULA", "sum(Rechnungen.Betrag)"},
+			{"Kunden", "", "afterUpdate", "TRIGGER", "if Status = 3 then\n\tfor r in Rechnungen do\n\t\tr.Betrag := 0\n\tend\nend"},
+			{"Kunden", "Mail senden", "onClick", "BUTTON", "sendEmail({\n\tto: 'E-Mail',\n\tsubject: \"Hallo \" + Name\n})"},
+			{"Rechnungen", "Umsatzsteuer", "fn", "FORMULA", "Betrag * 0.19"},
+			{"Rechnungen", "", "afterCreate", "TRIGGER", "Nummer := \"R-\" + format(now(), \"YYYY\") + \"-\" + Nr"},
+			{"Rechnungen", "Export", "onClick", "BUTTON", "let k := select Kunden;\nfor x in k do\n\thttp(\"POST\", \"https://example.com/api\", {}, x)\nend"},
+			{"Projekte", "", "canDelete", "PERMISSION", "userHasRole(\"Admin\")"},
+			{"Rechnungen", "Rechnung › Summe", "reportFn", "REPORT", "format(Betrag + Umsatzsteuer, \"#,##0.00 €\")"},
+			{"Rechnungen", "Rechnung › Mahnhinweis", "reportVisibility", "REPORT", "Kunde.Status = 3"},
+		},
+		permissions: []fixturePermission{
+			{"Kunden", "", "write", "Admin,Vertrieb"},
+			{"Kunden", "Umsatz", "read", "Admin,Buchhaltung"},
+			{"Rechnungen", "", "delete", "Admin"},
+			{"Rechnungen", "Betrag", "write", "Buchhaltung"},
+		},
+		views: []fixtureView{
+			{"Kunden", "Alle Kunden"},
+			{"Rechnungen", "Offene Rechnungen"},
+		},
+		automations: []fixtureAutomation{
+			{"J1", "Nachtlauf Mahnungen", "schedule", "täglich 02:00", "for r in select Rechnungen where Betrag > 1000 do\n\tsendEmail({\n\t\tto: \"buchhaltung@example.com\",\n\t\tsubject: \"Offen: \" + r.Nummer\n\t})\nend", false},
+			{"W1", "Zahlungseingang", "webhook", "POST /zahlung", "let r := first(select Rechnungen where Nummer = $request.nummer);\nr.Betrag := 0", false},
+		},
+	},
+	{
+		id: "erp001", name: "ERP", team: fixtureOps,
+		tables: []fixtureTable{
+			{id: "A", name: "Artikel", fields: []fixtureField{
+				{id: "A", name: "Bezeichnung", baseType: "string"},
+				{id: "B", name: "Preis", baseType: "number"},
+			}, uis: []fixtureUI{
+				{"U1", "Preis prüfen", "button", 25},
+			}, records: 5400},
+			{id: "B", name: "Lager", fields: []fixtureField{
+				{id: "A", name: "Artikel", baseType: "ref", refTable: "Artikel"},
+				{id: "B", name: "Bestand", baseType: "number"},
+			}, records: 5400},
+		},
+		scripts: []fixtureScript{
+			{"Artikel", "Preis prüfen", "onClick", "BUTTON", "if Preis < 0 then\n\talert(\"Preis ungültig\")\nend"},
+			{"Lager", "", "afterUpdate", "TRIGGER", "do as database 'CRM'\n\tselect Kunden\nend"},
+		},
+		automations: []fixtureAutomation{
+			{"J1", "Bestandsabgleich", "schedule", "stündlich", "for l in select Lager where Bestand < 0 do\n\tl.Bestand := 0\nend", true},
+		},
+	},
+}
+
+// fixtureEditors sind letzte Bearbeiter einiger Scripts (Element → Benutzer, Zeitpunkt)
+var fixtureEditors = map[string][2]string{
+	"Mail senden":  {"anna.berger@example.com", "2026-10-12 09:14:00"},
+	"Export":       {"jonas.weber@example.com", "2026-10-14 16:02:00"},
+	"Preis prüfen": {"anna.berger@example.com", "2026-09-30 11:45:00"},
+}
+
+// WriteFixture erzeugt eine synthetische Schema-Datenbank mit bekanntem Inhalt
+func WriteFixture(path string) error {
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := createSchema(conn); err != nil {
+		return fmt.Errorf("Schema anlegen: %w", err)
+	}
+
+	tx, err := conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, d := range fixtureData {
+		if err := insertFixtureDatabase(tx, d); err != nil {
+			return fmt.Errorf("Datenbank %s: %w", d.name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func insertFixtureDatabase(tx *sql.Tx, d fixtureDatabase) error {
+	_, err := tx.Exec(`INSERT INTO databases (id, name, team_id, team_name, version, table_count, code_count)
+		VALUES (?, ?, ?, ?, 1, ?, ?)`, d.id, d.name, d.team.id, d.team.name, len(d.tables), len(d.scripts)+len(d.automations))
+	if err != nil {
+		return err
+	}
+
+	tableIDs := make(map[string]string)
+	for _, t := range d.tables {
+		tableIDs[t.name] = t.id
+		_, err := tx.Exec(`INSERT INTO tables (database_id, table_id, name, caption, field_count)
+			VALUES (?, ?, ?, ?, ?)`, d.id, t.id, t.name, t.name, len(t.fields))
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO record_counts (database_id, table_id, record_count, counted_at)
+			VALUES (?, ?, ?, '2026-10-15 23:44:05')`, d.id, t.id, t.records)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, t := range d.tables {
+		for _, f := range t.fields {
+			formula := 0
+			if f.formula {
+				formula = 1
+			}
+			_, err := tx.Exec(`INSERT INTO fields (database_id, table_id, field_id, name, caption,
+					base_type, ref_table_id, ref_table_name, has_formula)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				d.id, t.id, f.id, f.name, f.name, f.baseType,
+				nullIfEmpty(tableIDs[f.refTable]), nullIfEmpty(f.refTable), formula)
+			if err != nil {
+				return err
+			}
+			for j, option := range f.options {
+				_, err := tx.Exec(`INSERT INTO field_options (database_id, table_id, field_id,
+						option_id, caption, sort_order)
+					VALUES (?, ?, ?, ?, ?, ?)`,
+					d.id, t.id, f.id, fmt.Sprint(j+1), option, j)
+				if err != nil {
+					return err
+				}
+			}
+			if f.refTable == "" {
+				continue
+			}
+			_, err = tx.Exec(`INSERT INTO relationships (database_id, database_name,
+					source_table_id, source_table_name, source_field_id, source_field_name,
+					target_table_id, target_table_name, relationship_type)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, 'N_TO_1')`,
+				d.id, d.name, t.id, t.name, f.id, f.name, tableIDs[f.refTable], f.refTable)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, s := range d.scripts {
+		editor := fixtureEditors[s.element]
+		_, err := tx.Exec(`INSERT INTO scripts (team_id, team_name, database_id, database_name,
+				table_id, table_name, element_id, element_name, code_type, code_category,
+				code, code_original, code_hash, line_count, modified_by, modified_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			d.team.id, d.team.name, d.id, d.name, nullIfEmpty(tableIDs[s.table]), nullIfEmpty(s.table),
+			fmt.Sprintf("E%d", i+1), nullIfEmpty(s.element), s.codeType, s.category,
+			s.code, s.code, scriptHash(s.code), strings.Count(s.code, "\n")+1,
+			nullIfEmpty(editor[0]), nullIfEmpty(editor[1]))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, a := range d.automations {
+		enabled := 1
+		if a.disabled {
+			enabled = 0
+		}
+		_, err := tx.Exec(`INSERT INTO automations (database_id, element_id, name, kind, trigger_condition, enabled)
+			VALUES (?, ?, ?, ?, ?, ?)`, d.id, a.id, a.name, a.kind, a.condition, enabled)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO scripts (team_id, team_name, database_id, database_name,
+				element_id, element_name, code_type, code_category,
+				code, code_original, code_hash, line_count)
+			VALUES (?, ?, ?, ?, ?, ?, ?, 'AUTOMATION', ?, ?, ?, ?)`,
+			d.team.id, d.team.name, d.id, d.name, a.id, a.name, a.kind,
+			a.code, a.code, scriptHash(a.code), strings.Count(a.code, "\n")+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, t := range d.tables {
+		if err := insertFixtureLayout(tx, d.id, t); err != nil {
+			return err
+		}
+	}
+	for i, v := range d.views {
+		_, err := tx.Exec(`INSERT INTO layout_elements (database_id, table_id, element_id, caption,
+				element_type, source, sort_order)
+			VALUES (?, ?, ?, ?, 'view', 'view', ?)`,
+			d.id, tableIDs[v.table], fmt.Sprintf("V%d", i+1), v.name, i)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, p := range d.permissions {
+		elementID := ""
+		for _, t := range d.tables {
+			for _, f := range t.fields {
+				if t.name == p.table && f.name == p.element {
+					elementID = f.id
+				}
+			}
+		}
+		_, err := tx.Exec(`INSERT INTO permissions (database_id, table_id, table_name,
+				element_id, element_name, access, roles)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			d.id, tableIDs[p.table], p.table, nullIfEmpty(elementID), nullIfEmpty(p.element), p.access, p.roles)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertFixtureLayout speichert Felder und Layout-Elemente einer Tabelle in
+// Formular-Reihenfolge; Elemente nach einem Reiter gehören zu diesem (wie der Extraktor)
+func insertFixtureLayout(tx *sql.Tx, dbID string, t fixtureTable) error {
+	type element struct {
+		id, name, baseType, source string
+		order                      int
+	}
+	var elements []element
+	for i, f := range t.fields {
+		elements = append(elements, element{f.id, f.name, f.baseType, "field", (i + 1) * 10})
+	}
+	for _, u := range t.uis {
+		elements = append(elements, element{u.id, u.name, u.baseType, "ui", u.order})
+	}
+	sort.Slice(elements, func(i, j int) bool { return elements[i].order < elements[j].order })
+
+	tab := ""
+	for _, e := range elements {
+		if e.baseType == "tab" {
+			tab = e.name
+		}
+		_, err := tx.Exec(`INSERT INTO layout_elements (database_id, table_id, element_id, caption,
+				element_type, source, sort_order, tab_caption)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			dbID, t.id, e.id, e.name, e.baseType, e.source, e.order, nullIfEmpty(tab))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nullIfEmpty speichert leere Strings als NULL (wie der Extraktor)
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// cmdGenFixture erzeugt die Fixture-Datenbank
+func cmdGenFixture(args []string) int {
+	opts := parseCLIOptions(args)
+	path := "fixture_schema.db"
+	if len(opts.positional) > 0 {
+		path = opts.positional[0]
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if !opts.force {
+			fail(exitUsage, "%s existiert bereits (--force zum Überschreiben)", path)
+		}
+		if err := os.Remove(path); err != nil {
+			fail(exitFailure, "%v", err)
+		}
+	}
+
+	if err := WriteFixture(path); err != nil {
+		fail(exitDBError, "Fixture erzeugen: %v", err)
+	}
+	if !quiet {
+		fmt.Printf("✓ Fixture-Datenbank geschrieben: %s\n", path)
+	}
+	return exitOK
+}
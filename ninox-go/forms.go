@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Formular-Layout: Felder, Layout-Elemente und Ansichten je Tabelle
+// =============================================================================
+
+// LayoutElement ist ein Element im Formular einer Tabelle oder eine Ansicht
+type LayoutElement struct {
+	DatabaseID string
+	TableID    string
+	ElementID  string
+	Caption    string
+	Type       string // Basistyp bzw. UI-Typ (button, tab, …), "view" für Ansichten
+	Source     string // field, ui oder view
+	Tab        string // Reiter, unter dem das Element liegt
+	Position   int    // Position im Formular (1-basiert, ohne Ansichten)
+}
+
+// elementTypeNames übersetzt häufige Element-Typen für die Anzeige
+var elementTypeNames = map[string]string{
+	"button": "Schaltfläche",
+	"tab":    "Reiter",
+	"line":   "Linie",
+	"text":   "Text",
+	"html":   "Text",
+	"view":   "Ansicht",
+	"ref":    "Verknüpfung",
+	"rev":    "Rückverknüpfung",
+	"string": "Text",
+	"number": "Zahl",
+	"date":   "Datum",
+	"choice": "Auswahl",
+	"email":  "E-Mail",
+}
+
+func elementTypeName(t string) string {
+	if name, ok := elementTypeNames[t]; ok {
+		return name
+	}
+	return t
+}
+
+// layoutKey identifiziert die Elemente einer Tabelle
+func layoutKey(databaseID, tableID string) string {
+	return databaseID + "/" + tableID
+}
+
+// HasLayout meldet, ob die Extraktion Layout-Elemente enthält (ältere nicht)
+func (db *NinoxDB) HasLayout() bool {
+	return len(db.tableColumns("layout_elements")) > 0
+}
+
+// GetLayoutElements lädt alle Layout-Elemente, gruppiert nach Tabelle in Formular-Reihenfolge
+func (db *NinoxDB) GetLayoutElements() (map[string][]LayoutElement, error) {
+	layout := make(map[string][]LayoutElement)
+	if !db.HasLayout() {
+		return layout, nil
+	}
+
+	ignore := db.ignoreFilter("database_id", "table_id", "")
+	rows, err := db.conn.Query(`
+		SELECT database_id, table_id, element_id, COALESCE(caption, element_id),
+			COALESCE(element_type, ''), source, COALESCE(tab_caption, '')
+		FROM layout_elements
+		WHERE 1 = 1` + ignore + `
+		ORDER BY database_id, table_id, source = 'view', sort_order, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e LayoutElement
+		if err := rows.Scan(&e.DatabaseID, &e.TableID, &e.ElementID, &e.Caption,
+			&e.Type, &e.Source, &e.Tab); err != nil {
+			return nil, err
+		}
+		k := layoutKey(e.DatabaseID, e.TableID)
+		if e.Source != "view" {
+			e.Position = len(layout[k]) + 1
+		}
+		layout[k] = append(layout[k], e)
+	}
+	return layout, rows.Err()
+}
+
+// scriptElement liefert das Layout-Element eines Scripts (über ID, sonst Name)
+func (m Model) scriptElement(s Script) *LayoutElement {
+	if s.ElementID == "" && s.ElementName == "" {
+		return nil
+	}
+	elements := m.layout[layoutKey(s.DatabaseID, s.TableID)]
+	for i := range elements {
+		if elements[i].ElementID == s.ElementID {
+			return &elements[i]
+		}
+	}
+	for i := range elements {
+		if elements[i].Caption == s.ElementName {
+			return &elements[i]
+		}
+	}
+	return nil
+}
+
+// elementContext beschreibt die Lage eines Scripts im Formular, z.B.
+// "Schaltfläche · Reiter „Allgemein“ · Position 5"
+func (m Model) elementContext(s Script) string {
+	e := m.scriptElement(s)
+	if e == nil {
+		return ""
+	}
+	parts := []string{elementTypeName(e.Type)}
+	if e.Tab != "" && e.Type != "tab" {
+		parts = append(parts, "Reiter „"+e.Tab+"“")
+	}
+	if e.Position > 0 {
+		parts = append(parts, fmt.Sprintf("Position %d", e.Position))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// layoutRow ist eine Zeile der Layout-Ansicht: ein Element oder eines seiner Scripts
+type layoutRow struct {
+	element *LayoutElement
+	script  *Script
+}
+
+// layoutRows baut die Zeilen für eine Tabelle: jedes Element gefolgt von seinen Scripts
+func (m Model) layoutRows(databaseID, tableID string) []layoutRow {
+	elements := m.layout[layoutKey(databaseID, tableID)]
+
+	scripts := make(map[*LayoutElement][]*Script)
+	for i := range m.allScripts {
+		s := &m.allScripts[i]
+		if s.DatabaseID != databaseID || s.TableID != tableID {
+			continue
+		}
+		if e := m.scriptElement(*s); e != nil {
+			scripts[e] = append(scripts[e], s)
+		}
+	}
+
+	var rows []layoutRow
+	for i := range elements {
+		e := &elements[i]
+		rows = append(rows, layoutRow{element: e})
+		attached := scripts[e]
+		sort.Slice(attached, func(a, b int) bool { return attached[a].CodeType < attached[b].CodeType })
+		for _, s := range attached {
+			rows = append(rows, layoutRow{element: e, script: s})
+		}
+	}
+	return rows
+}
+
+// openLayout zeigt das Formular-Layout der ausgewählten bzw. aktuellen Tabelle
+func (m Model) openLayout() (tea.Model, tea.Cmd) {
+	var table *Table
+	switch m.mode {
+	case viewTables:
+		if m.selectedTable < len(m.tables) {
+			table = &m.tables[m.selectedTable]
+		}
+	case viewFields, viewScripts:
+		table = m.currentTable
+	}
+	if table == nil {
+		return m, nil
+	}
+	if !m.db.HasLayout() {
+		m.status = "Extraktion ohne Layout-Elemente – bitte neu extrahieren"
+		return m, nil
+	}
+
+	m.layoutTable = table
+	m.layoutRowsCache = m.layoutRows(table.DatabaseID, table.TableID)
+	m.selectedLayout = 0
+	m.layoutReturn = m.mode
+	m.mode = viewLayout
+	return m, nil
+}
+
+// closeLayout kehrt zur Ansicht vor dem Öffnen zurück
+func (m Model) closeLayout() (tea.Model, tea.Cmd) {
+	m.mode = m.layoutReturn
+	m.prevMode = m.layoutReturn
+	return m, nil
+}
+
+// openLayoutScript zeigt das Script der ausgewählten Zeile
+func (m Model) openLayoutScript() (tea.Model, tea.Cmd) {
+	if m.selectedLayout >= len(m.layoutRowsCache) {
+		return m, nil
+	}
+	row := m.layoutRowsCache[m.selectedLayout]
+	if row.script == nil {
+		return m, nil
+	}
+	script := *row.script
+	m.codeScript = &script
+	m.codeView.SetContent(highlightCode(script.Code))
+	m.codeView.GotoTop()
+	m.prevMode = viewLayout
+	m.mode = viewCode
+	return m, nil
+}
+
+// renderLayout rendert die Elemente einer Tabelle in Formular-Reihenfolge
+func (m Model) renderLayout() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("🧩 Formular: "+m.layoutTable.Name) + "\n\n")
+
+	rows := m.layoutRowsCache
+	if len(rows) == 0 {
+		b.WriteString(mutedStyle.Render("  Keine Layout-Elemente für diese Tabelle extrahiert.") + "\n")
+		return boxStyle.Width(m.width - 4).Render(b.String())
+	}
+
+	header := fmt.Sprintf("  %4s  %-36s %-16s %s", "Pos", "Element", "Typ", "Scripts")
+	b.WriteString(tableHeaderStyle.Render(header) + "\n")
+
+	height := max(3, m.height-12)
+	start := 0
+	if m.selectedLayout >= height {
+		start = m.selectedLayout - height + 1
+	}
+	end := min(len(rows), start+height)
+
+	// Anzahl Scripts je Element für die Elementzeile
+	counts := make(map[*LayoutElement]int)
+	for _, r := range rows {
+		if r.script != nil {
+			counts[r.element]++
+		}
+	}
+
+	for i := start; i < end; i++ {
+		r := rows[i]
+		style := tableCellStyle
+		prefix := "  "
+		if i == m.selectedLayout {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+
+		var row string
+		if r.script != nil {
+			code := strings.TrimSpace(r.script.Code)
+			if j := strings.IndexByte(code, '\n'); j >= 0 {
+				code = code[:j] + " …"
+			}
+			row = fmt.Sprintf("%s%4s      ƒ %-14s %s", prefix, "", r.script.CodeType, truncate(code, max(10, m.width-36)))
+		} else {
+			e := r.element
+			pos := ""
+			if e.Position > 0 {
+				pos = fmt.Sprint(e.Position)
+			}
+			name := e.Caption
+			if e.Tab != "" && e.Type != "tab" {
+				name = "  " + name // unter dem Reiter eingerückt
+			}
+			if e.Type == "tab" {
+				name = "▸ " + name
+			}
+			row = fmt.Sprintf("%s%4s  %-36s %-16s %s", prefix, pos, truncate(name, 36),
+				truncate(elementTypeName(e.Type), 16), countBadge(counts[e]))
+		}
+		b.WriteString(style.Render(row) + "\n")
+	}
+
+	return boxStyle.Width(m.width - 4).Render(b.String())
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Häufigkeiten einer Spalte in der aktuellen Ergebnismenge (z.B. Typen:
+// trigger 60 %, formula 30 %); Enter filtert nach dem gewählten Wert
+// =============================================================================
+
+const maxFrequencyRows = 12
+
+// frequencyColumn ist eine Spalte der Script-Listen mit wiederkehrenden Werten
+type frequencyColumn struct {
+	name  string // wie in rowValues und den Sortierspalten
+	value func(Script) string
+}
+
+var frequencyColumns = []frequencyColumn{
+	{"Typ", func(s Script) string { return s.CodeType }},
+	{"Gruppe", func(s Script) string { return s.Group() }},
+	{"Tabelle", func(s Script) string { return s.TableName }},
+	{"Datenbank", func(s Script) string { return s.DatabaseName }},
+}
+
+// frequencyState ist der Zustand des Häufigkeits-Popups
+type frequencyState struct {
+	active   bool
+	column   int // Index in frequencyColumns
+	selected int
+}
+
+// valueCount ist ein Wert mit seiner Anzahl
+type valueCount struct {
+	value string
+	count int
+}
+
+// frequencyScripts liefert die Ergebnismenge der aktuellen Script-Liste
+func (m Model) frequencyScripts() ([]Script, bool) {
+	switch m.mode {
+	case viewScripts:
+		return m.scripts, true
+	case viewSearch:
+		return m.searchResults, true
+	case viewAllScripts:
+		return m.filteredScripts, true
+	}
+	return nil, false
+}
+
+// frequencies zählt die Werte der gewählten Spalte, häufigste zuerst
+func (m Model) frequencies() []valueCount {
+	scripts, _ := m.frequencyScripts()
+	value := frequencyColumns[m.frequency.column].value
+	counts := make(map[string]int)
+	for _, s := range scripts {
+		counts[value(s)]++
+	}
+	result := make([]valueCount, 0, len(counts))
+	for v, n := range counts {
+		result = append(result, valueCount{v, n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].count != result[j].count {
+			return result[i].count > result[j].count
+		}
+		return cmpText(result[i].value, result[j].value) < 0
+	})
+	return result
+}
+
+// openFrequency öffnet das Popup; die Spalte folgt der Sortierung, sofern sie Werte wiederholt
+func (m Model) openFrequency() (tea.Model, tea.Cmd) {
+	scripts, ok := m.frequencyScripts()
+	if !ok {
+		m.status = "Häufigkeiten: nur in Script-Listen (Tabelle, Suche, Gesamtansicht)"
+		return m, nil
+	}
+	if len(scripts) == 0 {
+		m.status = "Häufigkeiten: keine Scripts in der Liste"
+		return m, nil
+	}
+	m.frequency = frequencyState{active: true}
+	if pref, ok := m.sortPrefs[sortViewNames[m.mode]]; ok {
+		name := sortColumnName(m.mode, pref.Column)
+		for i, c := range frequencyColumns {
+			if c.name == name {
+				m.frequency.column = i
+			}
+		}
+	}
+	return m, nil
+}
+
+// handleFrequencyKey wechselt Spalte und Wert; Enter filtert nach dem Wert
+func (m Model) handleFrequencyKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := &m.frequency
+	values := m.frequencies()
+
+	switch msg.String() {
+	case "esc", "q", "H":
+		m.frequency = frequencyState{}
+	case "up", "k":
+		f.selected = max(0, f.selected-1)
+	case "down", "j":
+		f.selected = min(len(values)-1, f.selected+1)
+	case "left", "h", "shift+tab":
+		f.column = (f.column + len(frequencyColumns) - 1) % len(frequencyColumns)
+		f.selected = 0
+	case "right", "l", "tab":
+		f.column = (f.column + 1) % len(frequencyColumns)
+		f.selected = 0
+	case "enter":
+		m.frequency = frequencyState{}
+		if f.selected >= len(values) {
+			return m, nil
+		}
+		return m.filterByFrequency(frequencyColumns[f.column].name, values[f.selected].value)
+	}
+	return m, nil
+}
+
+// filterByFrequency grenzt die Ergebnismenge auf einen Wert ein. Wie bei "="
+// ist der Filter ein Textfilter; die Ausgangsmenge bleibt erhalten.
+func (m Model) filterByFrequency(column, value string) (tea.Model, tea.Cmd) {
+	if value == "" {
+		m.status = fmt.Sprintf("Nach leerem Wert (%s) kann nicht gefiltert werden", column)
+		return m, nil
+	}
+	switch m.mode {
+	case viewSearch:
+		m.filterBase = m.searchResults
+		m.filterBaseLabel = "🔍 " + m.searchInput.Value()
+	case viewScripts:
+		m.filterBase = m.scripts
+		if m.currentTable != nil {
+			m.filterBaseLabel = "▦ " + m.currentTable.Name
+		}
+	}
+	if m.mode != viewAllScripts {
+		m.filterStack = nil
+		m.prevMode = m.mode
+		m.mode = viewAllScripts
+	}
+	m.pushFilter(value)
+	m.status = fmt.Sprintf("Gefiltert nach %s: %s (%d Scripts)", column, value, len(m.filteredScripts))
+	return m, nil
+}
+
+// renderFrequency rendert das Popup mit Anteilen als Balken
+func (m Model) renderFrequency() string {
+	f := m.frequency
+	scripts, _ := m.frequencyScripts()
+	values := m.frequencies()
+
+	var tabs []string
+	for i, c := range frequencyColumns {
+		if i == f.column {
+			tabs = append(tabs, tableCellSelectedStyle.Render(" "+c.name+" "))
+		} else {
+			tabs = append(tabs, mutedStyle.Render(" "+c.name+" "))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Häufigkeiten in %d Scripts", len(scripts))) + "\n\n")
+	b.WriteString(strings.Join(tabs, " ") + "\n\n")
+
+	const barWidth = 20
+	start := max(0, min(f.selected-maxFrequencyRows/2, len(values)-maxFrequencyRows))
+	for i := start; i < min(len(values), start+maxFrequencyRows); i++ {
+		v := values[i]
+		share := float64(v.count) / float64(len(scripts))
+		label := v.value
+		if label == "" {
+			label = "(leer)"
+		}
+		style := tableCellStyle
+		prefix := "  "
+		if i == f.selected {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		bar := strings.Repeat("█", int(share*barWidth+0.5))
+		row := fmt.Sprintf("%s%-24s %5d %5.1f%% %-*s", prefix, truncate(label, 24), v.count, share*100, barWidth, bar)
+		b.WriteString(style.Render(row) + "\n")
+	}
+	if len(values) > maxFrequencyRows {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  … %d Werte", len(values))) + "\n")
+	}
+	b.WriteString("\n" + mutedStyle.Render("←→ Spalte  ↑↓ Wert  Enter filtern  Esc schließen"))
+
+	return statsBoxStyle.Width(lipgloss.Width(b.String()) + 4).Render(b.String())
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"net/mail"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Versionsgeschichte der Scripts als Git-Repository: je Stand des Verlaufs
+// (Extraktion bzw. Import) ein Commit mit dem Script-Baum von export-scripts
+// =============================================================================
+
+// gitSnapshotTrailer kennzeichnet den exportierten Stand in der Commit-Nachricht;
+// ein weiterer Lauf setzt nach dem zuletzt exportierten Stand fort
+const gitSnapshotTrailer = "Ninox-Stand:"
+
+// defaultGitMessage ist die Commit-Nachricht ohne --message bzw. git_message
+const defaultGitMessage = "Ninox-Extraktion vom {date} ({source})"
+
+// gitExport schreibt Stände als Commits in ein Arbeitsverzeichnis
+type gitExport struct {
+	dir       string
+	author    *mail.Address   // nil = Git-Konfiguration
+	message   string          // Vorlage mit {date}, {source} und {snapshot}
+	databases map[string]bool // Datenbank-IDs; nil = alle
+}
+
+// git führt einen Git-Befehl im Arbeitsverzeichnis aus
+func (g *gitExport) git(env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", g.dir}, args...)...)
+	cmd.Env = append(os.Environ(), env...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// Die letzte Zeile enthält bei Git die eigentliche Ursache
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg[strings.LastIndex(msg, "\n")+1:])
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// init legt das Repository an, falls dir noch keines ist
+func (g *gitExport) init() error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git nicht gefunden")
+	}
+	if err := os.MkdirAll(g.dir, 0o755); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(g.dir, ".git")); err == nil {
+		return nil
+	}
+	_, err := g.git(nil, "init", "-q")
+	return err
+}
+
+// lastSnapshot liefert den zuletzt exportierten Stand (0 = keiner)
+func (g *gitExport) lastSnapshot() int {
+	out, err := g.git(nil, "log", "-1", "--format=%B", "--grep=^"+gitSnapshotTrailer)
+	if err != nil {
+		return 0 // noch keine Commits
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if value, ok := strings.CutPrefix(line, gitSnapshotTrailer); ok {
+			id, _ := strconv.Atoi(strings.TrimSpace(value))
+			return id
+		}
+	}
+	return 0
+}
+
+// writeTree ersetzt die exportierten Scripts im Arbeitsverzeichnis;
+// andere Dateien (README, .gitignore …) bleiben erhalten
+func (g *gitExport) writeTree(scripts []Script) error {
+	err := filepath.WalkDir(g.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && isScriptFile(path) {
+			return os.Remove(path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var selected []Script
+	for _, s := range scripts {
+		if g.databases == nil || g.databases[s.DatabaseID] {
+			selected = append(selected, s)
+		}
+	}
+	// Feste Reihenfolge, damit gleichnamige Scripts bei jedem Stand denselben Zusatz erhalten
+	sort.Slice(selected, func(i, j int) bool {
+		a, b := selected[i], selected[j]
+		if pa, pb := scriptFilePath(a), scriptFilePath(b); pa != pb {
+			return pa < pb
+		}
+		return a.ElementID < b.ElementID
+	})
+	for i := range selected {
+		selected[i].ID = i + 1
+	}
+	_, err = ExportScripts(g.dir, selected, false)
+	return err
+}
+
+// commit übernimmt den Baum als Commit; ohne Änderungen wird nichts committet
+func (g *gitExport) commit(info snapshotInfo) (bool, error) {
+	if _, err := g.git(nil, "add", "-A"); err != nil {
+		return false, err
+	}
+	if status, err := g.git(nil, "status", "--porcelain"); err != nil || status == "" {
+		return false, err
+	}
+
+	var env []string
+	date := info.TakenAt
+	if t, ok := snapshotTime(info.TakenAt); ok {
+		date = t.Format("2006-01-02 15:04")
+		stamp := t.Format("2006-01-02 15:04:05 -0700")
+		env = append(env, "GIT_AUTHOR_DATE="+stamp, "GIT_COMMITTER_DATE="+stamp)
+	}
+	if g.author != nil {
+		env = append(env, "GIT_AUTHOR_NAME="+g.author.Name, "GIT_AUTHOR_EMAIL="+g.author.Address,
+			"GIT_COMMITTER_NAME="+g.author.Name, "GIT_COMMITTER_EMAIL="+g.author.Address)
+	}
+
+	message := strings.NewReplacer(
+		"{date}", date,
+		"{source}", info.Source,
+		"{snapshot}", strconv.Itoa(info.ID),
+	).Replace(g.message)
+	if info.ID > 0 {
+		message += fmt.Sprintf("\n\n%s %d", gitSnapshotTrailer, info.ID)
+	}
+	if _, err := g.git(env, "commit", "-q", "-m", message); err != nil {
+		if g.author == nil {
+			return false, fmt.Errorf("%w (Autor mit --author oder git_author angeben)", err)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// snapshotTime liest den Zeitpunkt eines Standes (UTC aus SQLite bzw. RFC 3339 der API)
+func snapshotTime(takenAt string) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02 15:04:05", time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, takenAt); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ExportGitHistory schreibt alle noch nicht exportierten Stände des Verlaufs als
+// Commits. Ohne Verlauf wird der aktuelle Stand der Extraktion committet.
+// Rückgabe: Zahl der Commits und der übersprungenen Stände ohne Änderungen.
+func ExportGitHistory(db *NinoxDB, g *gitExport) (commits, unchanged int, err error) {
+	if err := g.init(); err != nil {
+		return 0, 0, err
+	}
+
+	snapshots, err := db.ListSnapshots()
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(snapshots) == 0 {
+		scripts, err := db.GetAllScripts()
+		if err != nil {
+			return 0, 0, err
+		}
+		if err := g.writeTree(scripts); err != nil {
+			return 0, 0, err
+		}
+		extractedAt, _ := db.GetLatestExtractionDate()
+		ok, err := g.commit(snapshotInfo{TakenAt: extractedAt, Source: filepath.Base(db.path)})
+		if ok {
+			commits++
+		}
+		return commits, 0, err
+	}
+
+	last := g.lastSnapshot()
+	for _, info := range snapshots {
+		if info.ID <= last {
+			continue
+		}
+		stored, err := db.loadStoredSnapshot(info.ID)
+		if err != nil {
+			return commits, unchanged, fmt.Errorf("%s: %w", info.Label(), err)
+		}
+		scripts := make([]Script, 0, len(stored.scripts))
+		for _, s := range stored.scripts {
+			scripts = append(scripts, s)
+		}
+		if err := g.writeTree(scripts); err != nil {
+			return commits, unchanged, err
+		}
+		ok, err := g.commit(info)
+		if err != nil {
+			return commits, unchanged, fmt.Errorf("%s: %w", info.Label(), err)
+		}
+		if ok {
+			commits++
+		} else {
+			unchanged++
+		}
+	}
+	return commits, unchanged, nil
+}
+
+// cmdGitExport schreibt die Versionsgeschichte der Scripts in ein Git-Repository
+func cmdGitExport(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) != 1 {
+		fail(exitUsage, "Verwendung: ninox-tui git-export VERZEICHNIS [--author \"Name <mail>\"] [--message TEXT] [--name-template VORLAGE] [--database NAME] [--db DATEI]")
+	}
+
+	author, message := opts.author, opts.message
+	if cfg, err := LoadConfig(activeConfigPath()); err == nil {
+		author = firstNonEmpty(author, cfg.GitAuthor)
+		message = firstNonEmpty(message, cfg.GitMessage)
+	}
+	g := &gitExport{dir: opts.positional[0], message: firstNonEmpty(message, defaultGitMessage)}
+	if author != "" {
+		addr, err := mail.ParseAddress(author)
+		if err != nil {
+			fail(exitUsage, "Ungültiger Autor %q (erwartet: \"Name <mail>\")", author)
+		}
+		g.author = addr
+	}
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	applyNameTemplate(opts)
+
+	if opts.database != "" {
+		databases, err := selectDatabases(db, opts.database)
+		if err != nil {
+			fail(exitNoResults, "%v", err)
+		}
+		g.databases = make(map[string]bool)
+		for _, d := range databases {
+			g.databases[d.ID] = true
+		}
+	}
+
+	commits, unchanged, err := ExportGitHistory(db, g)
+	if err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	if !quiet {
+		switch {
+		case commits == 0:
+			fmt.Printf("Keine neuen Stände für %s\n", g.dir)
+		case unchanged > 0:
+			fmt.Printf("✓ %d Commits in %s (%d Stände ohne Script-Änderungen)\n", commits, g.dir, unchanged)
+		default:
+			fmt.Printf("✓ %d Commits in %s\n", commits, g.dir)
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// =============================================================================
+// graph: Verknüpfungsgraph der Tabellen als Graphviz-DOT (dot, neato, sfdp)
+// oder Mermaid; DOT bleibt auch bei großen Schemas lesbar
+// =============================================================================
+
+// graphFormats sind die Ausgabeformate von graph --format
+var graphFormats = map[string]func(db *NinoxDB, databases []Database, table string) (string, error){
+	"dot":     DotGraph,
+	"mermaid": MermaidER,
+}
+
+// Kantenstile: Kompositionen fett mit Raute am Eltern-Ende
+const (
+	dotEdgeStyle        = `color="#555555"`
+	dotCompositionStyle = `color="#b5651d", style=bold, arrowhead=diamond`
+)
+
+// tableGraph enthält die Tabellen und Verknüpfungen einer Datenbank für die Diagramme
+type tableGraph struct {
+	tables []Table // in der Reihenfolge von GetTables, nur die angezeigten
+	edges  []Relationship
+}
+
+// loadTableGraph lädt den Graphen einer Datenbank. Mit table nur die Tabelle und
+// ihre direkten Nachbarn (eingehende und ausgehende Verknüpfungen); nil, wenn
+// die Tabelle in dieser Datenbank fehlt.
+func loadTableGraph(db *NinoxDB, d Database, table string) (*tableGraph, error) {
+	tables, err := db.GetTables(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	rels, err := db.GetDatabaseRelationships(d.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		known[t.Name] = true
+	}
+	shown := make(map[string]bool)
+	g := &tableGraph{}
+	for _, r := range rels {
+		if table != "" && !strings.EqualFold(r.SourceTableName, table) && !strings.EqualFold(r.TargetTableName, table) {
+			continue
+		}
+		// Ziele in anderen Datenbanken fehlen im Diagramm (wie bei draw.io)
+		if !known[r.SourceTableName] || !known[r.TargetTableName] {
+			continue
+		}
+		g.edges = append(g.edges, r)
+		shown[r.SourceTableName] = true
+		shown[r.TargetTableName] = true
+	}
+	for _, t := range tables {
+		if table == "" || strings.EqualFold(t.Name, table) {
+			shown[t.Name] = true
+		}
+	}
+	if len(shown) == 0 {
+		return nil, nil
+	}
+	for _, t := range tables {
+		if shown[t.Name] {
+			g.tables = append(g.tables, t)
+		}
+	}
+	return g, nil
+}
+
+// dotQuote setzt einen Bezeichner oder eine Beschriftung in Anführungszeichen
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// DotGraph erzeugt einen gerichteten Graphen: je Datenbank ein Cluster, Kanten
+// von der verweisenden zur referenzierten Tabelle, beschriftet mit dem Feld
+func DotGraph(db *NinoxDB, databases []Database, table string) (string, error) {
+	var b strings.Builder
+	b.WriteString("digraph ninox {\n")
+	b.WriteString("    graph [rankdir=LR, fontname=\"Helvetica\", overlap=false, splines=true];\n")
+	b.WriteString("    node [shape=box, style=\"rounded,filled\", fillcolor=\"#eef3fb\", fontname=\"Helvetica\"];\n")
+	b.WriteString("    edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	found := false
+	for i, d := range databases {
+		g, err := loadTableGraph(db, d, table)
+		if err != nil {
+			return "", fmt.Errorf("Datenbank %s: %w", d.Name, err)
+		}
+		if g == nil {
+			continue
+		}
+		found = true
+
+		// Knoten-IDs enthalten die Datenbank, da Tabellennamen nur je Datenbank eindeutig sind
+		id := func(name string) string { return dotQuote(d.Name + "/" + name) }
+		fmt.Fprintf(&b, "\n    subgraph cluster_%d {\n        label=%s;\n        style=dashed;\n", i, dotQuote(d.Name))
+		for _, t := range g.tables {
+			label := fmt.Sprintf("%s\n%s Felder", t.Name, formatCount(t.FieldCount))
+			fmt.Fprintf(&b, "        %s [label=%s];\n", id(t.Name), dotQuote(label))
+		}
+		for _, r := range g.edges {
+			style := dotEdgeStyle
+			if r.IsComposition {
+				style = dotCompositionStyle
+			}
+			fmt.Fprintf(&b, "        %s -> %s [label=%s, %s];\n",
+				id(r.SourceTableName), id(r.TargetTableName), dotQuote(r.SourceFieldName), style)
+		}
+		b.WriteString("    }\n")
+	}
+	b.WriteString("}\n")
+	if !found {
+		return "", nil
+	}
+	return b.String(), nil
+}
+
+// cmdGraph gibt den Verknüpfungsgraphen aus (ohne DATEI auf stdout)
+func cmdGraph(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) > 1 {
+		fail(exitUsage, "Verwendung: ninox-tui graph [DATEI] [--format dot|mermaid] [--database NAME] [--table NAME] [--db DATEI]")
+	}
+	format := firstNonEmpty(opts.format, "dot")
+	build, ok := graphFormats[format]
+	if !ok {
+		fail(exitUsage, "Unbekanntes Format: %s (erlaubt: dot, mermaid)", format)
+	}
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	databases, err := selectDatabases(db, opts.database)
+	if err != nil {
+		fail(exitNoResults, "%v", err)
+	}
+	graph, err := build(db, databases, opts.table)
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+	if graph == "" {
+		fail(exitNoResults, "Tabelle %s nicht gefunden", opts.table)
+	}
+
+	if len(opts.positional) == 0 || opts.positional[0] == "-" {
+		fmt.Print(graph)
+		return exitOK
+	}
+	path := opts.positional[0]
+	if err := os.WriteFile(path, []byte(graph), 0o644); err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	if !quiet {
+		fmt.Printf("✓ Graph geschrieben: %s\n", path)
+	}
+	return exitOK
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Verlauf: jede Extraktion bzw. jeder Import wird als Stand in der Schema-DB
+// gespeichert (snapshots, snapshot_scripts, snapshot_fields, Code je Hash in
+// snapshot_code, Namen in snapshot_tables). Die TUI zeigt damit frühere Fassungen
+// eines Scripts oder Feldes.
+// =============================================================================
+
+// snapshotPrevious ist die Quelle eines nachträglich gesicherten Standes
+// (Datei ohne Verlauf, z.B. vom Python-Extraktor)
+const snapshotPrevious = "vorherige Extraktion"
+
+const maxHistoryRows = 12
+
+// recordSnapshot speichert Scripts und Felder des Schemas schema ("main" bzw. "prev")
+// samt Tabellennamen als Stand. Ist der Inhalt gleich dem letzten Stand, wird nichts gespeichert.
+// takenAt "" heißt jetzt.
+func recordSnapshot(tx *sql.Tx, schema, source, takenAt string) (bool, error) {
+	type scriptRow struct{ databaseID, tableID, elementID, codeType, elementName, code, hash string }
+	type fieldRow struct {
+		databaseID, tableID, fieldID, name, baseType, refTable string
+		hasFormula                                             int
+	}
+	type tableRow struct{ databaseID, databaseName, tableID, name string }
+
+	var scripts []scriptRow
+	rows, err := tx.Query(`SELECT database_id, COALESCE(table_id, ''), COALESCE(element_id, ''), code_type,
+			COALESCE(element_name, ''), COALESCE(code, '')
+		FROM ` + schema + `.scripts`)
+	if err != nil {
+		return false, err
+	}
+	for rows.Next() {
+		var s scriptRow
+		if err := rows.Scan(&s.databaseID, &s.tableID, &s.elementID, &s.codeType, &s.elementName, &s.code); err != nil {
+			rows.Close()
+			return false, err
+		}
+		s.hash = scriptHash(s.code)
+		scripts = append(scripts, s)
+	}
+	rows.Close()
+
+	var fields []fieldRow
+	rows, err = tx.Query(`SELECT database_id, table_id, field_id, COALESCE(name, ''), COALESCE(base_type, ''),
+			COALESCE(ref_table_name, ''), COALESCE(has_formula, 0)
+		FROM ` + schema + `.fields`)
+	if err != nil {
+		return false, err
+	}
+	for rows.Next() {
+		var f fieldRow
+		if err := rows.Scan(&f.databaseID, &f.tableID, &f.fieldID, &f.name, &f.baseType, &f.refTable, &f.hasFormula); err != nil {
+			rows.Close()
+			return false, err
+		}
+		fields = append(fields, f)
+	}
+	rows.Close()
+
+	var tables []tableRow
+	rows, err = tx.Query(`SELECT t.database_id, COALESCE(d.name, ''), t.table_id, COALESCE(t.name, '')
+		FROM ` + schema + `.tables t LEFT JOIN ` + schema + `.databases d ON d.id = t.database_id`)
+	if err != nil {
+		return false, err
+	}
+	for rows.Next() {
+		var t tableRow
+		if err := rows.Scan(&t.databaseID, &t.databaseName, &t.tableID, &t.name); err != nil {
+			rows.Close()
+			return false, err
+		}
+		tables = append(tables, t)
+	}
+	rows.Close()
+
+	// Fingerabdruck unabhängig von der Reihenfolge der Zeilen
+	var lines []string
+	for _, t := range tables {
+		lines = append(lines, strings.Join([]string{"t", t.databaseID, t.databaseName, t.tableID, t.name}, "\x00"))
+	}
+	for _, s := range scripts {
+		lines = append(lines, strings.Join([]string{"s", s.databaseID, s.tableID, s.elementID, s.codeType, s.elementName, s.hash}, "\x00"))
+	}
+	for _, f := range fields {
+		lines = append(lines, strings.Join([]string{"f", f.databaseID, f.tableID, f.fieldID, f.name, f.baseType, f.refTable, fmt.Sprint(f.hasFormula)}, "\x00"))
+	}
+	sort.Strings(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	fingerprint := hex.EncodeToString(sum[:])
+
+	var last sql.NullString
+	err = tx.QueryRow(`SELECT fingerprint FROM main.snapshots ORDER BY id DESC LIMIT 1`).Scan(&last)
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	if last.Valid && last.String == fingerprint {
+		return false, nil
+	}
+
+	res, err := tx.Exec(`INSERT INTO main.snapshots (taken_at, source, fingerprint)
+		VALUES (COALESCE(NULLIF(?, ''), CURRENT_TIMESTAMP), ?, ?)`, takenAt, source, fingerprint)
+	if err != nil {
+		return false, err
+	}
+	id, _ := res.LastInsertId()
+	for _, s := range scripts {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO main.snapshot_code (hash, code) VALUES (?, ?)`, s.hash, s.code); err != nil {
+			return false, err
+		}
+		_, err := tx.Exec(`INSERT INTO main.snapshot_scripts (snapshot_id, database_id, table_id, element_id, code_type,
+				element_name, code_hash)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`, id, s.databaseID, s.tableID, s.elementID, s.codeType, s.elementName, s.hash)
+		if err != nil {
+			return false, err
+		}
+	}
+	for _, t := range tables {
+		_, err := tx.Exec(`INSERT INTO main.snapshot_tables (snapshot_id, database_id, database_name, table_id, name)
+			VALUES (?, ?, ?, ?, ?)`, id, t.databaseID, t.databaseName, t.tableID, t.name)
+		if err != nil {
+			return false, err
+		}
+	}
+	for _, f := range fields {
+		_, err := tx.Exec(`INSERT INTO main.snapshot_fields (snapshot_id, database_id, table_id, field_id, name,
+				base_type, ref_table_name, has_formula)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, id, f.databaseID, f.tableID, f.fieldID, f.name, f.baseType, f.refTable, f.hasFormula)
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// attachedTable meldet, ob die angehängte Datei prev eine Tabelle enthält
+func attachedTable(tx *sql.Tx, name string) bool {
+	var n int
+	tx.QueryRow(`SELECT COUNT(*) FROM prev.sqlite_master WHERE type = 'table' AND name = ?`, name).Scan(&n)
+	return n > 0
+}
+
+// carryHistory übernimmt den Verlauf der bisherigen Datei (angehängt als prev).
+// Hat sie keinen, wird ihr Inhalt als erster Stand gesichert.
+func carryHistory(tx *sql.Tx) error {
+	if attachedTable(tx, "snapshots") {
+		for _, stmt := range []string{
+			`INSERT INTO main.snapshots (id, taken_at, source, fingerprint) SELECT id, taken_at, source, fingerprint FROM prev.snapshots`,
+			`INSERT OR IGNORE INTO main.snapshot_code (hash, code) SELECT hash, code FROM prev.snapshot_code`,
+			`INSERT INTO main.snapshot_scripts SELECT snapshot_id, database_id, table_id, element_id, code_type,
+				element_name, code_hash FROM prev.snapshot_scripts`,
+			`INSERT INTO main.snapshot_fields SELECT snapshot_id, database_id, table_id, field_id, name,
+				base_type, ref_table_name, has_formula FROM prev.snapshot_fields`,
+		} {
+			if _, err := tx.Exec(stmt); err != nil {
+				return fmt.Errorf("Verlauf übernehmen: %w", err)
+			}
+		}
+		// Tabellennamen gibt es erst in neueren Verläufen
+		if attachedTable(tx, "snapshot_tables") {
+			_, err := tx.Exec(`INSERT INTO main.snapshot_tables SELECT snapshot_id, database_id, database_name,
+				table_id, name FROM prev.snapshot_tables`)
+			if err != nil {
+				return fmt.Errorf("Verlauf übernehmen: %w", err)
+			}
+		}
+		return nil
+	}
+	for _, name := range []string{"databases", "tables", "fields", "scripts"} {
+		if !attachedTable(tx, name) {
+			return nil
+		}
+	}
+	var extractedAt sql.NullString
+	tx.QueryRow(`SELECT MAX(extracted_at) FROM prev.databases`).Scan(&extractedAt)
+	if _, err := recordSnapshot(tx, "prev", snapshotPrevious, extractedAt.String); err != nil {
+		return fmt.Errorf("Bisherigen Stand sichern: %w", err)
+	}
+	return nil
+}
+
+// --- Abfragen für die TUI ---
+
+// historyVersion ist eine Fassung eines Scripts oder Feldes; sie gilt ab takenAt
+// bis zum nächsten Stand mit anderem Inhalt
+type historyVersion struct {
+	takenAt string
+	source  string
+	missing bool   // in diesem Stand nicht vorhanden (gelöscht)
+	summary string // Felder: Name, Typ, Verweis, Formel
+	code    string // Scripts
+}
+
+// HasHistory meldet, ob die Extraktion einen Verlauf enthält
+func (db *NinoxDB) HasHistory() bool {
+	return len(db.tableColumns("snapshot_scripts")) > 0
+}
+
+// collapseHistory fasst aufeinanderfolgende gleiche Fassungen zusammen (neueste zuerst).
+// Stände vor dem ersten Auftreten entfallen.
+func collapseHistory(versions []historyVersion, key func(historyVersion) string) []historyVersion {
+	var result []historyVersion
+	for _, v := range versions {
+		if len(result) == 0 && v.missing {
+			continue
+		}
+		if len(result) > 0 && key(result[len(result)-1]) == key(v) {
+			continue
+		}
+		result = append(result, v)
+	}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
+
+// ScriptHistory lädt die Fassungen eines Scripts über alle Stände
+func (db *NinoxDB) ScriptHistory(s Script) ([]historyVersion, error) {
+	rows, err := db.conn.Query(`
+		SELECT sn.taken_at, sn.source, COALESCE(c.code, ''), ss.code_hash IS NULL
+		FROM snapshots sn
+		LEFT JOIN snapshot_scripts ss ON ss.snapshot_id = sn.id AND ss.database_id = ?
+			AND ss.table_id = ? AND ss.element_id = ? AND ss.code_type = ?
+		LEFT JOIN snapshot_code c ON c.hash = ss.code_hash
+		ORDER BY sn.id
+	`, s.DatabaseID, s.TableID, s.ElementID, s.CodeType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []historyVersion
+	for rows.Next() {
+		var v historyVersion
+		var takenAt sql.NullString
+		if err := rows.Scan(&takenAt, &v.source, &v.code, &v.missing); err != nil {
+			return nil, err
+		}
+		v.takenAt = takenAt.String
+		versions = append(versions, v)
+	}
+	return collapseHistory(versions, func(v historyVersion) string {
+		return fmt.Sprint(v.missing, v.code)
+	}), rows.Err()
+}
+
+// FieldHistory lädt die Fassungen eines Feldes über alle Stände
+func (db *NinoxDB) FieldHistory(f Field) ([]historyVersion, error) {
+	rows, err := db.conn.Query(`
+		SELECT sn.taken_at, sn.source, sf.field_id IS NULL, COALESCE(sf.name, ''), COALESCE(sf.base_type, ''),
+		       COALESCE(sf.ref_table_name, ''), COALESCE(sf.has_formula, 0)
+		FROM snapshots sn
+		LEFT JOIN snapshot_fields sf ON sf.snapshot_id = sn.id AND sf.database_id = ?
+			AND sf.table_id = ? AND sf.field_id = ?
+		ORDER BY sn.id
+	`, f.DatabaseID, f.TableID, f.FieldID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []historyVersion
+	for rows.Next() {
+		var v historyVersion
+		var takenAt sql.NullString
+		var name, baseType, ref string
+		var formula bool
+		if err := rows.Scan(&takenAt, &v.source, &v.missing, &name, &baseType, &ref, &formula); err != nil {
+			return nil, err
+		}
+		v.takenAt = takenAt.String
+		v.summary = name + " · " + baseType
+		if ref != "" {
+			v.summary += " → " + ref
+		}
+		if formula {
+			v.summary += " · Formel"
+		}
+		versions = append(versions, v)
+	}
+	return collapseHistory(versions, func(v historyVersion) string {
+		return fmt.Sprint(v.missing, v.summary)
+	}), rows.Err()
+}
+
+// --- Popup ---
+
+// historyState ist der Zustand des Verlaufs-Popups
+type historyState struct {
+	active   bool
+	title    string
+	script   *Script // nil = Feld
+	versions []historyVersion
+	selected int
+}
+
+// openHistory zeigt den Verlauf des ausgewählten Scripts bzw. Feldes
+func (m Model) openHistory() (tea.Model, tea.Cmd) {
+	if !m.db.HasHistory() {
+		m.status = "Kein Verlauf: erst mit ninox-tui extract bzw. import-archive erneut extrahieren"
+		return m, nil
+	}
+
+	var h historyState
+	var err error
+	switch s := m.activeScript(); {
+	case s != nil:
+		script := *s
+		h.script = &script
+		h.title = scriptLabel(script)
+		h.versions, err = m.db.ScriptHistory(script)
+	case m.mode == viewFields && m.selectedField < len(m.fields):
+		f := m.fields[m.selectedField]
+		h.title = "Feld " + firstNonEmpty(f.Caption, f.Name)
+		h.versions, err = m.db.FieldHistory(f)
+	default:
+		m.status = "Verlauf: ein Script oder Feld auswählen"
+		return m, nil
+	}
+	if err != nil {
+		m.status = fmt.Sprintf("⚠ Verlauf: %v", err)
+		return m, nil
+	}
+	if len(h.versions) == 0 {
+		m.status = "Verlauf: in keinem gespeicherten Stand enthalten"
+		return m, nil
+	}
+	h.active = true
+	m.history = h
+	return m, nil
+}
+
+// handleHistoryKey wählt eine Fassung; Enter vergleicht sie mit der aktuellen
+func (m Model) handleHistoryKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	h := &m.history
+	switch msg.String() {
+	case "esc", "q", "t":
+		m.history = historyState{}
+	case "up", "k":
+		h.selected = max(0, h.selected-1)
+	case "down", "j":
+		h.selected = min(len(h.versions)-1, h.selected+1)
+	case "enter":
+		if h.script == nil {
+			return m, nil
+		}
+		v := h.versions[h.selected]
+		s := *h.script
+		title := fmt.Sprintf("%s: Stand %s → aktuell", h.title, v.takenAt)
+		m.history = historyState{}
+		m.openDiff(title, UnifiedDiff("Stand "+v.takenAt, "aktuell", v.code, s.Code, diffContext))
+	}
+	return m, nil
+}
+
+// renderHistory rendert die Fassungen, die neueste zuerst
+func (m Model) renderHistory() string {
+	h := m.history
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("🕘 Verlauf: "+truncate(h.title, 50)) + "\n\n")
+
+	start := max(0, min(h.selected-maxHistoryRows/2, len(h.versions)-maxHistoryRows))
+	for i := start; i < min(len(h.versions), start+maxHistoryRows); i++ {
+		v := h.versions[i]
+		style := tableCellStyle
+		prefix := "  "
+		if i == h.selected {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		var detail string
+		switch {
+		case v.missing:
+			detail = "(gelöscht)"
+		case h.script != nil:
+			lines := len(splitLines(v.code))
+			detail = fmt.Sprintf("%d Zeilen", lines)
+			if i == 0 && v.code == h.script.Code {
+				detail += " (aktuell)"
+			} else {
+				detail += fmt.Sprintf(", %d geändert", changedLines(v.code, h.script.Code))
+			}
+		default:
+			detail = truncate(v.summary, 50)
+		}
+		row := fmt.Sprintf("%s%-19s %-20s %s", prefix, truncate(v.takenAt, 19), truncate(v.source, 20), detail)
+		b.WriteString(style.Render(row) + "\n")
+	}
+
+	hint := "↑↓ Auswahl  Esc schließen"
+	if h.script != nil {
+		hint = "↑↓ Auswahl  Enter mit aktuellem Code vergleichen  Esc schließen"
+	}
+	b.WriteString("\n" + mutedStyle.Render(hint))
+	return statsBoxStyle.Width(lipgloss.Width(b.String()) + 4).Render(b.String())
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Bezeichner-Index: Variablen und Feldnamen aus dem Code mit ihrer Häufigkeit.
+// Rein lexikalisch (Tokens wie beim Lint), ohne Auflösung gegen das Schema.
+// =============================================================================
+
+// identKeywords sind Schlüsselwörter von Ninox-Script, keine Bezeichner
+var identKeywords = map[string]bool{
+	"and": true, "or": true, "not": true, "if": true, "then": true, "else": true,
+	"end": true, "for": true, "in": true, "do": true, "while": true, "let": true,
+	"var": true, "function": true, "select": true, "where": true, "order": true,
+	"by": true, "from": true, "to": true, "switch": true, "case": true,
+	"default": true, "true": true, "false": true, "null": true, "this": true,
+	"return": true, "break": true, "continue": true, "as": true, "database": true,
+	"user": true, "server": true, "transaction": true,
+}
+
+// identUse ist das Vorkommen eines Bezeichners in einem Script
+type identUse struct {
+	Script *Script
+	Count  int
+	Line   int // erstes Vorkommen
+}
+
+// identEntry ist ein Bezeichner mit allen Scripts, die ihn verwenden
+type identEntry struct {
+	Name  string
+	Count int
+	Uses  []identUse // häufigste zuerst
+}
+
+// buildIdentIndex sammelt die Bezeichner der Scripts. Aufrufe (Name vor "(")
+// und Schlüsselwörter zählen nicht; 'Feld mit Leerzeichen' zählt als ein Name.
+func buildIdentIndex(scripts []Script) []identEntry {
+	index := make(map[string]*identEntry)
+	for i := range scripts {
+		s := &scripts[i]
+		uses := make(map[string]*identUse)
+		tokens := lintTokens(s.Code)
+		for j, t := range tokens {
+			if !t.quoted && (!isWordToken(t.text) || identKeywords[t.text]) {
+				continue
+			}
+			if !t.quoted && j+1 < len(tokens) && tokens[j+1].text == "(" {
+				continue
+			}
+			name := strings.TrimSpace(t.text)
+			if name == "" {
+				continue
+			}
+			if u, ok := uses[name]; ok {
+				u.Count++
+				continue
+			}
+			uses[name] = &identUse{Script: s, Count: 1, Line: t.line}
+		}
+		for name, u := range uses {
+			e, ok := index[name]
+			if !ok {
+				e = &identEntry{Name: name}
+				index[name] = e
+			}
+			e.Count += u.Count
+			e.Uses = append(e.Uses, *u)
+		}
+	}
+
+	entries := make([]identEntry, 0, len(index))
+	for _, e := range index {
+		sort.SliceStable(e.Uses, func(a, b int) bool {
+			if e.Uses[a].Count != e.Uses[b].Count {
+				return e.Uses[a].Count > e.Uses[b].Count
+			}
+			return scriptLabel(*e.Uses[a].Script) < scriptLabel(*e.Uses[b].Script)
+		})
+		entries = append(entries, *e)
+	}
+	sortIdentEntries(entries, false)
+	return entries
+}
+
+// sortIdentEntries sortiert nach Häufigkeit bzw. alphabetisch
+func sortIdentEntries(entries []identEntry, byName bool) {
+	sort.Slice(entries, func(a, b int) bool {
+		if !byName && entries[a].Count != entries[b].Count {
+			return entries[a].Count > entries[b].Count
+		}
+		return strings.ToLower(entries[a].Name) < strings.ToLower(entries[b].Name)
+	})
+}
+
+// visibleIdents liefert die Bezeichner passend zum Filter
+func (m Model) visibleIdents() []identEntry {
+	filter := strings.ToLower(strings.TrimSpace(m.identInput.Value()))
+	if filter == "" {
+		return m.identRows
+	}
+	var rows []identEntry
+	for _, e := range m.identRows {
+		if strings.Contains(strings.ToLower(e.Name), filter) {
+			rows = append(rows, e)
+		}
+	}
+	return rows
+}
+
+// openIdentifiers baut den Index für die aktuelle Datenbank (sonst alle) und öffnet ihn
+func (m Model) openIdentifiers() (tea.Model, tea.Cmd) {
+	if m.mode == viewIdentifiers {
+		return m, nil
+	}
+	scripts := m.allScripts
+	m.identScope = "alle Datenbanken"
+	if m.currentDB != nil {
+		scripts = nil
+		for _, s := range m.allScripts {
+			if s.DatabaseID == m.currentDB.ID {
+				scripts = append(scripts, s)
+			}
+		}
+		m.identScope = m.currentDB.Name
+	}
+
+	m.identRows = buildIdentIndex(scripts)
+	sortIdentEntries(m.identRows, m.identByName)
+	m.identOpen = nil
+	m.selectedIdent = 0
+	m.identReturn = m.mode
+	m.mode = viewIdentifiers
+	return m, nil
+}
+
+// closeIdentifiers verlässt erst die Script-Liste eines Bezeichners, dann die Ansicht
+func (m Model) closeIdentifiers() (tea.Model, tea.Cmd) {
+	if m.identOpen != nil {
+		m.identOpen = nil
+		return m, nil
+	}
+	m.mode = m.identReturn
+	m.prevMode = m.identReturn
+	return m, nil
+}
+
+// toggleIdentSort wechselt zwischen Häufigkeit und Name
+func (m Model) toggleIdentSort() (tea.Model, tea.Cmd) {
+	if m.identOpen != nil {
+		return m, nil
+	}
+	m.identByName = !m.identByName
+	rows := append([]identEntry{}, m.identRows...)
+	sortIdentEntries(rows, m.identByName)
+	m.identRows = rows
+	m.selectedIdent = 0
+	return m, nil
+}
+
+// moveIdent bewegt die Auswahl in der jeweils sichtbaren Liste
+func (m *Model) moveIdent(delta int) {
+	if m.identOpen != nil {
+		m.selectedIdentUse = max(0, min(len(m.identOpen.Uses)-1, m.selectedIdentUse+delta))
+		return
+	}
+	m.selectedIdent = max(0, min(len(m.visibleIdents())-1, m.selectedIdent+delta))
+}
+
+// selectIdent zeigt die Scripts des Bezeichners bzw. öffnet das ausgewählte Script
+// am ersten Vorkommen
+func (m Model) selectIdent() (tea.Model, tea.Cmd) {
+	if m.identOpen == nil {
+		rows := m.visibleIdents()
+		if m.selectedIdent >= len(rows) {
+			return m, nil
+		}
+		entry := rows[m.selectedIdent]
+		m.identOpen = &entry
+		m.selectedIdentUse = 0
+		return m, nil
+	}
+
+	use := m.identOpen.Uses[m.selectedIdentUse]
+	script := *use.Script
+	m.codeScript = &script
+	m.codeView.SetContent(highlightCode(script.Code))
+	m.codeView.SetYOffset(max(0, use.Line-3))
+	m.prevMode = viewIdentifiers
+	m.mode = viewCode
+	return m, nil
+}
+
+// startIdentFilter fokussiert die Filtereingabe
+func (m Model) startIdentFilter() (tea.Model, tea.Cmd) {
+	if m.identOpen != nil {
+		return m, nil
+	}
+	m.identFiltering = true
+	m.identInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleIdentFilterInput filtert beim Tippen; Esc verwirft, Enter übernimmt den Filter
+func (m Model) handleIdentFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Back) && msg.String() == "esc":
+		m.identInput.SetValue("")
+		fallthrough
+	case key.Matches(msg, keys.Enter):
+		m.identFiltering = false
+		m.identInput.Blur()
+		m.selectedIdent = 0
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.identInput, cmd = m.identInput.Update(msg)
+	m.selectedIdent = 0
+	return m, cmd
+}
+
+// renderIdentifiers rendert den Index bzw. die Scripts des gewählten Bezeichners
+func (m Model) renderIdentifiers() string {
+	var b strings.Builder
+	height := max(3, m.height-14)
+
+	if e := m.identOpen; e != nil {
+		b.WriteString(titleStyle.Render("🔤 "+e.Name) + "\n\n")
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  %d Vorkommen in %d Scripts (%s)", e.Count, len(e.Uses), m.identScope)) + "\n\n")
+		b.WriteString(tableHeaderStyle.Render(fmt.Sprintf("  %-50s %-15s %9s %7s", "Script", "Gruppe", "Vorkommen", "Zeile")) + "\n")
+
+		start := max(0, m.selectedIdentUse-height+1)
+		for i := start; i < min(len(e.Uses), start+height); i++ {
+			u := e.Uses[i]
+			style := tableCellStyle
+			prefix := "  "
+			if i == m.selectedIdentUse {
+				style = tableCellSelectedStyle
+				prefix = "▶ "
+			}
+			row := fmt.Sprintf("%s%-50s %-15s %9d %7d", prefix, truncate(scriptLabel(*u.Script), 50),
+				truncate(u.Script.Group(), 15), u.Count, u.Line)
+			b.WriteString(style.Render(row) + "\n")
+		}
+		return boxStyle.Width(m.width - 4).Render(b.String())
+	}
+
+	b.WriteString(titleStyle.Render("🔤 Bezeichner") + "\n\n")
+	order := "[Häufigkeit] · Name"
+	if m.identByName {
+		order = "Häufigkeit · [Name]"
+	}
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("  %d Bezeichner in %s · %s  (Tab wechseln)", len(m.identRows), m.identScope, order)) + "\n")
+	if m.identFiltering {
+		b.WriteString("  🔍 Filter: " + m.identInput.View() + "\n")
+		height--
+	} else if filter := m.identInput.Value(); filter != "" {
+		b.WriteString(mutedStyle.Render("  Filter: "+filter+"  (f ändern)") + "\n")
+		height--
+	}
+	b.WriteString("\n")
+
+	rows := m.visibleIdents()
+	if len(rows) == 0 {
+		b.WriteString(mutedStyle.Render("  Keine Bezeichner gefunden.") + "\n")
+		return boxStyle.Width(m.width - 4).Render(b.String())
+	}
+
+	b.WriteString(tableHeaderStyle.Render(fmt.Sprintf("  %-40s %9s %8s", "Bezeichner", "Vorkommen", "Scripts")) + "\n")
+	start := max(0, m.selectedIdent-height+1)
+	for i := start; i < min(len(rows), start+height); i++ {
+		e := rows[i]
+		style := tableCellStyle
+		prefix := "  "
+		if i == m.selectedIdent {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%s%-40s %9d %8d", prefix, truncate(e.Name, 40), e.Count, len(e.Uses))) + "\n")
+	}
+	return boxStyle.Width(m.width - 4).Render(b.String())
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// Ausblenden von Datenbanken, Tabellen und Scripts
+// =============================================================================
+
+// ignoreRules sind Glob-Muster aus der Konfiguration (Groß-/Kleinschreibung egal).
+//
+//	*_TEST            Datenbank, Tabelle oder Element mit passendem Namen
+//	Sandbox*/*        alle Tabellen von Datenbanken, die mit Sandbox beginnen
+//	CRM/Kunden/Test*  Elemente einer bestimmten Tabelle
+type ignoreRules []string
+
+// match prüft einen Pfad aus Datenbank, Tabelle und Element gegen die Muster
+func (r ignoreRules) match(parts ...string) bool {
+	for _, pattern := range r {
+		pattern = strings.ToLower(pattern)
+
+		if !strings.Contains(pattern, "/") {
+			for _, part := range parts {
+				if ok, _ := path.Match(pattern, strings.ToLower(part)); ok && part != "" {
+					return true
+				}
+			}
+			continue
+		}
+
+		segments := strings.Split(pattern, "/")
+		if len(segments) > len(parts) {
+			continue
+		}
+		matched := true
+		for i, segment := range segments {
+			if ok, _ := path.Match(segment, strings.ToLower(parts[i])); !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoredSet enthält die aufgrund der Muster ausgeblendeten Objekte
+type ignoredSet struct {
+	databases []interface{} // Datenbank-IDs
+	tables    []interface{} // "datenbank-id/tabellen-id"
+	scripts   []interface{} // Script-IDs
+}
+
+func (s *ignoredSet) empty() bool {
+	return s == nil || len(s.databases)+len(s.tables)+len(s.scripts) == 0
+}
+
+// SetIgnore ermittelt die ausgeblendeten Objekte zu den Mustern
+func (db *NinoxDB) SetIgnore(patterns []string) error {
+	db.mu.Lock()
+	db.ignored = nil
+	db.mu.Unlock()
+	if len(patterns) == 0 {
+		return nil
+	}
+	rules := ignoreRules(patterns)
+	set := &ignoredSet{}
+	dbNames := make(map[string]string)
+
+	rows, err := db.conn.Query(`SELECT id, name FROM databases`)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			rows.Close()
+			return err
+		}
+		dbNames[id] = name
+		if rules.match(name) {
+			set.databases = append(set.databases, id)
+		}
+	}
+	rows.Close()
+
+	rows, err = db.conn.Query(`SELECT database_id, table_id, name FROM tables`)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var dbID, tableID, name string
+		if err := rows.Scan(&dbID, &tableID, &name); err != nil {
+			rows.Close()
+			return err
+		}
+		if rules.match(dbNames[dbID], name) {
+			set.tables = append(set.tables, dbID+"/"+tableID)
+		}
+	}
+	rows.Close()
+
+	rows, err = db.conn.Query(`
+		SELECT id, COALESCE(database_name, ''), COALESCE(table_name, ''), COALESCE(element_name, '')
+		FROM scripts`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		var dbName, tableName, element string
+		if err := rows.Scan(&id, &dbName, &tableName, &element); err != nil {
+			return err
+		}
+		if rules.match(dbName, tableName, element) {
+			set.scripts = append(set.scripts, id)
+		}
+	}
+
+	db.mu.Lock()
+	db.ignored = set
+	db.mu.Unlock()
+	return nil
+}
+
+// SetShowIgnored blendet ausgeblendete Objekte vorübergehend wieder ein
+func (db *NinoxDB) SetShowIgnored(show bool) {
+	db.mu.Lock()
+	db.showIgnored = show
+	db.mu.Unlock()
+}
+
+// HasIgnored meldet, ob Muster aktiv sind und Objekte ausblenden
+func (db *NinoxDB) HasIgnored() bool {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return !db.ignored.empty()
+}
+
+// ignoreFilter liefert eine " AND ..."-Bedingung, die ausgeblendete Objekte
+// und archivierte Datenbanken ausschließt. Leere Spaltennamen werden übersprungen.
+// Die IDs stehen als Literale in der Bedingung statt als Platzhalter: ein breites
+// Muster wie * blendet tausende Scripts aus und würde sonst SQLites Grenze für
+// gebundene Variablen überschreiten.
+func (db *NinoxDB) ignoreFilter(dbCol, tableCol, idCol string) string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	archived := db.hiddenArchived()
+	if (db.showIgnored || db.ignored.empty()) && len(archived) == 0 {
+		return ""
+	}
+
+	var clause strings.Builder
+	notIn := func(expr string, values []interface{}) {
+		if expr == "" || len(values) == 0 {
+			return
+		}
+		clause.WriteString(" AND " + expr + " NOT IN (")
+		for i, v := range values {
+			if i > 0 {
+				clause.WriteString(", ")
+			}
+			clause.WriteString(sqlLiteral(v))
+		}
+		clause.WriteString(")")
+	}
+
+	notIn(dbCol, archived)
+	if db.showIgnored || db.ignored.empty() {
+		return clause.String()
+	}
+	notIn(dbCol, db.ignored.databases)
+	if tableCol != "" {
+		notIn(dbCol+" || '/' || COALESCE("+tableCol+", '')", db.ignored.tables)
+	}
+	notIn(idCol, db.ignored.scripts)
+	return clause.String()
+}
+
+// sqlLiteral schreibt eine ID als SQL-Literal (Zahl bzw. Text in Hochkommas)
+func sqlLiteral(v interface{}) string {
+	switch v := v.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	}
+	return "'" + strings.ReplaceAll(fmt.Sprint(v), "'", "''") + "'"
+}
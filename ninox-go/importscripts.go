@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Re-Import bearbeiteter Script-Dateien: Prüfbericht und Änderungsplan
+// =============================================================================
+
+// importStatus beschreibt das Ergebnis des Abgleichs einer Datei
+type importStatus string
+
+const (
+	importUnchanged importStatus = "unverändert"
+	importChanged   importStatus = "geändert"
+	importConflict  importStatus = "konflikt"         // Script wurde seit dem Export auch in der DB geändert
+	importUnmatched importStatus = "nicht zugeordnet" // kein passendes Script gefunden
+	importInvalid   importStatus = "fehlerhaft"       // Datei nicht lesbar bzw. Frontmatter ungültig
+)
+
+// importedScript ist eine abgeglichene Datei
+type importedScript struct {
+	Path   string
+	Status importStatus
+	Script *Script // Script in der DB (nil bei nicht zugeordnet/fehlerhaft)
+	Code   string  // Code aus der Datei
+	Note   string
+}
+
+// scriptUpdate ist ein Eintrag des Änderungsplans für die Ninox-API
+type scriptUpdate struct {
+	ScriptID   int    `json:"script_id"`
+	DatabaseID string `json:"database_id"`
+	TableID    string `json:"table_id,omitempty"`
+	ElementID  string `json:"element_id,omitempty"`
+	Type       string `json:"type"`
+	Label      string `json:"label"`
+	File       string `json:"file"`
+	BaseHash   string `json:"base_hash"` // Hash in der DB beim Abgleich
+	NewHash    string `json:"new_hash"`
+	Code       string `json:"code"`
+}
+
+// updatePlan ist der mit --plan geschriebene Änderungsplan
+type updatePlan struct {
+	Created string         `json:"created"`
+	Source  string         `json:"source"`
+	Updates []scriptUpdate `json:"updates"`
+}
+
+// sameCode vergleicht Code ohne abschließende Zeilenumbrüche (der Export ergänzt einen)
+func sameCode(a, b string) bool {
+	return strings.TrimRight(a, "\n") == strings.TrimRight(b, "\n")
+}
+
+// matchImportedFiles gleicht alle Script-Dateien unter dir mit den Scripts der DB ab.
+// Zuordnung über die ID im Frontmatter, ohne Frontmatter über den Exportpfad.
+func matchImportedFiles(dir string, scripts []Script) ([]importedScript, error) {
+	byID := make(map[int]*Script, len(scripts))
+	byPath := make(map[string]*Script, len(scripts))
+	for i := range scripts {
+		byID[scripts[i].ID] = &scripts[i]
+	}
+	paths, err := planScriptPaths(scripts)
+	if err != nil {
+		return nil, err
+	}
+	for i, rel := range paths {
+		byPath[rel] = &scripts[i]
+	}
+
+	var results []importedScript
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isScriptFile(path) {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		result := importedScript{Path: rel}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			result.Status, result.Note = importInvalid, err.Error()
+			results = append(results, result)
+			return nil
+		}
+		fm, code, err := parseFrontmatter(string(data))
+		if err != nil {
+			result.Status, result.Note = importInvalid, err.Error()
+			results = append(results, result)
+			return nil
+		}
+		result.Code = code
+
+		switch {
+		case fm != nil:
+			result.Script = byID[fm.ID]
+			if result.Script == nil {
+				result.Note = fmt.Sprintf("Script-ID %d nicht in der DB", fm.ID)
+			}
+		default:
+			result.Script = byPath[rel]
+			if result.Script == nil {
+				result.Note = "kein Frontmatter und kein Script unter diesem Pfad"
+			}
+		}
+
+		switch {
+		case result.Script == nil:
+			result.Status = importUnmatched
+		case sameCode(code, result.Script.Code):
+			result.Status = importUnchanged
+		case fm != nil && fm.Hash != "" && fm.Hash != result.Script.Hash:
+			result.Status = importConflict
+			result.Note = "Script wurde seit dem Export auch in der DB geändert"
+		default:
+			result.Status = importChanged
+		}
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Path < results[j].Path })
+	return results, nil
+}
+
+// writeImportReport gibt den Prüfbericht mit Diffs der geänderten Dateien aus
+func writeImportReport(results []importedScript, context int, noColor bool) map[importStatus]int {
+	counts := make(map[importStatus]int)
+	for _, r := range results {
+		counts[r.Status]++
+		if r.Status == importUnchanged {
+			continue
+		}
+
+		line := fmt.Sprintf("%-16s %s", r.Status, r.Path)
+		if r.Script != nil {
+			line += fmt.Sprintf("  (#%d %s)", r.Script.ID, scriptLabel(*r.Script))
+		}
+		if r.Note != "" {
+			line += " – " + r.Note
+		}
+		fmt.Println(line)
+
+		if r.Script != nil && (r.Status == importChanged || r.Status == importConflict) {
+			printDiff(UnifiedDiff("db/"+r.Path, "datei/"+r.Path, r.Script.Code, r.Code, context), noColor)
+		}
+	}
+
+	if !quiet {
+		fmt.Printf("\n%d Dateien: %d geändert, %d Konflikte, %d unverändert, %d nicht zugeordnet, %d fehlerhaft\n",
+			len(results), counts[importChanged], counts[importConflict], counts[importUnchanged],
+			counts[importUnmatched], counts[importInvalid])
+	}
+	return counts
+}
+
+// buildUpdatePlan erzeugt den Änderungsplan aus den geänderten Dateien (ohne Konflikte)
+func buildUpdatePlan(source string, results []importedScript) updatePlan {
+	plan := updatePlan{Created: time.Now().Format(time.RFC3339), Source: source, Updates: []scriptUpdate{}}
+	for _, r := range results {
+		if r.Status != importChanged {
+			continue
+		}
+		s := r.Script
+		plan.Updates = append(plan.Updates, scriptUpdate{
+			ScriptID: s.ID, DatabaseID: s.DatabaseID, TableID: s.TableID, ElementID: s.ElementID,
+			Type: s.CodeType, Label: scriptLabel(*s), File: r.Path,
+			BaseHash: s.Hash, NewHash: scriptHash(r.Code), Code: r.Code,
+		})
+	}
+	return plan
+}
+
+// cmdImportScripts gleicht ein Export-Verzeichnis mit der DB ab
+func cmdImportScripts(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) != 1 {
+		fail(exitUsage, "Verwendung: ninox-tui import-scripts VERZEICHNIS [--plan DATEI] [--name-template VORLAGE] [-U N] [--no-color] [--db DATEI]")
+	}
+	dir := opts.positional[0]
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		fail(exitUsage, "Kein Verzeichnis: %s", dir)
+	}
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	applyNameTemplate(opts)
+
+	scripts, err := db.GetAllScripts()
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+	results, err := matchImportedFiles(dir, scripts)
+	if err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	counts := writeImportReport(results, opts.context, opts.noColor)
+
+	if opts.plan != "" {
+		plan := buildUpdatePlan(opts.dbPath, results)
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			fail(exitFailure, "%v", err)
+		}
+		if err := os.WriteFile(opts.plan, append(data, '\n'), 0o644); err != nil {
+			fail(exitFailure, "%v", err)
+		}
+		if !quiet {
+			fmt.Printf("✓ Änderungsplan geschrieben: %s (%d Änderungen)\n", opts.plan, len(plan.Updates))
+		}
+	}
+
+	if counts[importChanged]+counts[importConflict] == 0 {
+		return exitNoResults
+	}
+	return exitOK
+}
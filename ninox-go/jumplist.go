@@ -0,0 +1,48 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Sprungliste (wie im Editor: Ctrl+O zurück, Alt+I vorwärts)
+// =============================================================================
+
+// maxJumps begrenzt die Länge der Sprungliste
+const maxJumps = 100
+
+// recordJump merkt sich die aktuelle Position vor einem Sprung.
+// Vorwärts-Einträge verfallen wie im Editor.
+func (m *Model) recordJump() {
+	m.jumps = append(m.jumps[:m.jumpPos:m.jumpPos], m.saveNav())
+	if len(m.jumps) > maxJumps {
+		m.jumps = m.jumps[len(m.jumps)-maxJumps:]
+	}
+	m.jumpPos = len(m.jumps)
+}
+
+// jumpBack springt zur vorherigen Position der Sprungliste
+func (m Model) jumpBack() (tea.Model, tea.Cmd) {
+	if m.jumpPos == 0 {
+		m.status = "Anfang der Sprungliste"
+		return m, nil
+	}
+	if m.jumpPos == len(m.jumps) {
+		// Aktuelle Position sichern, damit Alt+I hierher zurückführt
+		m.jumps = append(m.jumps[:len(m.jumps):len(m.jumps)], m.saveNav())
+	}
+	m.jumpPos--
+	m.restoreNav(m.jumps[m.jumpPos])
+	return m, nil
+}
+
+// jumpForward springt zur nächsten Position der Sprungliste
+func (m Model) jumpForward() (tea.Model, tea.Cmd) {
+	if m.jumpPos >= len(m.jumps)-1 {
+		m.status = "Ende der Sprungliste"
+		return m, nil
+	}
+	m.jumpPos++
+	m.restoreNav(m.jumps[m.jumpPos])
+	return m, nil
+}
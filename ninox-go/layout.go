@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Layout der Gesamtansicht nach gemessenen Höhen
+// =============================================================================
+
+// contentHeight liefert die für den Inhalt verfügbaren Zeilen
+// (Terminalhöhe abzüglich Kopf, Leisten und Fußzeile)
+func (m Model) contentHeight() int {
+	top, footer := m.renderChrome()
+	used := lipgloss.Height(footer)
+	for _, part := range top {
+		used += lipgloss.Height(part)
+	}
+	return max(1, m.height-used)
+}
+
+// allScriptsBodyHeight liefert die Zeilen für die Einträge der Gesamtansicht
+// (ohne Titel mit Leerzeile und Scroll-Info)
+func (m Model) allScriptsBodyHeight() int {
+	return max(1, m.contentHeight()-lipgloss.Height(titleStyle.Render("📜"))-2)
+}
+
+// allScriptEntryHeight liefert die tatsächliche Höhe eines Eintrags
+func (m Model) allScriptEntryHeight(i int) int {
+	return strings.Count(m.renderAllScriptEntry(i), "\n")
+}
+
+// allScriptsWindow liefert den sichtbaren Bereich [start, end) der Gesamtansicht.
+// Die Auswahl ist immer enthalten, auch wenn scrollOffset veraltet ist.
+func (m Model) allScriptsWindow() (start, end int) {
+	start = m.visibleAllScriptOffset()
+	body := m.allScriptsBodyHeight()
+
+	used := 0
+	for end = start; end < len(m.filteredScripts); end++ {
+		h := m.allScriptEntryHeight(end)
+		if used+h > body && end > start {
+			break
+		}
+		used += h
+	}
+	return start, end
+}
+
+// visibleAllScriptOffset liefert den kleinsten Versatz ab scrollOffset,
+// bei dem die Auswahl vollständig sichtbar ist
+func (m Model) visibleAllScriptOffset() int {
+	offset := min(m.scrollOffset, max(0, len(m.filteredScripts)-1))
+	selected := m.selectedAllScript
+	if selected <= offset {
+		return max(0, selected)
+	}
+
+	// Von der Auswahl rückwärts so viele Einträge nehmen, wie hineinpassen
+	body := m.allScriptsBodyHeight()
+	used := m.allScriptEntryHeight(selected)
+	first := selected
+	for first > offset {
+		h := m.allScriptEntryHeight(first - 1)
+		if used+h > body {
+			break
+		}
+		used += h
+		first--
+	}
+	return first
+}
+
+// ensureAllScriptVisible scrollt die Gesamtansicht, bis die Auswahl sichtbar ist
+func (m *Model) ensureAllScriptVisible() {
+	m.scrollOffset = m.visibleAllScriptOffset()
+}
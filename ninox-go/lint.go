@@ -0,0 +1,475 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// =============================================================================
+// Lint: Performance-Fallen in Ninox-Scripts
+// =============================================================================
+
+// largeTableRecords ist die Datensatzzahl, ab der eine Tabelle als groß gilt
+const largeTableRecords = 10000
+
+type lintSeverity int
+
+const (
+	severityInfo lintSeverity = iota
+	severityWarning
+	severityError
+)
+
+var severityNames = map[lintSeverity]string{
+	severityInfo:    "hinweis",
+	severityWarning: "warnung",
+	severityError:   "fehler",
+}
+
+// lintFinding ist ein Befund einer Regel an einer Stelle eines Scripts
+type lintFinding struct {
+	Rule     string
+	Severity lintSeverity
+	Script   *Script
+	Line     int
+	Message  string
+
+	SuppressedBy string // suppressedComment, suppressedBaseline oder leer
+}
+
+// location liefert die Fundstelle wie bei option-refs, z.B. "CRM/Rechnungen/Export:onClick:3"
+func (f lintFinding) location() string {
+	return fmt.Sprintf("%s:%d", scriptLabel(*f.Script), f.Line)
+}
+
+// lintToken ist ein Wort oder Zeichen des Codes (ohne Strings und Kommentare)
+type lintToken struct {
+	text   string
+	line   int
+	quoted bool // 'Name mit Leerzeichen'
+	inLoop bool // im Rumpf einer for- oder while-Schleife
+}
+
+// lintContext enthält die für Regeln nötigen Daten über das Schema
+type lintContext struct {
+	tableIDs      map[string]string // Datenbank-ID/Tabellenname → Tabellen-ID
+	recordCounts  map[string]int    // Datenbank-ID/Tabellen-ID → Datensätze
+	databaseNames map[string]bool   // alle extrahierten Datenbanken (Kleinschreibung)
+	choiceFields  []choiceField
+	calls         map[string]bool // in irgendeinem Script aufgerufene Namen (setzt runLint)
+}
+
+// records liefert die Datensatzzahl einer Tabelle der Datenbank (false = unbekannt)
+func (lc *lintContext) records(databaseID, table string) (int, bool) {
+	tableID, ok := lc.tableIDs[databaseID+"/"+table]
+	if !ok {
+		return 0, false
+	}
+	n, ok := lc.recordCounts[layoutKey(databaseID, tableID)]
+	return n, ok
+}
+
+// lintRule prüft ein Script anhand seiner Tokens
+type lintRule struct {
+	ID          string
+	Description string
+	Severity    lintSeverity // Standard-Schwere (einzelne Befunde können höher liegen)
+	check       func(lc *lintContext, s *Script, tokens []lintToken) []lintFinding
+}
+
+var lintRules = []lintRule{
+	{"perf-select-in-loop", "select in einer Schleife (eine Abfrage je Durchlauf)", severityWarning, checkSelectInLoop},
+	{"perf-unscoped-select", "select ohne where auf einer großen Tabelle", severityWarning, checkUnscopedSelect},
+	{"perf-http-in-loop", "http() in einer Schleife (ein Aufruf je Durchlauf)", severityError, checkHTTPInLoop},
+	{"sec-hardcoded-secret", "Zugangsdaten als Text im Script", severityError, checkHardcodedSecret},
+	{"ref-unknown-database", "do as database mit unbekannter Datenbank", severityError, checkUnknownDatabase},
+	{"ref-unknown-option", "Vergleich mit nicht existierendem Auswahlwert", severityWarning, checkUnknownOption},
+	{"unused-function", "globale Funktion ohne Aufruf", severityInfo, checkUnusedFunction},
+}
+
+var (
+	secretPattern   = regexp.MustCompile(`(?i)(?:["']?\b(?:api[_-]?key|apikey|token|secret|password|passwort|kennwort)\b["']?\s*(?::=|:|=)\s*"[^"]{6,}"|"Bearer\s+[A-Za-z0-9._\-]{16,}")`)
+	databasePattern = regexp.MustCompile(`\bdo\s+as\s+database\s+(?:'([^']+)'|"([^"]+)")`)
+)
+
+// lineOf liefert die Zeilennummer einer Byte-Position
+func lineOf(code string, pos int) int {
+	return strings.Count(code[:pos], "\n") + 1
+}
+
+// selectTarget liefert den Tabellennamen nach "select" an Position i und ob gefiltert wird
+func selectTarget(tokens []lintToken, i int) (table string, scoped, ok bool) {
+	if i+1 >= len(tokens) {
+		return "", false, false
+	}
+	next := tokens[i+1]
+	if !next.quoted && !isWordToken(next.text) {
+		return "", false, false
+	}
+	scoped = i+2 < len(tokens) && (tokens[i+2].text == "where" || tokens[i+2].text == "[")
+	return next.text, scoped, true
+}
+
+func checkSelectInLoop(lc *lintContext, s *Script, tokens []lintToken) []lintFinding {
+	var findings []lintFinding
+	for i, t := range tokens {
+		if t.text != "select" || !t.inLoop {
+			continue
+		}
+		table, _, _ := selectTarget(tokens, i)
+		f := lintFinding{Severity: severityWarning, Script: s, Line: t.line,
+			Message: "select " + table + " in einer Schleife – Abfrage vor die Schleife ziehen"}
+		if n, ok := lc.records(s.DatabaseID, table); ok && n >= largeTableRecords {
+			f.Severity = severityError
+			f.Message += fmt.Sprintf(" (%s Datensätze)", formatCount(n))
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+func checkUnscopedSelect(lc *lintContext, s *Script, tokens []lintToken) []lintFinding {
+	var findings []lintFinding
+	for i, t := range tokens {
+		if t.text != "select" {
+			continue
+		}
+		table, scoped, ok := selectTarget(tokens, i)
+		if !ok || scoped {
+			continue
+		}
+		if n, known := lc.records(s.DatabaseID, table); known && n >= largeTableRecords {
+			findings = append(findings, lintFinding{Severity: severityWarning, Script: s, Line: t.line,
+				Message: fmt.Sprintf("select %s ohne where lädt %s Datensätze", table, formatCount(n))})
+		}
+	}
+	return findings
+}
+
+func checkHTTPInLoop(lc *lintContext, s *Script, tokens []lintToken) []lintFinding {
+	var findings []lintFinding
+	for i, t := range tokens {
+		if t.text == "http" && t.inLoop && i+1 < len(tokens) && tokens[i+1].text == "(" {
+			findings = append(findings, lintFinding{Severity: severityError, Script: s, Line: t.line,
+				Message: "http() in einer Schleife – ein Aufruf je Durchlauf, besser gesammelt senden"})
+		}
+	}
+	return findings
+}
+
+func checkHardcodedSecret(lc *lintContext, s *Script, tokens []lintToken) []lintFinding {
+	var findings []lintFinding
+	for _, loc := range secretPattern.FindAllStringIndex(s.Code, -1) {
+		findings = append(findings, lintFinding{Severity: severityError, Script: s, Line: lineOf(s.Code, loc[0]),
+			Message: "Zugangsdaten im Klartext – in eine geschützte Tabelle oder Einstellung auslagern"})
+	}
+	return findings
+}
+
+func checkUnknownDatabase(lc *lintContext, s *Script, tokens []lintToken) []lintFinding {
+	var findings []lintFinding
+	for _, m := range databasePattern.FindAllStringSubmatchIndex(s.Code, -1) {
+		name := s.Code[m[2]:m[3]]
+		if m[2] < 0 {
+			name = s.Code[m[4]:m[5]]
+		}
+		if !lc.databaseNames[strings.ToLower(name)] {
+			findings = append(findings, lintFinding{Severity: severityError, Script: s, Line: lineOf(s.Code, m[0]),
+				Message: "Datenbank „" + name + "“ ist nicht in der Extraktion"})
+		}
+	}
+	return findings
+}
+
+func checkUnknownOption(lc *lintContext, s *Script, tokens []lintToken) []lintFinding {
+	var findings []lintFinding
+	for _, field := range lc.choiceFields {
+		for _, r := range findOptionRefs([]Script{*s}, field) {
+			if !r.Known {
+				findings = append(findings, lintFinding{Severity: severityWarning, Script: s, Line: r.Line,
+					Message: fmt.Sprintf("%s.%s hat keinen Auswahlwert %s", field.TableName, field.Name, r.OptionID)})
+			}
+		}
+	}
+	return findings
+}
+
+func checkUnusedFunction(lc *lintContext, s *Script, tokens []lintToken) []lintFinding {
+	var findings []lintFinding
+	for _, m := range functionDefPattern.FindAllStringSubmatchIndex(s.Code, -1) {
+		name := s.Code[m[2]:m[3]]
+		if !lc.calls[name] {
+			findings = append(findings, lintFinding{Severity: severityInfo, Script: s, Line: lineOf(s.Code, m[0]),
+				Message: "Funktion " + name + " wird in keinem Script aufgerufen"})
+		}
+	}
+	return findings
+}
+
+func isWordToken(s string) bool {
+	r := []rune(s)
+	return len(r) > 0 && (unicode.IsLetter(r[0]) || r[0] == '_')
+}
+
+// lintTokens zerlegt Code in Tokens. Schleifenrümpfe werden über die Einrückung
+// erkannt (Ninox liefert formatierte Scripts): alles hinter "do" einer for-/while-Zeile
+// und alle tiefer eingerückten Folgezeilen.
+func lintTokens(code string) []lintToken {
+	var tokens []lintToken
+	var loops []int // Einrückung der offenen Schleifenköpfe
+	inComment := false
+
+	for n, line := range strings.Split(code, "\n") {
+		indent := 0
+		for _, r := range line {
+			if r == '\t' {
+				indent += 4
+			} else if r == ' ' {
+				indent++
+			} else {
+				break
+			}
+		}
+		if strings.TrimSpace(line) != "" && !inComment {
+			for len(loops) > 0 && loops[len(loops)-1] >= indent {
+				loops = loops[:len(loops)-1]
+			}
+		}
+
+		var lineTokens []lintToken
+		runes := []rune(line)
+		for i := 0; i < len(runes); i++ {
+			r := runes[i]
+			switch {
+			case inComment:
+				if r == '*' && i+1 < len(runes) && runes[i+1] == '/' {
+					inComment = false
+					i++
+				}
+			case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+				i = len(runes)
+			case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+				inComment = true
+				i++
+			case r == '"':
+				for i++; i < len(runes) && runes[i] != '"'; i++ {
+				}
+			case r == '\'':
+				start := i + 1
+				for i++; i < len(runes) && runes[i] != '\''; i++ {
+				}
+				lineTokens = append(lineTokens, lintToken{text: string(runes[start:min(i, len(runes))]), quoted: true})
+			case unicode.IsLetter(r) || r == '_':
+				start := i
+				for i+1 < len(runes) && (unicode.IsLetter(runes[i+1]) || unicode.IsDigit(runes[i+1]) || runes[i+1] == '_') {
+					i++
+				}
+				lineTokens = append(lineTokens, lintToken{text: string(runes[start : i+1])})
+			case !unicode.IsSpace(r):
+				lineTokens = append(lineTokens, lintToken{text: string(r)})
+			}
+		}
+
+		// Schleifenkopf: Tokens hinter "do" liegen im Rumpf; ohne "end" auf derselben
+		// Zeile gehören die tiefer eingerückten Folgezeilen dazu
+		inLoop, header, opened := len(loops) > 0, false, false
+		for i := range lineTokens {
+			t := &lineTokens[i]
+			t.line = n + 1
+			t.inLoop = inLoop
+			switch {
+			case t.quoted:
+			case t.text == "for" || t.text == "while":
+				header = true
+			case t.text == "do" && header:
+				header, inLoop, opened = false, true, true
+			case t.text == "end" && opened:
+				opened = false
+			}
+		}
+		if opened {
+			loops = append(loops, indent)
+		}
+		tokens = append(tokens, lineTokens...)
+	}
+	return tokens
+}
+
+// runLint prüft alle Scripts mit allen Regeln; Ergebnis nach Schwere und Fundstelle sortiert
+func runLint(scripts []Script, lc *lintContext) []lintFinding {
+	lc.calls = make(map[string]bool)
+	for _, s := range scripts {
+		for _, name := range analyzeScript(s.Code).Calls {
+			lc.calls[name] = true
+		}
+	}
+
+	var findings []lintFinding
+	for i := range scripts {
+		s := &scripts[i]
+		tokens := lintTokens(s.Code)
+		suppress := scriptSuppressions(s.Code)
+		for _, rule := range lintRules {
+			seen := make(map[int]bool) // höchstens ein Befund je Regel und Zeile
+			for _, f := range rule.check(lc, s, tokens) {
+				if seen[f.Line] {
+					continue
+				}
+				seen[f.Line] = true
+				f.Rule = rule.ID
+				if suppressedByComment(f, suppress) {
+					f.SuppressedBy = suppressedComment
+				}
+				findings = append(findings, f)
+			}
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity > findings[j].Severity
+		}
+		return cmpText(findings[i].location(), findings[j].location()) < 0
+	})
+	return findings
+}
+
+// newLintContext lädt Tabellen, Datensatzzahlen und Auswahlfelder für die Regeln
+func newLintContext(db *NinoxDB) (*lintContext, error) {
+	counts, err := db.GetRecordCounts()
+	if err != nil {
+		return nil, err
+	}
+	choices, err := db.GetChoiceFields()
+	if err != nil {
+		return nil, err
+	}
+	databases, err := db.GetDatabases()
+	if err != nil {
+		return nil, err
+	}
+
+	lc := &lintContext{tableIDs: make(map[string]string), recordCounts: counts,
+		databaseNames: make(map[string]bool), choiceFields: choices}
+	for _, d := range databases {
+		lc.databaseNames[strings.ToLower(d.Name)] = true
+		tables, err := db.GetTables(d.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tables {
+			lc.tableIDs[d.ID+"/"+t.Name] = t.TableID
+		}
+	}
+	return lc, nil
+}
+
+// cmdLint prüft die Scripts mit allen Regeln (Text, --output json oder sarif).
+// Befunde aus der Baseline (--baseline bzw. lint_baseline) gelten als akzeptiert;
+// --update-baseline schreibt die aktuellen Befunde hinein.
+func cmdLint(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) > 0 {
+		fail(exitUsage, "Verwendung: ninox-tui lint [--database NAME] [--output text|json|sarif] [--baseline DATEI [--update-baseline]] [--db DATEI]")
+	}
+	if opts.output != "" && opts.output != "text" && opts.output != "json" && opts.output != "sarif" {
+		fail(exitUsage, "Unbekanntes Ausgabeformat: %s (erlaubt: text, json, sarif)", opts.output)
+	}
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	databases, err := selectDatabases(db, opts.database)
+	if err != nil {
+		fail(exitNoResults, "%v", err)
+	}
+	lc, err := newLintContext(db)
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+	scripts, err := db.GetAllScripts()
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+
+	selected := make(map[string]bool)
+	for _, d := range databases {
+		selected[d.ID] = true
+	}
+	var checked []Script
+	for _, s := range scripts {
+		if selected[s.DatabaseID] {
+			checked = append(checked, s)
+		}
+	}
+
+	findings := runLint(checked, lc)
+
+	baselinePath := opts.baseline
+	if baselinePath == "" {
+		if cfg, err := LoadConfig(activeConfigPath()); err == nil {
+			baselinePath = cfg.LintBaseline
+		}
+	}
+	if opts.updateBaseline {
+		if baselinePath == "" {
+			fail(exitUsage, "--update-baseline benötigt --baseline DATEI oder lint_baseline in der Konfiguration")
+		}
+		n, err := saveBaseline(baselinePath, findings)
+		if err != nil {
+			fail(exitFailure, "Baseline nicht gespeichert: %v", err)
+		}
+		if !quiet {
+			fmt.Printf("%d Befunde in die Baseline %s übernommen\n", n, baselinePath)
+		}
+		return exitOK
+	}
+	if baselinePath != "" {
+		baseline, err := loadBaseline(baselinePath)
+		if err != nil {
+			fail(exitFailure, "%v", err)
+		}
+		applyBaseline(findings, baseline)
+	}
+	active, suppressed := activeFindings(findings)
+
+	if opts.output == "json" || opts.output == "sarif" {
+		encode := findingsJSON
+		if opts.output == "sarif" {
+			encode = findingsSARIF
+		}
+		data, err := encode(findings)
+		if err != nil {
+			fail(exitFailure, "%v", err)
+		}
+		fmt.Println(string(data))
+		if len(active) == 0 {
+			return exitNoResults
+		}
+		return exitOK
+	}
+
+	counts := make(map[string]int)
+	for _, f := range active {
+		counts[severityNames[f.Severity]]++
+		fmt.Printf("%-8s %s\t%s\t%s\n", severityNames[f.Severity], f.location(), f.Rule, f.Message)
+	}
+	if !quiet {
+		var summary []string
+		for _, c := range sortedCounts(counts) {
+			summary = append(summary, fmt.Sprintf("%d %s", c.count, c.name))
+		}
+		if suppressed > 0 {
+			summary = append(summary, fmt.Sprintf("%d unterdrückt", suppressed))
+		}
+		if len(summary) == 0 {
+			summary = []string{"keine"}
+		}
+		fmt.Printf("\n%d Befunde (%s) in %d Scripts\n", len(active), strings.Join(summary, ", "), len(checked))
+	}
+	if len(active) == 0 {
+		return exitNoResults
+	}
+	return exitOK
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// =============================================================================
+// Lint-Unterdrückung: Kommentare im Script und Baseline-Datei
+// =============================================================================
+
+// suppressPattern erkennt "// ninox-lint: disable=perf-select-in-loop" bzw.
+// "disable-script=all" in Zeilen- und Blockkommentaren
+var suppressPattern = regexp.MustCompile(`(?://|/\*)\s*ninox-lint:\s*(disable|disable-script)\s*=\s*([\w\-]+(?:\s*,\s*[\w\-]+)*)`)
+
+// suppressedBy-Werte eines Befunds
+const (
+	suppressedComment  = "kommentar"
+	suppressedBaseline = "baseline"
+)
+
+// ruleMatches prüft, ob ein Name in einem Kommentar die Regel meint. Der
+// Präfix darf fehlen ("select-in-loop" = "perf-select-in-loop"), "all" meint alle.
+func ruleMatches(name, ruleID string) bool {
+	return name == "all" || name == ruleID || strings.HasSuffix(ruleID, "-"+name)
+}
+
+// scriptSuppressions sammelt die Unterdrückungen eines Scripts: Regelnamen je Zeile
+// (0 = ganzes Script). Ein Kommentar allein in einer Zeile gilt auch für die nächste.
+func scriptSuppressions(code string) map[int][]string {
+	var suppress map[int][]string
+	lines := strings.Split(code, "\n")
+	for n, line := range lines {
+		m := suppressPattern.FindStringSubmatchIndex(line)
+		if m == nil {
+			continue
+		}
+		if suppress == nil {
+			suppress = make(map[int][]string)
+		}
+		var names []string
+		for _, name := range strings.Split(line[m[4]:m[5]], ",") {
+			names = append(names, strings.TrimSpace(name))
+		}
+		if line[m[2]:m[3]] == "disable-script" {
+			suppress[0] = append(suppress[0], names...)
+			continue
+		}
+		suppress[n+1] = append(suppress[n+1], names...)
+		if strings.TrimSpace(line[:m[0]]) == "" {
+			suppress[n+2] = append(suppress[n+2], names...)
+		}
+	}
+	return suppress
+}
+
+// suppressedByComment prüft, ob ein Kommentar den Befund unterdrückt
+func suppressedByComment(f lintFinding, suppress map[int][]string) bool {
+	for _, line := range []int{0, f.Line} {
+		for _, name := range suppress[line] {
+			if ruleMatches(name, f.Rule) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// baselineEntry identifiziert einen akzeptierten Befund. Statt der Zeilennummer
+// zählt der Code der Zeile, damit Änderungen darüber die Baseline nicht entwerten.
+type baselineEntry struct {
+	Rule   string `json:"rule"`
+	Script string `json:"script"`
+	Code   string `json:"code"`
+}
+
+type lintBaseline struct {
+	Findings []baselineEntry `json:"findings"`
+}
+
+// baselineEntryOf liefert den Baseline-Eintrag eines Befunds
+func baselineEntryOf(f lintFinding) baselineEntry {
+	code := ""
+	if lines := strings.Split(f.Script.Code, "\n"); f.Line >= 1 && f.Line <= len(lines) {
+		code = strings.TrimSpace(lines[f.Line-1])
+	}
+	return baselineEntry{Rule: f.Rule, Script: scriptLabel(*f.Script), Code: code}
+}
+
+// loadBaseline liest eine Baseline-Datei; eine fehlende Datei ergibt eine leere Baseline
+func loadBaseline(path string) (map[baselineEntry]bool, error) {
+	entries := make(map[baselineEntry]bool)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var baseline lintBaseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("Ungültige Baseline %s: %w", path, err)
+	}
+	for _, e := range baseline.Findings {
+		entries[e] = true
+	}
+	return entries, nil
+}
+
+// saveBaseline schreibt alle nicht per Kommentar unterdrückten Befunde als Baseline
+func saveBaseline(path string, findings []lintFinding) (int, error) {
+	baseline := lintBaseline{Findings: []baselineEntry{}}
+	seen := make(map[baselineEntry]bool)
+	for _, f := range findings {
+		if f.SuppressedBy == suppressedComment {
+			continue
+		}
+		if e := baselineEntryOf(f); !seen[e] {
+			seen[e] = true
+			baseline.Findings = append(baseline.Findings, e)
+		}
+	}
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	return len(baseline.Findings), os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// applyBaseline markiert Befunde, die in der Baseline stehen
+func applyBaseline(findings []lintFinding, baseline map[baselineEntry]bool) {
+	for i := range findings {
+		if findings[i].SuppressedBy == "" && baseline[baselineEntryOf(findings[i])] {
+			findings[i].SuppressedBy = suppressedBaseline
+		}
+	}
+}
+
+// activeFindings trennt offene von unterdrückten Befunden
+func activeFindings(findings []lintFinding) (active []lintFinding, suppressed int) {
+	for _, f := range findings {
+		if f.SuppressedBy != "" {
+			suppressed++
+			continue
+		}
+		active = append(active, f)
+	}
+	return active, suppressed
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// list: Datenbanken, Tabellen, Felder, Scripts, Verknüpfungen und Statistik
+// ohne TUI ausgeben – tabulatorgetrennt oder als JSON (für Shell-Skripte, jq, CI)
+// =============================================================================
+
+// listColumn ist eine Ausgabespalte: Kopf der Textausgabe und Schlüssel im JSON
+type listColumn struct {
+	header string
+	key    string
+}
+
+// listKind beschreibt eine Art von list: Spalten und Zeilen (Werte in Spaltenreihenfolge)
+type listKind struct {
+	columns []listColumn
+	rows    func(db *NinoxDB, databases []Database, opts cliOptions) [][]interface{}
+}
+
+// listKinds ordnet die Arten von list ihren Spalten und Ausgabefunktionen zu
+var listKinds = map[string]listKind{
+	"databases": {[]listColumn{{"Datenbank", "name"}, {"ID", "id"}, {"Team", "team"},
+		{"Tabellen", "tables"}, {"Felder", "fields"}, {"Scripts", "scripts"}}, listDatabases},
+	"tables": {[]listColumn{{"Datenbank", "database"}, {"Tabelle", "name"}, {"ID", "id"},
+		{"Felder", "fields"}, {"Scripts", "scripts"}, {"Verknüpfungen", "relationships"}}, listTables},
+	"fields": {[]listColumn{{"Datenbank", "database"}, {"Tabelle", "table"}, {"Feld", "name"}, {"ID", "id"},
+		{"Typ", "type"}, {"Verweist auf", "refTable"}, {"Formel", "formula"}}, listFields},
+	"scripts": {[]listColumn{{"Script", "script"}, {"Kategorie", "category"}, {"Gruppe", "group"}, {"Zeilen", "lines"}}, listScripts},
+	"relationships": {[]listColumn{{"Datenbank", "database"}, {"Von", "source"}, {"Feld", "field"},
+		{"Nach", "target"}, {"Typ", "type"}, {"Komposition", "composition"}}, listRelationships},
+	"stats": {[]listColumn{{"Kennzahl", "metric"}, {"Wert", "value"}}, listStats},
+}
+
+// listKindNames liefert die Arten für die Verwendungsmeldung
+func listKindNames() string {
+	var names []string
+	for name := range listKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, "|")
+}
+
+// tableSelected prüft den Tabellenfilter (--table)
+func tableSelected(opts cliOptions, name string) bool {
+	return opts.table == "" || strings.EqualFold(name, opts.table)
+}
+
+func listDatabases(db *NinoxDB, databases []Database, opts cliOptions) [][]interface{} {
+	var rows [][]interface{}
+	for _, d := range databases {
+		rows = append(rows, []interface{}{d.Name, d.ID, firstNonEmpty(d.TeamName, d.TeamID),
+			d.TableCount, d.FieldCount, d.CodeCount})
+	}
+	return rows
+}
+
+func listTables(db *NinoxDB, databases []Database, opts cliOptions) [][]interface{} {
+	var rows [][]interface{}
+	for _, d := range databases {
+		tables, err := db.GetTables(d.ID)
+		if err != nil {
+			fail(exitDBError, "%v", err)
+		}
+		for _, t := range tables {
+			if tableSelected(opts, t.Name) {
+				rows = append(rows, []interface{}{d.Name, t.Name, t.TableID, t.FieldCount, t.ScriptCount, t.RelationCount})
+			}
+		}
+	}
+	return rows
+}
+
+func listFields(db *NinoxDB, databases []Database, opts cliOptions) [][]interface{} {
+	var rows [][]interface{}
+	for _, d := range databases {
+		tables, err := db.GetTables(d.ID)
+		if err != nil {
+			fail(exitDBError, "%v", err)
+		}
+		for _, t := range tables {
+			if !tableSelected(opts, t.Name) {
+				continue
+			}
+			fields, err := db.GetFields(d.ID, t.TableID)
+			if err != nil {
+				fail(exitDBError, "%v", err)
+			}
+			for _, f := range fields {
+				rows = append(rows, []interface{}{d.Name, t.Name, f.Name, f.FieldID, f.BaseType, f.RefTableName, f.HasFormula})
+			}
+		}
+	}
+	return rows
+}
+
+func listScripts(db *NinoxDB, databases []Database, opts cliOptions) [][]interface{} {
+	selected := make(map[string]bool)
+	for _, d := range databases {
+		selected[d.ID] = true
+	}
+	scripts, err := db.GetAllScripts()
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+
+	var rows [][]interface{}
+	for _, s := range scripts {
+		if selected[s.DatabaseID] && tableSelected(opts, s.TableName) {
+			rows = append(rows, []interface{}{scriptLabel(s), s.CodeCategory, s.Group(), s.LineCount})
+		}
+	}
+	return rows
+}
+
+func listRelationships(db *NinoxDB, databases []Database, opts cliOptions) [][]interface{} {
+	var rows [][]interface{}
+	for _, d := range databases {
+		rels, err := db.GetDatabaseRelationships(d.ID)
+		if err != nil {
+			fail(exitDBError, "%v", err)
+		}
+		for _, r := range rels {
+			if opts.table != "" && !tableSelected(opts, r.SourceTableName) && !tableSelected(opts, r.TargetTableName) {
+				continue
+			}
+			rows = append(rows, []interface{}{d.Name, r.SourceTableName, r.SourceFieldName,
+				r.TargetTableName, r.RelationshipType, r.IsComposition})
+		}
+	}
+	return rows
+}
+
+// statsJSON ist die Statistik-Ansicht als JSON (list stats --json)
+type statsJSON struct {
+	Databases       int            `json:"databases"`
+	Tables          int            `json:"tables"`
+	Fields          int            `json:"fields"`
+	Relationships   int            `json:"relationships"`
+	Scripts         int            `json:"scripts"`
+	ScriptsByType   map[string]int `json:"scriptsByType"`
+	ScriptsByGroup  map[string]int `json:"scriptsByGroup"`
+	LinesByDatabase map[string]int `json:"linesByDatabase"`
+}
+
+// cliStats berechnet die Statistik der gewählten Datenbank (--database) bzw. aller
+func cliStats(db *NinoxDB, databases []Database, opts cliOptions) statsJSON {
+	var stats *Stats
+	var err error
+	if opts.database != "" && len(databases) == 1 {
+		stats, err = db.GetDatabaseStats(databases[0].ID)
+	} else {
+		stats, err = db.GetStats()
+	}
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+
+	lines := make(map[string]int)
+	for name, tables := range stats.CodeLines {
+		for _, n := range tables {
+			lines[name] += n
+		}
+	}
+	return statsJSON{
+		Databases:       stats.DatabasesCount,
+		Tables:          stats.TablesCount,
+		Fields:          stats.FieldsCount,
+		Relationships:   stats.RelationshipsCount,
+		Scripts:         stats.ScriptsCount,
+		ScriptsByType:   stats.ScriptsByType,
+		ScriptsByGroup:  stats.ScriptsByGroup,
+		LinesByDatabase: lines,
+	}
+}
+
+// listStats gibt die Kennzahlen der Statistik-Ansicht aus: Gesamtzahlen,
+// Scripts je Typ (scripts.TYP) und Gruppe (groups.GRUPPE) und Codezeilen je Datenbank (lines.NAME)
+func listStats(db *NinoxDB, databases []Database, opts cliOptions) [][]interface{} {
+	stats := cliStats(db, databases, opts)
+	rows := [][]interface{}{
+		{"databases", stats.Databases},
+		{"tables", stats.Tables},
+		{"fields", stats.Fields},
+		{"relationships", stats.Relationships},
+		{"scripts", stats.Scripts},
+	}
+	for _, e := range sortedCounts(stats.ScriptsByType) {
+		rows = append(rows, []interface{}{"scripts." + e.name, e.count})
+	}
+	for _, e := range sortedCounts(stats.ScriptsByGroup) {
+		rows = append(rows, []interface{}{"groups." + e.name, e.count})
+	}
+	for _, e := range sortedCounts(stats.LinesByDatabase) {
+		rows = append(rows, []interface{}{"lines." + e.name, e.count})
+	}
+	return rows
+}
+
+// writeListJSON schreibt die Zeilen als JSON-Array von Objekten (Schlüssel in Spaltenreihenfolge)
+func writeListJSON(columns []listColumn, rows [][]interface{}) error {
+	var b bytes.Buffer
+	b.WriteString("[")
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  {")
+		for j, value := range row {
+			key, _ := json.Marshal(columns[j].key)
+			data, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%s: %s", key, data)
+		}
+		b.WriteString("}")
+	}
+	if len(rows) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("]\n")
+	_, err := os.Stdout.Write(b.Bytes())
+	return err
+}
+
+// cmdList gibt Schema-Objekte tabulatorgetrennt oder als JSON (--json) aus
+func cmdList(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) != 1 || listKinds[opts.positional[0]].rows == nil {
+		fail(exitUsage, "Verwendung: ninox-tui list %s [--database NAME] [--table NAME] [--json] [--db DATEI]", listKindNames())
+	}
+	if opts.output != "text" && opts.output != "json" {
+		fail(exitUsage, "Unbekanntes Ausgabeformat: %s (erlaubt: text, json)", opts.output)
+	}
+	name := opts.positional[0]
+	kind := listKinds[name]
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	databases, err := selectDatabases(db, opts.database)
+	if err != nil {
+		fail(exitNoResults, "%v", err)
+	}
+
+	// Die Statistik ist im JSON ein Objekt statt einer Liste von Kennzahlen
+	if name == "stats" && opts.output == "json" {
+		data, err := json.MarshalIndent(cliStats(db, databases, opts), "", "  ")
+		if err != nil {
+			fail(exitFailure, "%v", err)
+		}
+		fmt.Println(string(data))
+		return exitOK
+	}
+
+	rows := kind.rows(db, databases, opts)
+	if opts.output == "json" {
+		if err := writeListJSON(kind.columns, rows); err != nil {
+			fail(exitFailure, "%v", err)
+		}
+		if len(rows) == 0 {
+			return exitNoResults
+		}
+		return exitOK
+	}
+
+	if len(rows) == 0 {
+		return exitNoResults
+	}
+	if !quiet {
+		var headers []string
+		for _, c := range kind.columns {
+			headers = append(headers, c.header)
+		}
+		fmt.Println(strings.Join(headers, "\t"))
+	}
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = fmt.Sprint(v)
+		}
+		fmt.Println(strings.Join(cells, "\t"))
+	}
+	return exitOK
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Logikdichte je Tabelle: Formelfelder und Trigger im Verhältnis zur Feldzahl.
+// Tabellen mit viel Automatik fallen so in der Tabellenliste und der Statistik auf.
+// =============================================================================
+
+const (
+	heavyLogicDensity = 0.5 // ab diesem Anteil gilt eine Tabelle als stark automatisiert
+	heavyLogicMin     = 3   // … sofern sie mindestens so viele Formeln und Trigger hat
+	maxLogicTables    = 5   // Tabellen im Abschnitt der Statistik
+)
+
+// TableLogic ist die Logik einer Tabelle für die Statistik
+type TableLogic struct {
+	DatabaseName string
+	TableName    string
+	FieldCount   int
+	FormulaCount int
+	TriggerCount int
+}
+
+// logicDensity liefert Formelfelder und Trigger je Feld
+func logicDensity(fields, formulas, triggers int) float64 {
+	return float64(formulas+triggers) / float64(max(fields, 1))
+}
+
+// heavyLogic meldet stark automatisierte Tabellen
+func heavyLogic(fields, formulas, triggers int) bool {
+	return formulas+triggers >= heavyLogicMin && logicDensity(fields, formulas, triggers) >= heavyLogicDensity
+}
+
+// formatDensity zeigt die Logikdichte in Prozent, stark automatisierte mit ▲
+func formatDensity(fields, formulas, triggers int) string {
+	if formulas+triggers == 0 {
+		return "·"
+	}
+	text := fmt.Sprintf("%.0f%%", logicDensity(fields, formulas, triggers)*100)
+	if heavyLogic(fields, formulas, triggers) {
+		text = "▲" + text
+	}
+	return text
+}
+
+// Density liefert die Logikdichte der Tabelle
+func (t Table) Density() float64 {
+	return logicDensity(t.FieldCount, t.FormulaCount, t.TriggerCount)
+}
+
+// triggerCodeTypesSQL liefert die Code-Typen der Kategorie trigger als SQL-Liste.
+// Code-Typen gibt es in jeder Extraktion, code_category nicht.
+func triggerCodeTypesSQL() string {
+	seen := make(map[string]bool)
+	var types []string
+	for _, f := range append(append([]codeField{}, tableCodeFields...), fieldCodeFields...) {
+		if f.category == "trigger" && !seen[f.key] {
+			seen[f.key] = true
+			types = append(types, "'"+f.key+"'")
+		}
+	}
+	return "(" + strings.Join(types, ", ") + ")"
+}
+
+// formulaCountSQL zählt die Formelfelder der Tabelle t
+const formulaCountSQL = `(SELECT COUNT(*) FROM fields f
+	WHERE f.database_id = t.database_id AND f.table_id = t.table_id AND f.has_formula = 1)`
+
+// triggerCountSQL zählt die Trigger der Tabelle t; filter schränkt die Scripts s ein
+func triggerCountSQL(filter string) string {
+	return `(SELECT COUNT(*) FROM scripts s
+		WHERE s.database_id = t.database_id AND s.table_id = t.table_id
+		  AND s.code_type IN ` + triggerCodeTypesSQL() + filter + `)`
+}
+
+// GetTableLogic lädt die Tabellen mit der höchsten Logikdichte ("" = alle Datenbanken)
+func (db *NinoxDB) GetTableLogic(databaseID string) ([]TableLogic, error) {
+	scriptFilter := db.ignoreFilter("s.database_id", "s.table_id", "s.id")
+	filter := db.ignoreFilter("t.database_id", "t.table_id", "")
+	var args []interface{}
+	if databaseID != "" {
+		filter += " AND t.database_id = ?"
+		args = append(args, databaseID)
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT COALESCE(d.name, t.database_id), t.name, t.field_count,
+		       `+formulaCountSQL+`, `+triggerCountSQL(scriptFilter)+`
+		FROM tables t
+		LEFT JOIN databases d ON d.id = t.database_id
+		WHERE 1 = 1`+filter, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []TableLogic
+	for rows.Next() {
+		var t TableLogic
+		if err := rows.Scan(&t.DatabaseName, &t.TableName, &t.FieldCount, &t.FormulaCount, &t.TriggerCount); err != nil {
+			return nil, err
+		}
+		if t.FormulaCount+t.TriggerCount > 0 {
+			tables = append(tables, t)
+		}
+	}
+	sort.SliceStable(tables, func(i, j int) bool {
+		a, b := tables[i], tables[j]
+		dx, dy := logicDensity(a.FieldCount, a.FormulaCount, a.TriggerCount), logicDensity(b.FieldCount, b.FormulaCount, b.TriggerCount)
+		if dx != dy {
+			return dx > dy
+		}
+		return a.FormulaCount+a.TriggerCount > b.FormulaCount+b.TriggerCount
+	})
+	if len(tables) > maxLogicTables {
+		tables = tables[:maxLogicTables]
+	}
+	return tables, rows.Err()
+}
+
+// renderLogicDensity rendert den Statistik-Abschnitt der am stärksten automatisierten Tabellen
+func renderLogicDensity(tables []TableLogic, multiDB bool) string {
+	var b strings.Builder
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("  %-30s %7s %7s %7s %7s", "Tabelle", "Felder", "Formeln", "Trigger", "Dichte")) + "\n")
+	for _, t := range tables {
+		name := t.TableName
+		if multiDB {
+			name = t.DatabaseName + " › " + t.TableName
+		}
+		line := fmt.Sprintf("  %-30s %7d %7s %7s %7s", truncate(name, 30), t.FieldCount,
+			countBadge(t.FormulaCount), countBadge(t.TriggerCount), formatDensity(t.FieldCount, t.FormulaCount, t.TriggerCount))
+		style := normalStyle
+		if heavyLogic(t.FieldCount, t.FormulaCount, t.TriggerCount) {
+			style = normalStyle.Foreground(currentTheme.Accent).Bold(true)
+		}
+		b.WriteString(style.Render(line) + "\n")
+	}
+	return b.String()
+}
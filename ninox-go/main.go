@@ -2,6 +2,8 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -88,6 +90,20 @@ var (
 	tableCellSelectedStyle lipgloss.Style
 )
 
+// themeByName liefert ein Theme anhand seines Namens
+func themeByName(name string) (Theme, error) {
+	if name == "" {
+		return DarkTheme, nil
+	}
+	if theme, ok := customThemes[name]; ok {
+		return theme, nil
+	}
+	if theme, err := builtinTheme(name); err == nil {
+		return theme, nil
+	}
+	return Theme{}, fmt.Errorf("Unbekanntes Theme: %s (%s)", name, strings.Join(themeNames(), ", "))
+}
+
 // applyTheme wendet ein Theme auf alle Styles an
 func applyTheme(theme Theme) {
 	currentTheme = theme
@@ -171,6 +187,19 @@ const (
 	viewStats
 	viewHelp
 	viewAllScripts // Neue Gesamtansicht aller Scripts
+	viewRaw        // Rohdefinition einer Tabelle/eines Feldes
+	viewSQL        // SQL-Konsole
+	viewQueries    // Menü gespeicherter Abfragen
+	viewDiff       // Unified Diff (Scripts/Snapshots)
+	viewPath       // Verbindungswege zwischen zwei Tabellen
+	viewCompare    // Zwei Datenbanken nebeneinander
+	viewRoles      // Rollen und Rechte
+	viewLayout     // Formular-Layout einer Tabelle
+	viewAutomations // Zeitpläne, Webhooks und Trigger
+	viewFindings    // Befunde aller Lint-Regeln
+	viewTeams       // Teams über den Datenbanken
+	viewChanges     // Änderungen zwischen zwei Ständen des Verlaufs
+	viewIdentifiers // Bezeichner im Code mit Häufigkeit
 )
 
 // Tastenbelegung
@@ -190,6 +219,50 @@ type keyMap struct {
 	PageDown  key.Binding
 	AllScripts key.Binding // Neue Taste für Gesamtansicht
 	Filter    key.Binding  // Filter aktivieren
+	Undo      key.Binding  // Letzten Filter zurücknehmen
+	Raw       key.Binding  // Rohdefinition anzeigen
+	SQL       key.Binding  // SQL-Konsole öffnen
+	Queries   key.Binding  // Gespeicherte Abfragen
+	Save      key.Binding  // Abfrage speichern
+	Export    key.Binding  // Ergebnis exportieren
+	Copy      key.Binding  // Script-Code kopieren
+	Edit      key.Binding  // Script im externen Editor öffnen
+	CopyMode  key.Binding  // Kopiermodus (Ansicht ohne Rahmen auswählen)
+	CopyMeta  key.Binding  // Script mit Herkunftskopf kopieren
+	CopyLink  key.Binding  // Ninox-Link kopieren
+	Mermaid   key.Binding  // Mermaid-Diagramm kopieren
+	Compare   key.Binding  // Datenbanken vergleichen
+	ByValue   key.Binding  // Nach Wert der Zeile filtern
+	Frequency key.Binding  // Häufigkeiten einer Spalte
+	History   key.Binding  // Verlauf eines Scripts oder Feldes
+	Changes   key.Binding  // Änderungen zwischen zwei Ständen
+	Crumb     key.Binding  // Zu einer Ebene der Breadcrumb springen
+	DBTab     key.Binding  // Zum Tab einer Datenbank wechseln
+	Reindex   key.Binding  // Index im Hintergrund neu aufbauen
+	Ignored   key.Binding  // Ausgeblendete Objekte anzeigen
+	Archived  key.Binding  // Archivierte Datenbanken anzeigen
+	Sort      key.Binding  // Nächste Sortierspalte
+	SortDir   key.Binding  // Sortierrichtung umkehren
+	Finder    key.Binding  // Script-Auswahl (fzf)
+	JumpBack  key.Binding  // Sprungliste zurück
+	JumpFwd   key.Binding  // Sprungliste vorwärts
+	More      key.Binding  // Mehr Vorschauzeilen
+	Less      key.Binding  // Weniger Vorschauzeilen
+	RelDir    key.Binding  // Richtung der Beziehungen
+	RelSort   key.Binding  // Beziehungen nach Tabelle sortieren
+	FindAll   key.Binding  // Alle Treffer im Script
+	Switch    key.Binding  // Schnellwechsel der Tabellen
+	Path      key.Binding  // Weg zwischen zwei Tabellen
+	Roles     key.Binding  // Rollen und Rechte
+	Layout    key.Binding  // Formular-Layout der Tabelle
+	OptionRefs key.Binding // Scripts mit Auswahlwerten des Feldes
+	Automations key.Binding // Unbeaufsichtigt laufende Scripts
+	Findings    key.Binding // Befunde aller Lint-Regeln
+	Teams       key.Binding // Team-Übersicht
+	Mark        key.Binding // Script zum Vergleich markieren
+	DiffMarked  key.Binding // Markierte Scripts vergleichen
+	Files       key.Binding // Schema-Datei wechseln
+	Identifiers key.Binding // Bezeichner-Index
 }
 
 var keys = keyMap{
@@ -208,11 +281,59 @@ var keys = keyMap{
 	PageDown:  key.NewBinding(key.WithKeys("pgdown", "ctrl+d"), key.WithHelp("PgDn", "seite runter")),
 	AllScripts: key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "alle Scripts")),
 	Filter:    key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter")),
+	Undo:      key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "filter zurück")),
+	Raw:       key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "rohdaten")),
+	SQL:       key.NewBinding(key.WithKeys(":"), key.WithHelp(":", "sql")),
+	Queries:   key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "abfragen")),
+	Save:      key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "speichern")),
+	Export:    key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "exportieren")),
+	Copy:      key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "code kopieren")),
+	Edit:      key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "im editor")),
+	CopyMode:  key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "kopiermodus")),
+	CopyMeta:  key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", "mit kopf kopieren")),
+	CopyLink:  key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "link kopieren")),
+	Mermaid:   key.NewBinding(key.WithKeys("M"), key.WithHelp("M", "mermaid kopieren")),
+	Compare:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "vergleichen")),
+	ByValue:   key.NewBinding(key.WithKeys("="), key.WithHelp("=", "nach wert filtern")),
+	Frequency: key.NewBinding(key.WithKeys("H"), key.WithHelp("H", "häufigkeiten")),
+	History:   key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "verlauf")),
+	Changes:   key.NewBinding(key.WithKeys("U"), key.WithHelp("U", "änderungen")),
+	Crumb:     key.NewBinding(key.WithKeys("1", "2", "3"), key.WithHelp("1-3", "zur ebene springen")),
+	// Strg+Zahl senden die meisten Terminals nicht, daher Alt+Zahl
+	DBTab: key.NewBinding(key.WithKeys("alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9"),
+		key.WithHelp("alt+1-9", "datenbank-tab")),
+	Reindex: key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "neu indizieren")),
+	Ignored: key.NewBinding(key.WithKeys("."), key.WithHelp(".", "ausgeblendete zeigen")),
+	Archived: key.NewBinding(key.WithKeys("z"), key.WithHelp("z", "archivierte zeigen")),
+	Sort:    key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "sortieren")),
+	SortDir: key.NewBinding(key.WithKeys("O"), key.WithHelp("O", "sortierung umkehren")),
+	Finder:  key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "script wählen (fzf)")),
+	// Ctrl+I ist im Terminal identisch mit Tab, daher Alt+I für vorwärts
+	JumpBack: key.NewBinding(key.WithKeys("ctrl+o"), key.WithHelp("ctrl+o", "sprung zurück")),
+	JumpFwd:  key.NewBinding(key.WithKeys("alt+i"), key.WithHelp("alt+i", "sprung vorwärts")),
+	More:     key.NewBinding(key.WithKeys("+"), key.WithHelp("+", "mehr vorschau")),
+	Less:     key.NewBinding(key.WithKeys("-"), key.WithHelp("-", "weniger vorschau (0: kompakt)")),
+	RelDir:   key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "beziehungen: richtung")),
+	RelSort:  key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "beziehungen nach tabelle")),
+	FindAll:  key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "alle treffer im script")),
+	Switch:   key.NewBinding(key.WithKeys("ctrl+t"), key.WithHelp("ctrl+t", "tabelle wechseln")),
+	Path:     key.NewBinding(key.WithKeys("g"), key.WithHelp("g, g", "weg zwischen tabellen")),
+	Roles:    key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "rollen & rechte")),
+	Layout:   key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "formular-layout")),
+	OptionRefs: key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "verwendung der auswahlwerte")),
+	Automations: key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "automatisierungen")),
+	Findings:    key.NewBinding(key.WithKeys("B"), key.WithHelp("B", "befunde")),
+	Teams:       key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "teams")),
+	Mark:        key.NewBinding(key.WithKeys(" "), key.WithHelp("Leertaste", "zum vergleich markieren")),
+	DiffMarked:  key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "markierte scripts vergleichen")),
+	Files:       key.NewBinding(key.WithKeys("W"), key.WithHelp("W", "schema-datei wechseln")),
+	Identifiers: key.NewBinding(key.WithKeys("I"), key.WithHelp("I", "bezeichner-index")),
 }
 
 // Model ist das Hauptmodell der Anwendung
 type Model struct {
 	db            *NinoxDB
+	config        *Config
 	width, height int
 	mode          viewMode
 	prevMode      viewMode
@@ -223,18 +344,151 @@ type Model struct {
 	fields        []Field
 	scripts       []Script
 	searchResults []Script
+	searchFields  []FieldMatch // Treffer in Felddefinitionen (nach den Scripts)
 	relationships []Relationship
+	relDirection  relDirection // Richtungsfilter der Beziehungen
+	relByName     bool         // Beziehungen nach Gegenseite sortieren
 	stats         *Stats
+	statsScoped   *Stats // Statistik des Ausschnitts beim Öffnen (nil = keiner)
+	statsAll      bool   // Gesamtzahlen statt Ausschnitt anzeigen
+	trend         []trendPoint // Stände aus älteren Extraktionen (snapshots)
+	trendLoaded   bool
+
+	// Sortierung pro Ansicht (aus dem Zustandsverzeichnis)
+	sortPrefs map[string]sortPref
+
+	// Hervorgehobene Vorschau des ausgewählten Scripts (verzögert berechnet)
+	preview      previewState
+	previewLines int // Codezeilen je Vorschau, 0 = kompakt
+
+	// Sprungliste: besuchte Positionen, jumpPos == len(jumps) heißt "am Ende"
+	jumps   []navState
+	jumpPos int
+
+	// Kopiermodus
+	copyMode copyModeState
+
+	// Häufigkeiten einer Spalte (Popup)
+	frequency frequencyState
+
+	// Verlauf eines Scripts oder Feldes (Popup)
+	history historyState
+
+	// Automatisch gesicherte Sitzung (leer = nicht sichern)
+	sessionKey   string
+	sessionSaved string // zuletzt gesicherter Zustand, um unveränderte nicht erneut zu schreiben
 
 	// Gesamtansicht aller Scripts
 	allScripts         []Script // Alle Scripts aus der DB
 	filteredScripts    []Script // Gefilterte Scripts
 	selectedAllScript  int      // Auswahl in der Gesamtliste
 	filterInput        textinput.Model // Filter-Eingabe
-	filterText         string   // Aktueller Filter-Text
+	filterStack        []string // Angewendete Filter, jeder grenzt den vorherigen weiter ein
+	filterBase         []Script // Ausgangsmenge der Filter (nil = alle Scripts)
+	filterBaseLabel    string   // Herkunft der Ausgangsmenge, z.B. die Suche
+	searchWithin       []Script // Suche nur in diesen Scripts (gefilterte Gesamtansicht)
+	quickValues        []quickValue // Werte der Zeile für wiederholtes "="
+	quickIndex         int
 	filtering          bool     // Filter-Modus aktiv
 	scrollOffset       int      // Scroll-Position in der Liste
 
+	// SQL-Konsole
+	sqlInput    textinput.Model
+	sqlEditing  bool
+	sqlResult   *QueryResult
+	sqlErr      error
+	sqlSelected int
+	sqlOffset   int
+	sqlColOffset int // erste sichtbare Spalte nach der fixierten ersten Spalte
+	sqlTitle    string // Name der angezeigten gespeicherten Abfrage
+	sqlStatus   string // Rückmeldung, z.B. nach dem Speichern
+
+	// Gespeicherte Abfragen
+	sqlNameInput  textinput.Model
+	sqlNaming     bool
+	selectedQuery int
+
+	// Export von Abfrageergebnissen
+	sqlExportInput textinput.Model
+	sqlExporting   bool
+
+	// Alle Treffer im geöffneten Script
+	codeFindInput   textinput.Model
+	codeFinding     bool
+	codeMatches     []codeMatch
+	codeMatchQuery  string
+	codeMatchScript int // ID des durchsuchten Scripts
+	selectedMatch   int
+
+	// Schnellwechsel der Tabellen
+	switchInput    textinput.Model
+	switching      bool
+	switchSelected int
+	tableMRU       map[string][]string // Datenbank-ID → zuletzt geöffnete Tabellen-IDs
+
+	// Mehrere Schema-Dateien (nil = nur eine geöffnet)
+	files         *schemaFiles
+	fileSwitching bool
+	fileSelected  int
+
+	// Rollen und Rechte
+	roleRows      []roleRow
+	roleInput     textinput.Model
+	roleFiltering bool
+	selectedRole  int
+	roleReturn    viewMode // Ansicht vor dem Öffnen
+
+	// Formular-Layout (Datenbank-ID/Tabellen-ID → Elemente)
+	layout          map[string][]LayoutElement
+	layoutTable     *Table
+	layoutRowsCache []layoutRow
+	selectedLayout  int
+	layoutReturn    viewMode
+
+	// Auswahlwerte (Datenbank-ID/Tabellen-ID/Feld-ID → Werte)
+	fieldOptions map[string][]FieldOption
+
+	// Automatisierungen
+	automationRows     []automationRow
+	automationFilter   string // Art ("" = alle)
+	selectedAutomation int
+	automationReturn   viewMode
+
+	// Bezeichner-Index
+	identRows        []identEntry
+	identOpen        *identEntry // Bezeichner, dessen Scripts angezeigt werden
+	identByName      bool        // alphabetisch statt nach Häufigkeit
+	identScope       string
+	identInput       textinput.Model
+	identFiltering   bool
+	selectedIdent    int
+	selectedIdentUse int
+	identReturn      viewMode
+
+	// Befunde
+	findings           []lintFinding
+	findingSeverity    lintSeverity // Mindest-Schwere
+	selectedFinding    int
+	findingsReturn     viewMode
+	findingsSuppressed int  // per Kommentar oder Baseline ausgeblendet
+	findingsExporting  bool // Dateiname im Export-Eingabefeld
+	scriptsExporting   bool // Zielverzeichnis im Export-Eingabefeld (Gesamtansicht)
+	scriptHTMLExporting bool // Dateiname im Export-Eingabefeld (Code-Ansicht)
+	databaseExporting  bool // Zielverzeichnis im Export-Eingabefeld (Datenbank-Ansicht)
+
+	// Änderungen zwischen Ständen des Verlaufs
+	changeSnapshots []snapshotInfo
+	changeBase      int // Index des Ausgangsstandes; verglichen wird mit dem letzten
+	changeDiff      *SnapshotDiff
+	selectedChange  int
+	changesReturn   viewMode
+
+	// Teams
+	allDatabases []Database // alle Teams; databases enthält nur das gewählte
+	teams        []Team
+	currentTeam  *Team // nil = alle Teams
+	selectedTeam int   // 0 = alle Teams
+
 	// Auswahl
 	selectedDB     int
 	selectedTable  int
@@ -250,19 +504,70 @@ type Model struct {
 	// Aktueller Kontext
 	currentDB    *Database
 	currentTable *Table
+	codeScript   *Script // Script in der Code-Ansicht
+	rawTitle     string  // Titel der Rohdaten-Ansicht
+	diffTitle    string  // Titel der Diff-Ansicht
+
+	// Script-Vergleich
+	markedScripts []Script    // höchstens zwei, ältestes zuerst
+	scriptDiff    *scriptPair // nil, wenn die Diff-Ansicht etwas anderes zeigt
+
+	// Datenbankvergleich
+	compareLeft     *Database
+	compareRight    *Database
+	compareRows     []TableComparison
+	selectedCompare int
+
+	// Wegsuche zwischen Tabellen
+	pathFrom  string // gewählte Ausgangstabelle
+	pathTitle string
+
+	// Navigationszustand der Datenbank-Tabs (nach Datenbank-ID)
+	tabs map[string]navState
+
+	// Statusmeldung, wird beim nächsten Tastendruck gelöscht
+	status string
+
+	// Hintergrund-Reindex
+	reindexCancel context.CancelFunc
+	reindexCh     chan tea.Msg
+	progress      string         // Fortschrittsanzeige, bleibt bis zum Ende stehen
+	analysis      *AnalysisIndex // nil bis zum ersten Reindex
+
+	// Komplett-Export einer Datenbank
+	bundleCancel context.CancelFunc
+	bundleCh     chan tea.Msg
+	bundleName   string
 
 	// Flags
-	searching bool
+	showIgnored  bool // Per Muster ausgeblendete Objekte werden angezeigt
+	showArchived bool // Archivierte Datenbanken werden angezeigt
+	cheatsheet  bool // Tastenübersicht wird angezeigt
+	tour        tourState
+	searching   bool
 	err       error
 }
 
 // NewModel erstellt ein neues Model
-func NewModel(dbPath string) (*Model, error) {
+func NewModel(dbPath string, cfg *Config) (*Model, error) {
 	db, err := NewNinoxDB(dbPath)
 	if err != nil {
 		return nil, err
 	}
 
+	setCodeGroups(cfg.CodeGroups)
+	if err := setScriptNaming(cfg.ScriptNameTemplate); err != nil {
+		return nil, err
+	}
+
+	// Ausgeblendete Objekte aus der Konfiguration
+	if err := db.SetIgnore(cfg.Ignore); err != nil {
+		return nil, fmt.Errorf("Ausblendmuster: %w", err)
+	}
+	if err := db.SetArchived(cfg.Archived); err != nil {
+		return nil, fmt.Errorf("Archivierte Datenbanken: %w", err)
+	}
+
 	// Datenbanken laden
 	databases, err := db.GetDatabases()
 	if err != nil {
@@ -286,42 +591,190 @@ func NewModel(dbPath string) (*Model, error) {
 	ti.Placeholder = "Suchbegriff eingeben..."
 	ti.CharLimit = 100
 	ti.Width = 50
+	ti.ShowSuggestions = true // ↑↓ blättert im Suchverlauf, Tab übernimmt
+	ti.SetSuggestions(loadSearchHistory())
 
 	// Filter-Eingabe
 	fi := textinput.New()
-	fi.Placeholder = "Filter: Begriff AND Begriff OR Begriff..."
+	fi.Placeholder = "Filter: Begriff AND NOT Begriff OR Begriff..."
 	fi.CharLimit = 200
 	fi.Width = 80
 
+	// SQL-Eingabe
+	qi := textinput.New()
+	qi.Placeholder = "SELECT name, field_count FROM tables ORDER BY field_count DESC"
+	qi.CharLimit = 2000
+	qi.Width = 100
+	qi.Prompt = "SQL> "
+
+	ni := textinput.New()
+	ni.Placeholder = "Name der Abfrage"
+	ni.CharLimit = 80
+	ni.Width = 50
+	ni.Prompt = "Speichern als: "
+
+	ci := textinput.New()
+	ci.Placeholder = "Begriff"
+	ci.CharLimit = 100
+	ci.Width = 50
+	ci.Prompt = "Im Script suchen: "
+
+	wi := textinput.New()
+	wi.Placeholder = "Tabelle"
+	wi.CharLimit = 60
+	wi.Width = 30
+	wi.Prompt = "› "
+
+	ri := textinput.New()
+	ri.Placeholder = "Rolle, Tabelle, Feld… (AND/OR/NOT)"
+	ri.CharLimit = 100
+	ri.Width = 50
+	ri.Prompt = ""
+
+	ii := textinput.New()
+	ii.Placeholder = "Name"
+	ii.CharLimit = 100
+	ii.Width = 40
+	ii.Prompt = ""
+
+	ei := textinput.New()
+	ei.Placeholder = "ergebnis.csv | .json | .md"
+	ei.CharLimit = 255
+	ei.Width = 60
+	ei.Prompt = "Exportieren nach: "
+
 	// Viewports
 	cv := viewport.New(80, 20)
 	lv := viewport.New(80, 20)
 
-	return &Model{
+	var sortPrefs map[string]sortPref
+	loadState(sortFile, &sortPrefs)
+
+	var tableMRU map[string][]string
+	loadState(tableMRUFile, &tableMRU)
+
+	m := &Model{
 		db:              db,
 		databases:       databases,
 		stats:           stats,
 		mode:            viewDatabases,
 		searchInput:     ti,
 		filterInput:     fi,
+		sqlInput:        qi,
+		sqlNameInput:    ni,
+		codeFindInput:   ci,
+		switchInput:     wi,
+		roleInput:       ri,
+		identInput:      ii,
+		sqlExportInput:  ei,
+		config:          cfg,
 		codeView:        cv,
 		listView:        lv,
 		allScripts:      allScripts,
 		filteredScripts: allScripts, // Initial alle anzeigen
-	}, nil
+		sortPrefs:       sortPrefs,
+		tableMRU:        tableMRU,
+		previewLines:    defaultPreviewLines,
+	}
+	if cfg.PreviewLines != nil {
+		m.previewLines = max(0, min(maxPreviewLines, *cfg.PreviewLines))
+	}
+	m.setDatabases(databases)
+	m.applySort(viewDatabases)
+	m.applySort(viewAllScripts)
+
+	// Mehrere Teams: mit der Team-Übersicht beginnen
+	if m.multiTeam() {
+		m.mode = viewTeams
+	}
+
+	// Gespeicherte Analyse übernehmen, sofern sie alle Scripts abdeckt
+	m.analysis = cachedAnalysisIndex(db, allScripts)
+
+	// Layout-Elemente für den Kontext der Scripts (fehlen in älteren Extraktionen)
+	m.layout, _ = db.GetLayoutElements()
+	m.fieldOptions, _ = db.GetFieldOptions()
+
+	// Hinweis bei abweichendem Schema der Extraktion
+	if notice := db.CompatNotice(); notice != "" {
+		m.status = "⚠ " + notice
+	}
+	return m, nil
+}
+
+// startViews ordnet die Namen für --view den Ansichten zu
+var startViews = map[string]viewMode{
+	"teams":      viewTeams,
+	"databases":  viewDatabases,
+	"allscripts": viewAllScripts,
+	"stats":      viewStats,
+	"search":     viewSearch,
+}
+
+// SetStartView legt die Ansicht fest, in der das Programm startet
+func (m *Model) SetStartView(name string) error {
+	mode, ok := startViews[name]
+	if !ok {
+		return fmt.Errorf("unbekannte Ansicht: %s (erlaubt: teams, databases, allscripts, stats, search)", name)
+	}
+
+	switch mode {
+	case viewSearch:
+		// Suche startet mit geöffneter Eingabe
+		m.searching = true
+		m.searchInput.Focus()
+	default:
+		m.mode = mode
+	}
+	return nil
+}
+
+// SetStartFilter setzt den Filter der Gesamtansicht vor dem Start
+func (m *Model) SetStartFilter(filter string) {
+	m.pushFilter(filter)
 }
 
 // Init initialisiert das Model
 func (m Model) Init() tea.Cmd {
-	return nil
+	var tick tea.Cmd
+	if m.sessionKey != "" {
+		tick = sessionTick()
+	}
+	if m.searching {
+		return tea.Batch(textinput.Blink, tick)
+	}
+	return tick
 }
 
-// Update verarbeitet Nachrichten
-func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// Update verarbeitet Nachrichten; danach rücken Tour und Vorschau nach
+func (m Model) Update(msg tea.Msg) (next tea.Model, nextCmd tea.Cmd) {
+	defer func() { next, nextCmd = afterUpdate(next, nextCmd) }()
+
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
+	case previewTickMsg:
+		return m.handlePreviewTick(msg)
+
+	case previewReadyMsg:
+		return m.handlePreviewReady(msg)
+
+	case reindexProgressMsg, reindexDoneMsg:
+		return m.handleReindexMsg(msg)
+
+	case bundleProgressMsg, bundleDoneMsg:
+		return m.handleBundleMsg(msg)
+
+	case finderDoneMsg:
+		return m.handleFinderDone(msg)
+
+	case editorDoneMsg:
+		return m.handleEditorDone(msg)
+
+	case sessionTickMsg, sessionHangupMsg:
+		return m.handleSessionMsg(msg)
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -332,6 +785,43 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		m.status = ""
+		if !key.Matches(msg, keys.ByValue) {
+			m.quickValues = nil
+		}
+
+		// Tour: Schritt überspringen bzw. beenden
+		if m.tour.active {
+			if model, cmd, ok := m.handleTourKey(msg); ok {
+				return model, cmd
+			}
+		}
+
+		// Tastenübersicht schließt bei jeder Taste; ? öffnet die volle Hilfe
+		if m.cheatsheet {
+			m.cheatsheet = false
+			if key.Matches(msg, keys.Help) {
+				m.prevMode = m.mode
+				m.mode = viewHelp
+			}
+			return m, nil
+		}
+
+		// Im Kopiermodus
+		if m.copyMode.active {
+			return m.handleCopyModeKey(msg)
+		}
+
+		// Im Häufigkeits-Popup
+		if m.frequency.active {
+			return m.handleFrequencyKey(msg)
+		}
+
+		// Im Verlaufs-Popup
+		if m.history.active {
+			return m.handleHistoryKey(msg)
+		}
+
 		// Im Such-Modus
 		if m.searching {
 			switch {
@@ -342,13 +832,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, keys.Enter):
 				m.searching = false
 				m.searchInput.Blur()
-				results, err := m.db.SearchScripts(m.searchInput.Value(), 50)
-				if err == nil {
-					m.searchResults = results
-					m.selectedSearch = 0
-					m.mode = viewSearch
-				}
-				return m, nil
+				m.searchInput.SetSuggestions(addSearchHistory(m.searchInput.AvailableSuggestions(), m.searchInput.Value()))
+				m.recordJump()
+				return m.runSearch()
 			default:
 				m.searchInput, cmd = m.searchInput.Update(msg)
 				return m, cmd
@@ -365,8 +851,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, keys.Enter):
 				m.filtering = false
 				m.filterInput.Blur()
-				m.filterText = m.filterInput.Value()
-				m.applyFilter()
+				m.pushFilter(m.filterInput.Value())
+				m.filterInput.SetValue("")
 				return m, nil
 			default:
 				m.filterInput, cmd = m.filterInput.Update(msg)
@@ -374,16 +860,78 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Beim Benennen einer Abfrage
+		if m.sqlNaming {
+			return m.handleSQLNameInput(msg)
+		}
+
+		// Beim Export eines Abfrageergebnisses
+		if m.sqlExporting {
+			return m.handleSQLExportInput(msg)
+		}
+		if m.findingsExporting {
+			return m.handleFindingsExportInput(msg)
+		}
+		if m.scriptsExporting {
+			return m.handleScriptsExportInput(msg)
+		}
+		if m.scriptHTMLExporting {
+			return m.handleScriptHTMLExportInput(msg)
+		}
+		if m.databaseExporting {
+			return m.handleDatabaseExportInput(msg)
+		}
+
+		// Im Schnellwechsel
+		if m.switching {
+			return m.handleSwitcherInput(msg)
+		}
+		if m.fileSwitching {
+			return m.handleFileSwitcherKey(msg)
+		}
+
+		// Beim Filtern der Rechte
+		if m.roleFiltering {
+			return m.handleRoleFilterInput(msg)
+		}
+		if m.identFiltering {
+			return m.handleIdentFilterInput(msg)
+		}
+
+		// Bei der Suche im geöffneten Script
+		if m.codeFinding {
+			return m.handleCodeFindInput(msg)
+		}
+		if m.showsCodeMatches() {
+			if model, cmd, handled := m.handleCodeMatchKey(msg); handled {
+				return model, cmd
+			}
+		}
+
+		// In der SQL-Eingabe
+		if m.sqlEditing {
+			return m.handleSQLInput(msg)
+		}
+
 		// Normale Navigation
 		switch {
 		case key.Matches(msg, keys.Quit):
+			m.endSession()
 			return m, tea.Quit
 
 		case key.Matches(msg, keys.Search):
+			// Aus einer gefilterten Gesamtansicht heraus nur in den Treffern suchen
+			m.searchWithin = nil
+			if m.mode == viewAllScripts && len(m.filterStack) > 0 {
+				m.searchWithin = m.filteredScripts
+			}
 			m.searching = true
 			m.searchInput.Focus()
 			return m, textinput.Blink
 
+		case key.Matches(msg, keys.Finder):
+			return m.openFinder()
+
 		case key.Matches(msg, keys.AllScripts):
 			m.prevMode = m.mode
 			m.mode = viewAllScripts
@@ -392,6 +940,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case key.Matches(msg, keys.Filter):
+			if m.mode == viewRoles {
+				return m.startRoleFilter()
+			}
+			if m.mode == viewIdentifiers {
+				return m.startIdentFilter()
+			}
+			if m.mode == viewSearch {
+				// Suchergebnisse als Ausgangsmenge für Filter übernehmen
+				m.filterBase = m.searchResults
+				m.filterBaseLabel = "🔍 " + m.searchInput.Value()
+				m.filterStack = nil
+				m.applyFilter()
+				m.prevMode = m.mode
+				m.mode = viewAllScripts
+			}
 			if m.mode == viewAllScripts {
 				m.filtering = true
 				m.filterInput.Focus()
@@ -399,10 +962,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case key.Matches(msg, keys.Undo):
+			if m.mode == viewAllScripts && len(m.filterStack) > 0 {
+				m.filterStack = m.filterStack[:len(m.filterStack)-1]
+				m.applyFilter()
+			} else if m.mode == viewAllScripts && m.filterBase != nil {
+				m.filterBase = nil
+				m.filterBaseLabel = ""
+				m.applyFilter()
+			}
+			return m, nil
+
 		case key.Matches(msg, keys.Stats):
 			if m.mode == viewStats {
 				m.mode = m.prevMode
 			} else {
+				m.statsScoped = m.scopedStats()
+				m.statsAll = false
+				m.loadTrendOnce()
 				m.prevMode = m.mode
 				m.mode = viewStats
 			}
@@ -412,11 +989,160 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.mode == viewHelp {
 				m.mode = m.prevMode
 			} else {
+				m.cheatsheet = true
+			}
+			return m, nil
+
+		case key.Matches(msg, keys.Raw):
+			return m.handleRaw()
+
+		case key.Matches(msg, keys.Mark):
+			return m.toggleMark()
+
+		case key.Matches(msg, keys.DiffMarked):
+			return m.diffMarkedScripts()
+
+		case key.Matches(msg, keys.RelDir):
+			return m.cycleRelDirection()
+
+		case key.Matches(msg, keys.RelSort):
+			return m.toggleRelSort()
+
+		case key.Matches(msg, keys.FindAll):
+			return m.startCodeFind()
+
+		case key.Matches(msg, keys.Switch):
+			return m.openSwitcher()
+
+		case key.Matches(msg, keys.Files):
+			return m.openFileSwitcher()
+
+		case key.Matches(msg, keys.SQL):
+			return m.openSQLConsole()
+
+		case key.Matches(msg, keys.Queries):
+			if m.mode != viewQueries {
 				m.prevMode = m.mode
-				m.mode = viewHelp
+				m.mode = viewQueries
+			}
+			return m, nil
+
+		case key.Matches(msg, keys.Save):
+			return m.startSaveQuery()
+
+		case key.Matches(msg, keys.Export):
+			if m.mode == viewFindings {
+				return m.startExportFindings()
+			}
+			if m.mode == viewAllScripts {
+				return m.startExportScripts()
+			}
+			if m.mode == viewCode {
+				return m.startExportScriptHTML()
+			}
+			if m.mode == viewDatabases {
+				return m.startExportDatabase()
+			}
+			return m.startExportResult()
+
+		case key.Matches(msg, keys.Copy) && m.mode == viewCode:
+			return m.copyScript()
+
+		case key.Matches(msg, keys.CopyMeta):
+			return m.copyScriptWithHeader()
+
+		case key.Matches(msg, keys.Edit):
+			return m.openInEditor()
+
+		case key.Matches(msg, keys.CopyMode):
+			return m.openCopyMode()
+
+		case key.Matches(msg, keys.CopyLink):
+			return m.copyDeepLink()
+
+		case key.Matches(msg, keys.Mermaid):
+			return m.copyMermaid()
+
+		case key.Matches(msg, keys.Compare):
+			return m.handleCompare()
+
+		case key.Matches(msg, keys.Path):
+			return m.handlePath()
+
+		case key.Matches(msg, keys.Roles):
+			return m.openRoles()
+
+		case key.Matches(msg, keys.Layout):
+			return m.openLayout()
+
+		case key.Matches(msg, keys.OptionRefs):
+			return m.showOptionRefs()
+
+		case key.Matches(msg, keys.Automations):
+			return m.openAutomations()
+
+		case key.Matches(msg, keys.Findings):
+			return m.openFindings()
+
+		case key.Matches(msg, keys.Identifiers):
+			return m.openIdentifiers()
+
+		case key.Matches(msg, keys.Teams):
+			return m.openTeams()
+
+		case key.Matches(msg, keys.ByValue):
+			return m.filterByValue()
+
+		case key.Matches(msg, keys.Frequency):
+			return m.openFrequency()
+
+		case key.Matches(msg, keys.History):
+			return m.openHistory()
+
+		case key.Matches(msg, keys.Changes):
+			return m.openChanges()
+
+		case key.Matches(msg, keys.More):
+			if m.mode == viewAllScripts {
+				return m.resizePreview(1)
+			}
+			return m, nil
+
+		case key.Matches(msg, keys.Less):
+			if m.mode == viewAllScripts {
+				return m.resizePreview(-1)
 			}
 			return m, nil
 
+		case key.Matches(msg, keys.JumpBack):
+			return m.jumpBack()
+
+		case key.Matches(msg, keys.JumpFwd):
+			return m.jumpForward()
+
+		case key.Matches(msg, keys.Crumb):
+			m.recordJump()
+			return m.jumpToCrumb(int(msg.String()[0] - '0'))
+
+		case key.Matches(msg, keys.Reindex):
+			return m.startReindex()
+
+		case key.Matches(msg, keys.Ignored):
+			return m.toggleIgnored()
+
+		case key.Matches(msg, keys.Archived):
+			return m.toggleArchived()
+
+		case key.Matches(msg, keys.Sort):
+			return m.cycleSort(false)
+
+		case key.Matches(msg, keys.SortDir):
+			return m.cycleSort(true)
+
+		case key.Matches(msg, keys.DBTab):
+			m.recordJump()
+			return m.switchTab(int(msg.String()[len("alt+")] - '0'))
+
 		case key.Matches(msg, keys.Back):
 			return m.handleBack()
 
@@ -426,7 +1152,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, keys.Down):
 			return m.handleDown()
 
+		case m.mode == viewSQL && key.Matches(msg, keys.Left):
+			m.scrollSQLColumns(-1)
+			return m, nil
+
+		case m.mode == viewSQL && key.Matches(msg, keys.Right):
+			m.scrollSQLColumns(1)
+			return m, nil
+
 		case key.Matches(msg, keys.Enter), key.Matches(msg, keys.Right):
+			m.recordJump()
 			return m.handleEnter()
 
 		case key.Matches(msg, keys.Tab):
@@ -434,15 +1169,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, keys.PageUp):
 			if m.mode == viewAllScripts {
-				m.scrollOffset = max(0, m.scrollOffset-10)
+				// Seitenweise: Auswahl um einen sichtbaren Bereich verschieben
+				start, end := m.allScriptsWindow()
+				m.selectedAllScript = max(0, m.selectedAllScript-(end-start))
+				m.ensureAllScriptVisible()
 			}
 			m.codeView.ViewUp()
 			return m, nil
 
 		case key.Matches(msg, keys.PageDown):
 			if m.mode == viewAllScripts {
-				maxOffset := max(0, len(m.filteredScripts)-10)
-				m.scrollOffset = min(m.scrollOffset+10, maxOffset)
+				start, end := m.allScriptsWindow()
+				m.selectedAllScript = min(len(m.filteredScripts)-1, m.selectedAllScript+(end-start))
+				m.ensureAllScriptVisible()
 			}
 			m.codeView.ViewDown()
 			return m, nil
@@ -456,19 +1195,96 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-// applyFilter wendet den Filter auf alle Scripts an
-func (m *Model) applyFilter() {
-	if m.filterText == "" {
-		m.filteredScripts = m.allScripts
+// afterUpdate prüft nach jeder Nachricht den Tour-Schritt und fordert bei
+// Bedarf die Vorschau für das ausgewählte Script an
+func afterUpdate(model tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	m, ok := model.(Model)
+	if !ok {
+		return model, cmd
+	}
+	m = m.advanceTour()
+	if tick := m.requestPreview(); tick != nil {
+		return m, tea.Batch(cmd, tick)
+	}
+	return m, cmd
+}
+
+// pushFilter grenzt die aktuelle Ergebnismenge mit einem weiteren Filter ein
+func (m *Model) pushFilter(filter string) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
 		return
 	}
+	m.filterStack = append(m.filterStack, filter)
+	m.applyFilter()
+}
 
-	m.filteredScripts = filterScripts(m.allScripts, m.filterText)
+// applyFilter wendet alle Filter des Stapels nacheinander auf die Ausgangsmenge an
+func (m *Model) applyFilter() {
+	m.filteredScripts = m.allScripts
+	if m.filterBase != nil {
+		m.filteredScripts = m.filterBase
+	}
+	for _, filter := range m.filterStack {
+		m.filteredScripts = filterScripts(m.filteredScripts, filter)
+	}
+	m.applySort(viewAllScripts)
 	m.selectedAllScript = 0
 	m.scrollOffset = 0
 }
 
-// filterScripts filtert Scripts basierend auf AND/OR Logik
+// filterChain beschreibt den Filter-Stapel für die Statuszeile
+func (m Model) filterChain() string {
+	chain := m.filterStack
+	if m.filterBaseLabel != "" {
+		chain = append([]string{m.filterBaseLabel}, chain...)
+	}
+	return strings.Join(chain, " ▸ ")
+}
+
+// runSearch führt die Volltextsuche aus, bei gefilterter Gesamtansicht nur in deren Treffern
+func (m Model) runSearch() (tea.Model, tea.Cmd) {
+	limit := 50
+	if m.searchWithin != nil {
+		limit = -1 // erst nach dem Einschränken begrenzen
+	}
+
+	results, err := m.db.SearchScripts(m.searchInput.Value(), limit)
+	if err != nil {
+		m.status = fmt.Sprintf("Fehler bei der Suche: %v", err)
+		return m, nil
+	}
+
+	if m.searchWithin != nil {
+		allowed := make(map[int]bool, len(m.searchWithin))
+		for _, s := range m.searchWithin {
+			allowed[s.ID] = true
+		}
+		var within []Script
+		for _, s := range results {
+			if allowed[s.ID] && len(within) < 50 {
+				within = append(within, s)
+			}
+		}
+		results = within
+	}
+
+	m.searchResults = results
+	m.applySort(viewSearch)
+
+	// Felddefinitionen nur bei Suche über alles, nicht innerhalb eines Filters
+	m.searchFields = nil
+	if m.searchWithin == nil {
+		if fields, err := m.db.SearchFields(m.searchInput.Value(), maxFieldMatches); err == nil {
+			m.searchFields = fields
+		}
+	}
+	m.selectedSearch = 0
+	m.mode = viewSearch
+	return m, nil
+}
+
+// filterScripts filtert Scripts basierend auf AND/OR/NOT Logik
 func filterScripts(scripts []Script, filter string) []Script {
 	filter = strings.TrimSpace(filter)
 	if filter == "" {
@@ -496,9 +1312,16 @@ func matchesFilter(script Script, orGroups []string) bool {
 			script.ElementName + " " +
 			script.CodeType + " " +
 			script.CodeCategory + " " +
+			script.Group() + " " +
+			script.recencyTerms() + " " +
 			script.Code,
 	)
+	return matchesText(searchText, scriptFilterColumns(script), orGroups)
+}
 
+// matchesText prüft einen kleingeschriebenen Text gegen die OR-Gruppen eines Filters.
+// Begriffe der Form "spalte=Wert" vergleichen eine der columns exakt.
+func matchesText(searchText string, columns map[string]string, orGroups []string) bool {
 	// Mindestens eine OR-Gruppe muss matchen
 	for _, orGroup := range orGroups {
 		orGroup = strings.TrimSpace(orGroup)
@@ -511,11 +1334,17 @@ func matchesFilter(script Script, orGroups []string) bool {
 		allMatch := true
 
 		for _, term := range andTerms {
-			term = strings.TrimSpace(strings.ToLower(term))
+			// NOT vor einem Begriff: darf nicht vorkommen
+			term = strings.TrimSpace(term)
+			negate := strings.HasPrefix(term, "NOT ")
+			if negate {
+				term = strings.TrimSpace(term[len("NOT "):])
+			}
+			term = strings.ToLower(term)
 			if term == "" {
 				continue
 			}
-			if !strings.Contains(searchText, term) {
+			if matchesTerm(searchText, columns, term) == negate {
 				allMatch = false
 				break
 			}
@@ -538,6 +1367,10 @@ func max(a, b int) int {
 
 func (m Model) handleBack() (tea.Model, tea.Cmd) {
 	switch m.mode {
+	case viewDatabases:
+		if m.multiTeam() {
+			return m.openTeams()
+		}
 	case viewTables:
 		m.mode = viewDatabases
 		m.currentDB = nil
@@ -546,26 +1379,87 @@ func (m Model) handleBack() (tea.Model, tea.Cmd) {
 		m.currentTable = nil
 	case viewCode:
 		// Zurück zur vorherigen Ansicht
-		if m.prevMode == viewAllScripts {
-			m.mode = viewAllScripts
+		if m.prevMode == viewAllScripts || m.prevMode == viewRoles || m.prevMode == viewLayout || m.prevMode == viewAutomations || m.prevMode == viewFindings || m.prevMode == viewIdentifiers {
+			m.mode = m.prevMode
 		} else {
 			m.mode = viewScripts
 		}
 	case viewSearch:
 		m.mode = viewDatabases
+	case viewCompare:
+		m.mode = viewDatabases
+		m.compareLeft = nil
+		m.compareRight = nil
 	case viewAllScripts:
 		m.mode = viewDatabases
-		m.filterText = ""
+		m.filterStack = nil
+		m.filterBase = nil
+		m.filterBaseLabel = ""
 		m.filterInput.SetValue("")
 		m.filteredScripts = m.allScripts
-	case viewStats, viewHelp:
+	case viewStats, viewHelp, viewRaw, viewSQL, viewQueries, viewDiff, viewPath:
 		m.mode = m.prevMode
+	case viewRoles:
+		return m.closeRoles()
+	case viewLayout:
+		return m.closeLayout()
+	case viewAutomations:
+		return m.closeAutomations()
+	case viewFindings:
+		return m.closeFindings()
+	case viewIdentifiers:
+		return m.closeIdentifiers()
+	case viewChanges:
+		return m.closeChanges()
+	}
+	return m, nil
+}
+
+// handleRaw zeigt die gespeicherte Rohdefinition der ausgewählten Tabelle bzw. des Feldes
+func (m Model) handleRaw() (tea.Model, tea.Cmd) {
+	var raw map[string]interface{}
+	var err error
+
+	switch m.mode {
+	case viewTables:
+		if len(m.tables) == 0 {
+			return m, nil
+		}
+		t := m.tables[m.selectedTable]
+		raw, err = m.db.GetRawTable(t.DatabaseID, t.TableID)
+		m.rawTitle = "Tabelle " + t.Name
+	case viewFields:
+		if len(m.fields) == 0 {
+			return m, nil
+		}
+		f := m.fields[m.selectedField]
+		raw, err = m.db.GetRawField(f.DatabaseID, f.TableID, f.FieldID)
+		m.rawTitle = "Feld " + f.Name
+	default:
+		return m, nil
 	}
+
+	var content string
+	if err != nil {
+		content = fmt.Sprintf("Fehler beim Laden der Rohdaten: %v", err)
+	} else {
+		data, _ := json.MarshalIndent(raw, "", "  ")
+		content = highlightSource(string(data), "json")
+	}
+
+	m.codeView.SetContent(content)
+	m.codeView.GotoTop()
+	m.prevMode = m.mode
+	m.mode = viewRaw
 	return m, nil
 }
 
 func (m Model) handleUp() (tea.Model, tea.Cmd) {
 	switch m.mode {
+	case viewTeams:
+		if m.selectedTeam > 0 {
+			m.selectedTeam--
+		}
 	case viewDatabases:
 		if m.selectedDB > 0 {
 			m.selectedDB--
@@ -594,14 +1488,50 @@ func (m Model) handleUp() (tea.Model, tea.Cmd) {
 				m.scrollOffset = m.selectedAllScript
 			}
 		}
-	case viewCode:
+	case viewCode, viewRaw, viewDiff, viewPath:
 		m.codeView.ViewUp()
+	case viewSQL:
+		m.moveSQLSelection(-1)
+	case viewQueries:
+		if m.selectedQuery > 0 {
+			m.selectedQuery--
+		}
+	case viewCompare:
+		if m.selectedCompare > 0 {
+			m.selectedCompare--
+		}
+	case viewRoles:
+		if m.selectedRole > 0 {
+			m.selectedRole--
+		}
+	case viewLayout:
+		if m.selectedLayout > 0 {
+			m.selectedLayout--
+		}
+	case viewAutomations:
+		if m.selectedAutomation > 0 {
+			m.selectedAutomation--
+		}
+	case viewFindings:
+		if m.selectedFinding > 0 {
+			m.selectedFinding--
+		}
+	case viewIdentifiers:
+		m.moveIdent(-1)
+	case viewChanges:
+		if m.selectedChange > 0 {
+			m.selectedChange--
+		}
 	}
 	return m, nil
 }
 
 func (m Model) handleDown() (tea.Model, tea.Cmd) {
 	switch m.mode {
+	case viewTeams:
+		if m.selectedTeam < len(m.teams) {
+			m.selectedTeam++
+		}
 	case viewDatabases:
 		if m.selectedDB < len(m.databases)-1 {
 			m.selectedDB++
@@ -619,32 +1549,63 @@ func (m Model) handleDown() (tea.Model, tea.Cmd) {
 			m.selectedScript++
 		}
 	case viewSearch:
-		if m.selectedSearch < len(m.searchResults)-1 {
+		if m.selectedSearch < m.searchResultCount()-1 {
 			m.selectedSearch++
 		}
 	case viewAllScripts:
 		if m.selectedAllScript < len(m.filteredScripts)-1 {
 			m.selectedAllScript++
-			// Scroll-Anpassung (ca. 10 sichtbare Einträge)
-			visibleRows := 8
-			if m.selectedAllScript >= m.scrollOffset+visibleRows {
-				m.scrollOffset = m.selectedAllScript - visibleRows + 1
-			}
+			m.ensureAllScriptVisible()
 		}
-	case viewCode:
+	case viewCode, viewRaw, viewDiff, viewPath:
 		m.codeView.ViewDown()
+	case viewSQL:
+		m.moveSQLSelection(1)
+	case viewQueries:
+		if m.selectedQuery < len(m.config.SavedQueries)-1 {
+			m.selectedQuery++
+		}
+	case viewCompare:
+		if m.selectedCompare < len(m.compareRows)-1 {
+			m.selectedCompare++
+		}
+	case viewRoles:
+		if m.selectedRole < len(m.visibleRoles())-1 {
+			m.selectedRole++
+		}
+	case viewLayout:
+		if m.selectedLayout < len(m.layoutRowsCache)-1 {
+			m.selectedLayout++
+		}
+	case viewAutomations:
+		if m.selectedAutomation < len(m.visibleAutomations())-1 {
+			m.selectedAutomation++
+		}
+	case viewFindings:
+		if m.selectedFinding < len(m.visibleFindings())-1 {
+			m.selectedFinding++
+		}
+	case viewIdentifiers:
+		m.moveIdent(1)
+	case viewChanges:
+		if m.changeDiff != nil && m.selectedChange < len(m.changeDiff.Changes)-1 {
+			m.selectedChange++
+		}
 	}
 	return m, nil
 }
 
 func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 	switch m.mode {
+	case viewTeams:
+		return m.chooseTeam()
 	case viewDatabases:
 		if len(m.databases) > 0 {
 			m.currentDB = &m.databases[m.selectedDB]
 			tables, err := m.db.GetTables(m.currentDB.ID)
 			if err == nil {
 				m.tables = tables
+				m.applySort(viewTables)
 				m.selectedTable = 0
 				m.mode = viewTables
 			}
@@ -652,16 +1613,19 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 	case viewTables:
 		if len(m.tables) > 0 {
 			m.currentTable = &m.tables[m.selectedTable]
+			m.touchTable(m.currentDB.ID, m.currentTable.TableID)
 			// Felder laden
 			fields, err := m.db.GetFields(m.currentDB.ID, m.currentTable.TableID)
 			if err == nil {
 				m.fields = fields
+				m.applySort(viewFields)
 				m.selectedField = 0
 			}
 			// Scripts laden
 			scripts, err := m.db.GetScripts(m.currentDB.ID, m.currentTable.Name)
 			if err == nil {
 				m.scripts = scripts
+				m.applySort(viewScripts)
 				m.selectedScript = 0
 			}
 			// Beziehungen laden
@@ -677,27 +1641,218 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 	case viewScripts:
 		if len(m.scripts) > 0 {
 			script := m.scripts[m.selectedScript]
+			m.codeScript = &script
 			m.codeView.SetContent(highlightCode(script.Code))
 			m.mode = viewCode
 		}
 	case viewSearch:
-		if len(m.searchResults) > 0 {
+		if fm := m.selectedFieldMatch(); fm != nil {
+			return m.openFieldMatch(*fm)
+		}
+		if m.selectedSearch < len(m.searchResults) {
 			script := m.searchResults[m.selectedSearch]
+			m.codeScript = &script
 			m.codeView.SetContent(highlightCode(script.Code))
 			m.mode = viewCode
 		}
 	case viewAllScripts:
 		if len(m.filteredScripts) > 0 && m.selectedAllScript < len(m.filteredScripts) {
 			script := m.filteredScripts[m.selectedAllScript]
+			m.codeScript = &script
 			m.codeView.SetContent(highlightCode(script.Code))
 			m.prevMode = viewAllScripts
 			m.mode = viewCode
 		}
+	case viewQueries:
+		return m.openSavedQuery()
+	case viewCompare:
+		return m.openCompareDiff()
+	case viewRoles:
+		return m.openRoleScript()
+	case viewLayout:
+		return m.openLayoutScript()
+	case viewAutomations:
+		return m.openAutomationScript()
+	case viewFindings:
+		return m.openFindingScript()
+	case viewIdentifiers:
+		return m.selectIdent()
+	case viewChanges:
+		return m.openChangeDiff()
+	}
+	return m, nil
+}
+
+// activeScript liefert das Script unter dem Cursor bzw. in der Code-Ansicht
+func (m Model) activeScript() *Script {
+	switch m.mode {
+	case viewCode:
+		return m.codeScript
+	case viewScripts:
+		if m.selectedScript < len(m.scripts) {
+			return &m.scripts[m.selectedScript]
+		}
+	case viewSearch:
+		if m.selectedSearch < len(m.searchResults) {
+			return &m.searchResults[m.selectedSearch]
+		}
+	case viewAllScripts:
+		if m.selectedAllScript < len(m.filteredScripts) {
+			return &m.filteredScripts[m.selectedAllScript]
+		}
+	}
+	return nil
+}
+
+// copyScript kopiert den Code des geöffneten Scripts ohne Hervorhebung und Rahmen
+func (m Model) copyScript() (tea.Model, tea.Cmd) {
+	s := m.activeScript()
+	if s == nil {
+		return m, nil
+	}
+	via := copyToClipboard(s.Code)
+	m.status = fmt.Sprintf("✓ Script kopiert (%s): %d Zeilen", via, s.LineCount)
+	return m, nil
+}
+
+// copyScriptWithHeader kopiert das aktive Script samt Herkunftskopf
+func (m Model) copyScriptWithHeader() (tea.Model, tea.Cmd) {
+	s := m.activeScript()
+	if s == nil {
+		return m, nil
+	}
+	extractedAt, _ := m.db.GetExtractionDate(s.DatabaseID)
+	via := copyToClipboard(scriptWithHeader(*s, extractedAt))
+	m.status = fmt.Sprintf("✓ Script mit Kopf kopiert (%s)", via)
+	return m, nil
+}
+
+// copyDeepLink kopiert den Ninox-Link zur aktuellen Datenbank/Tabelle
+func (m Model) copyDeepLink() (tea.Model, tea.Cmd) {
+	var databaseID, tableID string
+
+	switch m.mode {
+	case viewDatabases:
+		if len(m.databases) > 0 {
+			databaseID = m.databases[m.selectedDB].ID
+		}
+	case viewTables:
+		if len(m.tables) > 0 {
+			databaseID = m.tables[m.selectedTable].DatabaseID
+			tableID = m.tables[m.selectedTable].TableID
+		}
+	case viewFields:
+		if m.currentTable != nil {
+			databaseID = m.currentTable.DatabaseID
+			tableID = m.currentTable.TableID
+		}
+	default:
+		if s := m.activeScript(); s != nil {
+			databaseID = s.DatabaseID
+			tableID = s.TableID
+		}
+	}
+
+	if databaseID == "" {
+		return m, nil
+	}
+
+	teamID, _ := m.db.GetTeamID(databaseID)
+	if teamID == "" && m.config.TeamID == "" {
+		m.status = "❌ Keine Team-ID bekannt (team_id in der Konfiguration setzen)"
+		return m, nil
+	}
+
+	link := m.config.DeepLink(teamID, databaseID, tableID)
+	via := copyToClipboard(link)
+	m.status = fmt.Sprintf("✓ Link kopiert (%s): %s", via, link)
+	return m, nil
+}
+
+// toggleIgnored blendet per Muster ausgeblendete Objekte ein bzw. wieder aus
+func (m Model) toggleIgnored() (tea.Model, tea.Cmd) {
+	if !m.db.HasIgnored() {
+		m.status = "Keine Objekte ausgeblendet (ignore in der Konfiguration)"
+		return m, nil
+	}
+	m.showIgnored = !m.showIgnored
+	m.db.SetShowIgnored(m.showIgnored)
+	m.reloadLists()
+
+	if m.showIgnored {
+		m.status = "Ausgeblendete Objekte werden angezeigt (. zum Ausblenden)"
+	} else {
+		m.status = "Objekte gemäß ignore ausgeblendet"
+	}
+	return m, nil
+}
+
+// reloadLists lädt Listen und Statistik nach geändertem Ausblenden neu und
+// kehrt zur Übersicht zurück
+func (m *Model) reloadLists() {
+	if databases, err := m.db.GetDatabases(); err == nil {
+		m.setDatabases(databases)
+		m.currentDB = nil
+		m.applySort(viewDatabases)
+	}
+	if stats, err := m.db.GetStats(); err == nil {
+		m.stats = stats
+	}
+	if allScripts, err := m.db.GetAllScripts(); err == nil {
+		m.allScripts = allScripts
+	}
+	if layout, err := m.db.GetLayoutElements(); err == nil {
+		m.layout = layout
+	}
+	m.filterBase = nil
+	m.filterBaseLabel = ""
+	m.applyFilter()
+	m.mode = viewDatabases
+	m.currentDB = nil
+	m.currentTable = nil
+	m.selectedDB = 0
+	m.tabs = nil
+	m.compareLeft = nil
+}
+
+// jumpToCrumb springt zur angegebenen Ebene der Breadcrumb (1 = Datenbanken)
+func (m Model) jumpToCrumb(level int) (tea.Model, tea.Cmd) {
+	switch {
+	case level == 1 && m.currentDB != nil:
+		m.mode = viewDatabases
+		m.currentDB = nil
+		m.currentTable = nil
+	case level == 2 && m.currentDB != nil:
+		m.mode = viewTables
+		m.currentTable = nil
+	case level == 3 && m.currentTable != nil:
+		if m.mode != viewFields && m.mode != viewScripts {
+			m.mode = viewScripts
+		}
 	}
 	return m, nil
 }
 
 func (m Model) handleTab() (tea.Model, tea.Cmd) {
+	if m.mode == viewAutomations {
+		return m.cycleAutomationFilter()
+	}
+	if m.mode == viewFindings {
+		return m.cycleFindingSeverity()
+	}
+	if m.mode == viewIdentifiers {
+		return m.toggleIdentSort()
+	}
+	if m.mode == viewChanges {
+		return m.cycleChangeBase()
+	}
+	if m.mode == viewDiff {
+		return m.toggleDiffLayout()
+	}
+	if m.mode == viewStats && m.statsScoped != nil {
+		m.statsAll = !m.statsAll
+		return m, nil
+	}
 	if m.currentTable != nil {
 		switch m.mode {
 		case viewFields:
@@ -714,10 +1869,15 @@ func (m Model) View() string {
 	if m.width == 0 {
 		return "Lade..."
 	}
+	if m.copyMode.active {
+		return m.renderCopyMode()
+	}
 
 	var content string
 
 	switch m.mode {
+	case viewTeams:
+		content = m.renderTeams()
 	case viewDatabases:
 		content = m.renderDatabases()
 	case viewTables:
@@ -736,8 +1896,62 @@ func (m Model) View() string {
 		content = m.renderHelp()
 	case viewAllScripts:
 		content = m.renderAllScripts()
+	case viewRaw:
+		content = m.renderRaw()
+	case viewSQL:
+		content = m.renderSQL()
+	case viewQueries:
+		content = m.renderQueries()
+	case viewDiff:
+		content = m.renderDiff()
+	case viewPath:
+		content = m.renderPath()
+	case viewCompare:
+		content = m.renderCompare()
+	case viewRoles:
+		content = m.renderRoles()
+	case viewLayout:
+		content = m.renderLayout()
+	case viewAutomations:
+		content = m.renderAutomations()
+	case viewFindings:
+		content = m.renderFindings()
+	case viewIdentifiers:
+		content = m.renderIdentifiers()
+	case viewChanges:
+		content = m.renderChanges()
+	}
+
+	top, footer := m.renderChrome()
+	parts := append(top, content, footer)
+
+	view := lipgloss.JoinVertical(lipgloss.Left, parts...)
+	if m.cheatsheet {
+		view = overlayRight(view, m.renderCheatsheet(), m.width, m.height, lipgloss.Height(top[0])+1)
+	}
+	if m.switching {
+		view = overlayRight(view, m.renderSwitcher(), m.width, m.height, lipgloss.Height(top[0])+1)
+	}
+	if m.fileSwitching {
+		view = overlayRight(view, m.renderFileSwitcher(), m.width, m.height, lipgloss.Height(top[0])+1)
+	}
+	if m.frequency.active {
+		view = overlayRight(view, m.renderFrequency(), m.width, m.height, lipgloss.Height(top[0])+1)
+	}
+	if m.history.active {
+		view = overlayRight(view, m.renderHistory(), m.width, m.height, lipgloss.Height(top[0])+1)
 	}
+	if m.tour.active {
+		// Unten rechts über der Fußzeile, damit Tastenübersicht und Popups frei bleiben
+		tour := m.renderTour()
+		view = overlayRight(view, tour, m.width, m.height, max(0, m.height-lipgloss.Height(tour)-1))
+	}
+	return view
+}
 
+// renderChrome rendert alles außer dem Inhalt: Kopf, Tabs, Such- und
+// Filterleiste oberhalb sowie die Fußzeile
+func (m Model) renderChrome() (top []string, footer string) {
 	// Header
 	header := m.renderHeader()
 
@@ -751,36 +1965,52 @@ func (m Model) View() string {
 	filterBar := ""
 	if m.filtering {
 		filterBar = boxStyle.Render("🔍 Filter: " + m.filterInput.View())
-	} else if m.mode == viewAllScripts && m.filterText != "" {
-		filterBar = mutedStyle.Render(fmt.Sprintf("  Filter: %s", m.filterText))
+	} else if m.scriptsExporting || m.databaseExporting || m.scriptHTMLExporting {
+		filterBar = boxStyle.Render("📁 " + m.sqlExportInput.View())
+	} else if m.mode == viewAllScripts && m.filterChain() != "" {
+		filterBar = mutedStyle.Render(fmt.Sprintf("  Filter: %s  (u zurück)", m.filterChain()))
 	}
 
 	// Footer/Hilfe
-	footer := m.renderFooter()
+	footer = m.renderFooter()
+	if m.status != "" {
+		footer = mutedStyle.Render("  "+m.status) + "\n" + footer
+	}
+	if m.progress != "" {
+		footer = mutedStyle.Render("  "+m.progress) + "\n" + footer
+	}
 
 	// Zusammenbauen
-	parts := []string{header}
+	top = []string{header}
+	if tabs := m.renderTabs(); tabs != "" {
+		top = append(top, tabs)
+	}
 	if searchBar != "" {
-		parts = append(parts, searchBar)
+		top = append(top, searchBar)
 	}
 	if filterBar != "" {
-		parts = append(parts, filterBar)
+		top = append(top, filterBar)
 	}
-	parts = append(parts, content, footer)
-
-	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+	return top, footer
 }
 
 func (m Model) renderHeader() string {
 	title := "📦 Ninox Schema Explorer"
+	if m.files != nil {
+		title += " · " + schemaFileLabel(m.files.paths[m.files.current])
+	}
 
-	// Breadcrumb
+	// Breadcrumb mit Ebenennummern zum direkten Springen
 	breadcrumb := ""
 	if m.currentDB != nil {
-		breadcrumb = m.currentDB.Name
+		crumbs := []string{firstNonEmpty(m.teamLabel(), "Datenbanken"), m.currentDB.Name}
 		if m.currentTable != nil {
-			breadcrumb += " > " + m.currentTable.Name
+			crumbs = append(crumbs, m.currentTable.Name)
+		}
+		for i, c := range crumbs {
+			crumbs[i] = fmt.Sprintf("%d %s", i+1, c)
 		}
+		breadcrumb = strings.Join(crumbs, " ▸ ")
 	}
 
 	left := headerStyle.Render(title)
@@ -799,8 +2029,63 @@ func (m Model) renderFooter() string {
 	if m.mode == viewFields || m.mode == viewScripts {
 		help = "Tab Wechseln • " + help
 	}
+	if m.mode == viewTables || m.mode == viewFields {
+		help = "r Rohdaten • E Layout • " + help
+	}
+	if m.mode == viewFields && len(m.relationships) > 0 {
+		help = "d Richtung: " + relDirectionNames[m.relDirection] + " • " + help
+	}
 	if m.mode == viewAllScripts {
-		help = "↑↓ Navigation • Enter Code • f Filter • Esc Zurück • ? Hilfe • q Beenden"
+		help = "↑↓ Navigation • Enter Code • f Filter • u Filter zurück • +/- Vorschau • x Exportieren • Esc Zurück • ? Hilfe • q Beenden"
+	}
+	if m.mode == viewSQL {
+		help = "↑↓ Zeilen • ←→ Spalten • : Abfrage bearbeiten • w Speichern • x Exportieren • Esc Zurück • q Beenden"
+	}
+	if m.mode == viewQueries {
+		help = "↑↓ Navigation • Enter Ausführen • : Neue Abfrage • Esc Zurück • q Beenden"
+	}
+	if m.mode == viewDatabases {
+		help = "c Vergleichen • " + help
+	}
+	if m.mode == viewCompare {
+		help = "↑↓ Navigation • Enter Feldunterschiede • Esc Zurück • q Beenden"
+	}
+	if m.mode == viewRoles {
+		help = "↑↓ Navigation • Enter Regel-Script • f Filter • Esc Zurück • q Beenden"
+	}
+	if m.mode == viewLayout {
+		help = "↑↓ Navigation • Enter Script • Esc Zurück • q Beenden"
+	}
+	if m.mode == viewAutomations {
+		help = "↑↓ Navigation • Enter Script • Tab Art • Esc Zurück • q Beenden"
+	}
+	if m.mode == viewFindings {
+		help = "↑↓ Navigation • Enter Fundstelle • Tab Schwere • x Export (.json/.sarif) • Esc Zurück • q Beenden"
+	}
+	if m.mode == viewChanges {
+		help = "↑↓ Navigation • Enter Code-Diff • Tab älterer Stand • Esc Zurück • q Beenden"
+	}
+	if m.mode == viewIdentifiers {
+		help = "↑↓ Navigation • Enter Scripts • f Filter • Tab Sortierung • Esc Zurück • q Beenden"
+		if m.identOpen != nil {
+			help = "↑↓ Navigation • Enter Script an der Fundstelle • Esc Bezeichner • q Beenden"
+		}
+	}
+	if m.mode == viewScripts || m.mode == viewSearch || m.mode == viewAllScripts {
+		help = "Leertaste Markieren • C Vergleichen • " + help
+	}
+	if m.mode == viewDiff && m.scriptDiff != nil {
+		help = "↑↓ Scrollen • Tab Nebeneinander/Unified • Esc Zurück • q Beenden"
+	}
+	if m.mode == viewTeams {
+		help = "↑↓ Navigation • Enter Datenbanken des Teams • a Alle Scripts • s Suchen • ? Hilfe • q Beenden"
+	}
+	if _, ok := sortViewNames[m.mode]; ok {
+		sorting := "o Sortieren"
+		if label := m.sortLabel(); label != "" {
+			sorting = "o Sortierung: " + label
+		}
+		help = sorting + " • " + help
 	}
 	return helpStyle.Render(help)
 }
@@ -808,10 +2093,20 @@ func (m Model) renderFooter() string {
 func (m Model) renderDatabases() string {
 	var b strings.Builder
 
-	b.WriteString(titleStyle.Render("📁 Datenbanken") + "\n\n")
+	title := "📁 Datenbanken"
+	if team := m.teamLabel(); team != "" {
+		title += " · " + team
+	}
+	b.WriteString(titleStyle.Render(title) + "\n\n")
+
+	// Team-Spalte, solange Datenbanken mehrerer Teams gemischt erscheinen
+	showTeam := m.multiTeam() && m.currentTeam == nil
 
 	// Tabellen-Header
-	header := fmt.Sprintf("  %-30s %10s %10s", "Name", "Tabellen", "Scripts")
+	header := fmt.Sprintf("  %-30s %10s %10s %10s %10s", "Name", "Tabellen", "Felder", "Scripts", "Verkn.")
+	if showTeam {
+		header += fmt.Sprintf("  %-20s", "Team")
+	}
 	b.WriteString(tableHeaderStyle.Render(header) + "\n")
 
 	for i, db := range m.databases {
@@ -822,20 +2117,46 @@ func (m Model) renderDatabases() string {
 			prefix = "▶ "
 		}
 
-		row := fmt.Sprintf("%s%-28s %10d %10d",
-			prefix, truncate(db.Name, 28), db.TableCount, db.CodeCount)
+		name := db.Name
+		if db.Archived {
+			name += " (archiviert)"
+		}
+		row := fmt.Sprintf("%s%-28s %10s %10s %10s %10s",
+			prefix, truncate(name, 28), countBadge(db.TableCount), countBadge(db.FieldCount),
+			countBadge(db.CodeCount), countBadge(db.RelationCount))
+		if showTeam {
+			row += fmt.Sprintf("  %-20s", truncate(firstNonEmpty(db.TeamName, db.TeamID), 20))
+		}
 		b.WriteString(style.Render(row) + "\n")
 	}
 
 	return boxStyle.Width(m.width - 4).Render(b.String())
 }
 
+// countBadge formatiert eine Anzahl für Listen; 0 wird als "·" gezeigt,
+// damit Tabellen mit Inhalt beim Überfliegen auffallen
+func countBadge(n int) string {
+	if n == 0 {
+		return "·"
+	}
+	return fmt.Sprint(n)
+}
+
 func (m Model) renderTables() string {
 	var b strings.Builder
 
 	b.WriteString(titleStyle.Render("📋 Tabellen: "+m.currentDB.Name) + "\n\n")
 
-	header := fmt.Sprintf("  %-35s %10s", "Name", "Felder")
+	// Datensätze nur zeigen, wenn gezählt wurde (--counts bzw. record-counts --refresh)
+	showRecords := false
+	for _, t := range m.tables {
+		showRecords = showRecords || t.RecordCount >= 0
+	}
+
+	header := fmt.Sprintf("  %-35s %10s %10s %10s %8s %8s %7s", "Name", "Felder", "Scripts", "Verkn.", "Formeln", "Trigger", "Dichte")
+	if showRecords {
+		header += fmt.Sprintf(" %12s", "Datensätze")
+	}
 	b.WriteString(tableHeaderStyle.Render(header) + "\n")
 
 	for i, t := range m.tables {
@@ -846,7 +2167,16 @@ func (m Model) renderTables() string {
 			prefix = "▶ "
 		}
 
-		row := fmt.Sprintf("%s%-33s %10d", prefix, truncate(t.Name, 33), t.FieldCount)
+		if i != m.selectedTable && heavyLogic(t.FieldCount, t.FormulaCount, t.TriggerCount) {
+			style = style.Foreground(currentTheme.Accent)
+		}
+
+		row := fmt.Sprintf("%s%-33s %10s %10s %10s %8s %8s %7s", prefix, truncate(t.Name, 33),
+			countBadge(t.FieldCount), countBadge(t.ScriptCount), countBadge(t.RelationCount),
+			countBadge(t.FormulaCount), countBadge(t.TriggerCount), formatDensity(t.FieldCount, t.FormulaCount, t.TriggerCount))
+		if showRecords {
+			row += fmt.Sprintf(" %12s", formatCount(t.RecordCount))
+		}
 		b.WriteString(style.Render(row) + "\n")
 	}
 
@@ -890,20 +2220,11 @@ func (m Model) renderFields() string {
 		b.WriteString(style.Render(row) + "\n")
 	}
 
+	// Auswahlwerte des ausgewählten Feldes
+	b.WriteString(m.renderFieldOptions())
+
 	// Beziehungen anzeigen
-	if len(m.relationships) > 0 {
-		b.WriteString("\n" + titleStyle.Render("🔗 Beziehungen") + "\n\n")
-		for _, r := range m.relationships {
-			direction := "→"
-			other := r.TargetTableName
-			if r.TargetTableName == m.currentTable.Name {
-				direction = "←"
-				other = r.SourceTableName
-			}
-			line := fmt.Sprintf("  %s %s (%s)", direction, other, r.RelationshipType)
-			b.WriteString(mutedStyle.Render(line) + "\n")
-		}
-	}
+	b.WriteString(m.renderRelationships())
 
 	return boxStyle.Width(m.width - 4).Render(b.String())
 }
@@ -917,16 +2238,15 @@ func (m Model) renderScripts() string {
 		b.WriteString(mutedStyle.Render("  Keine Scripts vorhanden\n"))
 	} else {
 		header := fmt.Sprintf("  %-25s %-15s %-12s %s",
-			"Element", "Typ", "Kategorie", "Zeilen")
+			"Element", "Typ", "Gruppe", "Zeilen") + m.recencyHeader()
 		b.WriteString(tableHeaderStyle.Render(header) + "\n")
 
 		for i, s := range m.scripts {
 			style := tableCellStyle
-			prefix := "  "
 			if i == m.selectedScript {
 				style = tableCellSelectedStyle
-				prefix = "▶ "
 			}
+			prefix := m.scriptPrefix(s, i == m.selectedScript)
 
 			element := s.ElementName
 			if element == "" {
@@ -937,8 +2257,8 @@ func (m Model) renderScripts() string {
 				prefix,
 				truncate(element, 23),
 				truncate(s.CodeType, 15),
-				truncate(s.CodeCategory, 12),
-				s.LineCount)
+				truncate(s.Group(), 12),
+				s.LineCount) + m.recencyCell(s)
 			b.WriteString(style.Render(row) + "\n")
 		}
 	}
@@ -950,15 +2270,53 @@ func (m Model) renderCode() string {
 	var b strings.Builder
 
 	title := "Code"
-	if len(m.scripts) > 0 && m.selectedScript < len(m.scripts) {
-		s := m.scripts[m.selectedScript]
+	if m.codeScript != nil {
+		s := m.codeScript
 		title = fmt.Sprintf("%s - %s", s.ElementName, s.CodeType)
 		if s.ElementName == "" {
 			title = fmt.Sprintf("(Tabelle) - %s", s.CodeType)
 		}
+		title += "  #" + s.Hash[:min(8, len(s.Hash))]
+	}
+
+	b.WriteString(titleStyle.Render("💻 "+title) + "\n")
+	contextLines := 0
+	if m.codeScript != nil {
+		if context := m.elementContext(*m.codeScript); context != "" {
+			b.WriteString(mutedStyle.Render(" 🧩 "+context) + "\n")
+			contextLines = 1
+		} else if context := reportContext(*m.codeScript); context != "" {
+			b.WriteString(mutedStyle.Render(" 📄 "+context) + "\n")
+			contextLines = 1
+		}
+		if by := attribution(m.codeScript.ModifiedBy, m.codeScript.ModifiedAt); by != "" {
+			b.WriteString(mutedStyle.Render(" ✎ Zuletzt geändert "+by) + "\n")
+			contextLines++
+		}
 	}
+	b.WriteString("\n")
 
-	b.WriteString(titleStyle.Render("💻 "+title) + "\n\n")
+	// Die Trefferliste (und die Layout-Zeile) verkleinern den Code-Ausschnitt
+	codeView := m.codeView
+	codeView.Height = max(3, codeView.Height-m.matchPanelHeight()-contextLines)
+	b.WriteString(codeView.View())
+
+	scrollInfo := fmt.Sprintf(" %d%% ", int(m.codeView.ScrollPercent()*100))
+	b.WriteString("\n" + mutedStyle.Render(scrollInfo))
+	if m.codeFinding {
+		b.WriteString("\n\n🔎 " + m.codeFindInput.View())
+	} else if m.showsCodeMatches() {
+		b.WriteString(m.renderCodeMatches())
+	}
+
+	return codeBoxStyle.Width(m.width - 4).Render(b.String())
+}
+
+// renderRaw rendert die Rohdefinition als JSON
+func (m Model) renderRaw() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("🧾 Rohdaten: "+m.rawTitle) + "\n\n")
 	b.WriteString(m.codeView.View())
 
 	scrollInfo := fmt.Sprintf(" %d%% ", int(m.codeView.ScrollPercent()*100))
@@ -970,24 +2328,27 @@ func (m Model) renderCode() string {
 func (m Model) renderSearch() string {
 	var b strings.Builder
 
-	b.WriteString(titleStyle.Render(fmt.Sprintf("🔍 Suchergebnisse: \"%s\"", m.searchInput.Value())) + "\n\n")
+	title := fmt.Sprintf("🔍 Suchergebnisse: \"%s\"", m.searchInput.Value())
+	if m.searchWithin != nil {
+		title += fmt.Sprintf(" in %d gefilterten Scripts", len(m.searchWithin))
+	}
+	b.WriteString(titleStyle.Render(title) + "\n\n")
 
-	if len(m.searchResults) == 0 {
+	if m.searchResultCount() == 0 {
 		b.WriteString(mutedStyle.Render("  Keine Treffer gefunden\n"))
-	} else {
+	} else if len(m.searchResults) > 0 {
 		b.WriteString(fmt.Sprintf("  %d Treffer\n\n", len(m.searchResults)))
 
 		header := fmt.Sprintf("  %-30s %-20s %-12s %s",
-			"Datenbank.Tabelle", "Element", "Typ", "Zeilen")
+			"Datenbank.Tabelle", "Element", "Typ", "Zeilen") + m.recencyHeader()
 		b.WriteString(tableHeaderStyle.Render(header) + "\n")
 
 		for i, s := range m.searchResults {
 			style := tableCellStyle
-			prefix := "  "
 			if i == m.selectedSearch {
 				style = tableCellSelectedStyle
-				prefix = "▶ "
 			}
+			prefix := m.scriptPrefix(s, i == m.selectedSearch)
 
 			loc := s.DatabaseName
 			if s.TableName != "" {
@@ -1004,60 +2365,109 @@ func (m Model) renderSearch() string {
 				truncate(loc, 28),
 				truncate(element, 20),
 				truncate(s.CodeType, 12),
-				s.LineCount)
+				s.LineCount) + m.recencyCell(s)
 			b.WriteString(style.Render(row) + "\n")
 		}
 	}
 
+	b.WriteString(m.renderFieldMatches())
+
 	return boxStyle.Width(m.width - 4).Render(b.String())
 }
 
 func (m Model) renderStats() string {
 	var b strings.Builder
 
-	b.WriteString(titleStyle.Render("📊 Statistiken") + "\n\n")
+	shown := m.shownStats()
+	b.WriteString(titleStyle.Render("📊 Statistiken") + "\n")
+	switch {
+	case shown.Scope != "":
+		b.WriteString(mutedStyle.Render("  "+truncate(shown.Scope, max(20, m.width-30))+" • Tab: Gesamt") + "\n\n")
+	case m.statsScoped != nil:
+		b.WriteString(mutedStyle.Render("  Gesamt • Tab: "+truncate(m.statsScoped.Scope, max(20, m.width-30))) + "\n\n")
+	default:
+		b.WriteString("\n")
+	}
 
 	// Hauptzahlen
 	stats := []struct {
 		label string
 		value int
 	}{
-		{"Datenbanken", m.stats.DatabasesCount},
-		{"Tabellen", m.stats.TablesCount},
-		{"Felder", m.stats.FieldsCount},
-		{"Verknüpfungen", m.stats.RelationshipsCount},
-		{"Scripts", m.stats.ScriptsCount},
+		{"Datenbanken", shown.DatabasesCount},
+		{"Tabellen", shown.TablesCount},
+		{"Felder", shown.FieldsCount},
+		{"Verknüpfungen", shown.RelationshipsCount},
+		{"Scripts", shown.ScriptsCount},
 	}
 
 	for _, s := range stats {
+		if shown.ScriptsOnly && (s.label == "Felder" || s.label == "Verknüpfungen") {
+			continue
+		}
 		line := fmt.Sprintf("  %-20s %8d", s.label, s.value)
 		b.WriteString(normalStyle.Render(line) + "\n")
 	}
 
+	// Analyse (nach R)
+	if m.analysis != nil {
+		uses := 0
+		for _, ids := range m.analysis.Usages {
+			uses += len(ids)
+		}
+		b.WriteString(normalStyle.Render(fmt.Sprintf("  %-20s %8d", "Globale Funktionen", len(m.analysis.Functions))) + "\n")
+		b.WriteString(normalStyle.Render(fmt.Sprintf("  %-20s %8d", "Aufrufende Scripts", uses)) + "\n")
+		if unused := m.analysis.UnusedFunctions(); len(unused) > 0 {
+			b.WriteString(mutedStyle.Render("  Unbenutzt: "+truncate(strings.Join(unused, ", "), max(20, m.width-20))) + "\n")
+		}
+	} else {
+		b.WriteString(mutedStyle.Render("  Funktionsanalyse: R drücken") + "\n")
+	}
+
+	// Scripts nach Gruppe
+	b.WriteString("\n" + titleStyle.Render("🧩 Scripts nach Gruppe") + "\n\n")
+	for _, entry := range sortedCounts(shown.ScriptsByGroup) {
+		bar := strings.Repeat("█", min(entry.count/5, 30))
+		barStyled := lipgloss.NewStyle().Foreground(currentTheme.Primary).Render(bar)
+		b.WriteString(fmt.Sprintf("  %-15s %s %d\n", truncate(entry.name, 15), barStyled, entry.count))
+	}
+
 	// Scripts nach Typ
 	b.WriteString("\n" + titleStyle.Render("📜 Scripts nach Typ") + "\n\n")
-	i := 0
-	for typ, count := range m.stats.ScriptsByType {
+	for i, entry := range sortedCounts(shown.ScriptsByType) {
 		if i >= 8 {
 			break
 		}
-		bar := strings.Repeat("█", min(count/5, 30))
+		bar := strings.Repeat("█", min(entry.count/5, 30))
 		// Farbiger Balken mit Theme-Farbe
 		barStyled := lipgloss.NewStyle().Foreground(currentTheme.Primary).Render(bar)
-		b.WriteString(fmt.Sprintf("  %-15s %s %d\n", truncate(typ, 15), barStyled, count))
-		i++
+		b.WriteString(fmt.Sprintf("  %-15s %s %d\n", truncate(entry.name, 15), barStyled, entry.count))
 	}
 
 	// Top Tabellen
 	b.WriteString("\n" + titleStyle.Render("🏆 Top Tabellen") + "\n\n")
-	i = 0
-	for table, count := range m.stats.TopTables {
+	for i, entry := range sortedCounts(shown.TopTables) {
 		if i >= 5 {
 			break
 		}
-		line := fmt.Sprintf("  %d. %-25s %5d Scripts", i+1, truncate(table, 25), count)
+		line := fmt.Sprintf("  %d. %-25s %5d Scripts", i+1, truncate(entry.name, 25), entry.count)
 		b.WriteString(normalStyle.Render(line) + "\n")
-		i++
+	}
+
+	// Stark automatisierte Tabellen (Formelfelder und Trigger je Feld)
+	if len(shown.LogicTables) > 0 {
+		b.WriteString("\n" + titleStyle.Render("⚡ Logikdichte") + "\n\n")
+		b.WriteString(renderLogicDensity(shown.LogicTables, shown.DatabasesCount > 1))
+	}
+
+	// Verteilung des Codes
+	b.WriteString("\n" + titleStyle.Render("🧱 Code-Verteilung") + "\n\n")
+	b.WriteString(renderCodeTreemap(shown.CodeLines, m.width-8))
+
+	// Entwicklung über ältere Extraktionen (nur Gesamtzahlen)
+	if shown.Scope == "" && len(m.trend) >= 2 {
+		b.WriteString("\n" + titleStyle.Render("📈 Entwicklung") + "\n\n")
+		b.WriteString(renderTrend(m.trend, m.width-8))
 	}
 
 	return statsBoxStyle.Width(m.width - 4).Render(b.String())
@@ -1077,10 +2487,52 @@ func (m Model) renderHelp() string {
 		{"Esc, ←/h", "Zurück"},
 		{"Tab", "Zwischen Felder/Scripts wechseln"},
 		{"a", "Alle Scripts (Gesamtansicht)"},
-		{"f", "Filter (in Gesamtansicht)"},
+		{"f", "Filter (in Gesamtansicht, grenzt weiter ein)"},
+		{"u", "Letzten Filter zurücknehmen"},
+		{"+, -", "Vorschauzeilen in der Gesamtansicht (0 = kompakt)"},
+		{"=", "Nach Wert der Zeile filtern (erneut: nächster Wert)"},
+		{"H", "Häufigkeiten einer Spalte in der Liste (←→ Spalte, Enter filtert nach dem Wert)"},
+		{"t", "Verlauf des Scripts bzw. Feldes über frühere Extraktionen (Enter: Diff zum aktuellen Code)"},
+		{"U", "Änderungen zwischen zwei Ständen des Verlaufs: Tabellen, Felder, Scripts (Tab: älterer Stand)"},
+		{"o", "Sortierspalte wechseln (wird gespeichert)"},
+		{"O", "Sortierrichtung umkehren"},
+		{"1, 2, 3", "Zur Ebene der Breadcrumb springen"},
+		{"Ctrl+O, Alt+I", "Sprungliste zurück/vorwärts (Ctrl+I ist im Terminal Tab)"},
+		{"Alt+1…9", "Tab der n-ten Datenbank (eigener Navigationszustand)"},
+		{"R", "Index im Hintergrund neu aufbauen (erneut: abbrechen)"},
+		{".", "Per ignore ausgeblendete Objekte ein-/ausblenden"},
+		{"z", "Archivierte Datenbanken ein-/ausblenden"},
+		{"f (Suche)", "Suchergebnisse weiter filtern"},
+		{"s (Filter)", "Nur in gefilterten Scripts suchen"},
+		{"r", "Rohdaten der Tabelle/des Feldes"},
+		{"F", "Alle Treffer im geöffneten Script auflisten"},
+		{"Ctrl+T", "Tabelle der aktuellen Datenbank wechseln (unscharf, zuletzt verwendete zuerst)"},
+		{"d, D", "Beziehungen: Richtung wählen / nach Tabelle sortieren"},
+		{"Leertaste, C", "Zwei Scripts markieren und vergleichen (Tab: nebeneinander/unified)"},
+		{":", "SQL-Konsole (nur lesend)"},
+		{"w", "Abfrage speichern (in SQL-Konsole)"},
+		{"m", "Gespeicherte Abfragen"},
+		{"x", "Ergebnis exportieren (.csv/.json/.md); in der Gesamtansicht: Scripts als Dateibaum; in der Datenbank-Ansicht: Komplett-Export der Datenbank; im Code: Script als HTML-Datei"},
+		{"y", "Code des geöffneten Scripts kopieren (ohne Rahmen, per SSH über OSC 52)"},
+		{"Y", "Script mit Herkunftskopf kopieren"},
+		{"v", "Kopiermodus: Ansicht ohne Farben und Rahmen, Zeilen oder Rechteck (r) auswählen, y kopiert"},
+		{"e", "Script in $EDITOR öffnen (mit --allow-write werden Änderungen gespeichert)"},
+		{"L", "Ninox-Link zur Datenbank/Tabelle kopieren"},
+		{"M", "Verknüpfungen als Mermaid-Diagramm kopieren (Datenbank bzw. Umgebung der Tabelle)"},
+		{"c, c", "Zwei Datenbanken nebeneinander vergleichen"},
+		{"g, g", "Kürzesten Weg zwischen zwei Tabellen zeigen (Ausgang, dann Ziel)"},
+		{"P", "Rollen & Rechte von Tabellen und Feldern (f: filtern)"},
+		{"E", "Formular-Layout der Tabelle: Elemente, Reiter, Ansichten und ihre Scripts"},
+		{"V", "Scripts, die Auswahlwerte des Feldes per Nummer verwenden (z.B. Status = 3)"},
+		{"A", "Automatisierungen: Zeitpläne, Webhooks und Trigger mit Auslöser (Tab: Art)"},
+		{"B", "Befunde aller Lint-Regeln je Datenbank (Tab: Schwere, x: Export als JSON oder SARIF)"},
+		{"I", "Bezeichner-Index: Variablen und Feldnamen im Code nach Häufigkeit (Enter: Scripts, Tab: Sortierung)"},
+		{"T", "Teams: Datenbanken nach Ninox-Team (bei Extraktion mehrerer Teams)"},
+		{"W", "Zwischen mehreren Schema-Dateien wechseln (mehrere .db oder ein Verzeichnis angeben)"},
 		{"s, /", "Suche öffnen"},
-		{"i", "Statistiken anzeigen"},
-		{"?", "Diese Hilfe"},
+		{"p", "Script auswählen (fzf, falls konfiguriert; sonst Suche)"},
+		{"i", "Statistiken (für Filter/Suche/Datenbank; Tab: Gesamt)"},
+		{"?", "Tastenübersicht (erneut ? für diese Hilfe)"},
 		{"PgUp/PgDn", "Im Code scrollen"},
 		{"q, Ctrl+C", "Beenden"},
 	}
@@ -1096,17 +2548,83 @@ func (m Model) renderHelp() string {
 	b.WriteString("\n" + titleStyle.Render("🔍 Filter-Syntax") + "\n\n")
 	b.WriteString(normalStyle.Render("  Begriff AND Begriff    Beide müssen vorkommen\n"))
 	b.WriteString(normalStyle.Render("  Begriff OR Begriff     Einer muss vorkommen\n"))
+	b.WriteString(normalStyle.Render("  NOT Begriff            Darf nicht vorkommen\n"))
+	b.WriteString(normalStyle.Render("  geändert:N             In einem der letzten N Stände geändert\n"))
+	b.WriteString(normalStyle.Render("  tabelle=Name           Spalte gleich Wert (datenbank, tabelle, element,\n"))
+	b.WriteString(normalStyle.Render("                         typ, kategorie, gruppe)\n"))
 	b.WriteString(normalStyle.Render("  Beispiel: http AND Kunden OR email\n"))
 
 	return boxStyle.Width(m.width - 4).Render(b.String())
 }
 
 // renderAllScripts rendert die Gesamtansicht aller Scripts
+// renderAllScriptEntry rendert einen Eintrag der Gesamtansicht samt Vorschau.
+// Jede Zeile endet mit einem Zeilenumbruch, die Höhe ist die Anzahl der Umbrüche.
+func (m Model) renderAllScriptEntry(i int) string {
+	s := m.filteredScripts[i]
+	isSelected := i == m.selectedAllScript
+
+	// Header-Zeile für das Script
+	headerLine := fmt.Sprintf("%s │ %s │ %s │ %s",
+		truncate(s.DatabaseName, 15),
+		truncate(s.TableName, 15),
+		truncate(s.ElementName, 15),
+		truncate(s.CodeType, 12),
+	)
+	headerLine += " │ " + truncate(s.Group(), 15)
+	if cell := m.recencyCell(s); cell != "" {
+		headerLine += " │" + cell
+	}
+
+	// Style basierend auf Auswahl
+	headerStyle := tableCellStyle
+	if isSelected {
+		headerStyle = tableCellSelectedStyle
+	}
+
+	// Prefix für Auswahl und Markierung
+	prefix := m.scriptPrefix(s, isSelected)
+
+	// Kompakt: erste Codezeile in der Kopfzeile, keine Code-Box
+	if m.previewLines == 0 {
+		line := prefix + headerLine
+		if first := strings.TrimSpace(strings.SplitN(s.Code, "\n", 2)[0]); first != "" {
+			line += " │ " + first
+		}
+		return headerStyle.Render(truncate(line, m.width-4)) + "\n"
+	}
+
+	entry := headerStyle.Render(prefix+headerLine) + "\n"
+
+	// Code-Vorschau, beim ausgewählten Script hervorgehoben
+	codePreview := previewText(s.Code, m.previewLines)
+	if isSelected && m.preview.id == s.ID && m.preview.lines == m.previewLines && m.preview.text != "" {
+		codePreview = m.preview.text
+	}
+
+	// Code-Box
+	codeStyle := lipgloss.NewStyle().
+		Foreground(currentTheme.TextMuted).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(currentTheme.Border).
+		Padding(0, 1).
+		Width(m.width - 10)
+
+	if isSelected {
+		codeStyle = codeStyle.BorderForeground(currentTheme.Primary)
+	}
+
+	return entry + codeStyle.Render(codePreview) + "\n\n"
+}
+
 func (m Model) renderAllScripts() string {
 	var b strings.Builder
 
 	// Titel mit Statistik
 	total := len(m.allScripts)
+	if m.filterBase != nil {
+		total = len(m.filterBase)
+	}
 	filtered := len(m.filteredScripts)
 
 	titleText := fmt.Sprintf("📜 Alle Scripts (%d", filtered)
@@ -1123,76 +2641,14 @@ func (m Model) renderAllScripts() string {
 		return boxStyle.Width(m.width - 4).Render(b.String())
 	}
 
-	// Berechne sichtbaren Bereich
-	visibleRows := m.height - 15 // Platz für Header, Footer, Code-Box
-	if visibleRows < 3 {
-		visibleRows = 3
-	}
-	visibleRows = visibleRows / 4 // Jedes Script braucht ca. 4 Zeilen
-
-	startIdx := m.scrollOffset
-	endIdx := startIdx + visibleRows
-	if endIdx > len(m.filteredScripts) {
-		endIdx = len(m.filteredScripts)
-	}
-
-	// Scripts anzeigen
+	// Sichtbarer Bereich nach tatsächlich gerenderten Höhen
+	startIdx, endIdx := m.allScriptsWindow()
 	for i := startIdx; i < endIdx; i++ {
-		s := m.filteredScripts[i]
-		isSelected := i == m.selectedAllScript
-
-		// Header-Zeile für das Script
-		headerLine := fmt.Sprintf("%s │ %s │ %s │ %s │ %s",
-			truncate(s.DatabaseName, 15),
-			truncate(s.TableName, 15),
-			truncate(s.ElementName, 15),
-			truncate(s.CodeType, 12),
-			truncate(s.CodeCategory, 10),
-		)
-
-		// Style basierend auf Auswahl
-		headerStyle := tableCellStyle
-		if isSelected {
-			headerStyle = tableCellSelectedStyle
-		}
-
-		// Prefix für Auswahl
-		prefix := "  "
-		if isSelected {
-			prefix = "▶ "
-		}
-
-		b.WriteString(headerStyle.Render(prefix+headerLine) + "\n")
-
-		// Code-Vorschau (erste 2-3 Zeilen)
-		codeLines := strings.Split(s.Code, "\n")
-		previewLines := 2
-		if len(codeLines) < previewLines {
-			previewLines = len(codeLines)
-		}
-
-		codePreview := strings.Join(codeLines[:previewLines], "\n")
-		if len(codeLines) > previewLines {
-			codePreview += "\n..."
-		}
-
-		// Code-Box
-		codeStyle := lipgloss.NewStyle().
-			Foreground(currentTheme.TextMuted).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(currentTheme.Border).
-			Padding(0, 1).
-			Width(m.width - 10)
-
-		if isSelected {
-			codeStyle = codeStyle.BorderForeground(currentTheme.Primary)
-		}
-
-		b.WriteString(codeStyle.Render(codePreview) + "\n\n")
+		b.WriteString(m.renderAllScriptEntry(i))
 	}
 
 	// Scroll-Info
-	if len(m.filteredScripts) > visibleRows {
+	if startIdx > 0 || endIdx < len(m.filteredScripts) {
 		scrollPercent := 0
 		if len(m.filteredScripts) > 0 {
 			scrollPercent = (m.selectedAllScript * 100) / len(m.filteredScripts)
@@ -1222,7 +2678,27 @@ func min(a, b int) int {
 
 func highlightCode(code string) string {
 	// Lexer für JavaScript (Ninox ist JS-ähnlich)
-	lexer := lexers.Get("javascript")
+	return highlightSource(code, "javascript")
+}
+
+// highlightSource hebt Quelltext mit dem angegebenen Chroma-Lexer hervor.
+// Ergebnisse werden pro Inhalt und Theme (samt Farben) im Render-Cache abgelegt.
+func highlightSource(code, language string) string {
+	key := cacheKey(code, currentTheme.fingerprint(), language)
+	if cached, ok := highlightCache.get(key); ok {
+		return cached
+	}
+
+	result, ok := renderHighlighted(code, language)
+	if ok {
+		highlightCache.put(key, result)
+	}
+	return result
+}
+
+// renderHighlighted führt die eigentliche Hervorhebung mit Zeilennummern durch
+func renderHighlighted(code, language string) (string, bool) {
+	lexer := lexers.Get(language)
 	if lexer == nil {
 		lexer = lexers.Fallback
 	}
@@ -1243,13 +2719,13 @@ func highlightCode(code string) string {
 	// Tokenize und formatieren
 	iterator, err := lexer.Tokenise(nil, code)
 	if err != nil {
-		return code
+		return code, false
 	}
 
 	var buf bytes.Buffer
 	err = formatter.Format(&buf, style, iterator)
 	if err != nil {
-		return code
+		return code, false
 	}
 
 	// Zeilennummern hinzufügen
@@ -1262,30 +2738,150 @@ func highlightCode(code string) string {
 		result.WriteString("\n")
 	}
 
-	return result.String()
+	return result.String(), true
+}
+
+// Exit-Codes für die Verwendung in Shell-Skripten
+const (
+	exitOK        = 0 // Erfolgreich, Ergebnisse gefunden
+	exitNoResults = 1 // Keine Ergebnisse
+	exitUsage     = 2 // Aufruffehler (unbekannte Option, fehlender Wert)
+	exitDBError   = 3 // Datenbank fehlt oder ist nicht lesbar
+	exitFailure   = 4 // Sonstiger Laufzeitfehler
+)
+
+// quiet unterdrückt dekorative Ausgaben (--quiet)
+var quiet bool
+
+// fail gibt eine Fehlermeldung auf stderr aus und beendet mit dem Exit-Code
+func fail(code int, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if !quiet {
+		msg = "❌ " + msg
+	}
+	fmt.Fprintln(os.Stderr, msg)
+	removeDemoDB()
+	os.Exit(code)
+}
+
+// argValue liefert den Wert einer Option mit Argument und rückt den Index vor
+func argValue(args []string, i *int) string {
+	if *i+1 >= len(args) {
+		fail(exitUsage, "Option %s benötigt einen Wert", args[*i])
+	}
+	*i++
+	return args[*i]
 }
 
 func printUsage() {
 	fmt.Println("Ninox Schema Explorer - Terminal UI")
 	fmt.Println("")
 	fmt.Println("Verwendung:")
-	fmt.Println("  ninox-tui [optionen] [datenbank.db]")
+	fmt.Println("  ninox-tui [optionen] [datenbank.db …|verzeichnis]")
+	fmt.Println("  ninox-tui <befehl> [optionen]")
+	fmt.Println("")
+	fmt.Println("  Statt einer Datei auch postgres://benutzer@host/datenbank (Build mit -tags postgres)")
+	fmt.Println("  oder TREIBER:DSN für einen anderen eingebauten database/sql-Treiber;")
+	fmt.Println("  http(s)://host:port öffnet eine per serve bereitgestellte Extraktion.")
+	fmt.Println("  Mehrere Dateien bzw. alle .db eines Verzeichnisses: W wechselt zwischen ihnen.")
+	fmt.Println("  Ohne Angabe ninox_schema.db; fehlt sie, stehen die .db-Dateien des Verzeichnisses")
+	fmt.Println("  und zuletzt geöffnete Extraktionen zur Auswahl.")
+	fmt.Println("")
+	fmt.Println("Befehle:")
+	fmt.Println("  diff-script ID1 ID2   Unified Diff zweier Scripts (-U N, --no-color, --db)")
+	fmt.Println("  gen-fixture [DATEI]   Synthetische Test-Datenbank erzeugen (--force)")
+	fmt.Println("  clear-cache           Render-Cache und Cache entfernter Abfragen löschen")
+	fmt.Println("  drawio [DATEI]        Datenmodell als draw.io-Diagramm exportieren (--database NAME)")
+	fmt.Println("  mermaid [DATEI]       Verknüpfungen als Mermaid-erDiagram ausgeben (--database NAME, --table NAME)")
+	fmt.Println("  graph [DATEI]         Verknüpfungsgraph für Graphviz ausgeben (--format dot|mermaid, --database, --table)")
+	fmt.Println("  serve                 Extraktion per HTTP für entfernte Clients bereitstellen (--listen ADRESSE)")
+	fmt.Println("  diff-snapshot ALT NEU Zwei Extraktionen vergleichen (--output text|html, -U N)")
+	fmt.Println("                        --webhook URL: Zusammenfassung senden (--webhook-format json|slack|teams)")
+	fmt.Println("  diff [ALT [NEU]]      Stände aus dem Verlauf (Nummer) oder Dateien vergleichen; ohne Angabe")
+	fmt.Println("                        vorletzter gegen letzten Stand (--list Stände, --output text|html, -U N)")
+	fmt.Println("  changelog ALT … NEU   CHANGELOG.md aus einer Folge von Extraktionen erzeugen")
+	fmt.Println("  digest [ALT …]        Änderungen und neue Lint-Befunde der letzten Tage (--days N, --output html;")
+	fmt.Println("                        ohne Dateien gilt snapshots aus der Konfiguration)")
+	fmt.Println("  path VON NACH         Kürzeste Wege zwischen zwei Tabellen (--database NAME)")
+	fmt.Println("  export-scripts DIR    Scripts als Dateien exportieren (--frontmatter, --name-template, --dry-run, --database NAME)")
+	fmt.Println("  git-export DIR        Script-Verlauf als Git-Repository: ein Commit je Extraktion")
+	fmt.Println("                        (--author \"Name <mail>\", --message TEXT mit {date} {source}, --database NAME)")
+	fmt.Println("  export-html DIR       Schema als statische Website mit hervorgehobenen Scripts (--database NAME)")
+	fmt.Println("  import-scripts DIR    Bearbeitete Dateien mit der DB abgleichen (--plan DATEI)")
+	fmt.Println("  extract               Schema und Scripts über die Ninox API extrahieren (--api-key KEY, --team ID[,ID],")
+	fmt.Println("                        --database NAME, --counts Datensätze zählen, --update nur Geändertes")
+	fmt.Println("                        neu schreiben; Ausgabe --db DATEI)")
+	fmt.Println("  import-archive ARCHIV Schema aus Ninox-Archiven (.ninox, Backup-ZIP, Schema-JSON) ohne API einlesen")
+	fmt.Println("                        (--team NAME, --database NAME|ID; Ausgabe --db DATEI)")
+	fmt.Println("  push PLAN             Änderungsplan per Ninox API übertragen (--dry-run, --yes, --team ID, --api-key KEY)")
+	fmt.Println("  option-refs T.FELD [WERT]  Scripts, die Auswahlwerte eines Feldes verwenden")
+	fmt.Println("  record-counts         Datensätze je Tabelle mit Triggern (--refresh: live über die API)")
+	fmt.Println("  lint                  Scripts auf Performance-Fallen, Zugangsdaten und tote Verweise prüfen (--database NAME, --output json|sarif)")
+	fmt.Println("  list ART              databases, tables, fields, scripts, relationships oder stats ausgeben")
+	fmt.Println("                        (tabulatorgetrennt, --json als JSON; --database, --table, -q ohne Kopf)")
 	fmt.Println("")
 	fmt.Println("Optionen:")
 	fmt.Println("  --dark     Dunkles Farbschema (Standard)")
 	fmt.Println("  --light    Helles Farbschema")
+	fmt.Println("  --theme    Farbschema nach Name: dark, light oder eigenes aus " + themeFileName)
+	fmt.Println("             (neben der Konfigurationsdatei bzw. theme_file)")
+	fmt.Println("  --view     Startansicht: teams, databases, allscripts, stats, search")
+	fmt.Println("  --filter   Gesamtansicht mit Filter öffnen (z.B. \"http AND Kunden\")")
+	fmt.Println("  --config   Pfad zur Konfigurationsdatei")
+	fmt.Println("             (Standard: " + defaultConfigPath() + ")")
+	fmt.Println("  --state-dir Verzeichnis für Verlauf, Sitzungszustand und Cache entfernter Abfragen")
+	fmt.Println("             (Standard: " + defaultStateDir() + ")")
+	fmt.Println("  --quiet    Keine dekorativen Ausgaben (für Skripte)")
+	fmt.Println("  --demo     Mit eingebetteten Beispieldaten starten")
+	fmt.Println("  --no-restore Abgebrochene Sitzung (Ansicht, Filter) nicht wiederherstellen")
+	fmt.Println("  --tour     Einführung: Hinweise Schritt für Schritt durch die wichtigsten Ansichten")
+	fmt.Println("             (mit --demo auch ohne eigene Extraktion)")
+	fmt.Println("  --no-cache Hervorgehobenen Code und entfernte Abfragen nicht auf der Festplatte zwischenspeichern")
+	fmt.Println("  --fzf      Script-Auswahl (p) über fzf, falls installiert")
+	fmt.Println("  --lang     Sprache exportierter Dokumente: de (Standard) oder en;")
+	fmt.Println("             gilt auch für export-html, diff, diff-snapshot, changelog und digest")
+	fmt.Println("  --allow-write Extraktion beschreiben (Analyse-Zwischenspeicher, Volltextindex);")
+	fmt.Println("             ohne diese Option wird sie nur lesend geöffnet")
+	fmt.Println("  --write-to DATEI Eigene Hilfstabellen in DATEI statt in der Extraktion speichern")
 	fmt.Println("  --help     Diese Hilfe anzeigen")
 	fmt.Println("")
+	fmt.Println("Umgebungsvariablen (Flags > Umgebung > Konfiguration):")
+	fmt.Println("  NINOX_TUI_DB, NINOX_TUI_THEME, NINOX_TUI_LANG, NINOX_TUI_URL,")
+	fmt.Println("  NINOX_TUI_TEAM, NINOX_TUI_FINDER, NINOX_TUI_CONFIG, NINOX_TUI_STATE_DIR,")
+	fmt.Println("  NINOX_TUI_WEBHOOK")
+	fmt.Println("")
+	fmt.Println("Exit-Codes:")
+	fmt.Println("  0 Erfolg/Ergebnisse • 1 keine Ergebnisse • 2 Aufruffehler")
+	fmt.Println("  3 Datenbankfehler • 4 sonstiger Fehler")
+	fmt.Println("")
 	fmt.Println("Beispiele:")
 	fmt.Println("  ninox-tui                      # Standard-DB, dunkles Theme")
 	fmt.Println("  ninox-tui --light              # Standard-DB, helles Theme")
 	fmt.Println("  ninox-tui --dark mydata.db     # Eigene DB, dunkles Theme")
 	fmt.Println("  ninox-tui --light mydata.db    # Eigene DB, helles Theme")
+	fmt.Println("  ninox-tui --view allscripts    # Direkt in der Gesamtansicht starten")
+	fmt.Println("  ninox-tui --demo               # Ohne Extraktion ausprobieren")
+	fmt.Println("  ninox-tui --demo --tour        # Geführte Einführung mit Beispieldaten")
+	fmt.Println("  ninox-tui prod.db staging.db   # Zwei Extraktionen, W wechselt")
 }
 
 func main() {
-	dbPath := "ninox_schema.db"
-	theme := DarkTheme // Standard
+	// Nicht-interaktive Unterbefehle
+	if len(os.Args) > 1 {
+		if cmd, ok := cliCommands[os.Args[1]]; ok {
+			os.Exit(cmd(os.Args[2:]))
+		}
+	}
+
+	var dbPaths []string
+	startView := ""
+	startFilter := ""
+	demo := false
+	restore := true
+	tour := false
+	themeName := ""
+	finder := ""
+	lang := ""
 
 	// Argumente parsen
 	args := os.Args[1:]
@@ -1294,42 +2890,155 @@ func main() {
 		switch arg {
 		case "--help", "-h":
 			printUsage()
-			os.Exit(0)
+			os.Exit(exitOK)
 		case "--dark", "-d":
-			theme = DarkTheme
+			themeName = DarkTheme.Name
 		case "--light", "-l":
-			theme = LightTheme
+			themeName = LightTheme.Name
+		case "--theme":
+			themeName = argValue(args, &i)
+		case "--config", "-c":
+			configPathOverride = argValue(args, &i)
+		case "--view":
+			startView = argValue(args, &i)
+		case "--filter":
+			startFilter = argValue(args, &i)
+		case "--quiet", "-q":
+			quiet = true
+		case "--demo":
+			demo = true
+		case "--no-restore":
+			restore = false
+		case "--tour":
+			tour = true
+		case "--state-dir":
+			stateDirOverride = argValue(args, &i)
+		case "--no-cache":
+			highlightCache = newRenderCache("")
+			noRemoteCache = true
+		case "--fzf":
+			finder = "fzf"
+		case "--lang":
+			lang = argValue(args, &i)
+		case "--allow-write":
+			writeAccess.allow = true
+		case "--write-to":
+			writeAccess.sidecar = argValue(args, &i)
 		default:
 			if !strings.HasPrefix(arg, "-") {
-				dbPath = arg
+				dbPaths = append(dbPaths, arg)
 			} else {
-				fmt.Printf("Unbekannte Option: %s\n", arg)
-				printUsage()
-				os.Exit(1)
+				fmt.Fprintf(os.Stderr, "Unbekannte Option: %s\n", arg)
+				if !quiet {
+					printUsage()
+				}
+				os.Exit(exitUsage)
 			}
 		}
 	}
 
-	// Theme anwenden
+	cfg, err := LoadConfig(activeConfigPath())
+	if err != nil {
+		fail(exitUsage, "%v", err)
+	}
+	cfg.ApplyEnv()
+
+	// Flags haben Vorrang vor Umgebung und Konfigurationsdatei
+	pickable := len(dbPaths) == 0 && cfg.DB == "" // ohne Angabe: Auswahl statt Fehler
+	if len(dbPaths) == 0 {
+		dbPaths = []string{firstNonEmpty(cfg.DB, "ninox_schema.db")}
+	}
+	if dbPaths, err = expandSchemaPaths(dbPaths); err != nil {
+		fail(exitUsage, "%v", err)
+	}
+	dbPath := dbPaths[0]
+	if themeName == "" {
+		themeName = cfg.Theme
+	}
+	if finder != "" {
+		cfg.Finder = finder
+	}
+	if lang == "" {
+		lang = cfg.Lang
+	}
+	if err := setDocLang(lang); err != nil {
+		fail(exitUsage, "%v", err)
+	}
+
+	// Theme anwenden (eigene Themes aus themes.toml)
+	if err := loadCustomThemes(themeFilePath(cfg)); err != nil {
+		fail(exitUsage, "%v", err)
+	}
+	theme, err := themeByName(themeName)
+	if err != nil {
+		fail(exitUsage, "%v", err)
+	}
 	applyTheme(theme)
 
-	// Prüfen ob DB existiert
-	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
-		fmt.Printf("❌ Datenbank nicht gefunden: %s\n", dbPath)
-		fmt.Println("   Bitte zuerst Daten extrahieren mit dem Python-Tool.")
-		os.Exit(1)
+	// Demo-Modus: eingebettete Beispieldaten statt eigener Extraktion
+	if demo {
+		dbPath, err = openDemoDB()
+		if err != nil {
+			fail(exitFailure, "%v", err)
+		}
+		defer removeDemoDB()
 	}
 
-	model, err := NewModel(dbPath)
+	// Ohne Angabe und ohne ninox_schema.db eine vorhandene Extraktion wählen lassen
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) && pickable && !demo && !quiet && isInteractive() {
+		picked, err := pickDB()
+		if err != nil {
+			fail(exitFailure, "%v", err)
+		}
+		if picked != "" {
+			dbPath, dbPaths = picked, []string{picked}
+		}
+	}
+
+	// Prüfen ob die Datenbanken existieren
+	for _, path := range dbPaths {
+		if _, err := os.Stat(path); os.IsNotExist(err) && isFileDB(path) && !demo {
+			if quiet {
+				fail(exitDBError, "Datenbank nicht gefunden: %s", path)
+			}
+			fail(exitDBError, "Datenbank nicht gefunden: %s\n   Bitte zuerst Daten extrahieren: ninox-tui extract --api-key KEY --team ID\n   bzw. aus einem Archiv: ninox-tui import-archive DATEI.ninox\n   oder mit --demo die Beispieldaten ansehen.", path)
+		}
+	}
+
+	model, err := NewModel(dbPath, cfg)
 	if err != nil {
-		fmt.Printf("❌ Fehler: %v\n", err)
-		os.Exit(1)
+		fail(exitDBError, "Fehler: %v", err)
 	}
 	defer model.db.Close()
+	if demo {
+		model.status = "Demo-Modus: eingebettete Beispieldaten"
+	} else {
+		model.SetSchemaFiles(dbPaths, cfg)
+		for i := len(dbPaths) - 1; i >= 0; i-- {
+			rememberDB(dbPaths[i])
+		}
+	}
+
+	// Abgebrochene Sitzung fortsetzen, sofern keine Startansicht verlangt ist
+	if !demo {
+		model.EnableSession(dbPath, restore && !tour && startFilter == "" && startView == "")
+	}
+	if tour {
+		model.StartTour()
+	}
+	if startFilter != "" {
+		model.SetStartFilter(startFilter)
+		if startView == "" {
+			startView = "allscripts"
+		}
+	}
+	if startView != "" {
+		if err := model.SetStartView(startView); err != nil {
+			fail(exitUsage, "%v", err)
+		}
+	}
 
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Fehler: %v\n", err)
-		os.Exit(1)
+	if err := runProgram(model, tea.WithAltScreen()); err != nil {
+		fail(exitFailure, "Fehler: %v", err)
 	}
 }
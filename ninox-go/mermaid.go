@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Export der Verknüpfungen als Mermaid-erDiagram (z.B. für Wiki-Seiten)
+// =============================================================================
+
+// mermaidUmlauts werden umschrieben, da ältere Mermaid-Versionen in Namen nur ASCII erlauben
+var mermaidUmlauts = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "Ä", "Ae", "Ö", "Oe", "Ü", "Ue", "ß", "ss")
+
+// mermaidName macht einen Tabellen-, Feld- oder Typnamen zum Mermaid-Bezeichner
+func mermaidName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-') {
+			return r
+		}
+		return '_'
+	}, mermaidUmlauts.Replace(strings.TrimSpace(name)))
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "T_" + name
+	}
+	return name
+}
+
+// mermaidLabel maskiert die Beschriftung einer Verknüpfung
+func mermaidLabel(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "'") + `"`
+}
+
+// writeMermaidDatabase schreibt das Diagramm einer Datenbank (mit table nur deren Umgebung)
+func writeMermaidDatabase(b *strings.Builder, db *NinoxDB, d Database, table string) (bool, error) {
+	g, err := loadTableGraph(db, d, table)
+	if err != nil || g == nil {
+		return false, err
+	}
+
+	fmt.Fprintf(b, "%%%% %s\nerDiagram\n", d.Name)
+	for _, t := range g.tables {
+		fields, err := db.GetFields(d.ID, t.TableID)
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintf(b, "    %s {\n", mermaidName(t.Name))
+		for _, f := range fields {
+			line := fmt.Sprintf("        %s %s", mermaidName(firstNonEmpty(f.BaseType, "any")), mermaidName(f.Name))
+			if f.RefTableName != "" {
+				line += " FK"
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("    }\n")
+	}
+
+	// Die verweisende Tabelle hat viele Datensätze je Ziel; Kompositionen durchgezogen
+	for _, r := range g.edges {
+		line := "||..o{"
+		if r.IsComposition {
+			line = "||--o{"
+		}
+		fmt.Fprintf(b, "    %s %s %s : %s\n", mermaidName(r.TargetTableName), line,
+			mermaidName(r.SourceTableName), mermaidLabel(r.SourceFieldName))
+	}
+	return true, nil
+}
+
+// MermaidER erzeugt je Datenbank ein erDiagram (mit table nur deren Umgebung)
+func MermaidER(db *NinoxDB, databases []Database, table string) (string, error) {
+	var diagrams []string
+	for _, d := range databases {
+		var b strings.Builder
+		ok, err := writeMermaidDatabase(&b, db, d, table)
+		if err != nil {
+			return "", fmt.Errorf("Datenbank %s: %w", d.Name, err)
+		}
+		if ok {
+			diagrams = append(diagrams, b.String())
+		}
+	}
+	return strings.Join(diagrams, "\n"), nil
+}
+
+// copyMermaid kopiert das Diagramm der Datenbank bzw. der Umgebung der Tabelle
+func (m Model) copyMermaid() (tea.Model, tea.Cmd) {
+	var database *Database
+	table := ""
+	switch {
+	case m.mode == viewDatabases && len(m.databases) > 0:
+		database = &m.databases[m.selectedDB]
+	case m.mode == viewTables:
+		database = m.currentDB
+	case m.mode == viewFields && m.currentTable != nil:
+		database = m.currentDB
+		table = m.currentTable.Name
+	}
+	if database == nil {
+		return m, nil
+	}
+
+	diagram, err := MermaidER(m.db, []Database{*database}, table)
+	if err != nil {
+		m.status = fmt.Sprintf("❌ %v", err)
+		return m, nil
+	}
+	via := copyToClipboard(diagram)
+	m.status = fmt.Sprintf("✓ Mermaid-Diagramm kopiert (%s): %s", via, firstNonEmpty(table, database.Name))
+	return m, nil
+}
+
+// cmdMermaid gibt die Verknüpfungen als Mermaid-erDiagram aus (ohne DATEI auf stdout)
+func cmdMermaid(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) > 1 {
+		fail(exitUsage, "Verwendung: ninox-tui mermaid [DATEI] [--database NAME] [--table NAME] [--db DATEI]")
+	}
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	databases, err := selectDatabases(db, opts.database)
+	if err != nil {
+		fail(exitNoResults, "%v", err)
+	}
+	diagram, err := MermaidER(db, databases, opts.table)
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+	if diagram == "" {
+		fail(exitNoResults, "Tabelle %s nicht gefunden", opts.table)
+	}
+
+	if len(opts.positional) == 0 || opts.positional[0] == "-" {
+		fmt.Print(diagram)
+		return exitOK
+	}
+	path := opts.positional[0]
+	if err := os.WriteFile(path, []byte(diagram), 0o644); err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	if !quiet {
+		fmt.Printf("✓ Mermaid-Diagramm geschrieben: %s\n", path)
+	}
+	return exitOK
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// =============================================================================
+// import-archive: Schema-Datenbank aus Ninox-Archiven (.ninox, Backup-ZIP oder
+// exportiertes Schema-JSON) erzeugen – ohne Zugriff auf die API
+// =============================================================================
+
+// defaultArchiveTeam ist das Team der importierten Datenbanken ohne --team
+const defaultArchiveTeam = "Archiv"
+
+// maxArchiveEntry begrenzt die Größe eines gelesenen JSON-Eintrags
+const maxArchiveEntry = 256 << 20
+
+// archiveSchema ist das Schema einer Datenbank aus einem Archiv
+type archiveSchema struct {
+	id, name string
+	dir      string // Ordner im ZIP-Archiv
+	settings map[string]any
+	schema   map[string]any
+}
+
+// decodeArchiveJSON dekodiert JSON wie die API-Antworten (Zahlen als json.Number)
+func decodeArchiveJSON(data []byte) (map[string]any, error) {
+	var v map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// archiveSchemaOf erkennt das Schema in einem JSON-Dokument: direkt (types auf
+// oberster Ebene) oder wie in der API-Antwort unter schema mit settings daneben
+func archiveSchemaOf(doc map[string]any, fallbackID string) *archiveSchema {
+	schema, settings := doc, jsonObject(doc["settings"])
+	if jsonObject(doc["types"]) == nil {
+		schema = jsonObject(doc["schema"])
+		if jsonObject(schema["types"]) == nil {
+			return nil
+		}
+	}
+	id := firstNonEmpty(jsonText(doc["id"]), jsonText(settings["id"]), jsonText(schema["id"]), fallbackID)
+	name := firstNonEmpty(jsonText(settings["name"]), jsonText(doc["name"]), id)
+	return &archiveSchema{id: id, name: name, settings: settings, schema: schema}
+}
+
+// readNinoxArchive liest die Schemas eines Archivs. ZIP-Archive können mehrere
+// Datenbanken enthalten; eine settings.json im selben Ordner liefert Name und Farbe.
+func readNinoxArchive(file string) ([]archiveSchema, error) {
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		// Kein ZIP: exportiertes Schema als einzelnes JSON-Dokument
+		data, rerr := os.ReadFile(file)
+		if rerr != nil {
+			return nil, rerr
+		}
+		doc, jerr := decodeArchiveJSON(data)
+		if jerr != nil {
+			return nil, fmt.Errorf("weder ZIP-Archiv noch JSON: %v", jerr)
+		}
+		s := archiveSchemaOf(doc, base)
+		if s == nil {
+			return nil, fmt.Errorf("kein Ninox-Schema gefunden")
+		}
+		return []archiveSchema{*s}, nil
+	}
+	defer zr.Close()
+
+	var schemas []archiveSchema
+	settings := make(map[string]map[string]any) // Ordner → settings.json
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".json") {
+			continue
+		}
+		data, err := readZipEntry(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		doc, err := decodeArchiveJSON(data)
+		if err != nil {
+			continue // andere JSON-Dateien (Datensätze, Dateien) ohne Objekt
+		}
+		dir := path.Dir(f.Name)
+		if strings.EqualFold(path.Base(f.Name), "settings.json") {
+			settings[dir] = doc
+			continue
+		}
+		// Ohne eigene ID benennt der Ordner (Team-Backup) bzw. das Archiv die Datenbank
+		fallbackID := base
+		if dir != "." {
+			fallbackID = path.Base(dir)
+		}
+		if s := archiveSchemaOf(doc, fallbackID); s != nil {
+			s.dir = dir
+			schemas = append(schemas, *s)
+		}
+	}
+	// settings.json kann vor oder nach dem Schema im Archiv stehen
+	for i, s := range schemas {
+		if st, ok := settings[s.dir]; ok && s.settings == nil {
+			schemas[i].settings = st
+			schemas[i].name = firstNonEmpty(jsonText(st["name"]), s.name)
+		}
+	}
+	if len(schemas) == 0 {
+		return nil, fmt.Errorf("kein Ninox-Schema im Archiv gefunden")
+	}
+	return schemas, nil
+}
+
+func readZipEntry(f *zip.File) ([]byte, error) {
+	if f.UncompressedSize64 > maxArchiveEntry {
+		return nil, fmt.Errorf("Eintrag zu groß (%d Bytes)", f.UncompressedSize64)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxArchiveEntry))
+}
+
+// cmdImportArchive schreibt die Schemas eines oder mehrerer Archive in eine Schema-Datenbank
+func cmdImportArchive(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) == 0 {
+		fail(exitUsage, "Verwendung: ninox-tui import-archive ARCHIV [ARCHIV…] [--team NAME] [--database NAME|ID] [--db DATEI]")
+	}
+	if !isFileDB(opts.dbPath) {
+		fail(exitUsage, "import-archive schreibt eine SQLite-Datei: %s", opts.dbPath)
+	}
+	team := firstNonEmpty(opts.team, defaultArchiveTeam)
+
+	stats, err := writeSchemaFile(opts.dbPath, func(e *extractor) error {
+		e.teamID, e.teamName = team, team
+		e.source = "import-archive"
+		e.stats.teams = 1
+		seen := make(map[string]string) // Datenbank-ID → Archiv
+		for _, file := range opts.positional {
+			schemas, err := readNinoxArchive(file)
+			if err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+			for _, s := range schemas {
+				if opts.database != "" && !strings.EqualFold(opts.database, s.id) && !strings.EqualFold(opts.database, s.name) {
+					continue
+				}
+				if prev, ok := seen[s.id]; ok {
+					return fmt.Errorf("Datenbank %s ist in %s und %s enthalten", s.id, prev, file)
+				}
+				seen[s.id] = file
+				if !quiet {
+					fmt.Printf("  Importiere %s (%s)\n", s.name, s.id)
+				}
+				if err := e.addDatabase(s.id, s.name, s.settings, s.schema); err != nil {
+					fmt.Fprintf(os.Stderr, "❌ %s: %v\n", s.name, err)
+					continue
+				}
+				e.stats.databases++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	if stats.databases == 0 {
+		fail(exitNoResults, "Keine Datenbank importiert")
+	}
+	if !quiet {
+		fmt.Printf("✓ %s geschrieben: %s\n", opts.dbPath, stats)
+	}
+	return exitOK
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Benachrichtigung per Webhook, wenn diff-snapshot Änderungen findet
+// =============================================================================
+
+// maxNotifyChanges begrenzt die Einträge in Chat-Nachrichten (Slack, Teams)
+const maxNotifyChanges = 30
+
+// changeKindNames benennt die Änderungsarten im JSON
+var changeKindNames = map[changeKind]string{
+	changeAdded:   "added",
+	changeRemoved: "removed",
+	changeChanged: "changed",
+}
+
+// changeJSON ist eine Änderung in der JSON-Nachricht
+type changeJSON struct {
+	Kind     string `json:"kind"`
+	Object   string `json:"object"`
+	Database string `json:"database"`
+	Table    string `json:"table,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Lines    int    `json:"lines,omitempty"`
+	Text     string `json:"text"`
+
+	ModifiedBy string `json:"modifiedBy,omitempty"`
+	ModifiedAt string `json:"modifiedAt,omitempty"`
+}
+
+// diffSummaryJSON ist die Nachricht im Format json
+type diffSummaryJSON struct {
+	Old     string         `json:"old"`
+	New     string         `json:"new"`
+	Summary map[string]int `json:"summary"`
+	Changes []changeJSON   `json:"changes"`
+}
+
+// notifyFormats ordnet --webhook-format den Nachrichten zu
+var notifyFormats = map[string]func(diff *SnapshotDiff) any{
+	"json":  diffSummaryPayload,
+	"slack": slackPayload,
+	"teams": teamsPayload,
+}
+
+func diffSummaryPayload(diff *SnapshotDiff) any {
+	payload := diffSummaryJSON{Old: diff.Old, New: diff.New, Summary: map[string]int{}, Changes: []changeJSON{}}
+	for _, name := range changeKindNames {
+		payload.Summary[name] = 0
+	}
+	for _, c := range diff.Changes {
+		payload.Summary[changeKindNames[c.Kind]]++
+		payload.Changes = append(payload.Changes, changeJSON{
+			Kind: changeKindNames[c.Kind], Object: c.Object, Database: c.Database, Table: c.Table,
+			Name: c.Name, Detail: c.Detail, Lines: c.Lines, Text: changelogEntry(c),
+			ModifiedBy: c.ModifiedBy, ModifiedAt: c.ModifiedAt,
+		})
+	}
+	return payload
+}
+
+// notifyTitle ist die Überschrift der Chat-Nachricht, z.B. "3 Schema-Änderungen (alt.db → neu.db)"
+func notifyTitle(diff *SnapshotDiff) string {
+	return fmt.Sprintf("%d Schema-Änderungen (%s → %s)", len(diff.Changes), diff.Old, diff.New)
+}
+
+// notifyLines listet die Änderungen für Chat-Nachrichten, gekürzt auf maxNotifyChanges
+func notifyLines(diff *SnapshotDiff, bullet string) []string {
+	var lines []string
+	for i, c := range diff.Changes {
+		if i == maxNotifyChanges {
+			lines = append(lines, fmt.Sprintf("… und %d weitere", len(diff.Changes)-i))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s%s: %s", bullet, c.Database, changelogEntry(c)))
+	}
+	return lines
+}
+
+// slackPayload erzeugt eine Nachricht für Slack-Incoming-Webhooks (mrkdwn)
+func slackPayload(diff *SnapshotDiff) any {
+	text := "*" + notifyTitle(diff) + "*\n" + strings.Join(notifyLines(diff, "• "), "\n")
+	return map[string]string{"text": text}
+}
+
+// teamsPayload erzeugt eine MessageCard für Microsoft-Teams-Webhooks
+func teamsPayload(diff *SnapshotDiff) any {
+	return map[string]string{
+		"@type":    "MessageCard",
+		"@context": "https://schema.org/extensions",
+		"summary":  notifyTitle(diff),
+		"title":    notifyTitle(diff),
+		"text":     strings.Join(notifyLines(diff, "- "), "\n\n"),
+	}
+}
+
+// notifyWebhook sendet die Änderungen als POST an webhook
+func notifyWebhook(webhook, format string, diff *SnapshotDiff) error {
+	build, ok := notifyFormats[format]
+	if !ok {
+		return fmt.Errorf("Unbekanntes Webhook-Format: %s (erlaubt: json, slack, teams)", format)
+	}
+	data, err := json.Marshal(build(diff))
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(webhook, "application/json", bytes.NewReader(data))
+	if urlErr, ok := err.(*url.Error); ok {
+		return urlErr.Err // die URL enthält bei Slack und Teams das Zugangstoken
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 500))
+		return fmt.Errorf("Webhook: %s %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
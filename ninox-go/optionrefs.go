@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Scripts, die Auswahlwerte eines Feldes über ihre Nummer verwenden
+// =============================================================================
+
+// choiceField ist ein Auswahlfeld samt Auswahlwerten
+type choiceField struct {
+	DatabaseID string
+	TableID    string
+	TableName  string
+	FieldID    string
+	Name       string
+	Options    []FieldOption
+}
+
+// caption liefert die Beschriftung eines Auswahlwerts ("" = unbekannt)
+func (f choiceField) caption(id string) (string, bool) {
+	for _, o := range f.Options {
+		if o.ID == id {
+			return o.Caption, true
+		}
+	}
+	return "", false
+}
+
+// optionRef ist eine Stelle, an der ein Script einen Auswahlwert verwendet
+type optionRef struct {
+	Script   *Script
+	Line     int
+	OptionID string
+	Caption  string // aufgelöste Beschriftung, leer wenn der Wert nicht (mehr) existiert
+	Known    bool
+	Text     string // die Codezeile
+}
+
+// optionRefPatterns liefert die Muster für Vergleiche und Zuweisungen mit Nummern:
+// Status = 3, r.Status != 3, number(Status) = 3, 3 = Status, Status := 3, chosen(Status, 3).
+// Gruppe "q" ist ein vorangestellter Punkt (Feld einer anderen Tabelle), "id" die Nummer.
+func optionRefPatterns(field string) []*regexp.Regexp {
+	name := `'` + regexp.QuoteMeta(field) + `'`
+	if regexp.MustCompile(`^[\pL_][\pL\pN_]*$`).MatchString(field) {
+		name = `(?:` + name + `|\b` + regexp.QuoteMeta(field) + `\b)`
+	}
+	return []*regexp.Regexp{
+		regexp.MustCompile(`(?P<q>\.\s*)?` + name + `(?:\s*\))?\s*(?:=|!=|<>|:=)\s*(?P<id>\d+)\b`),
+		regexp.MustCompile(`\b(?P<id>\d+)\s*(?:=|!=|<>)\s*(?:number\(\s*)?(?P<q>[\pL\pN_')\]]\s*\.\s*)?` + name),
+		regexp.MustCompile(`\bchosen\(\s*(?P<q>[^,()]*\.\s*)?` + name + `\s*,\s*(?P<id>\d+)\s*\)`),
+	}
+}
+
+// findOptionRefs sucht in den Scripts nach Verwendungen der Auswahlwerte eines Feldes.
+// Unqualifiziert zählt der Feldname nur in Scripts derselben Tabelle, mit Punkt
+// (z.B. r.Status) in allen Scripts der Datenbank.
+func findOptionRefs(scripts []Script, field choiceField) []optionRef {
+	patterns := optionRefPatterns(field.Name)
+
+	var refs []optionRef
+	for i := range scripts {
+		s := &scripts[i]
+		if s.DatabaseID != field.DatabaseID {
+			continue
+		}
+		sameTable := s.TableID == field.TableID
+
+		seen := make(map[int]bool) // Startposition → bereits gefunden
+		for _, re := range patterns {
+			q, id := re.SubexpIndex("q"), re.SubexpIndex("id")
+			for _, loc := range re.FindAllStringSubmatchIndex(s.Code, -1) {
+				qualified := loc[2*q] >= 0
+				if (!qualified && !sameTable) || seen[loc[0]] {
+					continue
+				}
+				seen[loc[0]] = true
+
+				optionID := s.Code[loc[2*id]:loc[2*id+1]]
+				caption, known := field.caption(optionID)
+				line := strings.Count(s.Code[:loc[0]], "\n") + 1
+				refs = append(refs, optionRef{
+					Script: s, Line: line, OptionID: optionID, Caption: caption, Known: known,
+					Text: strings.TrimSpace(strings.Split(s.Code, "\n")[line-1]),
+				})
+			}
+		}
+	}
+	return refs
+}
+
+// describeOption beschreibt einen Auswahlwert, z.B. "3 (Gesperrt)" bzw. "7 (unbekannt)"
+func (r optionRef) describeOption() string {
+	if !r.Known {
+		return r.OptionID + " (⚠ unbekannt)"
+	}
+	return fmt.Sprintf("%s (%s)", r.OptionID, r.Caption)
+}
+
+// GetChoiceField lädt ein Auswahlfeld über Tabellen- und Feldnamen
+func (db *NinoxDB) GetChoiceField(databaseID, tableName, fieldName string) (*choiceField, error) {
+	var f choiceField
+	err := db.conn.QueryRow(`
+		SELECT f.database_id, f.table_id, t.name, f.field_id, f.name
+		FROM fields f
+		JOIN tables t ON t.database_id = f.database_id AND t.table_id = f.table_id
+		WHERE f.database_id = ? AND t.name = ? AND (f.name = ? OR f.caption = ?)
+	`, databaseID, tableName, fieldName, fieldName).Scan(&f.DatabaseID, &f.TableID, &f.TableName, &f.FieldID, &f.Name)
+	if err != nil {
+		return nil, err
+	}
+	options, err := db.GetFieldOptions()
+	if err != nil {
+		return nil, err
+	}
+	f.Options = options[optionKey(f.DatabaseID, f.TableID, f.FieldID)]
+	return &f, nil
+}
+
+// GetChoiceFields lädt alle Felder mit Auswahlwerten
+func (db *NinoxDB) GetChoiceFields() ([]choiceField, error) {
+	options, err := db.GetFieldOptions()
+	if err != nil || len(options) == 0 {
+		return nil, err
+	}
+	rows, err := db.conn.Query(`
+		SELECT f.database_id, f.table_id, t.name, f.field_id, f.name
+		FROM fields f
+		JOIN tables t ON t.database_id = f.database_id AND t.table_id = f.table_id
+		ORDER BY f.database_id, t.name, f.name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []choiceField
+	for rows.Next() {
+		var f choiceField
+		if err := rows.Scan(&f.DatabaseID, &f.TableID, &f.TableName, &f.FieldID, &f.Name); err != nil {
+			return nil, err
+		}
+		if f.Options = options[optionKey(f.DatabaseID, f.TableID, f.FieldID)]; len(f.Options) > 0 {
+			fields = append(fields, f)
+		}
+	}
+	return fields, rows.Err()
+}
+
+// showOptionRefs zeigt die Scripts, die Auswahlwerte des ausgewählten Feldes verwenden,
+// als Ausgangsmenge der Gesamtansicht; die Statuszeile nennt die Treffer je Wert
+func (m Model) showOptionRefs() (tea.Model, tea.Cmd) {
+	if m.mode != viewFields || m.selectedField >= len(m.fields) {
+		return m, nil
+	}
+	f := m.fields[m.selectedField]
+	options := m.fieldOptions[optionKey(f.DatabaseID, f.TableID, f.FieldID)]
+	if len(options) == 0 {
+		m.status = "Kein Auswahlfeld (oder Extraktion ohne Auswahlwerte)"
+		return m, nil
+	}
+
+	field := choiceField{
+		DatabaseID: f.DatabaseID, TableID: f.TableID, TableName: m.currentTable.Name,
+		FieldID: f.FieldID, Name: f.Name, Options: options,
+	}
+	refs := findOptionRefs(m.allScripts, field)
+	if len(refs) == 0 {
+		m.status = fmt.Sprintf("Kein Script verwendet Auswahlwerte von %s", f.Name)
+		return m, nil
+	}
+
+	var scripts []Script
+	seen := make(map[int]bool)
+	counts := make(map[string]int)
+	for _, r := range refs {
+		counts[r.describeOption()]++
+		if !seen[r.Script.ID] {
+			seen[r.Script.ID] = true
+			scripts = append(scripts, *r.Script)
+		}
+	}
+	var summary []string
+	for _, c := range sortedCounts(counts) {
+		summary = append(summary, fmt.Sprintf("%s ×%d", c.name, c.count))
+	}
+
+	m.filterBase = scripts
+	m.filterBaseLabel = "🔘 " + f.Name
+	m.filterStack = nil
+	m.applyFilter()
+	m.prevMode = m.mode
+	m.mode = viewAllScripts
+	m.status = f.Name + ": " + strings.Join(summary, " · ")
+	return m, nil
+}
+
+// cmdOptionRefs listet Scripts, die Auswahlwerte eines Feldes verwenden
+func cmdOptionRefs(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) < 1 || len(opts.positional) > 2 || !strings.Contains(opts.positional[0], ".") {
+		fail(exitUsage, "Verwendung: ninox-tui option-refs TABELLE.FELD [WERT] [--database NAME] [--db DATEI]")
+	}
+	tableName, fieldName, _ := strings.Cut(opts.positional[0], ".")
+	value := ""
+	if len(opts.positional) == 2 {
+		value = opts.positional[1]
+	}
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	if !db.HasFieldOptions() {
+		fail(exitDBError, "Die Extraktion enthält keine Auswahlwerte – bitte neu extrahieren")
+	}
+
+	databases, err := selectDatabases(db, opts.database)
+	if err != nil {
+		fail(exitNoResults, "%v", err)
+	}
+	scripts, err := db.GetAllScripts()
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+
+	found, fieldFound := 0, false
+	for _, d := range databases {
+		field, err := db.GetChoiceField(d.ID, tableName, fieldName)
+		if err != nil {
+			continue
+		}
+		fieldFound = true
+		for _, r := range findOptionRefs(scripts, *field) {
+			if value != "" && r.OptionID != value {
+				continue
+			}
+			found++
+			fmt.Printf("%s:%d\t%s\t%s\n", scriptLabel(*r.Script), r.Line, r.describeOption(), r.Text)
+		}
+	}
+	if !fieldFound {
+		fail(exitNoResults, "Feld %s nicht gefunden", opts.positional[0])
+	}
+	if found == 0 {
+		return exitNoResults
+	}
+	return exitOK
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Kürzeste Verbindungswege zwischen zwei Tabellen über die Verknüpfungen
+// =============================================================================
+
+// maxPaths begrenzt die Zahl der ausgegebenen gleich kurzen Wege
+const maxPaths = 10
+
+// pathStep ist ein Schritt entlang einer Verknüpfung
+type pathStep struct {
+	rel     Relationship
+	from    string
+	to      string
+	forward bool // in Verweisrichtung (from verweist auf to)
+}
+
+// relationGraph ordnet jeder Tabelle die Schritte zu ihren Nachbarn zu (beide Richtungen)
+func relationGraph(rels []Relationship) map[string][]pathStep {
+	graph := make(map[string][]pathStep)
+	for _, r := range rels {
+		graph[r.SourceTableName] = append(graph[r.SourceTableName],
+			pathStep{rel: r, from: r.SourceTableName, to: r.TargetTableName, forward: true})
+		if r.SourceTableName != r.TargetTableName {
+			graph[r.TargetTableName] = append(graph[r.TargetTableName],
+				pathStep{rel: r, from: r.TargetTableName, to: r.SourceTableName})
+		}
+	}
+	return graph
+}
+
+// ShortestPaths liefert alle kürzesten Wege von from nach to (höchstens maxPaths).
+// Verknüpfungen gelten in beiden Richtungen; parallele Verknüpfungen ergeben eigene Wege.
+func ShortestPaths(rels []Relationship, from, to string) [][]pathStep {
+	if from == to {
+		return nil
+	}
+	graph := relationGraph(rels)
+
+	// Breitensuche mit allen Vorgängerschritten je Tabelle
+	dist := map[string]int{from: 0}
+	preds := make(map[string][]pathStep)
+	queue := []string{from}
+	for len(queue) > 0 {
+		table := queue[0]
+		queue = queue[1:]
+		if d, ok := dist[to]; ok && dist[table] >= d {
+			break
+		}
+		for _, step := range graph[table] {
+			d, seen := dist[step.to]
+			switch {
+			case !seen:
+				dist[step.to] = dist[table] + 1
+				preds[step.to] = append(preds[step.to], step)
+				queue = append(queue, step.to)
+			case d == dist[table]+1:
+				preds[step.to] = append(preds[step.to], step)
+			}
+		}
+	}
+	if _, ok := dist[to]; !ok {
+		return nil
+	}
+
+	// Wege rückwärts vom Ziel aufbauen
+	var paths [][]pathStep
+	var walk func(table string, suffix []pathStep)
+	walk = func(table string, suffix []pathStep) {
+		if len(paths) >= maxPaths {
+			return
+		}
+		if table == from {
+			paths = append(paths, append([]pathStep{}, suffix...))
+			return
+		}
+		for _, step := range preds[table] {
+			walk(step.from, append([]pathStep{step}, suffix...))
+		}
+	}
+	walk(to, nil)
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		return formatPath(paths[i]) < formatPath(paths[j])
+	})
+	return paths
+}
+
+// formatPath beschreibt einen Weg, z.B. "Rechnungen →Kunde→ Kunden ←Kunde← Projekte"
+func formatPath(path []pathStep) string {
+	if len(path) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(path[0].from)
+	for _, step := range path {
+		field := step.rel.SourceFieldName
+		if field == "" {
+			field = step.rel.RelationshipType
+		}
+		if step.forward {
+			fmt.Fprintf(&b, " —%s→ %s", field, step.to)
+		} else {
+			fmt.Fprintf(&b, " ←%s— %s", field, step.to)
+		}
+	}
+	return b.String()
+}
+
+// pathReport beschreibt die gefundenen Wege als Text
+func pathReport(paths [][]pathStep, from, to string) string {
+	if len(paths) == 0 {
+		return fmt.Sprintf("Keine Verbindung zwischen %s und %s.\n", from, to)
+	}
+	var b strings.Builder
+	steps := len(paths[0])
+	fmt.Fprintf(&b, "%d kürzeste(r) Weg(e) über %d Verknüpfung(en):\n\n", len(paths), steps)
+	for i, path := range paths {
+		fmt.Fprintf(&b, "%2d. %s\n", i+1, formatPath(path))
+	}
+	b.WriteString("\n→ Verweisrichtung (Feld verweist auf Tabelle), ← Gegenrichtung\n")
+	return b.String()
+}
+
+// handlePath merkt sich die Ausgangstabelle und zeigt beim zweiten Aufruf die Wege zur gewählten Tabelle
+func (m Model) handlePath() (tea.Model, tea.Cmd) {
+	if m.mode != viewTables || len(m.tables) == 0 {
+		return m, nil
+	}
+	selected := m.tables[m.selectedTable].Name
+
+	if m.pathFrom == "" || m.pathFrom == selected {
+		m.pathFrom = selected
+		m.status = fmt.Sprintf("Weg: %s gewählt – Zieltabelle wählen und g drücken", selected)
+		return m, nil
+	}
+
+	rels, err := m.db.GetDatabaseRelationships(m.currentDB.ID)
+	if err != nil {
+		m.status = fmt.Sprintf("❌ %v", err)
+		return m, nil
+	}
+	from := m.pathFrom
+	m.pathFrom = ""
+	m.pathTitle = fmt.Sprintf("Weg: %s → %s", from, selected)
+	m.codeView.SetContent(pathReport(ShortestPaths(rels, from, selected), from, selected))
+	m.codeView.GotoTop()
+	m.prevMode = m.mode
+	m.mode = viewPath
+	return m, nil
+}
+
+// renderPath rendert die gefundenen Wege
+func (m Model) renderPath() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("🧭 "+m.pathTitle) + "\n\n")
+	b.WriteString(m.codeView.View())
+
+	return boxStyle.Width(m.width - 4).Render(b.String())
+}
+
+// cmdPath gibt die kürzesten Wege zwischen zwei Tabellen aus
+func cmdPath(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) != 2 {
+		fail(exitUsage, "Verwendung: ninox-tui path VON NACH [--database NAME] [--db DATEI]")
+	}
+	from, to := opts.positional[0], opts.positional[1]
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+
+	databases, err := selectDatabases(db, opts.database)
+	if err != nil {
+		fail(exitNoResults, "%v", err)
+	}
+
+	found := false
+	for _, d := range databases {
+		tables, err := db.GetTables(d.ID)
+		if err != nil {
+			fail(exitDBError, "%v", err)
+		}
+		names := make(map[string]bool)
+		for _, t := range tables {
+			names[t.Name] = true
+		}
+		if !names[from] || !names[to] {
+			continue
+		}
+
+		rels, err := db.GetDatabaseRelationships(d.ID)
+		if err != nil {
+			fail(exitDBError, "%v", err)
+		}
+		paths := ShortestPaths(rels, from, to)
+		if !quiet {
+			fmt.Printf("## %s\n\n", d.Name)
+		}
+		fmt.Print(pathReport(paths, from, to))
+		found = found || len(paths) > 0
+	}
+
+	if !found {
+		fmt.Fprintf(os.Stderr, "Keine Verbindung zwischen %s und %s gefunden\n", from, to)
+		return exitNoResults
+	}
+	return exitOK
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Verzögerte Hervorhebung der Vorschau in der Gesamtansicht
+// =============================================================================
+
+// previewDelay ist die Wartezeit nach der letzten Cursorbewegung
+const previewDelay = 100 * time.Millisecond
+
+// Codezeilen je Vorschau; 0 ist die kompakte Darstellung (eine Zeile je Script)
+const (
+	defaultPreviewLines = 2
+	maxPreviewLines     = 20
+)
+
+// previewState hält die hervorgehobene Vorschau des ausgewählten Scripts
+type previewState struct {
+	seq   int    // Zähler je Anforderung; ältere Ergebnisse werden verworfen
+	want  int    // ID des angeforderten Scripts
+	id    int    // ID des Scripts, zu dem text gehört
+	lines int    // Zeilenzahl, mit der text erzeugt wurde
+	text  string // hervorgehobene Vorschau
+}
+
+// previewTickMsg beendet die Wartezeit einer Anforderung
+type previewTickMsg struct{ seq int }
+
+// previewReadyMsg liefert die fertig hervorgehobene Vorschau
+type previewReadyMsg struct {
+	seq, id, lines int
+	text           string
+}
+
+// previewText liefert die ersten Zeilen eines Scripts für die Vorschau
+func previewText(code string, lines int) string {
+	head, more := previewHead(code, lines)
+	if more {
+		head += "\n..."
+	}
+	return head
+}
+
+// previewHighlighted hebt die Vorschauzeilen hervor (teuer, läuft im Hintergrund)
+func previewHighlighted(code string, lines int) string {
+	head, more := previewHead(code, lines)
+	preview := strings.TrimRight(highlightCode(head), "\n")
+	if more {
+		preview += "\n..."
+	}
+	return preview
+}
+
+// previewHead liefert die ersten Zeilen und ob weitere folgen
+func previewHead(code string, lines int) (string, bool) {
+	codeLines := strings.Split(code, "\n")
+	n := min(lines, len(codeLines))
+	return strings.Join(codeLines[:n], "\n"), len(codeLines) > n
+}
+
+// handlePreviewTick hebt nach Ablauf der Wartezeit die Vorschau im Hintergrund hervor
+func (m Model) handlePreviewTick(msg previewTickMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.preview.seq {
+		return m, nil // inzwischen weitergeblättert
+	}
+	seq, id, lines := msg.seq, m.preview.want, m.previewLines
+	var code string
+	for _, s := range m.filteredScripts {
+		if s.ID == id {
+			code = s.Code
+			break
+		}
+	}
+	return m, func() tea.Msg {
+		return previewReadyMsg{seq: seq, id: id, lines: lines, text: previewHighlighted(code, lines)}
+	}
+}
+
+// handlePreviewReady übernimmt die Vorschau, sofern sie noch aktuell ist
+func (m Model) handlePreviewReady(msg previewReadyMsg) (tea.Model, tea.Cmd) {
+	if msg.seq == m.preview.seq {
+		m.preview.id, m.preview.lines, m.preview.text = msg.id, msg.lines, msg.text
+	}
+	return m, nil
+}
+
+// resizePreview ändert die Zeilenzahl der Vorschau (delta +1/-1)
+func (m Model) resizePreview(delta int) (tea.Model, tea.Cmd) {
+	m.previewLines = max(0, min(maxPreviewLines, m.previewLines+delta))
+	m.preview.want = 0 // Vorschau mit neuer Größe neu anfordern
+	if m.previewLines == 0 {
+		m.status = "Vorschau: kompakt (eine Zeile je Script)"
+	} else {
+		m.status = fmt.Sprintf("Vorschau: %d Zeilen", m.previewLines)
+	}
+	m.ensureAllScriptVisible()
+	return m, nil
+}
+
+// requestPreview startet die Wartezeit, wenn sich das ausgewählte Script geändert hat
+func (m *Model) requestPreview() tea.Cmd {
+	if m.mode != viewAllScripts || m.previewLines == 0 {
+		return nil
+	}
+	s := m.activeScript()
+	if s == nil || s.ID == m.preview.want {
+		return nil
+	}
+
+	m.preview.seq++
+	m.preview.want = s.ID
+	seq := m.preview.seq
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg {
+		return previewTickMsg{seq: seq}
+	})
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Push geänderter Scripts über die Ninox REST API
+// =============================================================================
+
+const defaultAPIURL = "https://api.ninox.com"
+
+// ninoxAPI ist ein minimaler Client für die Schema-Endpunkte der Ninox REST API
+type ninoxAPI struct {
+	baseURL string
+	teamID  string
+	apiKey  string
+	client  *http.Client
+}
+
+func newNinoxAPI(baseURL, teamID, apiKey string) *ninoxAPI {
+	return &ninoxAPI{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		teamID:  teamID,
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// request führt einen API-Aufruf aus und dekodiert die Antwort nach out (falls nicht nil)
+func (a *ninoxAPI) request(method, endpoint string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, a.baseURL+"/v1/"+endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+a.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 500))
+		return fmt.Errorf("%s %s: %s %s", method, endpoint, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber() // Versionsnummern unverändert zurückschreiben
+	return dec.Decode(out)
+}
+
+func (a *ninoxAPI) schemaEndpoint(databaseID string) string {
+	return fmt.Sprintf("teams/%s/databases/%s/schema", a.teamID, databaseID)
+}
+
+// GetSchema lädt das Schema einer Datenbank. Mit formatted sind die Scripts
+// lesbar formatiert wie in der Extraktion; zum Zurückschreiben dient das
+// unformatierte Schema, damit nur die geänderten Scripts neu geschrieben werden.
+func (a *ninoxAPI) GetSchema(databaseID string, formatted bool) (map[string]any, error) {
+	endpoint := a.schemaEndpoint(databaseID)
+	if formatted {
+		endpoint += "?formatScripts=T"
+	}
+	var schema map[string]any
+	err := a.request(http.MethodGet, endpoint, nil, &schema)
+	return schema, err
+}
+
+// UpdateSchema schreibt das geänderte Schema zurück
+func (a *ninoxAPI) UpdateSchema(databaseID string, schema map[string]any) error {
+	return a.request(http.MethodPatch, a.schemaEndpoint(databaseID), schema, nil)
+}
+
+// schemaCodeHolder liefert das Objekt, das den Code eines Scripts enthält:
+// das Schema selbst, eine Tabelle (types) oder ein Feld (types → fields)
+func schemaCodeHolder(schema map[string]any, u scriptUpdate) (map[string]any, error) {
+	if strings.HasPrefix(u.Type, "report") {
+		return nil, fmt.Errorf("Formeln aus Drucklayouts können nicht übertragen werden")
+	}
+	if kind := automationKinds[u.Type]; kind == "schedule" || kind == "webhook" {
+		return nil, fmt.Errorf("Zeitpläne und Webhooks können nicht übertragen werden")
+	}
+	if u.TableID == "" {
+		return schema, nil
+	}
+	types, _ := schema["types"].(map[string]any)
+	table, ok := types[u.TableID].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("Tabelle %s nicht im Schema", u.TableID)
+	}
+	if u.ElementID == "" {
+		return table, nil
+	}
+	fields, _ := table["fields"].(map[string]any)
+	field, ok := fields[u.ElementID].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("Element %s nicht im Schema", u.ElementID)
+	}
+	return field, nil
+}
+
+// pushDecision ist die Antwort auf die Rückfrage je Änderung
+type pushDecision int
+
+const (
+	pushSkip pushDecision = iota
+	pushApply
+	pushAll
+	pushQuit
+)
+
+// askPush fragt nach, ob eine Änderung übernommen werden soll
+func askPush(in *bufio.Reader) pushDecision {
+	for {
+		fmt.Print("Übernehmen? [j]a / [n]ein / [a]lle / [q] abbrechen: ")
+		line, err := in.ReadString('\n')
+		if err != nil && line == "" {
+			fmt.Println()
+			return pushQuit
+		}
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "j", "y":
+			return pushApply
+		case "n", "":
+			return pushSkip
+		case "a":
+			return pushAll
+		case "q":
+			return pushQuit
+		}
+	}
+}
+
+// pushScripts trägt die bestätigten Scripts in das unformatierte Schema ein und
+// schreibt es zurück; alle übrigen Scripts bleiben unverändert
+func pushScripts(api *ninoxAPI, databaseID string, updates []scriptUpdate) error {
+	schema, err := api.GetSchema(databaseID, false)
+	if err != nil {
+		return err
+	}
+	for _, u := range updates {
+		holder, err := schemaCodeHolder(schema, u)
+		if err != nil {
+			return fmt.Errorf("%s: %w", u.Label, err)
+		}
+		holder[u.Type] = strings.TrimRight(u.Code, "\n")
+	}
+	return api.UpdateSchema(databaseID, schema)
+}
+
+// loadUpdatePlan liest einen mit import-scripts --plan geschriebenen Änderungsplan
+func loadUpdatePlan(path string) (*updatePlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var plan updatePlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("Ungültiger Änderungsplan %s: %w", path, err)
+	}
+	return &plan, nil
+}
+
+// cmdPush überträgt die Änderungen eines Plans nach Ninox
+func cmdPush(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) != 1 {
+		fail(exitUsage, "Verwendung: ninox-tui push PLAN.json [--dry-run] [--yes] [--force] [--team ID] [--api-url URL]")
+	}
+
+	plan, err := loadUpdatePlan(opts.positional[0])
+	if err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	if len(plan.Updates) == 0 {
+		if !quiet {
+			fmt.Println("Keine Änderungen im Plan")
+		}
+		return exitNoResults
+	}
+
+	cfg, err := LoadConfig(activeConfigPath())
+	if err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	cfg.ApplyEnv()
+
+	teamID := firstNonEmpty(opts.team, os.Getenv("NINOX_TEAM_ID"), cfg.TeamID)
+	apiKey := firstNonEmpty(opts.apiKey, os.Getenv("NINOX_API_KEY"))
+	apiURL := firstNonEmpty(opts.apiURL, os.Getenv("NINOX_DOMAIN"), defaultAPIURL)
+	if teamID == "" || apiKey == "" {
+		fail(exitUsage, "Team-ID (--team bzw. NINOX_TEAM_ID) und API-Key (--api-key bzw. NINOX_API_KEY) werden benötigt")
+	}
+	api := newNinoxAPI(apiURL, teamID, apiKey)
+
+	// Änderungen je Datenbank bündeln: ein Schema-Abruf und ein Schreibvorgang pro Datenbank
+	var dbOrder []string
+	byDB := make(map[string][]scriptUpdate)
+	for _, u := range plan.Updates {
+		if _, ok := byDB[u.DatabaseID]; !ok {
+			dbOrder = append(dbOrder, u.DatabaseID)
+		}
+		byDB[u.DatabaseID] = append(byDB[u.DatabaseID], u)
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	applyAll := opts.yes || opts.dryRun
+	pushed, skipped, failed := 0, 0, 0
+	quit := false
+
+	for _, dbID := range dbOrder {
+		if quit {
+			break
+		}
+		schema, err := api.GetSchema(dbID, true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Schema %s: %v\n", dbID, err)
+			failed += len(byDB[dbID])
+			continue
+		}
+
+		var accepted []scriptUpdate
+		for _, u := range byDB[dbID] {
+			holder, err := schemaCodeHolder(schema, u)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %s: %v\n", u.Label, err)
+				failed++
+				continue
+			}
+			remote, _ := holder[u.Type].(string)
+
+			fmt.Printf("\n%s (%s)\n", u.Label, u.File)
+			switch {
+			case sameCode(remote, u.Code):
+				fmt.Println("  bereits aktuell")
+				skipped++
+				continue
+			case scriptHash(remote) != u.BaseHash && !opts.force:
+				fmt.Println("  ⚠ In Ninox seit der Extraktion geändert – übersprungen (--force überschreibt)")
+				skipped++
+				continue
+			}
+			printDiff(UnifiedDiff("ninox/"+u.File, "datei/"+u.File, remote, u.Code, opts.context), opts.noColor)
+
+			if !applyAll {
+				decision := askPush(in)
+				if decision == pushQuit {
+					quit = true
+					break
+				}
+				switch decision {
+				case pushSkip:
+					skipped++
+					continue
+				case pushAll:
+					applyAll = true
+				}
+			}
+			accepted = append(accepted, u)
+		}
+
+		// Bei Abbruch werden die bereits bestätigten Änderungen dieser Datenbank
+		// noch übertragen, alle übrigen zählen als übersprungen
+		if len(accepted) == 0 {
+			continue
+		}
+		if opts.dryRun {
+			fmt.Printf("→ %s: %d Änderungen würden übertragen (--dry-run)\n", dbID, len(accepted))
+			pushed += len(accepted)
+			continue
+		}
+		if err := pushScripts(api, dbID, accepted); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Schema %s schreiben: %v\n", dbID, err)
+			failed += len(accepted)
+			continue
+		}
+		fmt.Printf("✓ %s: %d Änderungen übertragen\n", dbID, len(accepted))
+		pushed += len(accepted)
+	}
+
+	if quit {
+		skipped = len(plan.Updates) - pushed - failed
+	}
+	if !quiet {
+		verb := "übertragen"
+		if opts.dryRun {
+			verb = "zu übertragen"
+		}
+		fmt.Printf("\n%d %s, %d übersprungen, %d fehlgeschlagen\n", pushed, verb, skipped, failed)
+	}
+	switch {
+	case failed > 0:
+		return exitFailure
+	case pushed == 0:
+		return exitNoResults
+	}
+	return exitOK
+}
+
+// firstNonEmpty liefert den ersten nicht leeren Wert
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestCheckReadOnlyQuery(t *testing.T) {
+	allowed := []string{
+		"SELECT * FROM scripts",
+		"select name from tables where name = 'a; b';",
+		"WITH x AS (SELECT 1) SELECT * FROM x",
+		"SELECT ';' -- ; DELETE FROM scripts",
+		"PRAGMA table_info(scripts)",
+		"SELECT * FROM pragma_table_info('scripts')",
+		`SELECT "attach" FROM scripts`,
+	}
+	for _, q := range allowed {
+		if err := checkReadOnlyQuery(q); err != nil {
+			t.Errorf("%q abgelehnt: %v", q, err)
+		}
+	}
+
+	rejected := []string{
+		"",
+		"DELETE FROM scripts",
+		"SELECT 1; PRAGMA query_only=0; DELETE FROM scripts",
+		"SELECT 1 /* */; DELETE FROM scripts",
+		"SELECT 1; ATTACH '/etc/x.db' AS x",
+		"ATTACH DATABASE 'x.db' AS x",
+		"PRAGMA query_only = 0",
+		"PRAGMA writable_schema",
+		"PRAGMA table_info = 1",
+	}
+	for _, q := range rejected {
+		if err := checkReadOnlyQuery(q); err == nil {
+			t.Errorf("%q zugelassen", q)
+		}
+	}
+}
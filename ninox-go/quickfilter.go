@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// "Nach diesem Wert filtern"
+// =============================================================================
+
+// quickValue ist ein Wert der aktuellen Zeile, nach dem gefiltert werden kann
+type quickValue struct {
+	label  string // z.B. "Tabelle"
+	value  string
+	filter string // z.B. "tabelle=Kunden"
+}
+
+// scriptFilterColumns sind die Spalten eines Scripts für Filterbegriffe wie
+// "tabelle=Kunden" (exakter Vergleich statt Teiltext in Namen und Code)
+func scriptFilterColumns(s Script) map[string]string {
+	return map[string]string{
+		"datenbank": s.DatabaseName,
+		"tabelle":   s.TableName,
+		"element":   s.ElementName,
+		"typ":       s.CodeType,
+		"kategorie": s.CodeCategory,
+		"gruppe":    s.Group(),
+	}
+}
+
+// matchesTerm prüft einen kleingeschriebenen Filterbegriff: "spalte=Wert" mit
+// bekannter Spalte vergleicht exakt, sonst muss der Begriff im Text vorkommen
+func matchesTerm(searchText string, columns map[string]string, term string) bool {
+	if key, value, ok := strings.Cut(term, "="); ok {
+		if column, known := columns[strings.TrimSpace(key)]; known {
+			return strings.EqualFold(column, strings.TrimSpace(value))
+		}
+	}
+	return strings.Contains(searchText, term)
+}
+
+// columnFilter ist der Filterbegriff für eine Spalte mit dem Wert
+func columnFilter(column, value string) string {
+	return column + "=" + value
+}
+
+// rowValues liefert die filterbaren Werte der Zeile unter dem Cursor,
+// den spezifischsten zuerst
+func (m Model) rowValues() []quickValue {
+	var values []quickValue
+	add := func(label, value, filter string) {
+		if value != "" {
+			values = append(values, quickValue{label, value, filter})
+		}
+	}
+
+	switch m.mode {
+	case viewDatabases:
+		if m.selectedDB < len(m.databases) {
+			name := m.databases[m.selectedDB].Name
+			add("Datenbank", name, columnFilter("datenbank", name))
+		}
+	case viewTables:
+		if m.selectedTable < len(m.tables) {
+			name := m.tables[m.selectedTable].Name
+			add("Tabelle", name, columnFilter("tabelle", name))
+		}
+		if m.currentDB != nil {
+			add("Datenbank", m.currentDB.Name, columnFilter("datenbank", m.currentDB.Name))
+		}
+	case viewFields:
+		// Feldnamen stehen nur im Code: Teiltext-Suche nach Verwendungen
+		if m.selectedField < len(m.fields) {
+			name := m.fields[m.selectedField].Name
+			add("Feld", name, name)
+		}
+		if m.currentTable != nil {
+			add("Tabelle", m.currentTable.Name, columnFilter("tabelle", m.currentTable.Name))
+		}
+	default:
+		if s := m.activeScript(); s != nil {
+			add("Tabelle", s.TableName, columnFilter("tabelle", s.TableName))
+			add("Typ", s.CodeType, columnFilter("typ", s.CodeType))
+			add("Datenbank", s.DatabaseName, columnFilter("datenbank", s.DatabaseName))
+			add("Gruppe", s.Group(), columnFilter("gruppe", s.Group()))
+			if rank := s.RecencyRank(); rank < recentSnapshots {
+				changed := fmt.Sprintf("geändert:%d", rank+1)
+				add("Änderung", changed, changed)
+			}
+		}
+	}
+	return values
+}
+
+// filterByValue filtert die Gesamtansicht nach einem Wert der aktuellen Zeile.
+// Wiederholtes Drücken wechselt zum nächsten Wert derselben Zeile.
+func (m Model) filterByValue() (tea.Model, tea.Cmd) {
+	if len(m.quickValues) > 0 && m.mode == viewAllScripts {
+		// Zuletzt gesetzten Wert durch den nächsten ersetzen
+		m.filterStack = m.filterStack[:len(m.filterStack)-1]
+		m.quickIndex = (m.quickIndex + 1) % len(m.quickValues)
+	} else {
+		m.quickValues = m.rowValues()
+		m.quickIndex = 0
+		if len(m.quickValues) == 0 {
+			return m, nil
+		}
+		if m.mode != viewAllScripts {
+			m.prevMode = m.mode
+			m.mode = viewAllScripts
+			m.filterStack = nil
+			m.filterBase = nil
+			m.filterBaseLabel = ""
+		}
+	}
+
+	v := m.quickValues[m.quickIndex]
+	m.pushFilter(v.filter)
+
+	m.status = fmt.Sprintf("Gefiltert nach %s: %s", v.label, v.value)
+	if len(m.quickValues) > 1 {
+		next := m.quickValues[(m.quickIndex+1)%len(m.quickValues)]
+		m.status += fmt.Sprintf(" (= erneut: %s)", next.label)
+	}
+	return m, nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// =============================================================================
+// Schreibschutz: Extraktionen werden standardmäßig nur lesend geöffnet
+// =============================================================================
+
+// writeMode legt fest, ob und wohin geschrieben werden darf
+type writeMode struct {
+	allow   bool   // --allow-write: Extraktion selbst beschreiben (Hilfstabellen, FTS-Rebuild)
+	sidecar string // --write-to: Hilfstabellen in dieser Datei statt in der Extraktion
+}
+
+// writeAccess wird über die Kommandozeile gesetzt
+var writeAccess writeMode
+
+var errReadOnly = errors.New("nur lesend geöffnet (--allow-write oder --write-to DATEI)")
+
+// sqliteBusyTimeout lässt parallele Verbindungen des Pools auf Sperren warten (ms)
+const sqliteBusyTimeout = 5000
+
+// sqliteDSN öffnet die Datei über eine URI, mit readOnly im Nur-Lese-Modus
+func sqliteDSN(path string, readOnly bool) string {
+	if strings.HasPrefix(path, "file:") {
+		return path
+	}
+	escaped := strings.NewReplacer("%", "%25", "?", "%3f", "#", "%23").Replace(path)
+	dsn := fmt.Sprintf("file:%s?_busy_timeout=%d", escaped, sqliteBusyTimeout)
+	if readOnly {
+		dsn += "&mode=ro"
+	}
+	return dsn
+}
+
+// openAux öffnet die Datenbank für eigene Hilfstabellen gemäß writeAccess (nil = keine)
+func (db *NinoxDB) openAux() error {
+	switch {
+	case writeAccess.sidecar != "":
+		aux, err := sql.Open("sqlite3", writeAccess.sidecar)
+		if err != nil {
+			return fmt.Errorf("Hilfsdatenbank: %w", err)
+		}
+		if err := aux.Ping(); err != nil {
+			aux.Close()
+			return fmt.Errorf("Hilfsdatenbank nicht erreichbar: %w", err)
+		}
+		db.aux = aux
+	case writeAccess.allow && !db.backend.local():
+		return fmt.Errorf("Hilfstabellen nur in SQLite-Dateien (--write-to DATEI statt --allow-write)")
+	case writeAccess.allow:
+		db.aux = db.conn.DB
+	}
+	return nil
+}
+
+// Writable meldet, ob die Extraktion selbst beschrieben werden darf
+func (db *NinoxDB) Writable() bool {
+	return writeAccess.allow
+}
+
+// auxDB liefert die Datenbank für Hilfstabellen oder errReadOnly
+func (db *NinoxDB) auxDB() (*sql.DB, error) {
+	if db.aux == nil {
+		return nil, errReadOnly
+	}
+	return db.aux, nil
+}
+
+// WriteTarget beschreibt, wohin geschrieben wird (für Hinweise in der Oberfläche)
+func (db *NinoxDB) WriteTarget() string {
+	switch {
+	case writeAccess.sidecar != "":
+		return "Hilfsdatenbank " + writeAccess.sidecar
+	case writeAccess.allow:
+		return "Extraktion"
+	}
+	return "nur lesend"
+}
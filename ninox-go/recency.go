@@ -0,0 +1,163 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// Änderungs-Heatmap: ob ein Script in einem der letzten Stände des Verlaufs
+// geändert wurde, z.B. ●○○ = im letzten Stand, ○●○ = im vorletzten
+// =============================================================================
+
+// recentSnapshots ist die Zahl der betrachteten Stände (Punkte je Zeile)
+const recentSnapshots = 3
+
+// recencyIndex sind die Änderungen je Script über die letzten Stände,
+// einmal je Extraktion berechnet
+type recencyIndex struct {
+	once  sync.Once
+	slots int              // vergleichbare Stände (0 = kein Verlauf)
+	masks map[string]uint8 // Script-Schlüssel → Bit k = im k-letzten Stand geändert
+}
+
+// recencyKey identifiziert ein Script über alle Stände hinweg
+func recencyKey(databaseID, tableID, elementID, codeType string) string {
+	return databaseID + "/" + tableID + "/" + elementID + "/" + codeType
+}
+
+// loadRecency vergleicht die letzten Stände paarweise. Ein Script gilt in einem
+// Stand als geändert, wenn es im Stand davor fehlt oder anderen Code hatte.
+func (db *NinoxDB) loadRecency() *recencyIndex {
+	r := db.recency
+	r.once.Do(func() {
+		r.masks = make(map[string]uint8)
+		if !db.HasHistory() {
+			return
+		}
+		rows, err := db.conn.Query(`SELECT id FROM snapshots ORDER BY id DESC LIMIT ?`, recentSnapshots+1)
+		if err != nil {
+			return
+		}
+		var ids []string
+		for rows.Next() {
+			var id int
+			if rows.Scan(&id) == nil {
+				ids = append(ids, strconv.Itoa(id))
+			}
+		}
+		rows.Close()
+		if len(ids) < 2 {
+			return
+		}
+
+		// Code-Hashes je Stand, der neueste zuerst
+		position := make(map[string]int, len(ids))
+		for i, id := range ids {
+			position[id] = i
+		}
+		hashes := make([]map[string]string, len(ids))
+		for i := range hashes {
+			hashes[i] = make(map[string]string)
+		}
+		rows, err = db.conn.Query(`
+			SELECT snapshot_id, database_id, table_id, element_id, code_type, code_hash
+			FROM snapshot_scripts
+			WHERE snapshot_id IN (` + strings.Join(ids, ", ") + `)`)
+		if err != nil {
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id, databaseID, tableID, elementID, codeType, hash string
+			if rows.Scan(&id, &databaseID, &tableID, &elementID, &codeType, &hash) != nil {
+				continue
+			}
+			hashes[position[id]][recencyKey(databaseID, tableID, elementID, codeType)] = hash
+		}
+
+		r.slots = len(ids) - 1
+		for k := 0; k < r.slots; k++ {
+			for key, hash := range hashes[k] {
+				if prev, ok := hashes[k+1][key]; !ok || prev != hash {
+					r.masks[key] |= 1 << k
+				}
+			}
+		}
+	})
+	return r
+}
+
+// RecentSnapshots liefert die Zahl der Stände, für die Änderungen bekannt sind
+func (db *NinoxDB) RecentSnapshots() int {
+	return db.loadRecency().slots
+}
+
+// markRecent trägt die Änderungen der letzten Stände in die Scripts ein
+func (db *NinoxDB) markRecent(scripts []Script, err error) ([]Script, error) {
+	if err != nil {
+		return scripts, err
+	}
+	r := db.loadRecency()
+	if r.slots == 0 {
+		return scripts, nil
+	}
+	for i := range scripts {
+		s := &scripts[i]
+		s.Recent = r.masks[recencyKey(s.DatabaseID, s.TableID, s.ElementID, s.CodeType)]
+	}
+	return scripts, nil
+}
+
+// RecencyRank ist der Abstand zur letzten Änderung (0 = im letzten Stand);
+// ohne Änderung in den betrachteten Ständen recentSnapshots
+func (s Script) RecencyRank() int {
+	for k := 0; k < recentSnapshots; k++ {
+		if s.Recent&(1<<k) != 0 {
+			return k
+		}
+	}
+	return recentSnapshots
+}
+
+// recencyDots stellt die Änderungen als Punkte dar, der letzte Stand links
+func recencyDots(mask uint8, slots int) string {
+	var b strings.Builder
+	for k := 0; k < slots; k++ {
+		if mask&(1<<k) != 0 {
+			b.WriteString("●")
+		} else {
+			b.WriteString("○")
+		}
+	}
+	return b.String()
+}
+
+// recencyTerms sind die Filterbegriffe eines Scripts: "geändert:N" heißt
+// in einem der letzten N Stände geändert
+func (s Script) recencyTerms() string {
+	var terms []string
+	for n := s.RecencyRank() + 1; n <= recentSnapshots; n++ {
+		terms = append(terms, "geändert:"+strconv.Itoa(n))
+	}
+	return strings.Join(terms, " ")
+}
+
+// recencyCell ist die Spalte "Änd." der Script-Listen (leer ohne Verlauf)
+func (m Model) recencyCell(s Script) string {
+	slots := m.db.RecentSnapshots()
+	if slots == 0 {
+		return ""
+	}
+	return " " + recencyDots(s.Recent, slots)
+}
+
+// recencyHeader ist die Spaltenüberschrift passend zu recencyCell
+func (m Model) recencyHeader() string {
+	slots := m.db.RecentSnapshots()
+	if slots == 0 {
+		return ""
+	}
+	return " Änd."
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Datenmengen: Anzahl Datensätze je Tabelle (Extraktion oder live über die API)
+// =============================================================================
+
+// recordCountsDDL legt die Hilfstabelle für live gezählte Datensätze an (siehe writeAccess)
+const recordCountsDDL = `CREATE TABLE IF NOT EXISTS tui_record_counts (
+	database_id TEXT NOT NULL,
+	table_id TEXT NOT NULL,
+	record_count INTEGER NOT NULL,
+	counted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (database_id, table_id)
+)`
+
+// HasRecordCounts meldet, ob die Extraktion Datensatz-Zählungen enthält (nur mit --counts)
+func (db *NinoxDB) HasRecordCounts() bool {
+	return len(db.tableColumns("record_counts")) > 0
+}
+
+// GetRecordCounts lädt die Anzahl Datensätze je Tabelle (Schlüssel Datenbank-ID/Tabellen-ID).
+// Live gezählte Werte aus der Hilfstabelle gehen vor, wenn sie neuer sind.
+func (db *NinoxDB) GetRecordCounts() (map[string]int, error) {
+	counts := make(map[string]int)
+	countedAt := make(map[string]string)
+
+	read := func(rows *sql.Rows, err error) error {
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var dbID, tableID, at string
+			var n int
+			if err := rows.Scan(&dbID, &tableID, &n, &at); err != nil {
+				return err
+			}
+			k := layoutKey(dbID, tableID)
+			if prev, ok := countedAt[k]; !ok || at >= prev {
+				counts[k], countedAt[k] = n, at
+			}
+		}
+		return rows.Err()
+	}
+
+	const query = `SELECT database_id, table_id, record_count, COALESCE(counted_at, '') FROM `
+	if db.HasRecordCounts() {
+		if err := read(db.conn.Query(query + "record_counts")); err != nil {
+			return nil, err
+		}
+	}
+	if aux, err := db.auxDB(); err == nil {
+		if err := read(aux.Query(query + "tui_record_counts")); err != nil && !strings.Contains(err.Error(), "no such table") {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+// SaveRecordCounts speichert live gezählte Datensätze in der Hilfstabelle
+func (db *NinoxDB) SaveRecordCounts(counts map[string]int) error {
+	aux, err := db.auxDB()
+	if err != nil {
+		return err
+	}
+	if _, err := aux.Exec(recordCountsDDL); err != nil {
+		return err
+	}
+	tx, err := aux.Begin()
+	if err != nil {
+		return err
+	}
+	for k, n := range counts {
+		dbID, tableID, _ := strings.Cut(k, "/")
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO tui_record_counts (database_id, table_id, record_count, counted_at)
+			VALUES (?, ?, ?, CURRENT_TIMESTAMP)`, dbID, tableID, n); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// CountRecords zählt die Datensätze einer Tabelle über den Query-Endpunkt
+func (a *ninoxAPI) CountRecords(databaseID, tableName string) (int, error) {
+	query := fmt.Sprintf("cnt(select '%s')", strings.ReplaceAll(tableName, "'", "''"))
+	var result json.Number
+	endpoint := fmt.Sprintf("teams/%s/databases/%s/query", a.teamID, databaseID)
+	if err := a.request(http.MethodPost, endpoint, map[string]string{"query": query}, &result); err != nil {
+		return 0, err
+	}
+	n, err := result.Int64()
+	return int(n), err
+}
+
+// formatCount formatiert eine Anzahl mit Tausenderpunkten ("-" = unbekannt)
+func formatCount(n int) string {
+	if n < 0 {
+		return "-"
+	}
+	s := fmt.Sprint(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "." + s[i:]
+	}
+	return s
+}
+
+// cmdRecordCounts listet die Datensätze je Tabelle, größte zuerst, mit der Zahl der Trigger.
+// Mit --refresh wird live über die API gezählt und (falls beschreibbar) gespeichert.
+func cmdRecordCounts(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) > 0 {
+		fail(exitUsage, "Verwendung: ninox-tui record-counts [--refresh] [--database NAME] [--team ID] [--allow-write | --write-to DATEI]")
+	}
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	databases, err := selectDatabases(db, opts.database)
+	if err != nil {
+		fail(exitNoResults, "%v", err)
+	}
+
+	counts, err := db.GetRecordCounts()
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+
+	if opts.refresh {
+		cfg, err := LoadConfig(activeConfigPath())
+		if err != nil {
+			fail(exitFailure, "%v", err)
+		}
+		cfg.ApplyEnv()
+		teamID := firstNonEmpty(opts.team, os.Getenv("NINOX_TEAM_ID"), cfg.TeamID)
+		apiKey := os.Getenv("NINOX_API_KEY")
+		if teamID == "" || apiKey == "" {
+			fail(exitUsage, "Team-ID (--team bzw. NINOX_TEAM_ID) und API-Key (NINOX_API_KEY) werden benötigt")
+		}
+		api := newNinoxAPI(firstNonEmpty(opts.apiURL, os.Getenv("NINOX_DOMAIN"), defaultAPIURL), teamID, apiKey)
+
+		live := make(map[string]int)
+		for _, d := range databases {
+			tables, err := db.GetTables(d.ID)
+			if err != nil {
+				fail(exitDBError, "%v", err)
+			}
+			for _, t := range tables {
+				n, err := api.CountRecords(d.ID, t.Name)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "⚠ %s/%s: %v\n", d.Name, t.Name, err)
+					continue
+				}
+				live[layoutKey(d.ID, t.TableID)] = n
+				counts[layoutKey(d.ID, t.TableID)] = n
+			}
+		}
+		if err := db.SaveRecordCounts(live); err != nil && !quiet {
+			fmt.Fprintf(os.Stderr, "Zählung nicht gespeichert: %v\n", err)
+		}
+	}
+
+	if len(counts) == 0 {
+		fail(exitNoResults, "Keine Datensatz-Zählungen – mit --counts extrahieren oder record-counts --refresh")
+	}
+
+	// Trigger je Tabelle: bei großen Tabellen besonders teuer
+	scripts, err := db.GetAllScripts()
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+	triggers := make(map[string]int)
+	for _, s := range scripts {
+		if automationKinds[s.CodeType] == "trigger" {
+			triggers[layoutKey(s.DatabaseID, s.TableID)]++
+		}
+	}
+
+	type line struct {
+		label          string
+		count, trigger int
+	}
+	var lines []line
+	for _, d := range databases {
+		tables, err := db.GetTables(d.ID)
+		if err != nil {
+			fail(exitDBError, "%v", err)
+		}
+		for _, t := range tables {
+			k := layoutKey(d.ID, t.TableID)
+			if n, ok := counts[k]; ok {
+				lines = append(lines, line{d.Name + "/" + t.Name, n, triggers[k]})
+			}
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].count > lines[j].count })
+
+	for _, l := range lines {
+		fmt.Printf("%12s  %-40s %d Trigger\n", formatCount(l.count), l.label, l.trigger)
+	}
+	return exitOK
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Beziehungen einer Tabelle: Richtungsfilter und Sortierung
+// =============================================================================
+
+// relDirection wählt die angezeigten Beziehungen relativ zur aktuellen Tabelle
+type relDirection int
+
+const (
+	relBoth     relDirection = iota // eingehend und ausgehend
+	relOutgoing                     // nur Verweise dieser Tabelle auf andere
+	relIncoming                     // nur Verweise anderer Tabellen auf diese
+)
+
+var relDirectionNames = map[relDirection]string{
+	relBoth:     "beide",
+	relOutgoing: "ausgehend",
+	relIncoming: "eingehend",
+}
+
+// relationEnd liefert Richtungspfeil und Gegenseite einer Beziehung aus Sicht der Tabelle.
+// Selbstverweise gelten als ausgehend.
+func relationEnd(r Relationship, table string) (arrow, other string, outgoing bool) {
+	if r.SourceTableName == table {
+		return "→", r.TargetTableName, true
+	}
+	return "←", r.SourceTableName, false
+}
+
+// shownRelationships liefert die Beziehungen gemäß Richtungsfilter und Sortierung
+func (m Model) shownRelationships() []Relationship {
+	if m.currentTable == nil {
+		return nil
+	}
+	table := m.currentTable.Name
+
+	var rels []Relationship
+	for _, r := range m.relationships {
+		_, _, outgoing := relationEnd(r, table)
+		if (m.relDirection == relOutgoing && !outgoing) || (m.relDirection == relIncoming && outgoing) {
+			continue
+		}
+		rels = append(rels, r)
+	}
+
+	if m.relByName {
+		sort.SliceStable(rels, func(i, j int) bool {
+			_, a, _ := relationEnd(rels[i], table)
+			_, b, _ := relationEnd(rels[j], table)
+			return cmpText(a, b) < 0
+		})
+	}
+	return rels
+}
+
+// cycleRelDirection schaltet zwischen beiden, ausgehenden und eingehenden Beziehungen um
+func (m Model) cycleRelDirection() (tea.Model, tea.Cmd) {
+	if m.mode != viewFields {
+		return m, nil
+	}
+	m.relDirection = (m.relDirection + 1) % 3
+	m.status = "Beziehungen: " + relDirectionNames[m.relDirection]
+	return m, nil
+}
+
+// toggleRelSort sortiert die Beziehungen nach der Gegenseite bzw. wie gespeichert
+func (m Model) toggleRelSort() (tea.Model, tea.Cmd) {
+	if m.mode != viewFields {
+		return m, nil
+	}
+	m.relByName = !m.relByName
+	if m.relByName {
+		m.status = "Beziehungen nach Tabelle sortiert"
+	} else {
+		m.status = "Beziehungen unsortiert"
+	}
+	return m, nil
+}
+
+// renderRelationships rendert den Abschnitt mit den Beziehungen der aktuellen Tabelle
+func (m Model) renderRelationships() string {
+	if len(m.relationships) == 0 {
+		return ""
+	}
+	rels := m.shownRelationships()
+
+	var b strings.Builder
+	title := "🔗 Beziehungen"
+	if m.relDirection != relBoth {
+		title += fmt.Sprintf(" (%s, %d von %d)", relDirectionNames[m.relDirection], len(rels), len(m.relationships))
+	}
+	b.WriteString("\n" + titleStyle.Render(title) + "\n\n")
+
+	if len(rels) == 0 {
+		b.WriteString(mutedStyle.Render("  Keine Beziehungen in dieser Richtung\n"))
+	}
+	for _, r := range rels {
+		arrow, other, _ := relationEnd(r, m.currentTable.Name)
+		line := fmt.Sprintf("  %s %s (%s)", arrow, other, r.RelationshipType)
+		b.WriteString(mutedStyle.Render(line) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,419 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Entfernte Extraktion: serve stellt eine Extraktion per HTTP bereit, die TUI
+// und alle Unterbefehle öffnen sie mit http(s)://host:port als Datenbankpfad.
+// Abfrageergebnisse werden je Stand der Extraktion zwischengespeichert.
+// =============================================================================
+
+// remoteDriverName ist der database/sql-Treiber für entfernte Extraktionen
+const remoteDriverName = "ninox-http"
+
+// defaultListen ist die Adresse von serve ohne --listen (nur lokal erreichbar)
+const defaultListen = "127.0.0.1:8765"
+
+// remoteQueryTimeout begrenzt eine Abfrage an serve; danach wird sie abgebrochen
+const remoteQueryTimeout = time.Minute
+
+// remoteTokenEnv enthält das gemeinsame Zugangstoken von serve und Client (optional)
+const remoteTokenEnv = "NINOX_TUI_REMOTE_TOKEN"
+
+var errRemoteReadOnly = errors.New("entfernte Extraktion ist nur lesend")
+
+func init() {
+	sql.Register(remoteDriverName, remoteDriver{})
+}
+
+// Grenzen des Ergebnis-Caches: große Abfragen (z.B. alle Scripts eines
+// mehrere GB großen Extrakts) werden nicht gespeichert, alte Einträge verfallen
+const (
+	remoteCacheMaxEntry  = 32 << 20  // größtes gespeichertes Ergebnis
+	remoteCacheMaxMemory = 128 << 20 // im Speicher je Sitzung
+	remoteCacheMaxBytes  = 512 << 20 // auf der Festplatte
+	remoteCacheMaxAge    = 7 * 24 * time.Hour
+)
+
+var (
+	// noRemoteCache wird mit --no-cache gesetzt: Ergebnisse nur im Speicher halten
+	noRemoteCache bool
+
+	remoteCacheOnce sync.Once
+	remoteCache     *renderCache
+	remotePruned    sync.Map // Server-Stand → bereits aufgeräumt
+)
+
+// remoteResults liefert den Ergebnis-Cache; er wird erst bei der ersten Abfrage
+// angelegt, damit --no-cache und --state-dir bereits ausgewertet sind
+func remoteResults() *renderCache {
+	remoteCacheOnce.Do(func() {
+		dir := ""
+		if !noRemoteCache {
+			dir = remoteCacheDir()
+		}
+		remoteCache = newRenderCache(dir)
+		remoteCache.maxEntry, remoteCache.maxMemory = remoteCacheMaxEntry, remoteCacheMaxMemory
+	})
+	return remoteCache
+}
+
+// remoteCacheDir liegt mit --state-dir bzw. NINOX_TUI_STATE_DIR im Zustandsverzeichnis,
+// sonst neben dem Render-Cache; leer, wenn keins verfügbar ist
+func remoteCacheDir() string {
+	if stateDirOverride != "" || os.Getenv("NINOX_TUI_STATE_DIR") != "" {
+		return filepath.Join(stateDir(), "remote")
+	}
+	if dir := defaultCacheDir(); dir != "" {
+		return filepath.Join(filepath.Dir(dir), "remote")
+	}
+	return ""
+}
+
+// remoteCachePrefix kennzeichnet die Einträge eines Servers bzw. eines Stands darauf
+func remoteCachePrefix(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:8]) + "-"
+}
+
+// pruneRemoteCache entfernt einmal je Stand die Ergebnisse älterer Stände desselben
+// Servers sowie verfallene Einträge und hält die Größe auf der Festplatte ein
+func pruneRemoteCache(base, version string) {
+	if _, done := remotePruned.LoadOrStore(base+"\x00"+version, true); done {
+		return
+	}
+	server := remoteCachePrefix(base)
+	current := server + remoteCachePrefix(base, version)
+	remoteResults().prune(remoteCacheMaxAge, remoteCacheMaxBytes, func(name string) bool {
+		return strings.HasPrefix(name, server) && !strings.HasPrefix(name, current)
+	})
+}
+
+// remoteBackend spricht SQLite-Dialekt, der Server prüft Schreibzugriffe selbst
+type remoteBackend struct{ sqliteBackend }
+
+func (remoteBackend) driver() string { return remoteDriverName }
+func (remoteBackend) local() bool    { return false }
+
+func (remoteBackend) readOnly(ctx context.Context, conn *sql.Conn) (func(), error) {
+	return func() {}, nil
+}
+
+// remoteInfo beschreibt den Stand der Extraktion auf dem Server
+type remoteInfo struct {
+	Version string `json:"version"`
+}
+
+// remoteRequest ist eine Abfrage an /api/query
+type remoteRequest struct {
+	Query string        `json:"query"`
+	Args  []interface{} `json:"args"`
+}
+
+// remotePage ist das Ergebnis einer Abfrage
+type remotePage struct {
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// =============================================================================
+// Client: database/sql-Treiber
+// =============================================================================
+
+type remoteDriver struct{}
+
+// Open fragt den Stand der Extraktion ab; er ist Teil der Cache-Schlüssel
+func (remoteDriver) Open(dsn string) (driver.Conn, error) {
+	c := &remoteConn{base: strings.TrimRight(dsn, "/"), client: &http.Client{Timeout: 2 * time.Minute}}
+	var info remoteInfo
+	if err := c.call(context.Background(), http.MethodGet, "/api/info", nil, &info); err != nil {
+		return nil, err
+	}
+	c.version = info.Version
+	pruneRemoteCache(c.base, c.version)
+	return c, nil
+}
+
+// remoteConn ist eine Verbindung zum Server (zustandslos, je Abfrage ein Request)
+type remoteConn struct {
+	base    string
+	client  *http.Client
+	version string
+}
+
+// call sendet einen Request und dekodiert die JSON-Antwort nach out
+func (c *remoteConn) call(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := os.Getenv(remoteTokenEnv); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 500))
+		return fmt.Errorf("Server %s: %s %s", c.base, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	return dec.Decode(out)
+}
+
+func (c *remoteConn) query(ctx context.Context, query string, args []interface{}) (driver.Rows, error) {
+	body, err := json.Marshal(remoteRequest{Query: query, Args: args})
+	if err != nil {
+		return nil, err
+	}
+	// Server und Stand stehen vorn im Namen, damit pruneRemoteCache alte Stände findet
+	sum := sha256.Sum256(append([]byte(c.base+"\x00"+c.version+"\x00"), body...))
+	key := remoteCachePrefix(c.base) + remoteCachePrefix(c.base, c.version) + hex.EncodeToString(sum[:])
+
+	var page remotePage
+	if cached, ok := remoteResults().get(key); ok {
+		dec := json.NewDecoder(strings.NewReader(cached))
+		dec.UseNumber()
+		if dec.Decode(&page) == nil {
+			return &remoteRows{page: page}, nil
+		}
+	}
+	if err := c.call(ctx, http.MethodPost, "/api/query", body, &page); err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(page); err == nil {
+		remoteResults().put(key, string(data))
+	}
+	return &remoteRows{page: page}, nil
+}
+
+func (c *remoteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values := make([]interface{}, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	return c.query(ctx, query, values)
+}
+
+func (c *remoteConn) Prepare(query string) (driver.Stmt, error) {
+	return &remoteStmt{conn: c, query: query}, nil
+}
+
+func (c *remoteConn) Close() error              { return nil }
+func (c *remoteConn) Begin() (driver.Tx, error) { return nil, errRemoteReadOnly }
+
+type remoteStmt struct {
+	conn  *remoteConn
+	query string
+}
+
+func (s *remoteStmt) Close() error  { return nil }
+func (s *remoteStmt) NumInput() int { return -1 }
+
+func (s *remoteStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errRemoteReadOnly
+}
+
+func (s *remoteStmt) Query(args []driver.Value) (driver.Rows, error) {
+	values := make([]interface{}, len(args))
+	for i, a := range args {
+		values[i] = a
+	}
+	return s.conn.query(context.Background(), s.query, values)
+}
+
+// remoteRows liefert die Zeilen einer Ergebnisseite
+type remoteRows struct {
+	page remotePage
+	pos  int
+}
+
+func (r *remoteRows) Columns() []string { return r.page.Columns }
+func (r *remoteRows) Close() error      { return nil }
+
+func (r *remoteRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.page.Rows) {
+		return io.EOF
+	}
+	for i, v := range r.page.Rows[r.pos] {
+		dest[i] = remoteValue(v)
+	}
+	r.pos++
+	return nil
+}
+
+// remoteValue wandelt JSON-Zahlen in int64 bzw. float64 wie bei SQLite
+func remoteValue(v interface{}) driver.Value {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// =============================================================================
+// Server: serve
+// =============================================================================
+
+// remoteServer beantwortet Abfragen gegen eine nur lesend geöffnete Extraktion
+type remoteServer struct {
+	db    *NinoxDB
+	token string
+}
+
+// version ändert sich mit jeder neuen Extraktion (Größe und Änderungszeit der Datei)
+func (s *remoteServer) version() string {
+	info, err := os.Stat(s.db.path)
+	if err != nil {
+		return snapshotDate(s.db)
+	}
+	return fmt.Sprintf("%x-%x", info.Size(), info.ModTime().UnixNano())
+}
+
+func (s *remoteServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+		http.Error(w, "Zugangstoken fehlt oder ist falsch", http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case r.URL.Path == "/api/info" && r.Method == http.MethodGet:
+		writeRemoteJSON(w, remoteInfo{Version: s.version()})
+	case r.URL.Path == "/api/query" && r.Method == http.MethodPost:
+		s.handleQuery(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *remoteServer) handleQuery(w http.ResponseWriter, r *http.Request) {
+	var req remoteRequest
+	dec := json.NewDecoder(io.LimitReader(r.Body, 1<<20))
+	dec.UseNumber()
+	if err := dec.Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkReadOnlyQuery(req.Query); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	for i, a := range req.Args {
+		req.Args[i] = remoteValue(a)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), remoteQueryTimeout)
+	defer cancel()
+	page, err := s.query(ctx, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeRemoteJSON(w, page)
+}
+
+// query führt die Abfrage auf einer eigenen, nur lesenden Verbindung aus
+func (s *remoteServer) query(ctx context.Context, req remoteRequest) (*remotePage, error) {
+	conn, err := s.db.conn.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	restore, err := s.db.backend.readOnly(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	rows, err := conn.QueryContext(ctx, req.Query, req.Args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	page := &remotePage{Columns: cols, Rows: [][]interface{}{}}
+	for rows.Next() {
+		values := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		page.Rows = append(page.Rows, values)
+	}
+	return page, rows.Err()
+}
+
+func writeRemoteJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// cmdServe stellt eine Extraktion für entfernte Clients bereit (nur lesend)
+func cmdServe(args []string) int {
+	opts := parseCLIOptions(args)
+	if len(opts.positional) > 0 {
+		fail(exitUsage, "Verwendung: ninox-tui serve [--listen ADRESSE] [--db DATEI]")
+	}
+	if !isFileDB(opts.dbPath) {
+		fail(exitUsage, "serve benötigt eine SQLite-Datei: %s", opts.dbPath)
+	}
+	writeAccess = writeMode{}
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	listen := firstNonEmpty(opts.listen, defaultListen)
+	server := &remoteServer{db: db, token: os.Getenv(remoteTokenEnv)}
+
+	if !quiet {
+		fmt.Printf("✓ %s unter http://%s (Strg+C beendet)\n", opts.dbPath, listen)
+		if server.token == "" {
+			fmt.Printf("  ohne Zugangstoken – %s setzen, wenn andere Rechner zugreifen\n", remoteTokenEnv)
+		}
+	}
+	httpServer := &http.Server{
+		Addr:              listen,
+		Handler:           server,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      remoteQueryTimeout + 30*time.Second,
+	}
+	if err := httpServer.ListenAndServe(); err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	return exitOK
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+)
+
+// =============================================================================
+// Drucklayouts: Druckvorlagen und ihre eingebetteten Formeln
+// =============================================================================
+
+// reportCategory ist die Kategorie der Formeln aus Druckvorlagen
+const reportCategory = "REPORT"
+
+// isReportScript meldet, ob ein Script aus einer Druckvorlage stammt
+// (Formel eines Elements oder das printout-Script der Tabelle)
+func isReportScript(s Script) bool {
+	return strings.EqualFold(s.CodeCategory, reportCategory) ||
+		strings.HasPrefix(s.CodeType, "report") || s.CodeType == "printout"
+}
+
+// reportContext beschreibt die Lage eines Scripts im Drucklayout, z.B.
+// "Drucklayout „Rechnung“ · Element „Summe“" (Elementname "Vorlage › Element")
+func reportContext(s Script) string {
+	if !isReportScript(s) {
+		return ""
+	}
+	if s.CodeType == "printout" || s.ElementName == "" {
+		return "Drucklayout der Tabelle"
+	}
+	report, element, ok := strings.Cut(s.ElementName, " › ")
+	if !ok {
+		return "Drucklayout „" + report + "“"
+	}
+	return "Drucklayout „" + report + "“ · Element „" + element + "“"
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Rollen und Rechte: Rollenlisten und Regel-Scripts von Tabellen und Feldern
+// =============================================================================
+
+// Permission ist eine Rollenliste für ein Recht an einer Tabelle bzw. einem Feld
+type Permission struct {
+	DatabaseID   string
+	DatabaseName string
+	TableID      string
+	TableName    string
+	ElementID    string
+	ElementName  string
+	Access       string // read, write, create, delete
+	Roles        []string
+}
+
+// permissionCodeTypes ordnet Regel-Scripts dem Recht zu, das sie steuern
+var permissionCodeTypes = map[string]string{
+	"canRead":    "read",
+	"canWrite":   "write",
+	"canCreate":  "create",
+	"canDelete":  "delete",
+	"visibility": "visible",
+}
+
+var accessNames = map[string]string{
+	"read":    "Lesen",
+	"write":   "Schreiben",
+	"create":  "Anlegen",
+	"delete":  "Löschen",
+	"visible": "Sichtbar",
+}
+
+// accessOrder bestimmt die Reihenfolge der Rechte innerhalb eines Objekts
+var accessOrder = map[string]int{"read": 0, "write": 1, "create": 2, "delete": 3, "visible": 4}
+
+// HasPermissions meldet, ob die Extraktion Rollenlisten enthält (ältere nicht)
+func (db *NinoxDB) HasPermissions() bool {
+	return len(db.tableColumns("permissions")) > 0
+}
+
+// GetPermissions lädt alle Rollenlisten; ohne permissions-Tabelle bleibt die Liste leer
+func (db *NinoxDB) GetPermissions() ([]Permission, error) {
+	if !db.HasPermissions() {
+		return nil, nil
+	}
+
+	ignore := db.ignoreFilter("p.database_id", "p.table_id", "")
+	rows, err := db.conn.Query(`
+		SELECT p.database_id, COALESCE(d.name, p.database_id), p.table_id,
+			COALESCE(p.table_name, ''), COALESCE(p.element_id, ''), COALESCE(p.element_name, ''),
+			p.access, p.roles
+		FROM permissions p
+		LEFT JOIN databases d ON d.id = p.database_id
+		WHERE 1 = 1` + ignore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var perms []Permission
+	for rows.Next() {
+		var p Permission
+		var roles string
+		if err := rows.Scan(&p.DatabaseID, &p.DatabaseName, &p.TableID, &p.TableName,
+			&p.ElementID, &p.ElementName, &p.Access, &roles); err != nil {
+			return nil, err
+		}
+		for _, role := range strings.Split(roles, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				p.Roles = append(p.Roles, role)
+			}
+		}
+		perms = append(perms, p)
+	}
+	return perms, rows.Err()
+}
+
+// roleRow ist eine Zeile der Rechte-Ansicht: entweder Rollenliste oder Regel-Script
+type roleRow struct {
+	Database, Table, Element string
+	Access                   string
+	Roles                    []string
+	Script                   *Script
+}
+
+// location beschreibt das Objekt der Zeile, z.B. "CRM/Kunden/Umsatz"
+func (r roleRow) location() string {
+	parts := []string{r.Database, r.Table}
+	if r.Element != "" {
+		parts = append(parts, r.Element)
+	}
+	return strings.Join(parts, "/")
+}
+
+// rule liefert die Rollen bzw. die erste Zeile des Regel-Scripts
+func (r roleRow) rule() string {
+	if r.Script != nil {
+		code := strings.TrimSpace(r.Script.Code)
+		if i := strings.IndexByte(code, '\n'); i >= 0 {
+			code = code[:i] + " …"
+		}
+		return "ƒ " + code
+	}
+	return strings.Join(r.Roles, ", ")
+}
+
+// searchText ist der durchsuchbare Text einer Zeile (wie bei Scripts inkl. Code)
+func (r roleRow) searchText() string {
+	text := r.location() + " " + r.Access + " " + accessNames[r.Access] + " " + strings.Join(r.Roles, " ")
+	if r.Script != nil {
+		text += " " + r.Script.CodeType + " " + r.Script.Code
+	}
+	return strings.ToLower(text)
+}
+
+// buildRoleRows führt Rollenlisten und Regel-Scripts zusammen, sortiert nach Objekt
+func buildRoleRows(perms []Permission, scripts []Script) []roleRow {
+	var rows []roleRow
+	for _, p := range perms {
+		rows = append(rows, roleRow{
+			Database: p.DatabaseName, Table: p.TableName, Element: p.ElementName,
+			Access: p.Access, Roles: p.Roles,
+		})
+	}
+	for i := range scripts {
+		s := &scripts[i]
+		access, ok := permissionCodeTypes[s.CodeType]
+		if !ok || s.TableName == "" {
+			continue
+		}
+		rows = append(rows, roleRow{
+			Database: s.DatabaseName, Table: s.TableName, Element: s.ElementName,
+			Access: access, Script: s,
+		})
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if c := cmpText(a.location(), b.location()); c != 0 {
+			// Tabellenrechte vor den Feldern der Tabelle
+			if a.Database == b.Database && a.Table == b.Table && (a.Element == "" || b.Element == "") {
+				return a.Element == ""
+			}
+			return c < 0
+		}
+		if accessOrder[a.Access] != accessOrder[b.Access] {
+			return accessOrder[a.Access] < accessOrder[b.Access]
+		}
+		return a.Script == nil && b.Script != nil
+	})
+	return rows
+}
+
+// roleCounts zählt, an wie vielen Stellen jede Rolle vorkommt
+func roleCounts(rows []roleRow) map[string]int {
+	counts := make(map[string]int)
+	for _, r := range rows {
+		for _, role := range r.Roles {
+			counts[role]++
+		}
+	}
+	return counts
+}
+
+// visibleRoles liefert die Zeilen, die zum Filter passen (AND/OR/NOT wie bei Scripts)
+func (m Model) visibleRoles() []roleRow {
+	filter := strings.TrimSpace(m.roleInput.Value())
+	if filter == "" {
+		return m.roleRows
+	}
+	orGroups := strings.Split(filter, " OR ")
+	var rows []roleRow
+	for _, r := range m.roleRows {
+		if matchesText(r.searchText(), nil, orGroups) {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// openRoles öffnet die Rechte-Ansicht
+func (m Model) openRoles() (tea.Model, tea.Cmd) {
+	if m.mode == viewRoles {
+		return m, nil
+	}
+	perms, err := m.db.GetPermissions()
+	if err != nil {
+		m.status = fmt.Sprintf("Fehler beim Laden der Rechte: %v", err)
+		return m, nil
+	}
+
+	m.roleRows = buildRoleRows(perms, m.allScripts)
+	m.roleReturn = m.mode
+	m.selectedRole = 0
+	m.mode = viewRoles
+	if !m.db.HasPermissions() {
+		m.status = "Extraktion ohne Rollenlisten – nur Regel-Scripts (neu extrahieren für Rollen)"
+	}
+	return m, nil
+}
+
+// closeRoles kehrt zur Ansicht vor dem Öffnen zurück
+func (m Model) closeRoles() (tea.Model, tea.Cmd) {
+	m.mode = m.roleReturn
+	m.prevMode = m.roleReturn
+	return m, nil
+}
+
+// startRoleFilter fokussiert die Filtereingabe der Rechte-Ansicht
+func (m Model) startRoleFilter() (tea.Model, tea.Cmd) {
+	m.roleFiltering = true
+	m.roleInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleRoleFilterInput filtert beim Tippen; Esc verwirft, Enter übernimmt den Filter
+func (m Model) handleRoleFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Back) && msg.String() == "esc":
+		m.roleInput.SetValue("")
+		fallthrough
+	case key.Matches(msg, keys.Enter):
+		m.roleFiltering = false
+		m.roleInput.Blur()
+		m.selectedRole = 0
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.roleInput, cmd = m.roleInput.Update(msg)
+	m.selectedRole = 0
+	return m, cmd
+}
+
+// openRoleScript zeigt das Regel-Script der ausgewählten Zeile
+func (m Model) openRoleScript() (tea.Model, tea.Cmd) {
+	rows := m.visibleRoles()
+	if m.selectedRole >= len(rows) || rows[m.selectedRole].Script == nil {
+		return m, nil
+	}
+	script := *rows[m.selectedRole].Script
+	m.codeScript = &script
+	m.codeView.SetContent(highlightCode(script.Code))
+	m.codeView.GotoTop()
+	m.prevMode = viewRoles
+	m.mode = viewCode
+	return m, nil
+}
+
+// renderRoles rendert Rollenlisten und Regel-Scripts
+func (m Model) renderRoles() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("🔐 Rollen & Rechte") + "\n\n")
+
+	if counts := sortedCounts(roleCounts(m.roleRows)); len(counts) > 0 {
+		var roles []string
+		for _, c := range counts {
+			roles = append(roles, fmt.Sprintf("%s (%d)", c.name, c.count))
+		}
+		b.WriteString(mutedStyle.Render("  Rollen: "+truncate(strings.Join(roles, " · "), max(10, m.width-18))) + "\n")
+	}
+	if m.roleFiltering {
+		b.WriteString("  🔍 Filter: " + m.roleInput.View() + "\n")
+	} else if filter := m.roleInput.Value(); filter != "" {
+		b.WriteString(mutedStyle.Render("  Filter: "+filter+"  (f ändern)") + "\n")
+	}
+	b.WriteString("\n")
+
+	rows := m.visibleRoles()
+	if len(rows) == 0 {
+		b.WriteString(mutedStyle.Render("  Keine Rollenlisten oder Regel-Scripts gefunden.") + "\n")
+		return boxStyle.Width(m.width - 4).Render(b.String())
+	}
+
+	header := fmt.Sprintf("  %-40s %-10s %s", "Objekt", "Recht", "Rollen / Regel")
+	b.WriteString(tableHeaderStyle.Render(header) + "\n")
+
+	// Fenster um die Auswahl, damit lange Listen scrollen
+	height := max(3, m.height-14)
+	start := 0
+	if m.selectedRole >= height {
+		start = m.selectedRole - height + 1
+	}
+	end := min(len(rows), start+height)
+
+	for i := start; i < end; i++ {
+		r := rows[i]
+		style := tableCellStyle
+		prefix := "  "
+		if i == m.selectedRole {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		row := fmt.Sprintf("%s%-40s %-10s %s", prefix, truncate(r.location(), 40),
+			accessNames[r.Access], truncate(r.rule(), max(10, m.width-62)))
+		b.WriteString(style.Render(row) + "\n")
+	}
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("\n  %d von %d Einträgen", len(rows), len(m.roleRows))) + "\n")
+
+	return boxStyle.Width(m.width - 4).Render(b.String())
+}
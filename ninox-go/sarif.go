@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+)
+
+// =============================================================================
+// SARIF: Befunde für Code-Scanning-Dashboards (GitHub, SonarQube-Importer)
+// =============================================================================
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// sarifLevels ordnet die Schwere den SARIF-Stufen zu
+var sarifLevels = map[lintSeverity]string{
+	severityInfo:    "note",
+	severityWarning: "warning",
+	severityError:   "error",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// sarifSuppressions ordnet die Unterdrückungsart der SARIF-Art zu
+var sarifSuppressions = map[string]sarifSuppression{
+	suppressedComment:  {Kind: "inSource", Justification: "ninox-lint: disable"},
+	suppressedBaseline: {Kind: "external", Justification: "Baseline"},
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine int `json:"startLine"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// findingsSARIF wandelt Befunde in ein SARIF-2.1.0-Protokoll. Die Artefakt-Pfade
+// entsprechen export-scripts, damit Dashboards sie den eingecheckten Dateien zuordnen.
+func findingsSARIF(findings []lintFinding) ([]byte, error) {
+	driver := sarifDriver{Name: "ninox-tui lint"}
+	ruleIndex := make(map[string]int)
+	for i, r := range lintRules {
+		rule := sarifRule{ID: r.ID, ShortDescription: sarifMessage{r.Description}}
+		rule.DefaultConfiguration.Level = sarifLevels[r.Severity]
+		driver.Rules = append(driver.Rules, rule)
+		ruleIndex[r.ID] = i
+	}
+
+	results := make([]sarifResult, 0, len(findings))
+	for _, f := range findings {
+		var loc sarifLocation
+		loc.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(scriptFilePath(*f.Script))
+		loc.PhysicalLocation.Region.StartLine = max(1, f.Line)
+		loc.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: scriptLabel(*f.Script), Kind: "function"}}
+		result := sarifResult{
+			RuleID:    f.Rule,
+			RuleIndex: ruleIndex[f.Rule],
+			Level:     sarifLevels[f.Severity],
+			Message:   sarifMessage{f.Message},
+			Locations: []sarifLocation{loc},
+		}
+		if s, ok := sarifSuppressions[f.SuppressedBy]; ok {
+			result.Suppressions = []sarifSuppression{s}
+		}
+		results = append(results, result)
+	}
+
+	return json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}, "", "  ")
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// =============================================================================
+// SQLite-Schema (entspricht ninox_api_extractor.py)
+// =============================================================================
+
+// schemaDDL erzeugt die Tabellen einer Schema-Datenbank
+var schemaDDL = []string{
+	`CREATE TABLE IF NOT EXISTS databases (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		team_id TEXT,
+		team_name TEXT,
+		archived INTEGER DEFAULT 0,
+		version INTEGER,
+		color TEXT,
+		icon TEXT,
+		table_count INTEGER DEFAULT 0,
+		code_count INTEGER DEFAULT 0,
+		extracted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		schema_hash TEXT,
+		last_synced TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS tables (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		caption TEXT,
+		icon TEXT,
+		hidden INTEGER DEFAULT 0,
+		field_count INTEGER DEFAULT 0,
+		UNIQUE(database_id, table_id),
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS fields (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		field_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		caption TEXT,
+		base_type TEXT,
+		is_required INTEGER DEFAULT 0,
+		ref_table_id TEXT,
+		ref_table_name TEXT,
+		ref_database_id TEXT,
+		is_composition INTEGER DEFAULT 0,
+		has_formula INTEGER DEFAULT 0,
+		UNIQUE(database_id, table_id, field_id),
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS relationships (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		database_name TEXT,
+		source_table_id TEXT NOT NULL,
+		source_table_name TEXT NOT NULL,
+		source_field_id TEXT,
+		source_field_name TEXT,
+		target_table_id TEXT,
+		target_table_name TEXT NOT NULL,
+		target_database_id TEXT,
+		target_database_name TEXT,
+		relationship_type TEXT NOT NULL,
+		is_composition INTEGER DEFAULT 0,
+		reverse_field_name TEXT,
+		found_in_code_type TEXT,
+		found_in_code TEXT,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS scripts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		team_id TEXT NOT NULL,
+		team_name TEXT,
+		database_id TEXT NOT NULL,
+		database_name TEXT,
+		table_id TEXT,
+		table_name TEXT,
+		element_id TEXT,
+		element_name TEXT,
+		code_type TEXT NOT NULL,
+		code_category TEXT,
+		code TEXT NOT NULL,
+		code_original TEXT,
+		code_hash TEXT,
+		line_count INTEGER DEFAULT 0,
+		modified_by TEXT,
+		modified_at TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS script_dependencies (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		script_id INTEGER NOT NULL,
+		source_database_id TEXT NOT NULL,
+		source_database_name TEXT,
+		target_database_name TEXT NOT NULL,
+		reference_type TEXT NOT NULL,
+		code_snippet TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (script_id) REFERENCES scripts(id) ON DELETE CASCADE,
+		FOREIGN KEY (source_database_id) REFERENCES databases(id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS layout_elements (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		element_id TEXT NOT NULL,
+		caption TEXT,
+		element_type TEXT,
+		source TEXT NOT NULL,
+		sort_order REAL,
+		tab_caption TEXT,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS field_options (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		field_id TEXT NOT NULL,
+		option_id TEXT NOT NULL,
+		caption TEXT,
+		sort_order REAL,
+		color TEXT,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS record_counts (
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		record_count INTEGER NOT NULL,
+		counted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (database_id, table_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS automations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		element_id TEXT NOT NULL,
+		name TEXT,
+		kind TEXT NOT NULL,
+		trigger_condition TEXT,
+		enabled INTEGER DEFAULT 1,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS permissions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		table_name TEXT,
+		element_id TEXT,
+		element_name TEXT,
+		access TEXT NOT NULL,
+		roles TEXT NOT NULL,
+		FOREIGN KEY (database_id) REFERENCES databases(id)
+	)`,
+	// Verlauf: jede Extraktion bzw. jeder Import als Stand, Code nur einmal je Hash
+	`CREATE TABLE IF NOT EXISTS snapshots (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		taken_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		source TEXT NOT NULL,
+		fingerprint TEXT
+	)`,
+	`CREATE TABLE IF NOT EXISTS snapshot_code (
+		hash TEXT PRIMARY KEY,
+		code TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS snapshot_scripts (
+		snapshot_id INTEGER NOT NULL,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		element_id TEXT NOT NULL,
+		code_type TEXT NOT NULL,
+		element_name TEXT,
+		code_hash TEXT NOT NULL,
+		FOREIGN KEY (snapshot_id) REFERENCES snapshots(id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS snapshot_fields (
+		snapshot_id INTEGER NOT NULL,
+		database_id TEXT NOT NULL,
+		table_id TEXT NOT NULL,
+		field_id TEXT NOT NULL,
+		name TEXT,
+		base_type TEXT,
+		ref_table_name TEXT,
+		has_formula INTEGER DEFAULT 0,
+		FOREIGN KEY (snapshot_id) REFERENCES snapshots(id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS snapshot_tables (
+		snapshot_id INTEGER NOT NULL,
+		database_id TEXT NOT NULL,
+		database_name TEXT,
+		table_id TEXT NOT NULL,
+		name TEXT,
+		FOREIGN KEY (snapshot_id) REFERENCES snapshots(id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_scripts_team ON scripts(team_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_snapshot_scripts ON snapshot_scripts(database_id, table_id, element_id, code_type)`,
+	`CREATE INDEX IF NOT EXISTS idx_snapshot_fields ON snapshot_fields(database_id, table_id, field_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_snapshot_tables ON snapshot_tables(snapshot_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_scripts_db ON scripts(database_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_scripts_table ON scripts(table_name)`,
+	`CREATE INDEX IF NOT EXISTS idx_scripts_type ON scripts(code_type)`,
+	`CREATE INDEX IF NOT EXISTS idx_relationships_source ON relationships(source_table_name)`,
+	`CREATE INDEX IF NOT EXISTS idx_relationships_target ON relationships(target_table_name)`,
+	`CREATE INDEX IF NOT EXISTS idx_fields_ref ON fields(ref_table_name)`,
+	`CREATE INDEX IF NOT EXISTS idx_permissions_table ON permissions(database_id, table_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_layout_table ON layout_elements(database_id, table_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_field_options ON field_options(database_id, table_id, field_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_automations_db ON automations(database_id)`,
+}
+
+// ftsDDL legt die Volltextsuche an; benötigt SQLite mit FTS5
+var ftsDDL = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS scripts_fts USING fts5(
+		code, team_name, database_name, table_name, element_name, code_type,
+		content='scripts', content_rowid='id'
+	)`,
+	`CREATE TRIGGER IF NOT EXISTS scripts_ai AFTER INSERT ON scripts BEGIN
+		INSERT INTO scripts_fts(rowid, code, team_name, database_name, table_name, element_name, code_type)
+		VALUES (new.id, new.code, new.team_name, new.database_name, new.table_name, new.element_name, new.code_type);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS scripts_ad AFTER DELETE ON scripts BEGIN
+		INSERT INTO scripts_fts(scripts_fts, rowid, code, team_name, database_name, table_name, element_name, code_type)
+		VALUES ('delete', old.id, old.code, old.team_name, old.database_name, old.table_name, old.element_name, old.code_type);
+	END`,
+}
+
+// schemaExecer ist eine Verbindung oder Transaktion, in der das Schema angelegt wird
+type schemaExecer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// createSchema legt alle Tabellen an. FTS5 ist optional: fehlt die Erweiterung,
+// greift SearchScripts auf LIKE zurück.
+func createSchema(conn schemaExecer) (fts bool, err error) {
+	for _, stmt := range schemaDDL {
+		if _, err := conn.Exec(stmt); err != nil {
+			return false, err
+		}
+	}
+
+	for _, stmt := range ftsDDL {
+		if _, err := conn.Exec(stmt); err != nil {
+			if strings.Contains(err.Error(), "fts5") {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+	return true, nil
+}
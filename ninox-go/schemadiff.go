@@ -0,0 +1,443 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Schema-Drift: Vergleich zweier gespeicherter Stände aus dem Verlauf (oder
+// zweier Dateien) als Befehl diff und als Ansicht der Änderungen in der TUI
+// =============================================================================
+
+// snapshotInfo ist ein gespeicherter Stand des Verlaufs
+type snapshotInfo struct {
+	ID      int
+	TakenAt string
+	Source  string
+	Scripts int
+	Fields  int
+}
+
+// Label beschreibt den Stand, z.B. "Stand #3 (2026-10-14 16:02, extract)"
+func (s snapshotInfo) Label() string {
+	return fmt.Sprintf("Stand #%d (%s, %s)", s.ID, strings.Replace(s.TakenAt[:min(16, len(s.TakenAt))], "T", " ", 1), s.Source)
+}
+
+// ListSnapshots lädt die Stände des Verlaufs, den ältesten zuerst
+func (db *NinoxDB) ListSnapshots() ([]snapshotInfo, error) {
+	if !db.HasHistory() {
+		return nil, nil
+	}
+	rows, err := db.conn.Query(`
+		SELECT sn.id, COALESCE(sn.taken_at, ''), sn.source,
+		       (SELECT COUNT(*) FROM snapshot_scripts ss WHERE ss.snapshot_id = sn.id),
+		       (SELECT COUNT(*) FROM snapshot_fields sf WHERE sf.snapshot_id = sn.id)
+		FROM snapshots sn
+		ORDER BY sn.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []snapshotInfo
+	for rows.Next() {
+		var s snapshotInfo
+		if err := rows.Scan(&s.ID, &s.TakenAt, &s.Source, &s.Scripts, &s.Fields); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, rows.Err()
+}
+
+// storedCodeCategory leitet die Kategorie aus Ebene und Code-Typ ab (der Verlauf speichert sie nicht)
+func storedCodeCategory(tableID, elementID, codeType string) string {
+	fields := fieldCodeFields
+	switch {
+	case tableID == "":
+		fields = databaseCodeFields
+	case elementID == "":
+		fields = tableCodeFields
+	}
+	for _, f := range fields {
+		if f.key == codeType {
+			return f.category
+		}
+	}
+	return ""
+}
+
+// loadStoredSnapshot lädt einen Stand aus dem Verlauf. Ältere Stände ohne
+// Tabellennamen erhalten die Namen der aktuellen Extraktion (sonst die IDs).
+func (db *NinoxDB) loadStoredSnapshot(id int) (*snapshot, error) {
+	s := &snapshot{
+		tables:  make(map[string]Table),
+		fields:  make(map[string]Field),
+		scripts: make(map[string]Script),
+		dbNames: make(map[string]string),
+		stored:  true,
+	}
+
+	// Namen der aktuellen Extraktion als Rückfall
+	tableNames := make(map[string]string) // layoutKey(Datenbank-ID, Tabellen-ID) → Name
+	if rows, err := db.conn.Query(`SELECT id, COALESCE(name, id) FROM databases`); err == nil {
+		for rows.Next() {
+			var dbID, name string
+			if rows.Scan(&dbID, &name) == nil {
+				s.dbNames[dbID] = name
+			}
+		}
+		rows.Close()
+	}
+	if rows, err := db.conn.Query(`SELECT database_id, table_id, COALESCE(name, table_id) FROM tables`); err == nil {
+		for rows.Next() {
+			var dbID, tableID, name string
+			if rows.Scan(&dbID, &tableID, &name) == nil {
+				tableNames[layoutKey(dbID, tableID)] = name
+			}
+		}
+		rows.Close()
+	}
+
+	// Tabellen des Standes; ohne snapshot_tables-Zeilen aus den Feldern abgeleitet
+	var tableIDs [][2]string
+	if len(db.tableColumns("snapshot_tables")) > 0 {
+		rows, err := db.conn.Query(`SELECT database_id, COALESCE(database_name, ''), table_id, COALESCE(name, '')
+			FROM snapshot_tables WHERE snapshot_id = ?`, id)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var dbID, dbName, tableID, name string
+			if err := rows.Scan(&dbID, &dbName, &tableID, &name); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if dbName != "" {
+				s.dbNames[dbID] = dbName
+			}
+			tableNames[layoutKey(dbID, tableID)] = firstNonEmpty(name, tableID)
+			tableIDs = append(tableIDs, [2]string{dbID, tableID})
+		}
+		rows.Close()
+	}
+	if len(tableIDs) == 0 {
+		rows, err := db.conn.Query(`SELECT DISTINCT database_id, table_id FROM snapshot_fields WHERE snapshot_id = ?`, id)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var t [2]string
+			if err := rows.Scan(&t[0], &t[1]); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			tableIDs = append(tableIDs, t)
+		}
+		rows.Close()
+	}
+	// Der Vergleich liest dbNames direkt; unbekannte Datenbanken heißen wie ihre ID
+	dbName := func(dbID string) string {
+		if s.dbNames[dbID] == "" {
+			s.dbNames[dbID] = dbID
+		}
+		return s.dbNames[dbID]
+	}
+	tableName := func(dbID, tableID string) string {
+		if tableID == "" {
+			return ""
+		}
+		return firstNonEmpty(tableNames[layoutKey(dbID, tableID)], tableID)
+	}
+
+	for _, t := range tableIDs {
+		name := tableName(t[0], t[1])
+		s.tables[dbName(t[0])+"/"+name] = Table{DatabaseID: t[0], TableID: t[1], Name: name}
+	}
+
+	rows, err := db.conn.Query(`SELECT database_id, table_id, field_id, COALESCE(name, ''), COALESCE(base_type, ''),
+			COALESCE(ref_table_name, ''), COALESCE(has_formula, 0)
+		FROM snapshot_fields WHERE snapshot_id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var f Field
+		if err := rows.Scan(&f.DatabaseID, &f.TableID, &f.FieldID, &f.Name, &f.BaseType, &f.RefTableName, &f.HasFormula); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		s.fields[dbName(f.DatabaseID)+"/"+tableName(f.DatabaseID, f.TableID)+"/"+f.Name] = f
+	}
+	rows.Close()
+
+	rows, err = db.conn.Query(`SELECT ss.database_id, ss.table_id, ss.element_id, ss.code_type,
+			COALESCE(ss.element_name, ''), ss.code_hash, COALESCE(c.code, '')
+		FROM snapshot_scripts ss
+		LEFT JOIN snapshot_code c ON c.hash = ss.code_hash
+		WHERE ss.snapshot_id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var sc Script
+		if err := rows.Scan(&sc.DatabaseID, &sc.TableID, &sc.ElementID, &sc.CodeType, &sc.ElementName, &sc.Hash, &sc.Code); err != nil {
+			return nil, err
+		}
+		sc.DatabaseName = dbName(sc.DatabaseID)
+		sc.TableName = tableName(sc.DatabaseID, sc.TableID)
+		sc.CodeCategory = storedCodeCategory(sc.TableID, sc.ElementID, sc.CodeType)
+		sc.LineCount = len(splitLines(sc.Code))
+		s.addScript(sc)
+	}
+	return s, rows.Err()
+}
+
+// DiffStoredSnapshots vergleicht zwei Stände des Verlaufs
+func (db *NinoxDB) DiffStoredSnapshots(from, to snapshotInfo) (*SnapshotDiff, error) {
+	a, err := db.loadStoredSnapshot(from.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", from.Label(), err)
+	}
+	b, err := db.loadStoredSnapshot(to.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", to.Label(), err)
+	}
+	return diffSnapshotContent(a, b, from.Label(), to.Label()), nil
+}
+
+// findSnapshot sucht einen Stand nach seiner Nummer
+func findSnapshot(snapshots []snapshotInfo, id int) (snapshotInfo, bool) {
+	for _, s := range snapshots {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return snapshotInfo{}, false
+}
+
+// --- Befehl diff ---
+
+// diffSide ist eine Seite des Vergleichs: Stand des Verlaufs oder Datei
+type diffSide struct {
+	content *snapshot
+	label   string
+}
+
+// resolveDiffSide lädt eine Nummer als Stand aus dem Verlauf von db, sonst die Datei
+func resolveDiffSide(db *NinoxDB, snapshots []snapshotInfo, ref string) (diffSide, error) {
+	if id, err := strconv.Atoi(ref); err == nil {
+		info, ok := findSnapshot(snapshots, id)
+		if !ok {
+			return diffSide{}, fmt.Errorf("Stand #%d nicht im Verlauf von %s (ninox-tui diff --list)", id, db.path)
+		}
+		content, err := db.loadStoredSnapshot(id)
+		return diffSide{content, info.Label()}, err
+	}
+	file := openCLIDB(ref)
+	defer file.Close()
+	content, err := loadSnapshot(file)
+	return diffSide{content, ref}, err
+}
+
+// writeSnapshotList gibt die Stände des Verlaufs aus
+func writeSnapshotList(snapshots []snapshotInfo) {
+	for _, s := range snapshots {
+		fmt.Printf("#%-4d %-19s %-22s %5d Scripts %5d Felder\n", s.ID, truncate(s.TakenAt, 19), s.Source, s.Scripts, s.Fields)
+	}
+}
+
+// cmdDiff vergleicht zwei Stände: Nummern aus dem Verlauf der --db-Datei oder
+// Dateien. Ohne Angabe der vorletzte mit dem letzten Stand.
+func cmdDiff(args []string) int {
+	opts := parseCLIOptions(args)
+	applyDocLang(opts.lang)
+	if len(opts.positional) > 2 {
+		fail(exitUsage, "Verwendung: ninox-tui diff [ALT [NEU]] [--list] [--output text|html] [-U N] [--no-color] [--lang de|en] [--db DATEI]")
+	}
+	if !snapshotOutputs[opts.output] {
+		fail(exitUsage, "Unbekanntes Ausgabeformat: %s (erlaubt: text, html)", opts.output)
+	}
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	snapshots, err := db.ListSnapshots()
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+	if opts.list {
+		if len(snapshots) == 0 {
+			fail(exitNoResults, "Kein Verlauf in %s", opts.dbPath)
+		}
+		writeSnapshotList(snapshots)
+		return exitOK
+	}
+
+	refs := opts.positional
+	switch len(refs) {
+	case 0:
+		if len(snapshots) < 2 {
+			fail(exitNoResults, "Zu wenige Stände im Verlauf von %s (%d) – ALT und NEU angeben", opts.dbPath, len(snapshots))
+		}
+		refs = []string{strconv.Itoa(snapshots[len(snapshots)-2].ID), strconv.Itoa(snapshots[len(snapshots)-1].ID)}
+	case 1:
+		// Stand gegen den letzten Stand, Datei gegen die --db-Datei
+		if _, err := strconv.Atoi(refs[0]); err == nil && len(snapshots) > 0 {
+			refs = append(refs, strconv.Itoa(snapshots[len(snapshots)-1].ID))
+		} else {
+			refs = append(refs, opts.dbPath)
+		}
+	}
+
+	var sides [2]diffSide
+	for i, ref := range refs {
+		if sides[i], err = resolveDiffSide(db, snapshots, ref); err != nil {
+			fail(exitDBError, "%v", err)
+		}
+	}
+	diff := diffSnapshotContent(sides[0].content, sides[1].content, sides[0].label, sides[1].label)
+
+	switch opts.output {
+	case "html":
+		if _, err := os.Stdout.Write(SnapshotDiffHTML(diff)); err != nil {
+			fail(exitFailure, "%v", err)
+		}
+	default:
+		if !quiet {
+			fmt.Printf("%s → %s\n", diff.Old, diff.New)
+		}
+		writeSnapshotDiffText(diff, opts.context, opts.noColor)
+	}
+	if len(diff.Changes) == 0 {
+		return exitNoResults
+	}
+	return exitOK
+}
+
+// --- Ansicht der Änderungen ---
+
+// openChanges zeigt die Änderungen vom vorletzten zum letzten Stand des Verlaufs
+func (m Model) openChanges() (tea.Model, tea.Cmd) {
+	if m.mode == viewChanges {
+		return m, nil
+	}
+	snapshots, err := m.db.ListSnapshots()
+	if err != nil {
+		m.status = fmt.Sprintf("⚠ Verlauf: %v", err)
+		return m, nil
+	}
+	if len(snapshots) < 2 {
+		m.status = "Änderungen: mindestens zwei Stände im Verlauf nötig (erneut extrahieren)"
+		return m, nil
+	}
+	m.changeSnapshots = snapshots
+	m.changesReturn = m.mode
+	m.mode = viewChanges
+	return m.loadChanges(len(snapshots) - 2)
+}
+
+// loadChanges vergleicht den Stand base mit dem letzten Stand
+func (m Model) loadChanges(base int) (tea.Model, tea.Cmd) {
+	snapshots := m.changeSnapshots
+	diff, err := m.db.DiffStoredSnapshots(snapshots[base], snapshots[len(snapshots)-1])
+	if err != nil {
+		m.status = fmt.Sprintf("⚠ Änderungen: %v", err)
+		return m, nil
+	}
+	m.changeBase = base
+	m.changeDiff = diff
+	m.selectedChange = 0
+	return m, nil
+}
+
+// cycleChangeBase wählt einen älteren Ausgangsstand (nach dem ältesten wieder den vorletzten)
+func (m Model) cycleChangeBase() (tea.Model, tea.Cmd) {
+	base := m.changeBase - 1
+	if base < 0 {
+		base = len(m.changeSnapshots) - 2
+	}
+	return m.loadChanges(base)
+}
+
+// closeChanges kehrt zur Ansicht vor dem Öffnen zurück
+func (m Model) closeChanges() (tea.Model, tea.Cmd) {
+	m.mode = m.changesReturn
+	m.prevMode = m.changesReturn
+	m.changeDiff = nil
+	return m, nil
+}
+
+// openChangeDiff zeigt den Unified Diff des ausgewählten Scripts
+func (m Model) openChangeDiff() (tea.Model, tea.Cmd) {
+	if m.changeDiff == nil || m.selectedChange >= len(m.changeDiff.Changes) {
+		return m, nil
+	}
+	c := m.changeDiff.Changes[m.selectedChange]
+	if c.Object != objectScript {
+		m.status = fmt.Sprintf("%s %s: %s", c.Object, c.Path(), firstNonEmpty(c.Detail, "kein Code"))
+		return m, nil
+	}
+	title := fmt.Sprintf("%s: %s → %s", c.Path(), m.changeSnapshots[m.changeBase].Label(),
+		m.changeSnapshots[len(m.changeSnapshots)-1].Label())
+	m.openDiff(title, UnifiedDiff("a/"+c.Path(), "b/"+c.Path(), c.OldCode, c.NewCode, diffContext))
+	return m, nil
+}
+
+// renderChanges rendert die hinzugekommenen, entfernten und geänderten Objekte
+func (m Model) renderChanges() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("± Änderungen") + "\n\n")
+	if m.changeDiff == nil {
+		return boxStyle.Width(m.width - 4).Render(b.String())
+	}
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("  %s → %s  (Tab älterer Stand)", m.changeDiff.Old, m.changeDiff.New)) + "\n\n")
+
+	changes := m.changeDiff.Changes
+	if len(changes) == 0 {
+		b.WriteString(mutedStyle.Render("  Keine Änderungen zwischen den Ständen.") + "\n")
+		return boxStyle.Width(m.width - 4).Render(b.String())
+	}
+
+	header := fmt.Sprintf("     %-8s %-50s %s", "Objekt", "Pfad", "Änderung")
+	b.WriteString(tableHeaderStyle.Render(header) + "\n")
+
+	height := max(3, m.height-14)
+	start := 0
+	if m.selectedChange >= height {
+		start = m.selectedChange - height + 1
+	}
+	end := min(len(changes), start+height)
+
+	counts := make(map[changeKind]int)
+	for _, c := range changes {
+		counts[c.Kind]++
+	}
+	for i := start; i < end; i++ {
+		c := changes[i]
+		style := tableCellStyle
+		switch c.Kind {
+		case changeAdded:
+			style = style.Foreground(diffAddStyle.GetForeground())
+		case changeRemoved:
+			style = style.Foreground(diffRemoveStyle.GetForeground())
+		}
+		prefix := "  "
+		if i == m.selectedChange {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		row := fmt.Sprintf("%s%c %-8s %-50s %s", prefix, c.Kind, c.Object, truncate(c.Path(), 50),
+			truncate(c.Detail, max(10, m.width-72)))
+		b.WriteString(style.Render(row) + "\n")
+	}
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("\n  %d Änderungen: %d neu, %d entfernt, %d geändert",
+		len(changes), counts[changeAdded], counts[changeRemoved], counts[changeChanged])) + "\n")
+
+	return boxStyle.Width(m.width - 4).Render(b.String())
+}
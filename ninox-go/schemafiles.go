@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Mehrere Extraktionen gleichzeitig (z.B. Produktion und Staging): W wechselt
+// zwischen den beim Start angegebenen Dateien. Jede Datei behält ihren Zustand.
+// =============================================================================
+
+// schemaFiles sind die geöffneten Extraktionen; alle Models teilen dieselbe Liste
+type schemaFiles struct {
+	paths   []string
+	current int
+	open    map[string]Model // zuletzt angezeigter Zustand je Datei
+	cfg     *Config
+}
+
+// expandSchemaPaths ersetzt Verzeichnisse durch die enthaltenen .db-Dateien
+func expandSchemaPaths(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil || !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "*.db"))
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("Keine .db-Dateien in %s", arg)
+		}
+		sort.Strings(matches)
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+// SetSchemaFiles macht die Dateien per W wählbar; paths[0] ist die des Models
+func (m *Model) SetSchemaFiles(paths []string, cfg *Config) {
+	if len(paths) < 2 {
+		return
+	}
+	m.files = &schemaFiles{paths: paths, open: make(map[string]Model), cfg: cfg}
+}
+
+// schemaFileLabel ist der Anzeigename einer Datei
+func schemaFileLabel(path string) string {
+	if !isFileDB(path) {
+		return path
+	}
+	return filepath.Base(path)
+}
+
+// openFileSwitcher zeigt die Auswahl der Extraktionen
+func (m Model) openFileSwitcher() (tea.Model, tea.Cmd) {
+	if m.files == nil {
+		m.status = "Nur eine Extraktion geöffnet (mehrere Dateien oder ein Verzeichnis beim Start angeben)"
+		return m, nil
+	}
+	m.fileSwitching = true
+	m.fileSelected = m.files.current
+	return m, nil
+}
+
+// handleFileSwitcherKey wählt eine Extraktion aus
+func (m Model) handleFileSwitcherKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, keys.Back), key.Matches(msg, keys.Files):
+		m.fileSwitching = false
+	case key.Matches(msg, keys.Up):
+		if m.fileSelected > 0 {
+			m.fileSelected--
+		}
+	case key.Matches(msg, keys.Down):
+		if m.fileSelected < len(m.files.paths)-1 {
+			m.fileSelected++
+		}
+	case key.Matches(msg, keys.Enter):
+		m.fileSwitching = false
+		return m.switchSchemaFile(m.fileSelected)
+	}
+	return m, nil
+}
+
+// switchSchemaFile wechselt zur Extraktion i; beim ersten Mal wird sie geöffnet
+func (m Model) switchSchemaFile(i int) (tea.Model, tea.Cmd) {
+	files := m.files
+	if i == files.current {
+		return m, nil
+	}
+	if m.progress != "" {
+		m.status = "Wechsel nicht möglich: " + m.progress
+		return m, nil
+	}
+
+	path := files.paths[i]
+	next, ok := files.open[path]
+	if !ok {
+		opened, err := NewModel(path, files.cfg)
+		if err != nil {
+			m.status = fmt.Sprintf("❌ %s: %v", schemaFileLabel(path), err)
+			return m, nil
+		}
+		next = *opened
+		next.files = files
+		next.EnableSession(path, false)
+	}
+
+	// Die verlassene Datei gilt als regulär beendet
+	m.endSession()
+	files.open[files.paths[files.current]] = m
+	files.current = i
+
+	model, cmd := next.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	next = model.(Model)
+	next.status = fmt.Sprintf("Extraktion %s (%d/%d)", schemaFileLabel(path), i+1, len(files.paths))
+	return next, cmd
+}
+
+// renderFileSwitcher rendert die Auswahl der Extraktionen als Kasten
+func (m Model) renderFileSwitcher() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("🗄 Extraktionen") + "\n\n")
+	for i, path := range m.files.paths {
+		style := tableCellStyle
+		prefix := "  "
+		if i == m.fileSelected {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		marker := " "
+		if i == m.files.current {
+			marker = "•"
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%s%s %s", prefix, marker, truncate(schemaFileLabel(path), 40))) + "\n")
+	}
+	b.WriteString("\n" + mutedStyle.Render("• geöffnet  ↑↓ Auswahl  Enter wechseln"))
+
+	return statsBoxStyle.Width(lipgloss.Width(b.String()) + 4).Render(b.String())
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Zwei Scripts vergleichen: mit Leertaste markieren, C zeigt den Diff,
+// Tab wechselt zwischen Unified Diff und Gegenüberstellung
+// =============================================================================
+
+// scriptPair sind die beiden verglichenen Scripts der Diff-Ansicht
+type scriptPair struct {
+	a, b       Script
+	sideBySide bool
+}
+
+// isMarked meldet, ob ein Script zum Vergleich markiert ist
+func (m Model) isMarked(s Script) bool {
+	for _, marked := range m.markedScripts {
+		if marked.ID == s.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// scriptPrefix ist das Zeilenpräfix der Script-Listen (Auswahl und Markierung)
+func (m Model) scriptPrefix(s Script, selected bool) string {
+	switch marked := m.isMarked(s); {
+	case selected && marked:
+		return "▶●"
+	case selected:
+		return "▶ "
+	case marked:
+		return " ●"
+	}
+	return "  "
+}
+
+// toggleMark markiert das ausgewählte Script; beim dritten fällt das älteste heraus
+func (m Model) toggleMark() (tea.Model, tea.Cmd) {
+	s := m.activeScript()
+	if s == nil || m.mode == viewCode {
+		return m, nil
+	}
+
+	marked := make([]Script, 0, 2)
+	for _, other := range m.markedScripts {
+		if other.ID != s.ID {
+			marked = append(marked, other)
+		}
+	}
+	if len(marked) == len(m.markedScripts) {
+		marked = append(marked, *s)
+		if len(marked) > 2 {
+			marked = marked[1:]
+		}
+	}
+	m.markedScripts = marked
+
+	switch len(marked) {
+	case 0:
+		m.status = "Keine Scripts markiert"
+	case 1:
+		m.status = "1 Script markiert – ein zweites markieren oder auswählen, C vergleicht"
+	default:
+		m.status = "2 Scripts markiert – C vergleicht"
+	}
+	return m, nil
+}
+
+// diffMarkedScripts vergleicht die beiden markierten Scripts; ist nur eines
+// markiert, wird es mit dem ausgewählten verglichen
+func (m Model) diffMarkedScripts() (tea.Model, tea.Cmd) {
+	pair := m.markedScripts
+	if len(pair) == 1 {
+		if s := m.activeScript(); s != nil && s.ID != pair[0].ID {
+			pair = append([]Script{pair[0]}, *s)
+		}
+	}
+	if len(pair) != 2 {
+		m.status = "Vergleich: zwei Scripts mit Leertaste markieren"
+		return m, nil
+	}
+
+	sideBySide := m.scriptDiff != nil && m.scriptDiff.sideBySide
+	m.showScriptDiff(scriptPair{a: pair[0], b: pair[1], sideBySide: sideBySide})
+	return m, nil
+}
+
+// toggleDiffLayout wechselt beim Script-Vergleich zwischen Unified Diff und Gegenüberstellung
+func (m Model) toggleDiffLayout() (tea.Model, tea.Cmd) {
+	if m.scriptDiff == nil {
+		return m, nil
+	}
+	pair := *m.scriptDiff
+	pair.sideBySide = !pair.sideBySide
+	m.showScriptDiff(pair)
+	return m, nil
+}
+
+// showScriptDiff öffnet die Diff-Ansicht für ein Script-Paar
+func (m *Model) showScriptDiff(pair scriptPair) {
+	nameA, nameB := scriptLabel(pair.a), scriptLabel(pair.b)
+	title := nameA + " ↔ " + nameB
+	if !pair.sideBySide {
+		m.openDiff(title, UnifiedDiff("a/"+nameA, "b/"+nameB, pair.a.Code, pair.b.Code, diffContext))
+	} else {
+		m.openDiff(title, "")
+		if pair.a.Code != pair.b.Code {
+			m.codeView.SetContent(renderSideBySide(pair.a.Code, pair.b.Code, m.codeView.Width))
+		}
+	}
+	m.scriptDiff = &pair
+}
+
+// renderSideBySide stellt zwei Fassungen in zwei Spalten gegenüber. Unveränderte
+// Abschnitte werden wie im HTML-Bericht bis auf diffContext Zeilen ausgelassen.
+func renderSideBySide(a, b string, width int) string {
+	rows := sideBySideRows(a, b)
+	visible := make([]bool, len(rows))
+	for i, row := range rows {
+		if row.kind == ' ' {
+			continue
+		}
+		for k := max(0, i-diffContext); k <= min(len(rows)-1, i+diffContext); k++ {
+			visible[k] = true
+		}
+	}
+
+	// Je Spalte: 4 Stellen Zeilennummer, Leerzeichen, Text; dazwischen " │ "
+	column := max(10, (width-3)/2)
+	cell := func(line int, text string, style *lipgloss.Style) string {
+		if line == 0 {
+			return strings.Repeat(" ", column)
+		}
+		content := fitRunes(fmt.Sprintf("%4d %s", line, strings.ReplaceAll(text, "\t", "    ")), column)
+		if style != nil {
+			return style.Render(content)
+		}
+		return content
+	}
+
+	var out strings.Builder
+	skipped := false
+	for i, row := range rows {
+		if !visible[i] {
+			if !skipped {
+				out.WriteString(mutedStyle.Render(fitRunes("   …", column)+" │ "+"   …") + "\n")
+				skipped = true
+			}
+			continue
+		}
+		skipped = false
+
+		var left, right *lipgloss.Style
+		if row.kind == '-' || row.kind == '~' {
+			left = &diffRemoveStyle
+		}
+		if row.kind == '+' || row.kind == '~' {
+			right = &diffAddStyle
+		}
+		out.WriteString(cell(row.lineA, row.textA, left) + " │ " + cell(row.lineB, row.textB, right) + "\n")
+	}
+	return out.String()
+}
+
+// fitRunes kürzt bzw. füllt einen Text auf genau n Zeichen
+func fitRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s + strings.Repeat(" ", n-len(r))
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Einzelnes Script als eigenständige HTML-Datei (Stile eingebettet, ohne
+// weitere Dateien) zum Anhängen an Tickets oder E-Mails
+// =============================================================================
+
+const scriptHTMLCSS = `
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 70em; padding: 0 1em; color: #222; }
+h1 { font-size: 1.4em; margin-bottom: 0.6em; }
+table.meta { border-collapse: collapse; margin-bottom: 1.2em; }
+table.meta th, table.meta td { text-align: left; padding: 0.2em 1.2em 0.2em 0; vertical-align: top; }
+table.meta th { color: #666; font-weight: normal; }
+pre { padding: 0.6em; border: 1px solid #ddd; border-radius: 4px; overflow-x: auto; font-size: 0.9em; }
+footer { color: #999; margin-top: 2em; font-size: 0.85em; }
+`
+
+// ScriptHTML rendert ein Script mit Kopfdaten und Hervorhebung als vollständige Seite
+func ScriptHTML(s Script, extractedAt string) ([]byte, error) {
+	lexer := lexers.Get("javascript")
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, s.Code)
+	if err != nil {
+		return nil, err
+	}
+	var code bytes.Buffer
+	formatter := chromahtml.New(chromahtml.WithLineNumbers(true)) // Stile inline
+	if err := formatter.Format(&code, styles.Get(siteCodeStyle), iterator); err != nil {
+		return nil, err
+	}
+
+	esc := html.EscapeString
+	title := scriptLabel(s)
+	meta := [][2]string{
+		{docText("Datenbank"), s.DatabaseName},
+		{docText("Tabelle"), firstNonEmpty(s.TableName, docText("(Datenbank)"))},
+		{"Element", firstNonEmpty(s.ElementName, docText("(Tabelle)"))},
+		{docText("Typ"), fmt.Sprintf("%s (%s)", s.CodeType, docText(s.Group()))},
+		{docText("Zeilen"), fmt.Sprint(s.LineCount)},
+		{"Hash", s.Hash[:min(8, len(s.Hash))]},
+	}
+	if by := docAttribution(s.ModifiedBy, s.ModifiedAt); by != "" {
+		meta = append(meta, [2]string{docText("Geändert"), by})
+	}
+	if extractedAt != "" {
+		meta = append(meta, [2]string{docText("Extrahiert"), extractedAt})
+	}
+
+	var b bytes.Buffer
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"" + docLang + "\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n<style>%s</style>\n</head>\n<body>\n", esc(title), scriptHTMLCSS)
+	fmt.Fprintf(&b, "<h1>%s</h1>\n<table class=\"meta\">\n", esc(title))
+	for _, row := range meta {
+		fmt.Fprintf(&b, "<tr><th>%s</th><td>%s</td></tr>\n", esc(row[0]), esc(row[1]))
+	}
+	b.WriteString("</table>\n")
+	b.Write(code.Bytes())
+	fmt.Fprintf(&b, "<footer>%s</footer>\n</body>\n</html>\n",
+		esc(docf("Erstellt mit ninox-tui am %s", docTime(time.Now()))))
+	return b.Bytes(), nil
+}
+
+// startExportScriptHTML fragt nach dem Dateinamen für das geöffnete Script
+func (m Model) startExportScriptHTML() (tea.Model, tea.Cmd) {
+	if m.codeScript == nil {
+		return m, nil
+	}
+	s := m.codeScript
+	m.scriptHTMLExporting = true
+	m.sqlExportInput.SetValue(exportFileName(firstNonEmpty(s.ElementName, s.TableName, s.DatabaseName)+"_"+s.CodeType, ".html"))
+	m.sqlExportInput.CursorEnd()
+	m.sqlExportInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleScriptHTMLExportInput verarbeitet die Eingabe des Dateinamens
+func (m Model) handleScriptHTMLExportInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch {
+	case key.Matches(msg, keys.Back):
+		m.scriptHTMLExporting = false
+		m.sqlExportInput.Blur()
+		return m, nil
+	case key.Matches(msg, keys.Enter):
+		m.scriptHTMLExporting = false
+		m.sqlExportInput.Blur()
+		path := strings.TrimSpace(m.sqlExportInput.Value())
+		if path == "" {
+			return m, nil
+		}
+		extractedAt, _ := m.db.GetExtractionDate(m.codeScript.DatabaseID)
+		data, err := ScriptHTML(*m.codeScript, extractedAt)
+		if err == nil {
+			err = os.WriteFile(path, data, 0o644)
+		}
+		if err != nil {
+			m.status = fmt.Sprintf("❌ Export fehlgeschlagen: %v", err)
+		} else {
+			m.status = fmt.Sprintf("✓ Script nach %s exportiert", path)
+		}
+		return m, nil
+	default:
+		m.sqlExportInput, cmd = m.sqlExportInput.Update(msg)
+		return m, cmd
+	}
+}
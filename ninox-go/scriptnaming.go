@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// =============================================================================
+// Namensvorlage für exportierte Scripts: eine Go-Vorlage über die Script-Felder,
+// z.B. "{{.Database}}/{{.Type}}/{{or .Element .Table \"_global\"}}", damit der
+// Export bestehenden Repository-Konventionen folgt
+// =============================================================================
+
+// scriptNaming ist die aktive Namensvorlage (nil = Datenbank/Tabelle/Element_Typ.ninox)
+var scriptNaming *template.Template
+
+// scriptNameData sind die Felder der Vorlage; Namen sind bereits dateisicher,
+// ein "/" in der Vorlage trennt Verzeichnisse
+type scriptNameData struct {
+	ID       int
+	Database string
+	Table    string // leer bei globalem Code
+	Element  string // leer bei Tabellen- und globalem Code
+	Type     string
+	Category string
+	Group    string
+	Hash     string // die ersten 8 Zeichen
+}
+
+// scriptNameFuncs sind die Hilfsfunktionen der Vorlage, z.B. {{.Element | lower}}
+// oder {{.Element | replace " " "_"}}
+var scriptNameFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"replace": func(old, new, s string) string {
+		return strings.ReplaceAll(s, old, new)
+	},
+}
+
+// parseScriptNaming prüft eine Namensvorlage; leer heißt Standardpfade
+func parseScriptNaming(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("name").Funcs(scriptNameFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("Namensvorlage: %w", err)
+	}
+	return tmpl, nil
+}
+
+// setScriptNaming setzt die Namensvorlage aus Konfiguration bzw. --name-template
+func setScriptNaming(text string) error {
+	tmpl, err := parseScriptNaming(text)
+	if err != nil {
+		return err
+	}
+	scriptNaming = tmpl
+	return nil
+}
+
+// applyNameTemplate übernimmt --name-template anstelle der Konfiguration
+func applyNameTemplate(opts cliOptions) {
+	if opts.nameTemplate == "" {
+		return
+	}
+	if err := setScriptNaming(opts.nameTemplate); err != nil {
+		fail(exitUsage, "%v", err)
+	}
+}
+
+// scriptExportPath liefert den relativen Exportpfad eines Scripts nach der
+// Namensvorlage. Leere Verzeichnisebenen entfallen, fehlt die Endung .ninox (bzw.
+// .nx), wird sie ergänzt.
+func scriptExportPath(s Script) (string, error) {
+	if scriptNaming == nil {
+		return scriptFilePath(s), nil
+	}
+	safe := func(name string) string {
+		if strings.TrimSpace(name) == "" {
+			return ""
+		}
+		return safeFileName(name)
+	}
+	data := scriptNameData{
+		ID: s.ID, Database: safe(s.DatabaseName), Table: safe(s.TableName), Element: safe(s.ElementName),
+		Type: safe(s.CodeType), Category: safe(s.CodeCategory), Group: safe(s.Group()),
+		Hash: s.Hash[:min(8, len(s.Hash))],
+	}
+
+	var b strings.Builder
+	if err := scriptNaming.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("Namensvorlage: %w", err)
+	}
+	var parts []string
+	for _, part := range strings.Split(b.String(), "/") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, safeFileName(part))
+		}
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("Namensvorlage ergibt keinen Pfad für %s", scriptLabel(s))
+	}
+	if !isScriptFile(parts[len(parts)-1]) {
+		parts[len(parts)-1] += scriptFileExt
+	}
+	return filepath.Join(parts...), nil
+}
+
+// planScriptPaths liefert die Exportpfade in der Reihenfolge der Scripts.
+// Gleichnamige Scripts erhalten die ID als Zusatz.
+func planScriptPaths(scripts []Script) ([]string, error) {
+	paths := make([]string, len(scripts))
+	used := make(map[string]bool)
+	for i, s := range scripts {
+		rel, err := scriptExportPath(s)
+		if err != nil {
+			return nil, err
+		}
+		if used[rel] {
+			rel = strings.TrimSuffix(rel, scriptFileExt) + fmt.Sprintf("-%d", s.ID) + scriptFileExt
+		}
+		used[rel] = true
+		paths[i] = rel
+	}
+	return paths, nil
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Sitzung automatisch sichern: Ansicht, Auswahl, Filter-Stapel und
+// angefangene Eingaben überstehen einen Verbindungsabbruch (SSH, SIGHUP).
+// Nach regulärem Beenden wird die Sitzung verworfen.
+// =============================================================================
+
+const (
+	sessionFile     = "session.json"
+	sessionInterval = 30 * time.Second
+)
+
+// sessionTickMsg löst das regelmäßige Sichern aus
+type sessionTickMsg struct{}
+
+// sessionHangupMsg meldet den Verlust des Terminals
+type sessionHangupMsg struct{}
+
+// savedSession ist der gesicherte Zustand einer Extraktion
+type savedSession struct {
+	Saved        time.Time `json:"saved"`
+	Mode         viewMode  `json:"mode"`
+	PrevMode     viewMode  `json:"prev_mode"`
+	Database     string    `json:"database,omitempty"` // Datenbank-ID
+	Table        string    `json:"table,omitempty"`    // Tabellen-ID
+	Script       int       `json:"script,omitempty"`   // geöffnetes Script
+	CodeOffset   int       `json:"code_offset,omitempty"`
+	Selected     int       `json:"selected,omitempty"`      // Auswahl der Gesamtansicht
+	FilterSearch string    `json:"filter_search,omitempty"` // Suche als Ausgangsmenge der Filter
+	Filters      []string  `json:"filters,omitempty"`
+	Search       string    `json:"search,omitempty"`
+	Filtering    string    `json:"filtering,omitempty"` // angefangene Filtereingabe
+	Searching    string    `json:"searching,omitempty"` // angefangene Sucheingabe
+}
+
+// sessionModes lassen sich aus der Extraktion wiederherstellen; andere
+// Ansichten (SQL-Konsole, Diff …) fallen auf die vorherige zurück
+var sessionModes = map[viewMode]bool{
+	viewTeams: true, viewDatabases: true, viewTables: true, viewFields: true, viewScripts: true,
+	viewCode: true, viewSearch: true, viewStats: true, viewAllScripts: true,
+}
+
+// sessionKey identifiziert die Extraktion (absoluter Pfad bzw. URL)
+func sessionKey(dbPath string) string {
+	if isFileDB(dbPath) {
+		if abs, err := filepath.Abs(dbPath); err == nil {
+			return abs
+		}
+	}
+	return redactArgs([]string{dbPath})
+}
+
+// loadSessions liest die gesicherten Sitzungen aller Extraktionen
+func loadSessions() map[string]savedSession {
+	sessions := make(map[string]savedSession)
+	loadState(sessionFile, &sessions)
+	return sessions
+}
+
+// sessionTick plant die nächste Sicherung
+func sessionTick() tea.Cmd {
+	return tea.Tick(sessionInterval, func(time.Time) tea.Msg { return sessionTickMsg{} })
+}
+
+// watchHangup leitet SIGHUP als Nachricht an die TUI weiter; ohne Handler
+// würde der Prozess sofort und ohne Sicherung beendet
+func watchHangup(p *tea.Program) (stop func()) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sig:
+			p.Send(sessionHangupMsg{})
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
+
+// snapshotSession beschreibt den aktuellen Zustand
+func (m Model) snapshotSession() savedSession {
+	s := savedSession{Mode: m.mode, PrevMode: m.prevMode}
+	if !sessionModes[s.Mode] {
+		s.Mode, s.PrevMode = m.prevMode, viewDatabases
+	}
+	if !sessionModes[s.Mode] {
+		s.Mode = viewDatabases
+	}
+	if m.currentDB != nil {
+		s.Database = m.currentDB.ID
+	}
+	if m.currentTable != nil {
+		s.Table = m.currentTable.TableID
+	}
+	if s.Mode == viewCode && m.codeScript != nil {
+		s.Script, s.CodeOffset = m.codeScript.ID, m.codeView.YOffset
+	}
+
+	// Ausgangsmengen aus Auswahlwerten o.ä. lassen sich nicht nachbilden, die
+	// Filter darauf würden nach dem Wiederherstellen etwas anderes zeigen
+	switch {
+	case m.filterBase == nil:
+		s.Filters = m.filterStack
+	case strings.HasPrefix(m.filterBaseLabel, "🔍 "):
+		s.FilterSearch = strings.TrimPrefix(m.filterBaseLabel, "🔍 ")
+		s.Filters = m.filterStack
+	}
+	if len(s.Filters) > 0 || s.FilterSearch != "" {
+		s.Selected = m.selectedAllScript
+	}
+	if s.Mode == viewSearch || s.PrevMode == viewSearch {
+		s.Search = m.searchInput.Value()
+	}
+	if m.filtering {
+		s.Filtering = m.filterInput.Value()
+	}
+	if m.searching {
+		s.Searching = m.searchInput.Value()
+	}
+	return s
+}
+
+// saveSession sichert den Zustand, sofern er sich seit der letzten Sicherung geändert hat
+func (m *Model) saveSession() {
+	if m.sessionKey == "" {
+		return
+	}
+	s := m.snapshotSession()
+	data, _ := json.Marshal(s)
+	if string(data) == m.sessionSaved {
+		return
+	}
+	s.Saved = time.Now()
+	sessions := loadSessions()
+	sessions[m.sessionKey] = s
+	if err := saveState(sessionFile, sessions); err != nil {
+		m.status = fmt.Sprintf("⚠ Sitzung nicht gesichert: %v", err)
+		return
+	}
+	m.sessionSaved = string(data)
+}
+
+// endSession verwirft die Sitzung nach regulärem Beenden
+func (m *Model) endSession() {
+	if m.sessionKey == "" {
+		return
+	}
+	sessions := loadSessions()
+	if _, ok := sessions[m.sessionKey]; ok {
+		delete(sessions, m.sessionKey)
+		saveState(sessionFile, sessions)
+	}
+}
+
+// handleSessionMsg sichert regelmäßig und beim Verlust des Terminals
+func (m Model) handleSessionMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.saveSession()
+	if _, ok := msg.(sessionHangupMsg); ok {
+		return m, tea.Quit
+	}
+	return m, sessionTick()
+}
+
+// EnableSession aktiviert das Sichern für die Extraktion. Mit restore wird eine
+// nicht regulär beendete Sitzung wiederhergestellt, sonst verworfen.
+func (m *Model) EnableSession(dbPath string, restore bool) {
+	m.sessionKey = sessionKey(dbPath)
+	s, ok := loadSessions()[m.sessionKey]
+	if !ok {
+		return
+	}
+	if !restore {
+		m.endSession()
+		return
+	}
+	m.restoreSession(s)
+	m.status = fmt.Sprintf("↺ Sitzung vom %s wiederhergestellt (--no-restore verwirft sie)", s.Saved.Format("02.01. 15:04"))
+}
+
+// restoreSession stellt eine gesicherte Sitzung wieder her; verschwundene
+// Datenbanken, Tabellen oder Scripts werden übergangen
+func (m *Model) restoreSession(s savedSession) {
+	// Datenbank und Tabelle wie beim Öffnen mit Enter
+	for i, d := range m.databases {
+		if d.ID != s.Database {
+			continue
+		}
+		m.mode, m.selectedDB = viewDatabases, i
+		m.enter()
+		for j, t := range m.tables {
+			if s.Table != "" && t.TableID == s.Table {
+				m.selectedTable = j
+				m.enter()
+				break
+			}
+		}
+		break
+	}
+
+	if s.FilterSearch != "" {
+		if base, err := m.db.SearchScripts(s.FilterSearch, 50); err == nil {
+			m.filterBase = base
+			m.filterBaseLabel = "🔍 " + s.FilterSearch
+		}
+	}
+	if len(s.Filters) > 0 || m.filterBase != nil {
+		m.filterStack = s.Filters
+		m.applyFilter()
+		if s.Selected < len(m.filteredScripts) {
+			m.selectedAllScript = s.Selected
+		}
+	}
+	if s.Search != "" {
+		m.searchInput.SetValue(s.Search)
+		model, _ := m.runSearch()
+		*m = model.(Model)
+	}
+
+	m.mode, m.prevMode = s.Mode, s.PrevMode
+	if s.Mode == viewCode {
+		m.mode = s.PrevMode
+		for _, script := range m.allScripts {
+			if script.ID == s.Script {
+				script := script
+				m.codeScript = &script
+				m.codeView.SetContent(highlightCode(script.Code))
+				m.codeView.SetYOffset(s.CodeOffset)
+				m.mode = viewCode
+				break
+			}
+		}
+	}
+	if (m.mode == viewTables || m.mode == viewFields || m.mode == viewScripts) && m.currentDB == nil {
+		m.mode = viewDatabases
+	}
+
+	if s.Filtering != "" && m.mode == viewAllScripts {
+		m.filtering = true
+		m.filterInput.SetValue(s.Filtering)
+		m.filterInput.Focus()
+	}
+	if s.Searching != "" {
+		m.searching = true
+		m.searchInput.SetValue(s.Searching)
+		m.searchInput.Focus()
+	}
+	m.sessionSaved = ""
+}
+
+// enter öffnet den ausgewählten Eintrag wie mit Enter
+func (m *Model) enter() {
+	model, _ := m.handleEnter()
+	*m = model.(Model)
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// =============================================================================
+// Vergleich zweier Schema-Stände (z.B. zweier Extraktionen)
+// =============================================================================
+
+// changeKind beschreibt die Art einer Änderung
+type changeKind byte
+
+const (
+	changeAdded   changeKind = '+'
+	changeRemoved changeKind = '-'
+	changeChanged changeKind = '~'
+)
+
+// Objektarten in der Reihenfolge der Ausgabe
+const (
+	objectTable  = "Tabelle"
+	objectField  = "Feld"
+	objectScript = "Script"
+)
+
+var objectOrder = map[string]int{objectTable: 0, objectField: 1, objectScript: 2}
+
+// SchemaChange ist eine einzelne Änderung zwischen zwei Ständen
+type SchemaChange struct {
+	Kind     changeKind
+	Object   string // objectTable, objectField, objectScript
+	Database string
+	Table    string
+	Name     string // Feld- bzw. Element- und Script-Bezeichnung
+	Category string // Script-Kategorie (TRIGGER, BUTTON, ...)
+	Detail   string // z.B. "Typ string → number"
+	Lines    int    // Scripts: Zeilen (geändert bzw. hinzugefügt/entfernt)
+
+	ModifiedBy, ModifiedAt string // Scripts: letzter Bearbeiter im neuen Stand (falls extrahiert)
+
+	OldCode, NewCode string // nur bei Scripts
+}
+
+// Path liefert den Pfad des geänderten Objekts, z.B. "CRM/Kunden/Email"
+func (c SchemaChange) Path() string {
+	parts := []string{c.Database}
+	if c.Table != "" {
+		parts = append(parts, c.Table)
+	}
+	if c.Name != "" && c.Object != objectTable {
+		parts = append(parts, c.Name)
+	}
+	return strings.Join(parts, "/")
+}
+
+// Attribution liefert "von BENUTZER am DATUM" bzw. "" ohne Bearbeiter
+func (c SchemaChange) Attribution() string {
+	return docAttribution(c.ModifiedBy, c.ModifiedAt)
+}
+
+// attribution formuliert den letzten Bearbeiter, z.B. "von anna@example.com am 2026-10-14 16:02"
+func attribution(by, at string) string {
+	if by == "" {
+		return ""
+	}
+	if at == "" {
+		return "von " + by
+	}
+	return fmt.Sprintf("von %s am %s", by, strings.Replace(at[:min(16, len(at))], "T", " ", 1))
+}
+
+// SnapshotDiff enthält alle Änderungen von Old nach New
+type SnapshotDiff struct {
+	Old, New string // Bezeichnung der Stände (Dateipfade)
+	Changes  []SchemaChange
+}
+
+// snapshot ist der für den Vergleich geladene Inhalt einer Extraktion
+type snapshot struct {
+	tables  map[string]Table  // "DB/Tabelle"
+	fields  map[string]Field  // "DB/Tabelle/Feld"
+	scripts map[string]Script // "DB/Tabelle/Element:Typ" (Duplikate mit "#n")
+	dbNames map[string]string // Datenbank-ID → Name
+	stored  bool              // aus dem Verlauf (ohne Beschriftungen und Bearbeiter)
+}
+
+// loadSnapshot lädt Tabellen, Felder und Scripts einer Extraktion
+func loadSnapshot(db *NinoxDB) (*snapshot, error) {
+	s := &snapshot{
+		tables:  make(map[string]Table),
+		fields:  make(map[string]Field),
+		scripts: make(map[string]Script),
+		dbNames: make(map[string]string),
+	}
+
+	databases, err := db.GetDatabases()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range databases {
+		s.dbNames[d.ID] = d.Name
+		tables, err := db.GetTables(d.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tables {
+			s.tables[d.Name+"/"+t.Name] = t
+			fields, err := db.GetFields(d.ID, t.TableID)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range fields {
+				s.fields[d.Name+"/"+t.Name+"/"+f.Name] = f
+			}
+		}
+	}
+
+	scripts, err := db.GetAllScripts()
+	if err != nil {
+		return nil, err
+	}
+	for _, sc := range scripts {
+		s.addScript(sc)
+	}
+	return s, nil
+}
+
+// addScript nimmt ein Script auf; gleich bezeichnete erhalten "#n"
+func (s *snapshot) addScript(sc Script) {
+	key := scriptLabel(sc)
+	for n := 2; ; n++ {
+		if _, exists := s.scripts[key]; !exists {
+			break
+		}
+		key = fmt.Sprintf("%s#%d", scriptLabel(sc), n)
+	}
+	s.scripts[key] = sc
+}
+
+// DiffSnapshots vergleicht zwei Extraktionen
+func DiffSnapshots(oldDB, newDB *NinoxDB) (*SnapshotDiff, error) {
+	a, err := loadSnapshot(oldDB)
+	if err != nil {
+		return nil, fmt.Errorf("alter Stand: %w", err)
+	}
+	b, err := loadSnapshot(newDB)
+	if err != nil {
+		return nil, fmt.Errorf("neuer Stand: %w", err)
+	}
+
+	return diffSnapshotContent(a, b, oldDB.path, newDB.path), nil
+}
+
+// diffSnapshotContent vergleicht zwei geladene Stände
+func diffSnapshotContent(a, b *snapshot, oldLabel, newLabel string) *SnapshotDiff {
+	diff := &SnapshotDiff{Old: oldLabel, New: newLabel}
+	// Der Verlauf speichert keine Beschriftungen; gegen eine Datei verglichen wären sonst alle geändert
+	ignoreCaption := a.stored != b.stored
+	add := func(c SchemaChange) { diff.Changes = append(diff.Changes, c) }
+
+	// Tabellen
+	for key, t := range b.tables {
+		if _, ok := a.tables[key]; !ok {
+			add(SchemaChange{Kind: changeAdded, Object: objectTable, Database: b.dbNames[t.DatabaseID], Table: t.Name})
+		}
+	}
+	for key, t := range a.tables {
+		if _, ok := b.tables[key]; !ok {
+			add(SchemaChange{Kind: changeRemoved, Object: objectTable, Database: a.dbNames[t.DatabaseID], Table: t.Name})
+		}
+	}
+
+	// Felder
+	tableName := func(s *snapshot, f Field) string {
+		for _, t := range s.tables {
+			if t.DatabaseID == f.DatabaseID && t.TableID == f.TableID {
+				return t.Name
+			}
+		}
+		return f.TableID
+	}
+	for key, f := range b.fields {
+		change := SchemaChange{Object: objectField, Database: b.dbNames[f.DatabaseID], Table: tableName(b, f), Name: f.Name}
+		old, ok := a.fields[key]
+		if ignoreCaption {
+			old.Caption, f.Caption = "", ""
+		}
+		switch {
+		case !ok:
+			change.Kind = changeAdded
+			change.Detail = f.BaseType
+			add(change)
+		case fieldChanges(old, f) != "":
+			change.Kind = changeChanged
+			change.Detail = fieldChanges(old, f)
+			add(change)
+		}
+	}
+	for key, f := range a.fields {
+		if _, ok := b.fields[key]; !ok {
+			add(SchemaChange{Kind: changeRemoved, Object: objectField, Database: a.dbNames[f.DatabaseID],
+				Table: tableName(a, f), Name: f.Name, Detail: f.BaseType})
+		}
+	}
+
+	// Scripts
+	scriptName := func(s Script) string {
+		if s.ElementName != "" {
+			return s.ElementName + ":" + s.CodeType
+		}
+		return s.CodeType
+	}
+	for key, s := range b.scripts {
+		change := SchemaChange{Object: objectScript, Database: s.DatabaseName, Table: s.TableName, Name: scriptName(s),
+			Category: s.CodeCategory, NewCode: s.Code, ModifiedBy: s.ModifiedBy, ModifiedAt: s.ModifiedAt}
+		old, ok := a.scripts[key]
+		switch {
+		case !ok:
+			change.Kind = changeAdded
+			change.Lines = s.LineCount
+			change.Detail = docf("%d Zeilen", s.LineCount)
+			add(change)
+		case old.Hash != s.Hash:
+			change.Kind = changeChanged
+			change.OldCode = old.Code
+			change.Lines = changedLines(old.Code, s.Code)
+			change.Detail = docf("%d Zeilen geändert", change.Lines)
+			add(change)
+		}
+	}
+	for key, s := range a.scripts {
+		if _, ok := b.scripts[key]; !ok {
+			add(SchemaChange{Kind: changeRemoved, Object: objectScript, Database: s.DatabaseName, Table: s.TableName,
+				Name: scriptName(s), Category: s.CodeCategory, OldCode: s.Code, Lines: s.LineCount, Detail: docf("%d Zeilen", s.LineCount)})
+		}
+	}
+
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		x, y := diff.Changes[i], diff.Changes[j]
+		if x.Database != y.Database {
+			return x.Database < y.Database
+		}
+		if x.Table != y.Table {
+			return x.Table < y.Table
+		}
+		if x.Object != y.Object {
+			return objectOrder[x.Object] < objectOrder[y.Object]
+		}
+		return x.Name < y.Name
+	})
+	return diff
+}
+
+// fieldChanges beschreibt die Unterschiede zweier Felddefinitionen ("" = gleich)
+func fieldChanges(a, b Field) string {
+	var changes []string
+	if a.BaseType != b.BaseType {
+		changes = append(changes, docf("Typ %s → %s", a.BaseType, b.BaseType))
+	}
+	if a.RefTableName != b.RefTableName {
+		changes = append(changes, docf("Verweis %s → %s", orDash(a.RefTableName), orDash(b.RefTableName)))
+	}
+	if a.HasFormula != b.HasFormula {
+		if b.HasFormula {
+			changes = append(changes, docText("Formel hinzugefügt"))
+		} else {
+			changes = append(changes, docText("Formel entfernt"))
+		}
+	}
+	if a.Caption != b.Caption {
+		changes = append(changes, docf("Beschriftung %q → %q", a.Caption, b.Caption))
+	}
+	return strings.Join(changes, ", ")
+}
+
+// changedLines zählt die hinzugefügten und entfernten Zeilen zwischen zwei Fassungen
+func changedLines(a, b string) int {
+	n := 0
+	for _, op := range diffLines(splitLines(a), splitLines(b)) {
+		if op.kind != ' ' {
+			n++
+		}
+	}
+	return n
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "–"
+	}
+	return s
+}
+
+// writeSnapshotDiffText gibt die Änderungen als Liste mit Unified Diffs aus
+func writeSnapshotDiffText(diff *SnapshotDiff, context int, noColor bool) {
+	for _, c := range diff.Changes {
+		line := fmt.Sprintf("%c %-8s %s", c.Kind, docText(c.Object), c.Path())
+		if c.Detail != "" {
+			line += " (" + c.Detail + ")"
+		}
+		if by := c.Attribution(); by != "" {
+			line += " " + by
+		}
+		fmt.Println(line)
+		if c.Kind == changeChanged && c.Object == objectScript {
+			printDiff(UnifiedDiff("a/"+c.Path(), "b/"+c.Path(), c.OldCode, c.NewCode, context), noColor)
+		}
+	}
+}
+
+// snapshotOutputs ordnet --output den Ausgabeformaten zu
+var snapshotOutputs = map[string]bool{"text": true, "html": true}
+
+// cmdDiffSnapshot vergleicht zwei Extraktionen (alte und neue Datei)
+func cmdDiffSnapshot(args []string) int {
+	opts := parseCLIOptions(args)
+	applyDocLang(opts.lang)
+	if len(opts.positional) != 2 {
+		fail(exitUsage, "Verwendung: ninox-tui diff-snapshot ALT.db NEU.db [--output text|html] [-U N] [--no-color] [--lang de|en] [--webhook URL [--webhook-format json|slack|teams]]")
+	}
+	if !snapshotOutputs[opts.output] {
+		fail(exitUsage, "Unbekanntes Ausgabeformat: %s (erlaubt: text, html)", opts.output)
+	}
+
+	// Webhook: Flag > Umgebung (NINOX_TUI_WEBHOOK) > Konfiguration
+	webhook, webhookFormat := opts.webhook, opts.webhookFormat
+	if cfg, err := LoadConfig(activeConfigPath()); err == nil {
+		cfg.ApplyEnv()
+		webhook = firstNonEmpty(webhook, cfg.Webhook)
+		webhookFormat = firstNonEmpty(webhookFormat, cfg.WebhookFormat)
+	}
+	webhookFormat = firstNonEmpty(webhookFormat, "json")
+	if notifyFormats[webhookFormat] == nil {
+		fail(exitUsage, "Unbekanntes Webhook-Format: %s (erlaubt: json, slack, teams)", webhookFormat)
+	}
+
+	oldDB := openCLIDB(opts.positional[0])
+	defer oldDB.Close()
+	newDB := openCLIDB(opts.positional[1])
+	defer newDB.Close()
+
+	diff, err := DiffSnapshots(oldDB, newDB)
+	if err != nil {
+		fail(exitDBError, "%v", err)
+	}
+
+	switch opts.output {
+	case "html":
+		// Der Bericht wird auch ohne Änderungen erzeugt (Nachweis für Tickets)
+		if _, err := os.Stdout.Write(SnapshotDiffHTML(diff)); err != nil {
+			fail(exitFailure, "%v", err)
+		}
+	default:
+		writeSnapshotDiffText(diff, opts.context, opts.noColor)
+	}
+
+	if len(diff.Changes) == 0 {
+		return exitNoResults
+	}
+	if webhook != "" {
+		if err := notifyWebhook(webhook, webhookFormat, diff); err != nil {
+			fail(exitFailure, "Benachrichtigung fehlgeschlagen: %v", err)
+		}
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "Benachrichtigung gesendet (%s)\n", webhookFormat)
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Sortierung der Listen (pro Ansicht, sitzungsübergreifend gespeichert)
+// =============================================================================
+
+// sortPref ist die gewählte Sortierung einer Ansicht
+type sortPref struct {
+	Column int  `json:"column"`
+	Desc   bool `json:"desc"`
+}
+
+// sortKey ist eine sortierbare Spalte
+type sortKey[T any] struct {
+	name string
+	cmp  func(a, b T) int
+}
+
+const sortFile = "sort.json"
+
+func cmpText(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func cmpInt(a, b int) int {
+	return a - b
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+var databaseSortKeys = []sortKey[Database]{
+	{"Name", func(a, b Database) int { return cmpText(a.Name, b.Name) }},
+	{"Tabellen", func(a, b Database) int { return cmpInt(a.TableCount, b.TableCount) }},
+	{"Scripts", func(a, b Database) int { return cmpInt(a.CodeCount, b.CodeCount) }},
+	{"Felder", func(a, b Database) int { return cmpInt(a.FieldCount, b.FieldCount) }},
+	{"Verknüpfungen", func(a, b Database) int { return cmpInt(a.RelationCount, b.RelationCount) }},
+}
+
+var tableSortKeys = []sortKey[Table]{
+	{"Name", func(a, b Table) int { return cmpText(a.Name, b.Name) }},
+	{"Felder", func(a, b Table) int { return cmpInt(a.FieldCount, b.FieldCount) }},
+	{"Scripts", func(a, b Table) int { return cmpInt(a.ScriptCount, b.ScriptCount) }},
+	{"Verknüpfungen", func(a, b Table) int { return cmpInt(a.RelationCount, b.RelationCount) }},
+	{"Datensätze", func(a, b Table) int { return cmpInt(a.RecordCount, b.RecordCount) }},
+	{"Logikdichte", func(a, b Table) int { return cmpFloat(a.Density(), b.Density()) }},
+}
+
+var fieldSortKeys = []sortKey[Field]{
+	{"Name", func(a, b Field) int { return cmpText(a.Name, b.Name) }},
+	{"ID", func(a, b Field) int { return cmpText(a.FieldID, b.FieldID) }},
+	{"Typ", func(a, b Field) int { return cmpText(a.BaseType, b.BaseType) }},
+}
+
+var scriptSortKeys = []sortKey[Script]{
+	{"Datenbank", func(a, b Script) int { return cmpText(a.DatabaseName, b.DatabaseName) }},
+	{"Tabelle", func(a, b Script) int { return cmpText(a.TableName, b.TableName) }},
+	{"Element", func(a, b Script) int { return cmpText(a.ElementName, b.ElementName) }},
+	{"Typ", func(a, b Script) int { return cmpText(a.CodeType, b.CodeType) }},
+	{"Zeilen", func(a, b Script) int { return cmpInt(a.LineCount, b.LineCount) }},
+	{"Änderung", func(a, b Script) int { return cmpInt(a.RecencyRank(), b.RecencyRank()) }},
+}
+
+// sortViewNames sind die Schlüssel der sortierbaren Ansichten in sort.json
+var sortViewNames = map[viewMode]string{
+	viewDatabases:  "databases",
+	viewTables:     "tables",
+	viewFields:     "fields",
+	viewScripts:    "scripts",
+	viewAllScripts: "allscripts",
+	viewSearch:     "search",
+}
+
+// sortItems sortiert stabil; ohne gespeicherte Wahl bleibt die Reihenfolge der DB
+func sortItems[T any](items []T, keys []sortKey[T], pref sortPref, ok bool) {
+	if !ok || pref.Column < 0 || pref.Column >= len(keys) {
+		return
+	}
+	cmp := keys[pref.Column].cmp
+	sort.SliceStable(items, func(i, j int) bool {
+		if pref.Desc {
+			return cmp(items[i], items[j]) > 0
+		}
+		return cmp(items[i], items[j]) < 0
+	})
+}
+
+// sortColumnCount liefert die Anzahl sortierbarer Spalten einer Ansicht
+func sortColumnCount(mode viewMode) int {
+	switch mode {
+	case viewDatabases:
+		return len(databaseSortKeys)
+	case viewTables:
+		return len(tableSortKeys)
+	case viewFields:
+		return len(fieldSortKeys)
+	case viewScripts, viewAllScripts, viewSearch:
+		return len(scriptSortKeys)
+	}
+	return 0
+}
+
+// sortColumnName liefert den Namen der sortierten Spalte einer Ansicht
+func sortColumnName(mode viewMode, column int) string {
+	var names []string
+	switch mode {
+	case viewDatabases:
+		for _, k := range databaseSortKeys {
+			names = append(names, k.name)
+		}
+	case viewTables:
+		for _, k := range tableSortKeys {
+			names = append(names, k.name)
+		}
+	case viewFields:
+		for _, k := range fieldSortKeys {
+			names = append(names, k.name)
+		}
+	default:
+		for _, k := range scriptSortKeys {
+			names = append(names, k.name)
+		}
+	}
+	if column < 0 || column >= len(names) {
+		return ""
+	}
+	return names[column]
+}
+
+// applySort sortiert die Daten einer Ansicht gemäß der gespeicherten Wahl
+func (m *Model) applySort(mode viewMode) {
+	pref, ok := m.sortPrefs[sortViewNames[mode]]
+	switch mode {
+	case viewDatabases:
+		// Zeiger zeigen nach dem Umsortieren auf andere Einträge: IDs vorher merken
+		currentID, compareID := databaseID(m.currentDB), databaseID(m.compareLeft)
+		sortItems(m.databases, databaseSortKeys, pref, ok)
+		m.rebindDatabases(currentID, compareID)
+	case viewTables:
+		sortItems(m.tables, tableSortKeys, pref, ok)
+	case viewFields:
+		sortItems(m.fields, fieldSortKeys, pref, ok)
+	case viewScripts:
+		sortItems(m.scripts, scriptSortKeys, pref, ok)
+	case viewAllScripts:
+		sortItems(m.filteredScripts, scriptSortKeys, pref, ok)
+	case viewSearch:
+		sortItems(m.searchResults, scriptSortKeys, pref, ok)
+	}
+}
+
+// databaseID liefert die ID einer Datenbank oder "" für nil
+func databaseID(db *Database) string {
+	if db == nil {
+		return ""
+	}
+	return db.ID
+}
+
+// rebindDatabases richtet Zeiger auf Datenbanken nach dem Umsortieren neu aus
+func (m *Model) rebindDatabases(currentID, compareID string) {
+	find := func(id string) *Database {
+		for i := range m.databases {
+			if id != "" && m.databases[i].ID == id {
+				return &m.databases[i]
+			}
+		}
+		return nil
+	}
+
+	for id, state := range m.tabs {
+		state.currentDB = find(id)
+		m.tabs[id] = state
+	}
+	m.currentDB = find(currentID)
+	m.compareLeft = find(compareID)
+}
+
+// cycleSort wählt die nächste Sortierspalte (reverse: Richtung umkehren) und speichert sie
+func (m Model) cycleSort(reverse bool) (tea.Model, tea.Cmd) {
+	name, ok := sortViewNames[m.mode]
+	if !ok {
+		return m, nil
+	}
+	pref, set := m.sortPrefs[name]
+	switch {
+	case reverse:
+		pref.Desc = !pref.Desc
+	case set:
+		pref.Column = (pref.Column + 1) % sortColumnCount(m.mode)
+		pref.Desc = false
+	}
+
+	if m.sortPrefs == nil {
+		m.sortPrefs = make(map[string]sortPref)
+	}
+	m.sortPrefs[name] = pref
+	saveState(sortFile, m.sortPrefs)
+
+	m.applySort(m.mode)
+	m.resetSelection()
+	m.status = "Sortiert nach " + m.sortLabel()
+	return m, nil
+}
+
+// sortLabel beschreibt die Sortierung der aktuellen Ansicht, z.B. "Zeilen ↓"
+func (m Model) sortLabel() string {
+	pref, ok := m.sortPrefs[sortViewNames[m.mode]]
+	if !ok {
+		return ""
+	}
+	arrow := "↑"
+	if pref.Desc {
+		arrow = "↓"
+	}
+	return fmt.Sprintf("%s %s", sortColumnName(m.mode, pref.Column), arrow)
+}
+
+// resetSelection setzt die Auswahl der aktuellen Ansicht auf den Anfang
+func (m *Model) resetSelection() {
+	switch m.mode {
+	case viewDatabases:
+		m.selectedDB = 0
+	case viewTables:
+		m.selectedTable = 0
+	case viewFields:
+		m.selectedField = 0
+	case viewScripts:
+		m.selectedScript = 0
+	case viewAllScripts:
+		m.selectedAllScript = 0
+		m.scrollOffset = 0
+	case viewSearch:
+		m.selectedSearch = 0
+	}
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// SQL-Konsole
+// =============================================================================
+
+// sqlRowLimit begrenzt die Anzahl geladener Ergebniszeilen
+const sqlRowLimit = 1000
+
+// sqlMaxColWidth begrenzt die Breite einer Ergebnisspalte
+const sqlMaxColWidth = 40
+
+// openSQLConsole wechselt in die SQL-Konsole und fokussiert die Eingabe
+func (m Model) openSQLConsole() (tea.Model, tea.Cmd) {
+	if m.mode != viewSQL {
+		m.prevMode = m.mode
+		m.mode = viewSQL
+	}
+	m.sqlEditing = true
+	m.sqlTitle = ""
+	m.sqlInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleSQLInput verarbeitet Tasten während der Eingabe einer Abfrage
+func (m Model) handleSQLInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch {
+	case key.Matches(msg, keys.Back):
+		m.sqlEditing = false
+		m.sqlInput.Blur()
+		if m.sqlResult == nil && m.sqlErr == nil {
+			m.mode = m.prevMode
+		}
+		return m, nil
+	case key.Matches(msg, keys.Enter):
+		m.sqlEditing = false
+		m.sqlInput.Blur()
+		m.runSQL(m.sqlInput.Value())
+		return m, nil
+	default:
+		m.sqlInput, cmd = m.sqlInput.Update(msg)
+		return m, cmd
+	}
+}
+
+// startSaveQuery fragt nach einem Namen für die aktuelle Abfrage
+func (m Model) startSaveQuery() (tea.Model, tea.Cmd) {
+	if m.mode != viewSQL || m.sqlInput.Value() == "" {
+		return m, nil
+	}
+	m.sqlNaming = true
+	m.sqlNameInput.SetValue(m.sqlTitle)
+	m.sqlNameInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleSQLNameInput verarbeitet die Eingabe des Abfrage-Namens
+func (m Model) handleSQLNameInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch {
+	case key.Matches(msg, keys.Back):
+		m.sqlNaming = false
+		m.sqlNameInput.Blur()
+		return m, nil
+	case key.Matches(msg, keys.Enter):
+		m.sqlNaming = false
+		m.sqlNameInput.Blur()
+		name := strings.TrimSpace(m.sqlNameInput.Value())
+		if name == "" {
+			return m, nil
+		}
+		err := m.config.SaveQuery(SavedQuery{Name: name, SQL: m.sqlInput.Value()})
+		if err != nil {
+			m.sqlStatus = fmt.Sprintf("❌ Speichern fehlgeschlagen: %v", err)
+		} else {
+			m.sqlTitle = name
+			m.sqlStatus = fmt.Sprintf("✓ Abfrage \"%s\" gespeichert", name)
+		}
+		return m, nil
+	default:
+		m.sqlNameInput, cmd = m.sqlNameInput.Update(msg)
+		return m, cmd
+	}
+}
+
+// startExportResult fragt nach dem Zieldateinamen für den Export
+func (m Model) startExportResult() (tea.Model, tea.Cmd) {
+	if m.mode != viewSQL || m.sqlResult == nil || len(m.sqlResult.Columns) == 0 {
+		return m, nil
+	}
+	m.sqlExporting = true
+	m.sqlExportInput.SetValue(exportFileName(m.sqlTitle, ".csv"))
+	m.sqlExportInput.CursorEnd()
+	m.sqlExportInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleSQLExportInput verarbeitet die Eingabe des Export-Dateinamens
+func (m Model) handleSQLExportInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch {
+	case key.Matches(msg, keys.Back):
+		m.sqlExporting = false
+		m.sqlExportInput.Blur()
+		return m, nil
+	case key.Matches(msg, keys.Enter):
+		m.sqlExporting = false
+		m.sqlExportInput.Blur()
+		path := strings.TrimSpace(m.sqlExportInput.Value())
+		if path == "" {
+			return m, nil
+		}
+		if err := ExportResult(path, m.sqlResult); err != nil {
+			m.sqlStatus = fmt.Sprintf("❌ Export fehlgeschlagen: %v", err)
+		} else {
+			m.sqlStatus = fmt.Sprintf("✓ %d Zeilen nach %s exportiert", len(m.sqlResult.Rows), path)
+		}
+		return m, nil
+	default:
+		m.sqlExportInput, cmd = m.sqlExportInput.Update(msg)
+		return m, cmd
+	}
+}
+
+// openSavedQuery führt eine gespeicherte Abfrage aus und zeigt ihr Ergebnis
+func (m Model) openSavedQuery() (tea.Model, tea.Cmd) {
+	if m.selectedQuery >= len(m.config.SavedQueries) {
+		return m, nil
+	}
+	q := m.config.SavedQueries[m.selectedQuery]
+	m.sqlInput.SetValue(q.SQL)
+	m.sqlTitle = q.Name
+	m.runSQL(q.SQL)
+	m.prevMode = viewQueries
+	m.mode = viewSQL
+	return m, nil
+}
+
+// runSQL führt die Abfrage aus und setzt die Ergebnisanzeige zurück
+func (m *Model) runSQL(query string) {
+	m.sqlResult, m.sqlErr = m.db.RunQuery(query, sqlRowLimit)
+	m.sqlStatus = ""
+	m.sqlSelected = 0
+	m.sqlOffset = 0
+	m.sqlColOffset = 0
+}
+
+// scrollSQLColumns verschiebt die sichtbaren Spalten; die erste Spalte bleibt fixiert
+func (m *Model) scrollSQLColumns(delta int) {
+	if m.sqlResult == nil || len(m.sqlResult.Columns) < 2 {
+		return
+	}
+	m.sqlColOffset = max(0, min(m.sqlColOffset+delta, len(m.sqlResult.Columns)-2))
+}
+
+// sqlVisibleRows liefert die Anzahl sichtbarer Ergebniszeilen
+func (m Model) sqlVisibleRows() int {
+	rows := m.height - 14
+	if rows < 3 {
+		rows = 3
+	}
+	return rows
+}
+
+// moveSQLSelection verschiebt die Auswahl in der Ergebnistabelle
+func (m *Model) moveSQLSelection(delta int) {
+	if m.sqlResult == nil || len(m.sqlResult.Rows) == 0 {
+		return
+	}
+	m.sqlSelected = max(0, min(m.sqlSelected+delta, len(m.sqlResult.Rows)-1))
+
+	visible := m.sqlVisibleRows()
+	if m.sqlSelected < m.sqlOffset {
+		m.sqlOffset = m.sqlSelected
+	}
+	if m.sqlSelected >= m.sqlOffset+visible {
+		m.sqlOffset = m.sqlSelected - visible + 1
+	}
+}
+
+// renderSQL rendert Eingabe und Ergebnistabelle der SQL-Konsole
+func (m Model) renderSQL() string {
+	var b strings.Builder
+
+	title := "🗄 SQL-Konsole (nur lesend)"
+	if m.sqlTitle != "" {
+		title = "🗄 " + m.sqlTitle
+	}
+	b.WriteString(titleStyle.Render(title) + "\n\n")
+	b.WriteString("  " + m.sqlInput.View() + "\n\n")
+
+	if m.sqlNaming {
+		b.WriteString("  " + m.sqlNameInput.View() + "\n\n")
+	} else if m.sqlExporting {
+		b.WriteString("  " + m.sqlExportInput.View() + "\n\n")
+	} else if m.sqlStatus != "" {
+		b.WriteString(mutedStyle.Render("  "+m.sqlStatus) + "\n\n")
+	}
+
+	switch {
+	case m.sqlErr != nil:
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  ❌ %v", m.sqlErr)) + "\n")
+	case m.sqlResult == nil:
+		b.WriteString(mutedStyle.Render("  Abfrage eingeben und mit Enter ausführen.") + "\n")
+		b.WriteString(mutedStyle.Render("  Tabellen: databases, tables, fields, relationships, scripts") + "\n")
+	case len(m.sqlResult.Columns) == 0:
+		b.WriteString(mutedStyle.Render("  Abfrage ohne Ergebnisspalten ausgeführt.") + "\n")
+	default:
+		b.WriteString(m.renderSQLTable())
+	}
+
+	return boxStyle.Width(m.width - 4).Render(b.String())
+}
+
+// renderSQLTable rendert das Abfrageergebnis als Tabelle
+func (m Model) renderSQLTable() string {
+	var b strings.Builder
+	res := m.sqlResult
+
+	widths := make([]int, len(res.Columns))
+	for i, col := range res.Columns {
+		widths[i] = min(len(col), sqlMaxColWidth)
+	}
+	for _, row := range res.Rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], min(len(sqlCell(cell)), sqlMaxColWidth))
+		}
+	}
+
+	// Erste Spalte fixieren, danach ab sqlColOffset so viele Spalten wie passen
+	visible := []int{0}
+	used := widths[0] + 4
+	for i := 1 + m.sqlColOffset; i < len(widths); i++ {
+		if used+widths[i]+3 > m.width-10 && len(visible) > 1 {
+			break
+		}
+		visible = append(visible, i)
+		used += widths[i] + 3
+	}
+	moreLeft := m.sqlColOffset > 0
+	moreRight := visible[len(visible)-1] < len(widths)-1
+
+	formatRow := func(cells []string) string {
+		parts := make([]string, len(visible))
+		for k, i := range visible {
+			parts[k] = fmt.Sprintf("%-*s", widths[i], truncate(sqlCell(cells[i]), widths[i]))
+		}
+		row := parts[0] + " ┃ "
+		if moreLeft {
+			row += "◂ "
+		}
+		row += strings.Join(parts[1:], " │ ")
+		if moreRight {
+			row += " ▸"
+		}
+		return row
+	}
+
+	b.WriteString(tableHeaderStyle.Render("  "+formatRow(res.Columns)) + "\n")
+
+	end := min(m.sqlOffset+m.sqlVisibleRows(), len(res.Rows))
+	for i := m.sqlOffset; i < end; i++ {
+		style := tableCellStyle
+		prefix := "  "
+		if i == m.sqlSelected {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		b.WriteString(style.Render(prefix+formatRow(res.Rows[i])) + "\n")
+	}
+
+	info := fmt.Sprintf("  %d Zeilen", len(res.Rows))
+	if len(res.Rows) > 0 {
+		info = fmt.Sprintf("  %d/%d Zeilen", m.sqlSelected+1, len(res.Rows))
+	}
+	if res.Truncated {
+		info += fmt.Sprintf(" (auf %d begrenzt)", sqlRowLimit)
+	}
+	if moreLeft || moreRight {
+		info += fmt.Sprintf(" • Spalten %d-%d/%d (←→ blättern)", visible[1]+1, visible[len(visible)-1]+1, len(widths))
+	}
+	b.WriteString("\n" + mutedStyle.Render(info))
+
+	return b.String()
+}
+
+// sqlCell bereitet einen Zellwert für die einzeilige Darstellung auf
+func sqlCell(s string) string {
+	s = strings.ReplaceAll(s, "\r", "")
+	s = strings.ReplaceAll(s, "\n", "⏎")
+	return strings.ReplaceAll(s, "\t", " ")
+}
+
+// renderQueries rendert das Menü der gespeicherten Abfragen
+func (m Model) renderQueries() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("📑 Gespeicherte Abfragen") + "\n\n")
+
+	if len(m.config.SavedQueries) == 0 {
+		b.WriteString(mutedStyle.Render("  Noch keine Abfragen gespeichert.") + "\n")
+		b.WriteString(mutedStyle.Render("  In der SQL-Konsole (:) mit w speichern oder in") + "\n")
+		b.WriteString(mutedStyle.Render("  "+m.config.path+" eintragen.") + "\n")
+		return boxStyle.Width(m.width - 4).Render(b.String())
+	}
+
+	header := fmt.Sprintf("  %-30s %s", "Name", "Beschreibung")
+	b.WriteString(tableHeaderStyle.Render(header) + "\n")
+
+	for i, q := range m.config.SavedQueries {
+		style := tableCellStyle
+		prefix := "  "
+		if i == m.selectedQuery {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+
+		desc := q.Description
+		if desc == "" {
+			desc = sqlCell(q.SQL)
+		}
+
+		row := fmt.Sprintf("%s%-28s %s", prefix, truncate(q.Name, 28), truncate(desc, max(10, m.width-42)))
+		b.WriteString(style.Render(row) + "\n")
+	}
+
+	return boxStyle.Width(m.width - 4).Render(b.String())
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// =============================================================================
+// Zustand (Verlauf, Lesezeichen, Sitzungen)
+// =============================================================================
+
+// stateDirOverride wird mit --state-dir gesetzt
+var stateDirOverride string
+
+// stateDir liefert das Verzeichnis für veränderlichen Programmzustand:
+// --state-dir > NINOX_TUI_STATE_DIR > Plattform-Standard.
+func stateDir() string {
+	if stateDirOverride != "" {
+		return stateDirOverride
+	}
+	if dir := os.Getenv("NINOX_TUI_STATE_DIR"); dir != "" {
+		return dir
+	}
+	return defaultStateDir()
+}
+
+// defaultStateDir folgt XDG unter Linux, sonst den Konventionen von macOS/Windows
+func defaultStateDir() string {
+	switch runtime.GOOS {
+	case "windows":
+		// %LocalAppData%\ninox-tui\state
+		if dir, err := os.UserCacheDir(); err == nil {
+			return filepath.Join(dir, "ninox-tui", "state")
+		}
+	case "darwin":
+		if dir, err := os.UserConfigDir(); err == nil {
+			return filepath.Join(dir, "ninox-tui", "state")
+		}
+	default:
+		if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+			return filepath.Join(dir, "ninox-tui")
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, ".local", "state", "ninox-tui")
+		}
+	}
+	return filepath.Join(os.TempDir(), "ninox-tui-state")
+}
+
+// loadState liest eine Zustandsdatei. Eine fehlende Datei lässt v unverändert.
+func loadState(name string, v interface{}) error {
+	path := filepath.Join(stateDir(), name)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("Ungültige Zustandsdatei %s: %w", path, err)
+	}
+	return nil
+}
+
+// saveState schreibt eine Zustandsdatei. Über eine temporäre Datei und
+// Umbenennen, damit ein Abbruch beim Schreiben die alte Datei nicht zerstört.
+func saveState(name string, v interface{}) error {
+	dir := stateDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, werr := f.Write(append(data, '\n'))
+	if serr := f.Sync(); werr == nil {
+		werr = serr
+	}
+	if cerr := f.Close(); werr == nil {
+		werr = cerr
+	}
+	if werr == nil {
+		werr = os.Chmod(f.Name(), 0o644)
+	}
+	if werr == nil {
+		werr = os.Rename(f.Name(), filepath.Join(dir, name))
+	}
+	if werr != nil {
+		os.Remove(f.Name())
+	}
+	return werr
+}
+
+// Suchverlauf
+const (
+	historyFile    = "history.json"
+	historyMaxSize = 50
+)
+
+// loadSearchHistory liefert die zuletzt verwendeten Suchbegriffe (neueste zuerst)
+func loadSearchHistory() []string {
+	var history []string
+	loadState(historyFile, &history)
+	return history
+}
+
+// addSearchHistory setzt einen Suchbegriff an den Anfang des Verlaufs
+func addSearchHistory(history []string, query string) []string {
+	if query == "" {
+		return history
+	}
+	result := []string{query}
+	for _, h := range history {
+		if h != query && len(result) < historyMaxSize {
+			result = append(result, h)
+		}
+	}
+	saveState(historyFile, result)
+	return result
+}
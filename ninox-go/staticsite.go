@@ -0,0 +1,325 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// =============================================================================
+// export-html: Schema als statische Website (Übersicht, Datenbanken, Tabellen
+// und hervorgehobene Scripts) für alle ohne Terminal
+// =============================================================================
+
+// siteCodeStyle ist der Chroma-Stil der Script-Seiten (helle Seiten)
+const siteCodeStyle = "github"
+
+const siteCSS = `
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 70em; padding: 0 1em; color: #222; }
+h1 { font-size: 1.5em; margin-bottom: 0.2em; } h2 { font-size: 1.15em; margin-top: 1.6em; }
+a { color: #0969da; text-decoration: none; } a:hover { text-decoration: underline; }
+.crumbs { color: #666; margin-bottom: 1em; } .meta { color: #666; margin-bottom: 1.2em; }
+table.list { border-collapse: collapse; width: 100%; }
+table.list th, table.list td { text-align: left; padding: 0.3em 0.8em 0.3em 0; border-bottom: 1px solid #eee; }
+table.list td.num, table.list th.num { text-align: right; }
+.chroma { padding: 0.6em; border: 1px solid #ddd; border-radius: 4px; overflow-x: auto; font-size: 0.9em; }
+footer { color: #999; margin-top: 3em; font-size: 0.85em; }
+`
+
+// sitePage ist eine Seite der Website mit Pfad relativ zum Zielverzeichnis
+type sitePage struct {
+	path   string
+	title  string
+	crumbs [][2]string // Pfad, Beschriftung (Pfad leer = aktuelle Seite)
+}
+
+// siteHref liefert den relativen, URL-kodierten Link von einer Seite zu einer anderen
+func siteHref(from, to string) string {
+	rel, err := filepath.Rel(filepath.Dir(from), to)
+	if err != nil {
+		rel = to
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for i, p := range parts {
+		if p != ".." {
+			parts[i] = url.PathEscape(p)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// link rendert einen Link von der Seite zu to
+func (p sitePage) link(to, label string) string {
+	return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(siteHref(p.path, to)), html.EscapeString(label))
+}
+
+// write schreibt die Seite mit Kopf, Brotkrumen und body unter dir
+func (p sitePage) write(dir, body string) error {
+	var b bytes.Buffer
+	esc := html.EscapeString
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"" + docLang + "\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n<link rel=\"stylesheet\" href=\"%s\">\n</head>\n<body>\n",
+		esc(p.title), esc(siteHref(p.path, "style.css")))
+
+	var crumbs []string
+	for _, c := range p.crumbs {
+		if c[0] == "" {
+			crumbs = append(crumbs, esc(c[1]))
+		} else {
+			crumbs = append(crumbs, p.link(c[0], c[1]))
+		}
+	}
+	if len(crumbs) > 0 {
+		fmt.Fprintf(&b, "<div class=\"crumbs\">%s</div>\n", strings.Join(crumbs, " › "))
+	}
+	fmt.Fprintf(&b, "<h1>%s</h1>\n%s", esc(p.title), body)
+	fmt.Fprintf(&b, "<footer>%s</footer>\n</body>\n</html>\n",
+		esc(docf("Erstellt mit ninox-tui export-html am %s", docTime(time.Now()))))
+
+	path := filepath.Join(dir, p.path)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b.Bytes(), 0o644)
+}
+
+// siteScriptPaths vergibt die Seiten der Scripts wie export-scripts (Endung .html,
+// gleichnamige Scripts mit der ID als Zusatz)
+func siteScriptPaths(scripts []Script) map[int]string {
+	paths := make(map[int]string)
+	used := make(map[string]bool)
+	for _, s := range scripts {
+		rel := strings.TrimSuffix(scriptFilePath(s), scriptFileExt)
+		if used[rel] {
+			rel += fmt.Sprintf("-%d", s.ID)
+		}
+		used[rel] = true
+		paths[s.ID] = rel + ".html"
+	}
+	return paths
+}
+
+// highlightHTML hebt ein Script mit Zeilennummern (verlinkbar als #L3) hervor
+func highlightHTML(code string) (string, error) {
+	lexer := lexers.Get("javascript")
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	formatter := chromahtml.New(chromahtml.WithClasses(true), chromahtml.WithLineNumbers(true),
+		chromahtml.WithLinkableLineNumbers(true, "L"))
+	if err := formatter.Format(&b, styles.Get(siteCodeStyle), iterator); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// writeSiteCSS schreibt die Seiten- und Chroma-Stile nach style.css
+func writeSiteCSS(dir string) error {
+	var b bytes.Buffer
+	b.WriteString(strings.TrimLeft(siteCSS, "\n"))
+	formatter := chromahtml.New(chromahtml.WithClasses(true), chromahtml.WithLineNumbers(true))
+	if err := formatter.WriteCSS(&b, styles.Get(siteCodeStyle)); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "style.css"), b.Bytes(), 0o644)
+}
+
+// scriptTableHTML listet Scripts mit Links auf ihre Seiten
+func scriptTableHTML(page sitePage, scripts []Script, paths map[int]string) string {
+	if len(scripts) == 0 {
+		return "<p>" + docText("Keine Scripts.") + "</p>\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "<table class=\"list\">\n<tr><th>Script</th><th>%s</th><th>%s</th><th class=\"num\">%s</th></tr>\n",
+		docText("Typ"), docText("Gruppe"), docText("Zeilen"))
+	for _, s := range scripts {
+		name := firstNonEmpty(s.ElementName, docText("(Tabelle)"))
+		if s.TableName == "" {
+			name = firstNonEmpty(s.ElementName, docText("(Datenbank)"))
+		}
+		fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td><td class=\"num\">%d</td></tr>\n",
+			page.link(paths[s.ID], name), html.EscapeString(s.CodeType), html.EscapeString(docText(s.Group())), s.LineCount)
+	}
+	b.WriteString("</table>\n")
+	return b.String()
+}
+
+// ExportSite schreibt die Website für die gewählten Datenbanken nach dir und
+// liefert die Zahl der Seiten
+func ExportSite(db *NinoxDB, databases []Database, dir string) (int, error) {
+	esc := html.EscapeString
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	if err := writeSiteCSS(dir); err != nil {
+		return 0, err
+	}
+
+	all, err := db.GetAllScripts()
+	if err != nil {
+		return 0, err
+	}
+	scriptsByTable := make(map[string][]Script) // Datenbank-ID/Tabellen-ID ("" = Datenbank-Ebene)
+	for _, s := range all {
+		scriptsByTable[layoutKey(s.DatabaseID, s.TableID)] = append(scriptsByTable[layoutKey(s.DatabaseID, s.TableID)], s)
+	}
+	paths := siteScriptPaths(all)
+	pages := 0
+
+	// Übersicht
+	root := sitePage{path: "index.html", title: docText("Ninox-Schema")}
+	var index strings.Builder
+	fmt.Fprintf(&index, "<div class=\"meta\">%s</div>\n", esc(docf("Extraktion vom %s", snapshotDate(db))))
+	fmt.Fprintf(&index, "<table class=\"list\">\n<tr><th>%s</th><th>Team</th>"+
+		"<th class=\"num\">%s</th><th class=\"num\">%s</th><th class=\"num\">Scripts</th></tr>\n",
+		docText("Datenbank"), docText("Tabellen"), docText("Felder"))
+	for _, d := range databases {
+		fmt.Fprintf(&index, "<tr><td>%s</td><td>%s</td><td class=\"num\">%d</td><td class=\"num\">%d</td><td class=\"num\">%d</td></tr>\n",
+			root.link(filepath.Join(safeFileName(d.Name), "index.html"), d.Name), esc(firstNonEmpty(d.TeamName, d.TeamID)),
+			d.TableCount, d.FieldCount, d.CodeCount)
+	}
+	index.WriteString("</table>\n")
+	if err := root.write(dir, index.String()); err != nil {
+		return pages, err
+	}
+	pages++
+
+	for _, d := range databases {
+		dbDir := safeFileName(d.Name)
+		dbPage := sitePage{path: filepath.Join(dbDir, "index.html"), title: d.Name,
+			crumbs: [][2]string{{"index.html", docText("Übersicht")}, {"", d.Name}}}
+		tables, err := db.GetTables(d.ID)
+		if err != nil {
+			return pages, err
+		}
+		rels, err := db.GetDatabaseRelationships(d.ID)
+		if err != nil {
+			return pages, err
+		}
+
+		var body strings.Builder
+		fmt.Fprintf(&body, "<h2>%s</h2>\n<table class=\"list\">\n<tr><th>%s</th>"+
+			"<th class=\"num\">%s</th><th class=\"num\">Scripts</th><th class=\"num\">%s</th></tr>\n",
+			docText("Tabellen"), docText("Tabelle"), docText("Felder"), docText("Verknüpfungen"))
+		for _, t := range tables {
+			fmt.Fprintf(&body, "<tr><td>%s</td><td class=\"num\">%d</td><td class=\"num\">%d</td><td class=\"num\">%d</td></tr>\n",
+				dbPage.link(filepath.Join(dbDir, safeFileName(t.Name), "index.html"), t.Name),
+				t.FieldCount, t.ScriptCount, t.RelationCount)
+		}
+		body.WriteString("</table>\n<h2>" + docText("Globale Scripts") + "</h2>\n")
+		body.WriteString(scriptTableHTML(dbPage, scriptsByTable[layoutKey(d.ID, "")], paths))
+		if err := dbPage.write(dir, body.String()); err != nil {
+			return pages, err
+		}
+		pages++
+
+		for _, t := range tables {
+			tablePage := sitePage{path: filepath.Join(dbDir, safeFileName(t.Name), "index.html"), title: t.Name,
+				crumbs: [][2]string{{"index.html", docText("Übersicht")}, {dbPage.path, d.Name}, {"", t.Name}}}
+			fields, err := db.GetFields(d.ID, t.TableID)
+			if err != nil {
+				return pages, err
+			}
+
+			var body strings.Builder
+			fmt.Fprintf(&body, "<h2>%s</h2>\n<table class=\"list\">\n<tr><th>%s</th><th>%s</th><th>%s</th><th>%s</th></tr>\n",
+				docText("Felder"), docText("Feld"), docText("Typ"), docText("Verweist auf"), docText("Formel"))
+			for _, f := range fields {
+				formula := ""
+				if f.HasFormula {
+					formula = docText("ja")
+				}
+				fmt.Fprintf(&body, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+					esc(f.Name), esc(f.BaseType), esc(f.RefTableName), formula)
+			}
+			body.WriteString("</table>\n")
+
+			var related []string
+			for _, r := range rels {
+				if r.SourceTableName == t.Name || r.TargetTableName == t.Name {
+					related = append(related, fmt.Sprintf("<li>%s.%s → %s <span class=\"meta\">(%s)</span></li>",
+						esc(r.SourceTableName), esc(r.SourceFieldName), esc(r.TargetTableName), esc(r.RelationshipType)))
+				}
+			}
+			if len(related) > 0 {
+				body.WriteString("<h2>" + docText("Verknüpfungen") + "</h2>\n<ul>\n" + strings.Join(related, "\n") + "\n</ul>\n")
+			}
+
+			tableScripts := scriptsByTable[layoutKey(d.ID, t.TableID)]
+			body.WriteString("<h2>Scripts</h2>\n" + scriptTableHTML(tablePage, tableScripts, paths))
+			if err := tablePage.write(dir, body.String()); err != nil {
+				return pages, err
+			}
+			pages++
+		}
+
+		// Script-Seiten (Tabellen- und Datenbank-Ebene)
+		for _, s := range all {
+			if s.DatabaseID != d.ID {
+				continue
+			}
+			crumbs := [][2]string{{"index.html", docText("Übersicht")}, {dbPage.path, d.Name}}
+			if s.TableName != "" {
+				crumbs = append(crumbs, [2]string{filepath.Join(dbDir, safeFileName(s.TableName), "index.html"), s.TableName})
+			}
+			title := strings.TrimPrefix(scriptLabel(s), d.Name+"/")
+			page := sitePage{path: paths[s.ID], title: title, crumbs: append(crumbs, [2]string{"", firstNonEmpty(s.ElementName, s.CodeType)})}
+
+			code, err := highlightHTML(s.Code)
+			if err != nil {
+				return pages, err
+			}
+			meta := docf("%s · %s · %d Zeilen · #%s", s.CodeType, docText(s.Group()),
+				s.LineCount, s.Hash[:min(8, len(s.Hash))])
+			if by := docAttribution(s.ModifiedBy, s.ModifiedAt); by != "" {
+				meta += " · " + docText("geändert") + " " + by
+			}
+			if err := page.write(dir, fmt.Sprintf("<div class=\"meta\">%s</div>\n%s", esc(meta), code)); err != nil {
+				return pages, err
+			}
+			pages++
+		}
+	}
+	return pages, nil
+}
+
+// cmdExportHTML schreibt das Schema als statische Website
+func cmdExportHTML(args []string) int {
+	opts := parseCLIOptions(args)
+	applyDocLang(opts.lang)
+	if len(opts.positional) != 1 {
+		fail(exitUsage, "Verwendung: ninox-tui export-html VERZEICHNIS [--database NAME] [--lang de|en] [--db DATEI]")
+	}
+	dir := opts.positional[0]
+
+	db := openCLIDB(opts.dbPath)
+	defer db.Close()
+	databases, err := selectDatabases(db, opts.database)
+	if err != nil {
+		fail(exitNoResults, "%v", err)
+	}
+
+	n, err := ExportSite(db, databases, dir)
+	if err != nil {
+		fail(exitFailure, "%v", err)
+	}
+	if !quiet {
+		fmt.Printf("✓ %d Seiten geschrieben – %s öffnen\n", n, filepath.Join(dir, "index.html"))
+	}
+	return exitOK
+}
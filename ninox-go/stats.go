@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// =============================================================================
+// Statistiken für den aktuellen Ausschnitt
+// =============================================================================
+
+// scriptStats berechnet Statistiken aus einer Script-Menge (z.B. Filter- oder Suchergebnis)
+func scriptStats(scripts []Script, scope string) *Stats {
+	stats := &Stats{
+		ScriptsCount:   len(scripts),
+		ScriptsByType:  make(map[string]int),
+		ScriptsByGroup: make(map[string]int),
+		TopTables:      make(map[string]int),
+		CodeLines:      make(map[string]map[string]int),
+		Scope:          scope,
+		ScriptsOnly:    true,
+	}
+
+	databases := make(map[string]bool)
+	tables := make(map[string]bool)
+	for _, s := range scripts {
+		databases[s.DatabaseID] = true
+		if s.TableName != "" {
+			tables[s.DatabaseID+"/"+s.TableName] = true
+			stats.TopTables[s.TableName]++
+		}
+		stats.ScriptsByType[s.CodeType]++
+		stats.ScriptsByGroup[s.Group()]++
+		addCodeLines(stats.CodeLines, s.DatabaseName, s.TableName, s.LineCount)
+	}
+	stats.DatabasesCount = len(databases)
+	stats.TablesCount = len(tables)
+	return stats
+}
+
+// scopedStats berechnet die Statistiken für den Ausschnitt der aktuellen Ansicht:
+// Filter der Gesamtansicht, Suchergebnisse oder die geöffnete Datenbank.
+// Ohne Einschränkung liefert sie nil (dann gelten die Gesamtzahlen).
+func (m Model) scopedStats() *Stats {
+	switch {
+	case m.mode == viewAllScripts && (len(m.filterStack) > 0 || m.filterBase != nil):
+		return scriptStats(m.filteredScripts, "Filter: "+m.filterChain())
+
+	case m.mode == viewSearch && m.searchInput.Value() != "":
+		return scriptStats(m.searchResults, fmt.Sprintf("Suche: %q", m.searchInput.Value()))
+
+	case m.currentDB != nil && m.mode != viewDatabases && m.mode != viewAllScripts:
+		stats, err := m.db.GetDatabaseStats(m.currentDB.ID)
+		if err != nil {
+			return nil
+		}
+		stats.Scope = "Datenbank: " + m.currentDB.Name
+		return stats
+	}
+	return nil
+}
+
+// shownStats liefert die in der Statistik-Ansicht angezeigten Zahlen
+func (m Model) shownStats() *Stats {
+	if m.statsScoped != nil && !m.statsAll {
+		return m.statsScoped
+	}
+	return m.stats
+}
+
+// countEntry ist ein Name mit Anzahl
+type countEntry struct {
+	name  string
+	count int
+}
+
+// sortedCounts sortiert eine Zählung absteigend (bei Gleichstand nach Name)
+func sortedCounts(counts map[string]int) []countEntry {
+	entries := make([]countEntry, 0, len(counts))
+	for name, count := range counts {
+		entries = append(entries, countEntry{name, count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].count != entries[j].count {
+			return entries[i].count > entries[j].count
+		}
+		return entries[i].name < entries[j].name
+	})
+	return entries
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Schnellwechsel zwischen den Tabellen der aktuellen Datenbank (Ctrl+T)
+// =============================================================================
+
+const (
+	tableMRUFile    = "tables_mru.json"
+	maxTableMRU     = 20 // gemerkte Tabellen je Datenbank
+	maxSwitcherRows = 12
+)
+
+// fuzzyScore bewertet, ob pattern als Teilfolge in text vorkommt (ohne Groß-/Kleinschreibung).
+// Aufeinanderfolgende Zeichen und Wortanfänge zählen mehr, Lücken weniger.
+func fuzzyScore(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(text)
+	if len(p) == 0 {
+		return 0, true
+	}
+
+	score, pi, last := 0, 0, -1
+	for ti := 0; ti < len(t) && pi < len(p); ti++ {
+		if unicode.ToLower(t[ti]) != p[pi] {
+			continue
+		}
+		score++
+		switch {
+		case last == ti-1:
+			score += 3 // zusammenhängend
+		case last >= 0:
+			score -= min(ti-last-1, 3) // Lücke
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) || (unicode.IsUpper(t[ti]) && unicode.IsLower(t[ti-1])) {
+			score += 2 // Wortanfang
+		}
+		last = ti
+		pi++
+	}
+	return score, pi == len(p)
+}
+
+// touchTable merkt sich die geöffnete Tabelle als zuletzt verwendet
+func (m *Model) touchTable(databaseID, tableID string) {
+	if m.tableMRU == nil {
+		m.tableMRU = make(map[string][]string)
+	}
+	recent := []string{tableID}
+	for _, id := range m.tableMRU[databaseID] {
+		if id != tableID && len(recent) < maxTableMRU {
+			recent = append(recent, id)
+		}
+	}
+	m.tableMRU[databaseID] = recent
+	saveState(tableMRUFile, m.tableMRU)
+}
+
+// switcherCandidates liefert die Indizes in m.tables passend zur Eingabe:
+// nach Trefferqualität, bei Gleichstand zuletzt verwendete zuerst, dann nach Name
+func (m Model) switcherCandidates() []int {
+	rank := make(map[string]int)
+	for i, id := range m.tableMRU[m.currentDB.ID] {
+		rank[id] = i + 1
+	}
+	mruRank := func(t Table) int {
+		if r, ok := rank[t.TableID]; ok {
+			return r
+		}
+		return maxTableMRU + 1
+	}
+
+	query := strings.TrimSpace(m.switchInput.Value())
+	var candidates []int
+	scores := make(map[int]int)
+	for i, t := range m.tables {
+		score, ok := fuzzyScore(query, t.Name)
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, i)
+		scores[i] = score
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		x, y := candidates[a], candidates[b]
+		if scores[x] != scores[y] {
+			return scores[x] > scores[y]
+		}
+		if rx, ry := mruRank(m.tables[x]), mruRank(m.tables[y]); rx != ry {
+			return rx < ry
+		}
+		return cmpText(m.tables[x].Name, m.tables[y].Name) < 0
+	})
+	return candidates
+}
+
+// openSwitcher öffnet den Schnellwechsel für die Tabellen der aktuellen Datenbank
+func (m Model) openSwitcher() (tea.Model, tea.Cmd) {
+	if m.currentDB == nil || len(m.tables) == 0 {
+		m.status = "Schnellwechsel: erst eine Datenbank öffnen"
+		return m, nil
+	}
+	m.switching = true
+	m.switchSelected = 0
+	m.switchInput.SetValue("")
+	m.switchInput.Focus()
+	return m, textinput.Blink
+}
+
+// handleSwitcherInput verarbeitet Tasten im Schnellwechsel. Buchstaben gehören der
+// Eingabe, daher navigieren nur Pfeiltasten bzw. Ctrl+P/Ctrl+N.
+func (m Model) handleSwitcherInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch {
+	case msg.String() == "esc":
+		m.switching = false
+		m.switchInput.Blur()
+		return m, nil
+	case msg.String() == "up" || msg.String() == "ctrl+p":
+		if m.switchSelected > 0 {
+			m.switchSelected--
+		}
+		return m, nil
+	case msg.String() == "down" || msg.String() == "ctrl+n":
+		if m.switchSelected < len(m.switcherCandidates())-1 {
+			m.switchSelected++
+		}
+		return m, nil
+	case key.Matches(msg, keys.Enter):
+		candidates := m.switcherCandidates()
+		m.switching = false
+		m.switchInput.Blur()
+		if m.switchSelected >= len(candidates) {
+			return m, nil
+		}
+		m.recordJump()
+		m.selectedTable = candidates[m.switchSelected]
+		m.mode = viewTables
+		return m.handleEnter()
+	default:
+		m.switchInput, cmd = m.switchInput.Update(msg)
+		m.switchSelected = 0
+		return m, cmd
+	}
+}
+
+// renderSwitcher rendert den Schnellwechsel als Kasten
+func (m Model) renderSwitcher() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("⇄ Tabelle in "+m.currentDB.Name) + "\n\n")
+	b.WriteString(m.switchInput.View() + "\n\n")
+
+	recent := make(map[string]bool)
+	for _, id := range m.tableMRU[m.currentDB.ID] {
+		recent[id] = true
+	}
+
+	candidates := m.switcherCandidates()
+	if len(candidates) == 0 {
+		b.WriteString(mutedStyle.Render("Keine passende Tabelle"))
+	}
+	start := max(0, min(m.switchSelected-maxSwitcherRows/2, len(candidates)-maxSwitcherRows))
+	for i := start; i < min(len(candidates), start+maxSwitcherRows); i++ {
+		t := m.tables[candidates[i]]
+		style := tableCellStyle
+		prefix := "  "
+		if i == m.switchSelected {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		marker := " "
+		if recent[t.TableID] {
+			marker = "•"
+		}
+		row := fmt.Sprintf("%s%s %-28s %4d Felder", prefix, marker, truncate(t.Name, 28), t.FieldCount)
+		b.WriteString(style.Render(row) + "\n")
+	}
+	b.WriteString("\n" + mutedStyle.Render("• zuletzt verwendet  ↑↓ Auswahl  Enter öffnen"))
+
+	return statsBoxStyle.Width(lipgloss.Width(b.String()) + 4).Render(b.String())
+}
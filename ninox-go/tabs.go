@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Sitzungs-Tabs pro Datenbank
+// =============================================================================
+
+// navState ist der Navigationszustand eines Datenbank-Tabs
+type navState struct {
+	mode, prevMode viewMode
+	currentDB      *Database
+	currentTable   *Table
+	tables         []Table
+	fields         []Field
+	scripts        []Script
+	relationships  []Relationship
+	selectedDB     int
+	selectedTable  int
+	selectedField  int
+	selectedScript int
+	selectedAll    int // Gesamtansicht: Auswahl und Scrollposition
+	allOffset      int
+	codeScript     *Script
+	codeOffset     int
+}
+
+// saveNav sichert den Navigationszustand der aktuellen Datenbank
+func (m *Model) saveNav() navState {
+	return navState{
+		mode: m.mode, prevMode: m.prevMode,
+		currentDB: m.currentDB, currentTable: m.currentTable,
+		tables: m.tables, fields: m.fields, scripts: m.scripts, relationships: m.relationships,
+		selectedDB: m.selectedDB, selectedTable: m.selectedTable, selectedField: m.selectedField, selectedScript: m.selectedScript,
+		selectedAll: m.selectedAllScript, allOffset: m.scrollOffset,
+		codeScript: m.codeScript, codeOffset: m.codeView.YOffset,
+	}
+}
+
+// restoreNav stellt einen gesicherten Navigationszustand wieder her
+func (m *Model) restoreNav(s navState) {
+	m.mode, m.prevMode = s.mode, s.prevMode
+	m.currentDB, m.currentTable = s.currentDB, s.currentTable
+	m.tables, m.fields, m.scripts, m.relationships = s.tables, s.fields, s.scripts, s.relationships
+	m.selectedTable, m.selectedField, m.selectedScript = s.selectedTable, s.selectedField, s.selectedScript
+	if m.mode == viewDatabases {
+		m.selectedDB = s.selectedDB
+	}
+	if m.mode == viewAllScripts && s.selectedAll < len(m.filteredScripts) {
+		m.selectedAllScript, m.scrollOffset = s.selectedAll, s.allOffset
+	}
+	m.codeScript = s.codeScript
+
+	if m.mode == viewCode && m.codeScript != nil {
+		m.codeView.SetContent(highlightCode(m.codeScript.Code))
+		m.codeView.SetYOffset(s.codeOffset)
+	}
+}
+
+// switchTab wechselt zum Tab der n-ten Datenbank (1-basiert) und legt ihn bei Bedarf an
+func (m Model) switchTab(n int) (tea.Model, tea.Cmd) {
+	if n < 1 || n > len(m.databases) {
+		return m, nil
+	}
+	target := &m.databases[n-1]
+	if m.currentDB != nil && m.currentDB.ID == target.ID {
+		return m, nil
+	}
+
+	if m.tabs == nil {
+		m.tabs = make(map[string]navState)
+	}
+	if m.currentDB != nil {
+		m.tabs[m.currentDB.ID] = m.saveNav()
+	}
+
+	if state, ok := m.tabs[target.ID]; ok {
+		m.restoreNav(state)
+		return m, nil
+	}
+
+	// Neuer Tab startet in der Tabellenliste der Datenbank
+	tables, err := m.db.GetTables(target.ID)
+	if err != nil {
+		m.status = fmt.Sprintf("❌ %v", err)
+		return m, nil
+	}
+	m.restoreNav(navState{mode: viewTables, prevMode: viewDatabases, currentDB: target, tables: tables})
+	m.applySort(viewTables)
+	m.selectedDB = n - 1
+	return m, nil
+}
+
+// renderTabs rendert die Leiste der geöffneten Datenbank-Tabs
+func (m Model) renderTabs() string {
+	open := make(map[string]bool, len(m.tabs)+1)
+	for id := range m.tabs {
+		open[id] = true
+	}
+	if m.currentDB != nil {
+		open[m.currentDB.ID] = true
+	}
+	if len(open) < 2 {
+		return ""
+	}
+
+	var indexes []int
+	for i, db := range m.databases {
+		if open[db.ID] {
+			indexes = append(indexes, i)
+		}
+	}
+
+	parts := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		label := fmt.Sprintf(" %d %s ", i+1, m.databases[i].Name)
+		if m.currentDB != nil && m.databases[i].ID == m.currentDB.ID {
+			parts = append(parts, selectedStyle.Render(label))
+		} else {
+			parts = append(parts, mutedStyle.Render(label))
+		}
+	}
+	return strings.Join(parts, mutedStyle.Render("│")) + mutedStyle.Render("  (Alt+Zahl)")
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// =============================================================================
+// Teams: Ebene über den Datenbanken, wenn mehrere Teams extrahiert wurden
+// =============================================================================
+
+// Team fasst die Datenbanken eines Ninox-Teams (Workspace) zusammen
+type Team struct {
+	ID        string
+	Name      string
+	Databases int
+	Tables    int
+	Scripts   int
+}
+
+// buildTeams gruppiert die Datenbanken nach Team, sortiert nach Name
+func buildTeams(databases []Database) []Team {
+	index := make(map[string]int)
+	var teams []Team
+	for _, d := range databases {
+		i, ok := index[d.TeamID]
+		if !ok {
+			name := firstNonEmpty(d.TeamName, d.TeamID, "(ohne Team)")
+			i = len(teams)
+			index[d.TeamID] = i
+			teams = append(teams, Team{ID: d.TeamID, Name: name})
+		}
+		teams[i].Databases++
+		teams[i].Tables += d.TableCount
+		teams[i].Scripts += d.CodeCount
+	}
+	sort.SliceStable(teams, func(i, j int) bool { return cmpText(teams[i].Name, teams[j].Name) < 0 })
+	return teams
+}
+
+// setDatabases übernimmt neu geladene Datenbanken und wendet die Team-Auswahl an
+func (m *Model) setDatabases(databases []Database) {
+	m.allDatabases = databases
+	m.teams = buildTeams(databases)
+	if m.currentTeam != nil {
+		found := false
+		for _, t := range m.teams {
+			if t.ID == m.currentTeam.ID {
+				found = true
+			}
+		}
+		if !found {
+			m.currentTeam = nil
+		}
+	}
+
+	m.databases = nil
+	for _, d := range databases {
+		if m.currentTeam == nil || d.TeamID == m.currentTeam.ID {
+			m.databases = append(m.databases, d)
+		}
+	}
+	m.selectedDB = min(m.selectedDB, max(0, len(m.databases)-1))
+}
+
+// multiTeam meldet, ob die Extraktion Datenbanken aus mehreren Teams enthält
+func (m Model) multiTeam() bool {
+	return len(m.teams) > 1
+}
+
+// openTeams öffnet die Team-Übersicht; der Cursor steht auf dem gewählten Team
+func (m Model) openTeams() (tea.Model, tea.Cmd) {
+	if !m.multiTeam() {
+		m.status = "Die Extraktion enthält nur ein Team (extract --all-teams für mehrere)"
+		return m, nil
+	}
+	m.selectedTeam = 0
+	for i, t := range m.teams {
+		if m.currentTeam != nil && t.ID == m.currentTeam.ID {
+			m.selectedTeam = i + 1
+		}
+	}
+	m.currentDB = nil
+	m.currentTable = nil
+	m.mode = viewTeams
+	return m, nil
+}
+
+// chooseTeam zeigt die Datenbanken des ausgewählten Teams (erste Zeile: alle Teams)
+func (m Model) chooseTeam() (tea.Model, tea.Cmd) {
+	m.currentTeam = nil
+	if m.selectedTeam > 0 && m.selectedTeam <= len(m.teams) {
+		team := m.teams[m.selectedTeam-1]
+		m.currentTeam = &team
+	}
+	m.selectedDB = 0
+	m.setDatabases(m.allDatabases)
+	m.applySort(viewDatabases)
+	m.mode = viewDatabases
+	return m, nil
+}
+
+// teamLabel liefert den Namen des gewählten Teams ("" = alle Teams)
+func (m Model) teamLabel() string {
+	if m.currentTeam == nil {
+		return ""
+	}
+	return m.currentTeam.Name
+}
+
+// renderTeams rendert die Teams mit ihren Datenbanken, Tabellen und Scripts
+func (m Model) renderTeams() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("👥 Teams") + "\n\n")
+
+	header := fmt.Sprintf("  %-30s %-24s %12s %10s %10s", "Name", "ID", "Datenbanken", "Tabellen", "Scripts")
+	b.WriteString(tableHeaderStyle.Render(header) + "\n")
+
+	all := Team{Name: "Alle Teams", Databases: len(m.allDatabases)}
+	for _, t := range m.teams {
+		all.Tables += t.Tables
+		all.Scripts += t.Scripts
+	}
+	rows := append([]Team{all}, m.teams...)
+
+	for i, t := range rows {
+		style := tableCellStyle
+		prefix := "  "
+		if i == m.selectedTeam {
+			style = tableCellSelectedStyle
+			prefix = "▶ "
+		}
+		row := fmt.Sprintf("%s%-28s %-24s %12s %10s %10s", prefix, truncate(t.Name, 28), truncate(t.ID, 24),
+			countBadge(t.Databases), countBadge(t.Tables), countBadge(t.Scripts))
+		b.WriteString(style.Render(row) + "\n")
+	}
+
+	return boxStyle.Width(m.width - 4).Render(b.String())
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Eigene Themes aus einer TOML-Datei (themes.toml neben der Konfiguration).
+// Gelesen wird nur die flache Form: je Theme ein Abschnitt mit Schlüssel = "Wert".
+//
+//	[solarized]
+//	base = "dark"              # fehlende Farben aus diesem Theme
+//	primary = "#268bd2"
+//	selection_bg = "#073642"
+//	code_style = "solarized-dark"
+// =============================================================================
+
+// themeFileName ist die Theme-Datei im Verzeichnis der Konfiguration
+const themeFileName = "themes.toml"
+
+// customThemes sind die geladenen eigenen Themes nach Name
+var customThemes = map[string]Theme{}
+
+// themeColorFields ordnet die Schlüssel der Datei den Farben eines Themes zu
+var themeColorFields = map[string]func(t *Theme) *lipgloss.Color{
+	"primary":      func(t *Theme) *lipgloss.Color { return &t.Primary },
+	"secondary":    func(t *Theme) *lipgloss.Color { return &t.Secondary },
+	"accent":       func(t *Theme) *lipgloss.Color { return &t.Accent },
+	"text":         func(t *Theme) *lipgloss.Color { return &t.Text },
+	"text_muted":   func(t *Theme) *lipgloss.Color { return &t.TextMuted },
+	"background":   func(t *Theme) *lipgloss.Color { return &t.Background },
+	"surface":      func(t *Theme) *lipgloss.Color { return &t.Surface },
+	"border":       func(t *Theme) *lipgloss.Color { return &t.Border },
+	"selection_fg": func(t *Theme) *lipgloss.Color { return &t.SelectionFg },
+	"selection_bg": func(t *Theme) *lipgloss.Color { return &t.SelectionBg },
+}
+
+// hexColorPattern erkennt Farben der Form #RGB bzw. #RRGGBB
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// themeFilePath liefert die Theme-Datei neben der Konfigurationsdatei
+func themeFilePath(cfg *Config) string {
+	if cfg.ThemeFile != "" {
+		return cfg.ThemeFile
+	}
+	return filepath.Join(filepath.Dir(firstNonEmpty(cfg.path, defaultConfigPath())), themeFileName)
+}
+
+// loadCustomThemes liest die eigenen Themes; eine fehlende Datei ist kein Fehler
+func loadCustomThemes(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	themes, err := parseThemes(bufio.NewScanner(f))
+	if err != nil {
+		return fmt.Errorf("Ungültige Theme-Datei %s: %w", path, err)
+	}
+	customThemes = themes
+	return nil
+}
+
+// parseThemes wertet die Abschnitte der Theme-Datei aus
+func parseThemes(scanner *bufio.Scanner) (map[string]Theme, error) {
+	type section struct {
+		values map[string]string
+		line   int
+	}
+	var order []string
+	sections := make(map[string]*section)
+	var current *section
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(stripTOMLComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := unquoteTOML(strings.TrimSpace(line[1 : len(line)-1]))
+			if name == "" {
+				return nil, fmt.Errorf("Zeile %d: Theme ohne Namen", n)
+			}
+			if _, ok := sections[name]; ok {
+				return nil, fmt.Errorf("Zeile %d: Theme %q doppelt", n, name)
+			}
+			current = &section{values: make(map[string]string), line: n}
+			sections[name] = current
+			order = append(order, name)
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("Zeile %d: erwartet Schlüssel = \"Wert\"", n)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("Zeile %d: Schlüssel vor dem ersten [Theme]", n)
+		}
+		current.values[strings.ToLower(strings.TrimSpace(k))] = unquoteTOML(strings.TrimSpace(v))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	themes := make(map[string]Theme, len(order))
+	for _, name := range order {
+		sec := sections[name]
+		if name == DarkTheme.Name || name == LightTheme.Name {
+			return nil, fmt.Errorf("Zeile %d: %q ist ein eingebautes Theme", sec.line, name)
+		}
+		base := DarkTheme
+		if b, ok := sec.values["base"]; ok {
+			var err error
+			if base, err = builtinTheme(b); err != nil {
+				return nil, fmt.Errorf("Theme %s: %w", name, err)
+			}
+		}
+		theme := base
+		theme.Name = name
+		for k, v := range sec.values {
+			switch field, isColor := themeColorFields[k]; {
+			case k == "base":
+			case k == "code_style":
+				if _, ok := styles.Registry[v]; !ok {
+					return nil, fmt.Errorf("Theme %s: unbekannter Code-Stil %q", name, v)
+				}
+				theme.CodeStyle = v
+			case isColor:
+				if !validThemeColor(v) {
+					return nil, fmt.Errorf("Theme %s: ungültige Farbe %s = %q (#RRGGBB oder 0-255)", name, k, v)
+				}
+				*field(&theme) = lipgloss.Color(v)
+			default:
+				return nil, fmt.Errorf("Theme %s: unbekannter Schlüssel %q", name, k)
+			}
+		}
+		themes[name] = theme
+	}
+	return themes, nil
+}
+
+// fingerprint kennzeichnet ein Theme samt aller Farben und Code-Stil, damit
+// geänderte eigene Themes nicht auf zwischengespeicherte Ausgaben treffen
+func (t Theme) fingerprint() string {
+	return scriptHash(fmt.Sprintf("%#v", t))[:12]
+}
+
+// builtinTheme liefert eines der eingebauten Themes
+func builtinTheme(name string) (Theme, error) {
+	switch name {
+	case DarkTheme.Name:
+		return DarkTheme, nil
+	case LightTheme.Name:
+		return LightTheme, nil
+	}
+	return Theme{}, fmt.Errorf("Unbekanntes Basis-Theme: %s (dark, light)", name)
+}
+
+// themeNames listet die verfügbaren Themes, eingebaute zuerst
+func themeNames() []string {
+	var custom []string
+	for name := range customThemes {
+		custom = append(custom, name)
+	}
+	sort.Strings(custom)
+	return append([]string{DarkTheme.Name, LightTheme.Name}, custom...)
+}
+
+// validThemeColor akzeptiert Hex-Farben und ANSI-Farbnummern
+func validThemeColor(v string) bool {
+	if hexColorPattern.MatchString(v) {
+		return true
+	}
+	n, err := strconv.Atoi(v)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// stripTOMLComment entfernt einen Kommentar außerhalb von Anführungszeichen
+func stripTOMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquoteTOML entfernt einfache bzw. doppelte Anführungszeichen eines Werts
+func unquoteTOML(v string) string {
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
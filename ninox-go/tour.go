@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Einführungstour (--tour): Hinweise Schritt für Schritt durch die wichtigsten
+// Ansichten. Ein Schritt gilt als erledigt, sobald die Ansicht den erwarteten
+// Zustand erreicht; erledigte Schritte werden übersprungen.
+// =============================================================================
+
+// tourStep ist ein Hinweis der Tour mit seiner Erfüllungsbedingung
+type tourStep struct {
+	hint string
+	done func(m Model) bool
+}
+
+var tourSteps = []tourStep{
+	{"Willkommen! ↑↓ bzw. j/k wählen aus, Enter öffnet die markierte Datenbank (in der Team-Übersicht zuerst das Team).",
+		func(m Model) bool { return m.mode == viewTables }},
+	{"Das sind die Tabellen der Datenbank. Enter öffnet eine Tabelle mit ihren Feldern.",
+		func(m Model) bool { return m.mode == viewFields }},
+	{"Felder mit Typ, Formel und Verknüpfungen. Tab wechselt zu den Scripts der Tabelle.",
+		func(m Model) bool { return m.mode == viewScripts }},
+	{"Trigger, Formeln und Buttons der Tabelle. Enter zeigt den Code des markierten Scripts.",
+		func(m Model) bool { return m.mode == viewCode }},
+	{"Esc führt jederzeit zurück. Jetzt a drücken: die Gesamtansicht aller Scripts.",
+		func(m Model) bool { return m.mode == viewAllScripts }},
+	{"Alle Scripts aller Datenbanken. f filtert – z.B. select eingeben und mit Enter bestätigen.",
+		func(m Model) bool { return len(m.filterStack) > 0 }},
+	{"Jeder Filter grenzt weiter ein, u nimmt den letzten zurück. s sucht im Code, Enter startet die Suche.",
+		func(m Model) bool { return m.mode == viewSearch }},
+	{"i zeigt Statistiken zum aktuellen Ausschnitt (Suche, Filter oder Datenbank).",
+		func(m Model) bool { return m.mode == viewStats }},
+	{"Zum Schluss: ? zeigt die Tasten der aktuellen Ansicht, ein zweites ? die vollständige Hilfe.",
+		func(m Model) bool { return m.cheatsheet || m.mode == viewHelp }},
+}
+
+// tourState ist der Fortschritt der Tour
+type tourState struct {
+	active bool
+	step   int
+}
+
+// StartTour beginnt die Tour beim ersten Schritt
+func (m *Model) StartTour() {
+	m.tour = tourState{active: true}
+}
+
+// advanceTour springt über alle bereits erfüllten Schritte
+func (m Model) advanceTour() Model {
+	for m.tour.active && tourSteps[m.tour.step].done(m) {
+		m = m.nextTourStep()
+	}
+	return m
+}
+
+// nextTourStep geht zum nächsten Schritt bzw. beendet die Tour
+func (m Model) nextTourStep() Model {
+	m.tour.step++
+	if m.tour.step >= len(tourSteps) {
+		m.tour = tourState{}
+		m.status = "✓ Tour beendet – ? zeigt jederzeit die Tasten"
+	}
+	return m
+}
+
+// handleTourKey überspringt einen Schritt (Strg+N) oder beendet die Tour (Strg+X)
+func (m Model) handleTourKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "ctrl+n":
+		return m.nextTourStep(), nil, true
+	case "ctrl+x":
+		m.tour = tourState{}
+		m.status = "Tour beendet"
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// renderTour rendert den Hinweis des aktuellen Schritts
+func (m Model) renderTour() string {
+	width := min(44, max(24, m.width/3))
+	title := titleStyle.Render(fmt.Sprintf("🧭 Tour %d/%d", m.tour.step+1, len(tourSteps)))
+	hint := lipgloss.NewStyle().Width(width).Render(tourSteps[m.tour.step].hint)
+	help := mutedStyle.Render("Strg+N überspringen • Strg+X beenden")
+	return statsBoxStyle.Render(title + "\n\n" + hint + "\n\n" + help)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Code-Verteilung: Datenbanken als Balken, unterteilt nach Tabellen
+// =============================================================================
+
+// maxTreemapDatabases begrenzt die Zahl der Balken in der Statistik
+const maxTreemapDatabases = 6
+
+// partialBlocks sind Achtelblöcke für das Balkenende (1/8 … 7/8)
+var partialBlocks = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// addCodeLines zählt Codezeilen je Datenbank und Tabelle
+func addCodeLines(lines map[string]map[string]int, database, table string, n int) {
+	if table == "" {
+		table = "(global)"
+	}
+	if lines[database] == nil {
+		lines[database] = make(map[string]int)
+	}
+	lines[database][table] += n
+}
+
+// treemapSegments verteilt width Zellen proportional auf die Einträge.
+// Kumuliertes Runden hält die Summe exakt; sehr kleine Tabellen erhalten 0 Zellen.
+func treemapSegments(entries []countEntry, total, width int) []int {
+	widths := make([]int, len(entries))
+	cum, pos := 0, 0
+	for i, e := range entries {
+		cum += e.count
+		next := (cum*width + total/2) / total
+		widths[i] = next - pos
+		pos = next
+	}
+	return widths
+}
+
+// renderCodeTreemap rendert je Datenbank einen Balken, dessen Länge ihrem Codeumfang
+// entspricht (größte Datenbank = volle Breite), unterteilt nach den Tabellen.
+// Darunter stehen die Namen der Tabellen, soweit sie in ihr Segment passen.
+func renderCodeTreemap(lines map[string]map[string]int, width int) string {
+	totals := make(map[string]int)
+	for database, tables := range lines {
+		for _, n := range tables {
+			totals[database] += n
+		}
+	}
+	databases := sortedCounts(totals)
+	if len(databases) == 0 || databases[0].count == 0 {
+		return mutedStyle.Render("  Kein Code vorhanden") + "\n"
+	}
+
+	colors := []lipgloss.Color{currentTheme.Primary, currentTheme.Secondary, currentTheme.Accent}
+	glyphs := []string{"█", "▓"} // wechselnd, damit gleiche Farben unterscheidbar bleiben
+	labelWidth := 15
+	barWidth := max(10, width-labelWidth-16)
+
+	var b strings.Builder
+	for i, database := range databases {
+		if i >= maxTreemapDatabases {
+			b.WriteString(mutedStyle.Render(fmt.Sprintf("  … %d weitere Datenbanken", len(databases)-i)) + "\n")
+			break
+		}
+		if database.count == 0 {
+			continue
+		}
+
+		// Länge in Achtelzellen, mindestens ein Achtel
+		eighths := max(1, (database.count*barWidth*8+databases[0].count/2)/databases[0].count)
+		full, partial := eighths/8, eighths%8
+
+		tables := sortedCounts(lines[database.name])
+		widths := treemapSegments(tables, database.count, full)
+
+		var bar, labels strings.Builder
+		last := 0
+		for k, w := range widths {
+			if w == 0 {
+				continue
+			}
+			last = k
+			style := lipgloss.NewStyle().Foreground(colors[k%len(colors)])
+			bar.WriteString(style.Render(strings.Repeat(glyphs[k%len(glyphs)], w)))
+
+			name := ""
+			if w >= 6 {
+				name = truncate(tables[k].name, w-1)
+			}
+			labels.WriteString(fmt.Sprintf("%-*s", w, name))
+		}
+		if partial > 0 {
+			bar.WriteString(lipgloss.NewStyle().Foreground(colors[last%len(colors)]).Render(partialBlocks[partial]))
+		}
+
+		b.WriteString(fmt.Sprintf("  %-*s %s %d Z.\n", labelWidth, truncate(database.name, labelWidth), bar.String(), database.count))
+		if label := strings.TrimRight(labels.String(), " "); label != "" {
+			b.WriteString(mutedStyle.Render(fmt.Sprintf("  %-*s %s", labelWidth, "", label)) + "\n")
+		}
+	}
+	return b.String()
+}
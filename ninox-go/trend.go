@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// =============================================================================
+// Entwicklung: Codezeilen und Scripts je Datenbank über ältere Extraktionen
+// =============================================================================
+
+// maxTrendPoints begrenzt die Zahl der Stände je Datenbank in der Statistik
+const maxTrendPoints = 8
+
+// trendPoint ist der Umfang einer Extraktion je Datenbank (Schlüssel: Name)
+type trendPoint struct {
+	Date    string
+	Lines   map[string]int
+	Scripts map[string]int
+}
+
+// trendPointOf zählt Codezeilen und Scripts einer Extraktion
+func trendPointOf(db *NinoxDB) (trendPoint, error) {
+	p := trendPoint{Date: snapshotDate(db), Lines: make(map[string]int), Scripts: make(map[string]int)}
+	scripts, err := db.GetAllScripts()
+	if err != nil {
+		return p, err
+	}
+	for _, s := range scripts {
+		p.Lines[s.DatabaseName] += s.LineCount
+		p.Scripts[s.DatabaseName]++
+	}
+	return p, nil
+}
+
+// expandHome ersetzt ein führendes ~ durch das Home-Verzeichnis
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// loadTrend lädt die Stände aller Extraktionen zum Muster (snapshots in der
+// Konfiguration) und hängt die geöffnete als neuesten Stand an
+func loadTrend(pattern string, current *NinoxDB) ([]trendPoint, error) {
+	paths, err := filepath.Glob(expandHome(pattern))
+	if err != nil {
+		return nil, fmt.Errorf("Ungültiges Muster %s: %w", pattern, err)
+	}
+	currentPath, _ := filepath.Abs(current.path)
+
+	var points []trendPoint
+	for _, path := range paths {
+		if abs, _ := filepath.Abs(path); abs == currentPath {
+			continue
+		}
+		db, err := NewNinoxDB(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		p, err := trendPointOf(db)
+		db.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		points = append(points, p)
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Date < points[j].Date })
+
+	latest, err := trendPointOf(current)
+	if err != nil {
+		return nil, err
+	}
+	return append(points, latest), nil
+}
+
+// loadTrendOnce lädt die Entwicklung beim ersten Öffnen der Statistik
+func (m *Model) loadTrendOnce() {
+	if m.trendLoaded || m.config.Snapshots == "" {
+		return
+	}
+	m.trendLoaded = true
+	trend, err := loadTrend(m.config.Snapshots, m.db)
+	if err != nil {
+		m.status = fmt.Sprintf("Entwicklung nicht geladen: %v", err)
+		return
+	}
+	m.trend = trend
+}
+
+// trendChange beschreibt die Veränderung vom ersten zum letzten Stand, z.B. "+18 %"
+func trendChange(first, last int) string {
+	switch {
+	case first == 0 && last == 0:
+		return "±0 %"
+	case first == 0:
+		return "neu"
+	}
+	pct := (last - first) * 100 / first
+	if pct > 0 {
+		return fmt.Sprintf("+%d %%", pct)
+	}
+	if pct == 0 {
+		return "±0 %"
+	}
+	return fmt.Sprintf("%d %%", pct)
+}
+
+// renderTrend rendert je Datenbank einen Balken pro Stand (Länge = Codezeilen,
+// größter Stand = volle Breite) mit der Zahl der Scripts dahinter
+func renderTrend(points []trendPoint, width int) string {
+	if len(points) < 2 {
+		return ""
+	}
+	if len(points) > maxTrendPoints {
+		points = points[len(points)-maxTrendPoints:]
+	}
+	first, last := points[0], points[len(points)-1]
+
+	// Datenbanken nach aktuellem Umfang; entfernte Datenbanken zählen mit dem letzten Stand
+	totals := make(map[string]int)
+	for _, p := range points {
+		for name, n := range p.Lines {
+			totals[name] = n
+		}
+	}
+	for name := range totals {
+		totals[name] = last.Lines[name]
+	}
+	databases := sortedCounts(totals)
+	if len(databases) > maxTreemapDatabases {
+		databases = databases[:maxTreemapDatabases]
+	}
+
+	peak := 1
+	for _, p := range points {
+		for _, d := range databases {
+			peak = max(peak, p.Lines[d.name])
+		}
+	}
+	barWidth := max(10, width-34)
+	barStyle := lipgloss.NewStyle().Foreground(currentTheme.Primary)
+
+	var b strings.Builder
+	for _, d := range databases {
+		summary := fmt.Sprintf("%s → %s Zeilen (%s) · %d → %d Scripts",
+			formatCount(first.Lines[d.name]), formatCount(last.Lines[d.name]),
+			trendChange(first.Lines[d.name], last.Lines[d.name]),
+			first.Scripts[d.name], last.Scripts[d.name])
+		b.WriteString(normalStyle.Render(fmt.Sprintf("  %-15s", truncate(d.name, 15))) + mutedStyle.Render(" "+summary) + "\n")
+
+		for _, p := range points {
+			eighths := p.Lines[d.name] * barWidth * 8 / peak
+			bar := strings.Repeat("█", eighths/8) + partialBlocks[eighths%8]
+			b.WriteString(fmt.Sprintf("    %-10s %s %s\n", p.Date, barStyle.Render(bar),
+				mutedStyle.Render(fmt.Sprintf("%s (%d)", formatCount(p.Lines[d.name]), p.Scripts[d.name]))))
+		}
+	}
+	return b.String()
+}
@@ -26,6 +26,7 @@ Danach Suche:
 import os
 import re
 import json
+import hashlib
 import sqlite3
 import requests
 import logging
@@ -708,6 +709,7 @@ class CodeCategory(Enum):
     REFERENCE = "reference"
     VIEW = "view"
     REPORT = "report"
+    AUTOMATION = "automation"
     OTHER = "other"
 
 
@@ -727,9 +729,27 @@ TABLE_CODE_FIELDS = {
     'canWrite': CodeCategory.PERMISSION,
     'canCreate': CodeCategory.PERMISSION,
     'canDelete': CodeCategory.PERMISSION,
-    'printout': CodeCategory.OTHER,
+    'printout': CodeCategory.REPORT,
 }
 
+# Druckvorlagen (Schema-Schlüssel) und Formel-Schlüssel ihrer Elemente
+REPORT_KEYS = ('reports', 'printLayouts')
+REPORT_CODE_FIELDS = ('fn', 'formula', 'expression', 'visibility')
+
+# Unbeaufsichtigt laufende Scripts (Schema-Schlüssel → Art) und ihre Auslöser
+AUTOMATION_KEYS = {
+    'jobs': 'schedule',
+    'schedules': 'schedule',
+    'automations': 'schedule',
+    'webhooks': 'webhook',
+}
+AUTOMATION_CODE_FIELDS = ('code', 'script', 'fn')
+AUTOMATION_CONDITION_FIELDS = ('cron', 'schedule', 'interval', 'event', 'trigger', 'url', 'path')
+
+# Letzter Bearbeiter eines Schema-Elements, sofern die API ihn mitliefert
+MODIFIED_BY_FIELDS = ('modifiedBy', 'modifiedByUser', 'lastModifiedBy', 'updatedBy')
+MODIFIED_AT_FIELDS = ('modifiedAt', 'lastModified', 'updatedAt', 'modified')
+
 FIELD_CODE_FIELDS = {
     'fn': CodeCategory.FORMULA,
     'afterUpdate': CodeCategory.TRIGGER,
@@ -749,6 +769,33 @@ FIELD_CODE_FIELDS = {
     'color': CodeCategory.OTHER,
 }
 
+# Rollenlisten nach Ebene (Schema-Schlüssel → Recht)
+TABLE_ROLE_FIELDS = {
+    'readRoles': 'read',
+    'writeRoles': 'write',
+    'createRoles': 'create',
+    'deleteRoles': 'delete',
+}
+
+FIELD_ROLE_FIELDS = {
+    'readRoles': 'read',
+    'writeRoles': 'write',
+}
+
+
+def element_modification(data: Optional[Dict]) -> Tuple[Optional[str], Optional[str]]:
+    """Liefert (Benutzer, Zeitpunkt) der letzten Änderung eines Schema-Elements.
+    Benutzer kommen als Text oder Objekt, Zeitpunkte als ISO-Text oder Epoch-Millisekunden."""
+    if not isinstance(data, dict):
+        return None, None
+    user = next((data[k] for k in MODIFIED_BY_FIELDS if data.get(k)), None)
+    if isinstance(user, dict):
+        user = user.get('email') or user.get('name') or user.get('id')
+    when = next((data[k] for k in MODIFIED_AT_FIELDS if data.get(k)), None)
+    if isinstance(when, (int, float)):
+        when = datetime.fromtimestamp(when / 1000 if when > 1e11 else when).strftime('%Y-%m-%d %H:%M:%S')
+    return (str(user) if user else None), (str(when) if when else None)
+
 
 @dataclass
 class Relationship:
@@ -795,6 +842,8 @@ class CodeLocation:
     code: str
     code_original: Optional[str] = None  # Original mit IDs
     line_count: int = 0
+    modified_by: Optional[str] = None  # letzter Bearbeiter (falls von der API geliefert)
+    modified_at: Optional[str] = None
 
     def __post_init__(self):
         if self.code:
@@ -863,6 +912,12 @@ class NinoxAPIClient:
         """Holt das Schema einer einzelnen Tabelle"""
         return self._request('GET', f'teams/{self.team_id}/databases/{db_id}/tables/{table_id}') or {}
     
+    def count_records(self, db_id: str, table_name: str) -> int:
+        """Zählt die Datensätze einer Tabelle über den Query-Endpunkt"""
+        query = "cnt(select '%s')" % table_name.replace("'", "''")
+        result = self._request('POST', f'teams/{self.team_id}/databases/{db_id}/query', json={'query': query})
+        return int(result or 0)
+
     def get_views(self, db_id: str) -> List[Dict]:
         """Holt alle Views einer Datenbank"""
         try:
@@ -893,9 +948,12 @@ class NinoxSchemaExtractor:
         (r"(?:sum|max|min|avg|cnt)\s*\(\s*([A-Za-z_][A-Za-z0-9_äöüÄÖÜß]*)\.([A-Za-z_][A-Za-z0-9_äöüÄÖÜß]*)", "aggregate"),
     ]
     
-    def __init__(self, api_client: NinoxAPIClient, db_path: str = "ninox_schema.db"):
+    def __init__(self, api_client: NinoxAPIClient, db_path: str = "ninox_schema.db",
+                 count_records: bool = False, archived: Optional[List[str]] = None):
         self.api = api_client
         self.db_path = db_path
+        self.count_records = count_records  # Datensätze je Tabelle zählen (ein Request pro Tabelle)
+        self.archived = set(archived or [])  # als archiviert markierte DB-IDs
         self.conn: Optional[sqlite3.Connection] = None
         
     def init_database(self):
@@ -910,6 +968,9 @@ class NinoxSchemaExtractor:
             CREATE TABLE IF NOT EXISTS databases (
                 id TEXT PRIMARY KEY,
                 name TEXT NOT NULL,
+                team_id TEXT,
+                team_name TEXT,
+                archived INTEGER DEFAULT 0,
                 version INTEGER,
                 color TEXT,
                 icon TEXT,
@@ -995,7 +1056,10 @@ class NinoxSchemaExtractor:
                 code_category TEXT,
                 code TEXT NOT NULL,
                 code_original TEXT,
+                code_hash TEXT,
                 line_count INTEGER DEFAULT 0,
+                modified_by TEXT,
+                modified_at TEXT,
                 created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
                 FOREIGN KEY (database_id) REFERENCES databases(id)
             )
@@ -1017,6 +1081,77 @@ class NinoxSchemaExtractor:
             )
         """)
 
+        # Layout-Elemente und Ansichten je Tabelle
+        cursor.execute("""
+            CREATE TABLE IF NOT EXISTS layout_elements (
+                id INTEGER PRIMARY KEY AUTOINCREMENT,
+                database_id TEXT NOT NULL,
+                table_id TEXT NOT NULL,
+                element_id TEXT NOT NULL,
+                caption TEXT,
+                element_type TEXT,
+                source TEXT NOT NULL,
+                sort_order REAL,
+                tab_caption TEXT,
+                FOREIGN KEY (database_id) REFERENCES databases(id)
+            )
+        """)
+
+        # Auswahlwerte von Auswahl- und Mehrfachauswahlfeldern
+        cursor.execute("""
+            CREATE TABLE IF NOT EXISTS field_options (
+                id INTEGER PRIMARY KEY AUTOINCREMENT,
+                database_id TEXT NOT NULL,
+                table_id TEXT NOT NULL,
+                field_id TEXT NOT NULL,
+                option_id TEXT NOT NULL,
+                caption TEXT,
+                sort_order REAL,
+                color TEXT,
+                FOREIGN KEY (database_id) REFERENCES databases(id)
+            )
+        """)
+
+        # Anzahl Datensätze je Tabelle (nur mit --counts)
+        cursor.execute("""
+            CREATE TABLE IF NOT EXISTS record_counts (
+                database_id TEXT NOT NULL,
+                table_id TEXT NOT NULL,
+                record_count INTEGER NOT NULL,
+                counted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                PRIMARY KEY (database_id, table_id)
+            )
+        """)
+
+        # Zeitgesteuerte Scripts und Webhooks samt Auslöser
+        cursor.execute("""
+            CREATE TABLE IF NOT EXISTS automations (
+                id INTEGER PRIMARY KEY AUTOINCREMENT,
+                database_id TEXT NOT NULL,
+                element_id TEXT NOT NULL,
+                name TEXT,
+                kind TEXT NOT NULL,
+                trigger_condition TEXT,
+                enabled INTEGER DEFAULT 1,
+                FOREIGN KEY (database_id) REFERENCES databases(id)
+            )
+        """)
+
+        # Rollenbasierte Rechte von Tabellen und Feldern
+        cursor.execute("""
+            CREATE TABLE IF NOT EXISTS permissions (
+                id INTEGER PRIMARY KEY AUTOINCREMENT,
+                database_id TEXT NOT NULL,
+                table_id TEXT NOT NULL,
+                table_name TEXT,
+                element_id TEXT,
+                element_name TEXT,
+                access TEXT NOT NULL,
+                roles TEXT NOT NULL,
+                FOREIGN KEY (database_id) REFERENCES databases(id)
+            )
+        """)
+
         # Volltextsuche für Scripts
         cursor.execute("""
             CREATE VIRTUAL TABLE IF NOT EXISTS scripts_fts USING fts5(
@@ -1057,15 +1192,21 @@ class NinoxSchemaExtractor:
         cursor.execute("CREATE INDEX IF NOT EXISTS idx_dependencies_script ON script_dependencies(script_id)")
         cursor.execute("CREATE INDEX IF NOT EXISTS idx_dependencies_source ON script_dependencies(source_database_name)")
         cursor.execute("CREATE INDEX IF NOT EXISTS idx_dependencies_target ON script_dependencies(target_database_name)")
+        cursor.execute("CREATE INDEX IF NOT EXISTS idx_permissions_table ON permissions(database_id, table_id)")
+        cursor.execute("CREATE INDEX IF NOT EXISTS idx_layout_table ON layout_elements(database_id, table_id)")
+        cursor.execute("CREATE INDEX IF NOT EXISTS idx_field_options ON field_options(database_id, table_id, field_id)")
+        cursor.execute("CREATE INDEX IF NOT EXISTS idx_automations_db ON automations(database_id)")
         
         self.conn.commit()
     
-    def extract_all(self, database_ids: Optional[List[str]] = None) -> Dict[str, Any]:
+    def extract_all(self, database_ids: Optional[List[str]] = None,
+                    teams: Optional[List[Tuple[str, str]]] = None) -> Dict[str, Any]:
         """
         Extrahiert alle (oder ausgewählte) Datenbanken.
         
         Args:
             database_ids: Optional Liste von DB-IDs, sonst alle
+            teams: Optional Liste von (Team-ID, Team-Name), sonst nur das Team des Clients
             
         Returns:
             Statistiken über die Extraktion
@@ -1084,6 +1225,7 @@ class NinoxSchemaExtractor:
         self.init_database()
 
         stats = {
+            'teams': 0,
             'databases': 0,
             'tables': 0,
             'fields': 0,
@@ -1091,30 +1233,35 @@ class NinoxSchemaExtractor:
             'scripts': 0,
         }
         
-        # Alle Datenbanken laden
-        databases = self.api.get_databases()
-        logger.info(f"Gefunden: {len(databases)} Datenbanken")
-        
-        for db_info in databases:
-            db_id = db_info.get('id')
-            db_name = db_info.get('name', db_id)
-            
-            # Filter wenn gewünscht
-            if database_ids and db_id not in database_ids:
-                continue
-            
-            logger.info(f"Extrahiere: {db_name} ({db_id})")
-            
-            try:
-                db_stats = self._extract_database(db_id, db_name)
-                stats['databases'] += 1
-                stats['tables'] += db_stats['tables']
-                stats['fields'] += db_stats['fields']
-                stats['relationships'] += db_stats['relationships']
-                stats['scripts'] += db_stats['scripts']
-            except Exception as e:
-                logger.error(f"Fehler bei {db_name}: {e}")
-                continue
+        # Mehrere Teams nacheinander in dieselbe Datei (Team je Datenbank gespeichert)
+        for team_id, team_name in teams or [(self.api.team_id, self.api.team_name)]:
+            self.api.team_id, self.api.team_name = team_id, team_name
+            stats['teams'] += 1
+
+            # Alle Datenbanken des Teams laden
+            databases = self.api.get_databases()
+            logger.info(f"Team {team_name}: {len(databases)} Datenbanken")
+
+            for db_info in databases:
+                db_id = db_info.get('id')
+                db_name = db_info.get('name', db_id)
+
+                # Filter wenn gewünscht
+                if database_ids and db_id not in database_ids:
+                    continue
+
+                logger.info(f"Extrahiere: {db_name} ({db_id})")
+
+                try:
+                    db_stats = self._extract_database(db_id, db_name)
+                    stats['databases'] += 1
+                    stats['tables'] += db_stats['tables']
+                    stats['fields'] += db_stats['fields']
+                    stats['relationships'] += db_stats['relationships']
+                    stats['scripts'] += db_stats['scripts']
+                except Exception as e:
+                    logger.error(f"Fehler bei {db_name}: {e}")
+                    continue
             
         self.conn.commit()
         return stats
@@ -1131,11 +1278,14 @@ class NinoxSchemaExtractor:
         
         # Datenbank einfügen
         cursor.execute("""
-            INSERT INTO databases (id, name, version, color, icon)
-            VALUES (?, ?, ?, ?, ?)
+            INSERT INTO databases (id, name, team_id, team_name, archived, version, color, icon)
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?)
         """, (
             db_id,
             settings.get('name', db_name),
+            self.api.team_id,
+            self.api.team_name,
+            1 if db_id in self.archived else 0,
             schema.get('version'),
             settings.get('color'),
             settings.get('icon')
@@ -1164,8 +1314,10 @@ class NinoxSchemaExtractor:
         team_name = self.api.team_name
 
         def make_code_location(db_id, db_name, table_id, table_name, element_id, element_name,
-                               code_type, category, code) -> CodeLocation:
-            """Erstellt CodeLocation - Code kommt bereits formatiert von der API (formatScripts=T)"""
+                               code_type, category, code, owner=None) -> CodeLocation:
+            """Erstellt CodeLocation - Code kommt bereits formatiert von der API (formatScripts=T).
+            owner ist das Schema-Element mit dem Code (für den letzten Bearbeiter)."""
+            modified_by, modified_at = element_modification(owner)
             return CodeLocation(
                 team_id=team_id,
                 team_name=team_name,
@@ -1178,7 +1330,9 @@ class NinoxSchemaExtractor:
                 code_type=code_type,
                 code_category=category,
                 code=code,
-                code_original=None  # Nicht mehr benötigt da API bereits formatierten Code liefert
+                code_original=None,  # Nicht mehr benötigt da API bereits formatierten Code liefert
+                modified_by=modified_by,
+                modified_at=modified_at
             )
         
         # Verknüpfungen sammeln
@@ -1191,7 +1345,7 @@ class NinoxSchemaExtractor:
             if code and isinstance(code, str) and code.strip():
                 all_scripts.append(make_code_location(
                     db_id, db_name, None, None, None, None,
-                    code_type, category.value, code
+                    code_type, category.value, code, schema
                 ))
         
         # Tabellen und Felder
@@ -1220,8 +1374,12 @@ class NinoxSchemaExtractor:
                 if code and isinstance(code, str) and code.strip():
                     all_scripts.append(make_code_location(
                         db_id, db_name, type_id, table_name, None, None,
-                        code_type, category.value, code
+                        code_type, category.value, code, type_data
                     ))
+
+            # Table-Level Rollen
+            self._insert_permissions(cursor, db_id, type_id, table_name, None, None,
+                                     type_data, TABLE_ROLE_FIELDS)
             
             # Felder extrahieren
             for field_id, field_data in fields.items():
@@ -1264,6 +1422,21 @@ class NinoxSchemaExtractor:
                     has_formula
                 ))
                 stats['fields'] += 1
+
+                # Auswahlwerte (choice, multi)
+                for option_id, option in (field_data.get('values') or {}).items():
+                    if not isinstance(option, dict):
+                        option = {'caption': option}
+                    cursor.execute("""
+                        INSERT INTO field_options (database_id, table_id, field_id, option_id,
+                                                   caption, sort_order, color)
+                        VALUES (?, ?, ?, ?, ?, ?, ?)
+                    """, (db_id, type_id, field_id, str(option_id), option.get('caption', ''),
+                          option.get('order'), option.get('color')))
+
+                # Field-Level Rollen
+                self._insert_permissions(cursor, db_id, type_id, table_name, field_id, field_name,
+                                         field_data, FIELD_ROLE_FIELDS)
                 
                 # Verknüpfung erstellen
                 if base_type == 'ref' and ref_table_name:
@@ -1293,16 +1466,93 @@ class NinoxSchemaExtractor:
                         
                         all_scripts.append(make_code_location(
                             db_id, db_name, type_id, table_name, field_id, field_name,
-                            code_type, category.value, code
+                            code_type, category.value, code, field_data
                         ))
         
+            # Layout-Elemente (Schaltflächen, Reiter, Texte …) samt Code
+            for ui_id, ui_data in type_data.get('uis', {}).items():
+                ui_name = ui_data.get('caption', ui_id)
+                for code_type, category in FIELD_CODE_FIELDS.items():
+                    code = ui_data.get(code_type, '')
+                    if code and isinstance(code, str) and code.strip():
+                        all_scripts.append(make_code_location(
+                            db_id, db_name, type_id, table_name, ui_id, ui_name,
+                            code_type, category.value, code, ui_data
+                        ))
+            self._insert_layout(cursor, db_id, type_id, type_data)
+
+            if self.count_records:
+                try:
+                    count = self.api.count_records(db_id, table_name)
+                    cursor.execute("""
+                        INSERT OR REPLACE INTO record_counts (database_id, table_id, record_count)
+                        VALUES (?, ?, ?)
+                    """, (db_id, type_id, count))
+                except Exception as e:
+                    logger.warning(f"Datensätze von {table_name} nicht gezählt: {e}")
+
+            # Druckvorlagen der Tabelle samt eingebetteter Formeln
+            for report_id, report in self._reports(type_data):
+                for element_id, element_name, code_type, code in self._report_formulas(report_id, report):
+                    all_scripts.append(make_code_location(
+                        db_id, db_name, type_id, table_name, element_id, element_name,
+                        code_type, CodeCategory.REPORT.value, code, report
+                    ))
+
+        # Druckvorlagen auf Datenbank-Ebene (Tabelle über "type" bzw. "typeId")
+        for report_id, report in self._reports(schema):
+            table_id = report.get('type') or report.get('typeId')
+            table_name = table_id_to_name.get(table_id) if table_id else None
+            for element_id, element_name, code_type, code in self._report_formulas(report_id, report):
+                all_scripts.append(make_code_location(
+                    db_id, db_name, table_id, table_name, element_id, element_name,
+                    code_type, CodeCategory.REPORT.value, code, report
+                ))
+
+        # Ansichten (Views) der Datenbank
+        for order, view in enumerate(self.api.get_views(db_id)):
+            table_id = view.get('type')
+            if not table_id:
+                continue
+            cursor.execute("""
+                INSERT INTO layout_elements (database_id, table_id, element_id, caption,
+                                             element_type, source, sort_order)
+                VALUES (?, ?, ?, ?, 'view', 'view', ?)
+            """, (db_id, table_id, str(view.get('id', order)), view.get('caption', ''),
+                  view.get('order', order)))
+
+        # Zeitgesteuerte Scripts und Webhooks (Datenbank-Ebene)
+        for key, kind in AUTOMATION_KEYS.items():
+            value = schema.get(key) or {}
+            items = value.items() if isinstance(value, dict) else enumerate(value)
+            for job_id, job in items:
+                if not isinstance(job, dict):
+                    continue
+                job_id = str(job.get('id', job_id))
+                name = job.get('caption') or job.get('name') or job_id
+                condition = next((str(job[k]) for k in AUTOMATION_CONDITION_FIELDS if job.get(k)), None)
+                cursor.execute("""
+                    INSERT INTO automations (database_id, element_id, name, kind, trigger_condition, enabled)
+                    VALUES (?, ?, ?, ?, ?, ?)
+                """, (db_id, job_id, name, kind, condition, 0 if job.get('disabled') else 1))
+                for code_key in AUTOMATION_CODE_FIELDS:
+                    code = job.get(code_key)
+                    if code and isinstance(code, str) and code.strip():
+                        table_id = job.get('type') or job.get('typeId')
+                        all_scripts.append(make_code_location(
+                            db_id, db_name, table_id, table_id_to_name.get(table_id) if table_id else None,
+                            job_id, name, kind, CodeCategory.AUTOMATION.value, code, job
+                        ))
+                        break
+
         # Scripts speichern und Formel-Referenzen extrahieren
         for script in all_scripts:
             cursor.execute("""
                 INSERT INTO scripts (team_id, team_name, database_id, database_name,
                                     table_id, table_name, element_id, element_name,
-                                    code_type, code_category, code, code_original, line_count)
-                VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+                                    code_type, code_category, code, code_original, code_hash, line_count,
+                                    modified_by, modified_at)
+                VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
             """, (
                 script.team_id,
                 script.team_name,
@@ -1316,7 +1566,10 @@ class NinoxSchemaExtractor:
                 script.code_category,
                 script.code,
                 script.code_original,
-                script.line_count
+                hashlib.sha256(script.code.encode('utf-8')).hexdigest(),
+                script.line_count,
+                script.modified_by,
+                script.modified_at
             ))
             script_id = cursor.lastrowid
             stats['scripts'] += 1
@@ -1389,6 +1642,90 @@ class NinoxSchemaExtractor:
         
         return stats
     
+    def _insert_layout(self, cursor, db_id: str, table_id: str, type_data: Dict):
+        """Speichert Felder und Layout-Elemente einer Tabelle in Formular-Reihenfolge.
+        Elemente nach einem Reiter gehören zu diesem Reiter."""
+        elements = []
+        for source, key in (('field', 'fields'), ('ui', 'uis')):
+            for element_id, data in type_data.get(key, {}).items():
+                elements.append((data.get('order', 0) or 0, source, element_id, data))
+        elements.sort(key=lambda e: (e[0], e[2]))
+
+        tab = None
+        for order, source, element_id, data in elements:
+            element_type = data.get('base') or data.get('uiType') or ''
+            if element_type == 'tab':
+                tab = data.get('caption', element_id)
+            cursor.execute("""
+                INSERT INTO layout_elements (database_id, table_id, element_id, caption,
+                                             element_type, source, sort_order, tab_caption)
+                VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+            """, (db_id, table_id, element_id, data.get('caption', element_id),
+                  element_type, source, order, tab))
+
+    @staticmethod
+    def _reports(data: Dict) -> List[Tuple[str, Dict]]:
+        """Liefert die Druckvorlagen eines Schema-Objekts als (ID, Vorlage) – als Dict oder Liste"""
+        reports = []
+        for key in REPORT_KEYS:
+            value = data.get(key) or {}
+            items = value.items() if isinstance(value, dict) else enumerate(value)
+            for report_id, report in items:
+                if isinstance(report, dict):
+                    reports.append((str(report.get('id', report_id)), report))
+        return reports
+
+    @staticmethod
+    def _report_formulas(report_id: str, report: Dict) -> List[Tuple[str, str, str, str]]:
+        """Sammelt die Formeln einer Druckvorlage rekursiv aus ihren Elementen.
+        Liefert (element_id, element_name, code_type, code); element_id ist "Vorlage/Element",
+        element_name "Vorlage › Element"."""
+        report_name = report.get('caption') or report.get('name') or report_id
+        formulas = []
+
+        def walk(element_id: str, name: str, data: Dict):
+            for key in REPORT_CODE_FIELDS:
+                code = data.get(key)
+                if code and isinstance(code, str) and code.strip():
+                    formulas.append((element_id, name, 'report' + key[0].upper() + key[1:], code))
+            for child_key in ('elements', 'items', 'children'):
+                children = data.get(child_key) or {}
+                items = children.items() if isinstance(children, dict) else enumerate(children)
+                for child_id, child in items:
+                    if not isinstance(child, dict):
+                        continue
+                    child_id = str(child.get('id', child_id))
+                    caption = child.get('caption') or child.get('name') or child_id
+                    walk(f"{report_id}/{child_id}", f"{report_name} › {caption}", child)
+
+        walk(report_id, report_name, report)
+        return formulas
+
+    @staticmethod
+    def _role_names(value: Any) -> List[str]:
+        """Normalisiert eine Rollenliste (Liste, Dict mit Flags oder kommagetrennter Text)"""
+        if isinstance(value, dict):
+            return sorted(str(role) for role, enabled in value.items() if enabled)
+        if isinstance(value, (list, tuple)):
+            return sorted(str(role) for role in value if role)
+        if isinstance(value, str):
+            return sorted(role.strip() for role in value.split(',') if role.strip())
+        return []
+
+    def _insert_permissions(self, cursor, db_id: str, table_id: str, table_name: str,
+                            element_id: Optional[str], element_name: Optional[str],
+                            data: Dict, role_fields: Dict[str, str]):
+        """Speichert die Rollenlisten einer Tabelle bzw. eines Feldes"""
+        for key, access in role_fields.items():
+            roles = self._role_names(data.get(key))
+            if not roles:
+                continue
+            cursor.execute("""
+                INSERT INTO permissions (database_id, table_id, table_name, element_id,
+                                         element_name, access, roles)
+                VALUES (?, ?, ?, ?, ?, ?, ?)
+            """, (db_id, table_id, table_name, element_id, element_name, access, ','.join(roles)))
+
     def _extract_formula_references(self, code: str, known_tables: Set[str]) -> Set[str]:
         """Extrahiert Tabellen-Referenzen aus Code"""
         references = set()
@@ -2030,12 +2367,18 @@ Beispiele:
     # Extract
     extract_p = subparsers.add_parser('extract', help='Extrahiert von der API')
     extract_p.add_argument('--domain', help='Ninox Domain (z.B. https://app.ninox.com)')
-    extract_p.add_argument('--team', help='Team/Workspace ID')
+    extract_p.add_argument('--team', help='Team/Workspace ID (mehrere durch Komma getrennt)')
+    extract_p.add_argument('--all-teams', action='store_true',
+                           help='Alle Teams extrahieren, auf die der API-Key Zugriff hat')
     extract_p.add_argument('--apikey', help='API Key')
     extract_p.add_argument('--config', help='Config YAML Datei')
     extract_p.add_argument('--env', default='dev', help='Environment in Config')
     extract_p.add_argument('--db', default='ninox_schema.db', help='SQLite Ausgabe')
     extract_p.add_argument('--databases', nargs='*', help='Nur bestimmte DB-IDs')
+    extract_p.add_argument('--counts', action='store_true',
+                           help='Datensätze je Tabelle zählen (ein API-Aufruf pro Tabelle)')
+    extract_p.add_argument('--archive', nargs='*', metavar='DB_ID',
+                           help='Diese DB-IDs als archiviert markieren (im Explorer ausgeblendet)')
     
     # Search
     search_p = subparsers.add_parser('search', help='Sucht in Scripts')
@@ -2101,24 +2444,37 @@ Beispiele:
         team_name = team_name or os.getenv('NINOX_TEAM_NAME') or team_id
         api_key = api_key or os.getenv('NINOX_API_KEY')
 
-        if not all([domain, team_id, api_key]):
+        if not all([domain, team_id or args.all_teams, api_key]):
             print("❌ Fehler: domain, team und apikey müssen angegeben werden!")
             return
 
         print(f"🔌 Verbinde mit: {domain}")
 
-        # Client erstellen und Team-Namen von API holen wenn nicht in Config
-        client = NinoxAPIClient(domain, team_id, api_key, team_name=team_name)
-        if not team_name or team_name == team_id:
-            api_team_name = client.get_team_name()
-            client.team_name = api_team_name
-            print(f"📦 Team: {api_team_name} ({team_id})")
+        # Mehrere Teams: alle per API oder als Komma-Liste; Namen kommen von der API
+        teams = None
+        team_ids = [t.strip() for t in (team_id or '').split(',') if t.strip()]
+        if args.all_teams or len(team_ids) > 1:
+            client = NinoxAPIClient(domain, team_ids[0] if team_ids else '', api_key)
+            names = {t.get('id'): t.get('name', t.get('id')) for t in client.get_teams()}
+            if args.all_teams:
+                team_ids = list(names)
+            teams = [(t, names.get(t, t)) for t in team_ids]
+            for t, name in teams:
+                print(f"📦 Team: {name} ({t})")
         else:
-            print(f"📦 Team: {team_name} ({team_id})")
+            # Client erstellen und Team-Namen von API holen wenn nicht in Config
+            client = NinoxAPIClient(domain, team_id, api_key, team_name=team_name)
+            if not team_name or team_name == team_id:
+                api_team_name = client.get_team_name()
+                client.team_name = api_team_name
+                print(f"📦 Team: {api_team_name} ({team_id})")
+            else:
+                print(f"📦 Team: {team_name} ({team_id})")
 
-        extractor = NinoxSchemaExtractor(client, args.db)
+        extractor = NinoxSchemaExtractor(client, args.db, count_records=args.counts,
+                                         archived=args.archive)
 
-        stats = extractor.extract_all(args.databases)
+        stats = extractor.extract_all(args.databases, teams=teams)
         
         print(f"\n✅ Extraktion abgeschlossen:")
         for key, value in stats.items():